@@ -7,10 +7,12 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/pow"
 	"github.com/Kash-Protocol/kashd/infrastructure/config"
 	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
 	"github.com/Kash-Protocol/kashd/infrastructure/db/database/ldb"
 	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+	"github.com/Kash-Protocol/kashd/infrastructure/metrics"
 	"github.com/Kash-Protocol/kashd/infrastructure/os/execenv"
 	"github.com/Kash-Protocol/kashd/infrastructure/os/limits"
 	"github.com/Kash-Protocol/kashd/infrastructure/os/signal"
@@ -89,11 +91,25 @@ func (app *kashdApp) main(startedChan chan<- struct{}) error {
 	}
 	profiling.TrackHeap(app.cfg.AppDir, log)
 
+	// Enable the Prometheus metrics server if requested.
+	if app.cfg.MetricsListen != "" {
+		metrics.Start(app.cfg.MetricsListen, log)
+	}
+
 	// Return now if an interrupt signal was triggered.
 	if signal.InterruptRequested(interrupt) {
 		return nil
 	}
 
+	// Set the RandomX mode before any proof of work gets verified.
+	randomXMode, err := pow.ParseMode(app.cfg.RandomXMode)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	pow.SetGlobalMode(randomXMode)
+	log.Infof("Verifying proof of work in RandomX %s mode", randomXMode)
+
 	if app.cfg.ResetDatabase {
 		err := removeDatabase(app.cfg)
 		if err != nil {
@@ -96,6 +96,7 @@ func DomainTransactionToMsgTx(domainTransaction *externalapi.DomainTransaction)
 		SubnetworkID: domainTransaction.SubnetworkID,
 		Gas:          domainTransaction.Gas,
 		Payload:      domainTransaction.Payload,
+		Type:         domainTransaction.Type,
 	}
 }
 
@@ -146,6 +147,7 @@ func MsgTxToDomainTransaction(msgTx *MsgTx) *externalapi.DomainTransaction {
 		SubnetworkID: msgTx.SubnetworkID,
 		Gas:          msgTx.Gas,
 		Payload:      payload,
+		Type:         msgTx.Type,
 	}
 }
 
@@ -220,6 +222,7 @@ func RPCTransactionToDomainTransaction(rpcTransaction *RPCTransaction) (*externa
 		SubnetworkID: *subnetworkID,
 		Gas:          rpcTransaction.Gas,
 		Payload:      payload,
+		Type:         externalapi.DomainTransactionType(rpcTransaction.Type),
 	}, nil
 }
 
@@ -289,6 +292,7 @@ func DomainTransactionToRPCTransaction(transaction *externalapi.DomainTransactio
 		SubnetworkID: subnetworkID,
 		Gas:          transaction.Gas,
 		Payload:      payload,
+		Type:         uint32(transaction.Type),
 	}
 }
 
@@ -540,13 +544,17 @@ func DomainBlockWithTrustedDataToBlockWithTrustedData(block *externalapi.BlockWi
 	}
 }
 
-// DomainBlockWithTrustedDataToBlockWithTrustedDataV4 converts a set of *externalapi.DomainBlock, daa window indices and ghostdag data indices
-// to *MsgBlockWithTrustedDataV4
-func DomainBlockWithTrustedDataToBlockWithTrustedDataV4(block *externalapi.DomainBlock, daaWindowIndices, ghostdagDataIndices []uint64) *MsgBlockWithTrustedDataV4 {
+// DomainBlockWithTrustedDataToBlockWithTrustedDataV4 converts a set of *externalapi.DomainBlock, daa window indices,
+// ghostdag data indices, the oracle prices in effect at the block and its reserve state to *MsgBlockWithTrustedDataV4
+func DomainBlockWithTrustedDataToBlockWithTrustedDataV4(block *externalapi.DomainBlock, daaWindowIndices, ghostdagDataIndices []uint64,
+	oraclePrices *externalapi.OraclePrices, reserveState *externalapi.ReserveState) *MsgBlockWithTrustedDataV4 {
+
 	return &MsgBlockWithTrustedDataV4{
 		Block:               DomainBlockToMsgBlock(block),
 		DAAWindowIndices:    daaWindowIndices,
 		GHOSTDAGDataIndices: ghostdagDataIndices,
+		OraclePrices:        oraclePrices,
+		ReserveState:        reserveState,
 	}
 }
 
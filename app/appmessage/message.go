@@ -163,6 +163,21 @@ const (
 	CmdGetMempoolEntriesByAddressesResponseMessage
 	CmdGetCoinSupplyRequestMessage
 	CmdGetCoinSupplyResponseMessage
+	CmdGetReserveStateRequestMessage
+	CmdGetReserveStateResponseMessage
+	CmdGetOraclePriceRequestMessage
+	CmdGetOraclePriceResponseMessage
+	CmdNotifyReserveRatioChangedRequestMessage
+	CmdNotifyReserveRatioChangedResponseMessage
+	CmdReserveRatioChangedNotificationMessage
+	CmdGetFeeEstimateRequestMessage
+	CmdGetFeeEstimateResponseMessage
+	CmdGetTransactionRequestMessage
+	CmdGetTransactionResponseMessage
+	CmdGetTransactionConfirmationsRequestMessage
+	CmdGetTransactionConfirmationsResponseMessage
+	CmdGetTransactionsByAddressesRequestMessage
+	CmdGetTransactionsByAddressesResponseMessage
 )
 
 // ProtocolMessageCommandToString maps all MessageCommands to their string representation
@@ -300,6 +315,21 @@ var RPCMessageCommandToString = map[MessageCommand]string{
 	CmdGetMempoolEntriesByAddressesResponseMessage:                "GetMempoolEntriesByAddressesResponse",
 	CmdGetCoinSupplyRequestMessage:                                "GetCoinSupplyRequest",
 	CmdGetCoinSupplyResponseMessage:                               "GetCoinSupplyResponse",
+	CmdGetReserveStateRequestMessage:                              "GetReserveStateRequest",
+	CmdGetReserveStateResponseMessage:                             "GetReserveStateResponse",
+	CmdGetOraclePriceRequestMessage:                               "GetOraclePriceRequest",
+	CmdGetOraclePriceResponseMessage:                              "GetOraclePriceResponse",
+	CmdNotifyReserveRatioChangedRequestMessage:                    "NotifyReserveRatioChangedRequest",
+	CmdNotifyReserveRatioChangedResponseMessage:                   "NotifyReserveRatioChangedResponse",
+	CmdReserveRatioChangedNotificationMessage:                     "ReserveRatioChangedNotification",
+	CmdGetFeeEstimateRequestMessage:                               "GetFeeEstimateRequest",
+	CmdGetFeeEstimateResponseMessage:                              "GetFeeEstimateResponse",
+	CmdGetTransactionRequestMessage:                               "GetTransactionRequest",
+	CmdGetTransactionResponseMessage:                              "GetTransactionResponse",
+	CmdGetTransactionConfirmationsRequestMessage:                  "GetTransactionConfirmationsRequest",
+	CmdGetTransactionConfirmationsResponseMessage:                 "GetTransactionConfirmationsResponse",
+	CmdGetTransactionsByAddressesRequestMessage:                   "GetTransactionsByAddressesRequest",
+	CmdGetTransactionsByAddressesResponseMessage:                  "GetTransactionsByAddressesResponse",
 }
 
 // Message is an interface that describes a kaspa message. A type that
@@ -1,5 +1,7 @@
 package appmessage
 
+import "github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+
 // MsgBlockWithTrustedDataV4 represents a kash BlockWithTrustedDataV4 message
 type MsgBlockWithTrustedDataV4 struct {
 	baseMessage
@@ -7,6 +9,8 @@ type MsgBlockWithTrustedDataV4 struct {
 	Block               *MsgBlock
 	DAAWindowIndices    []uint64
 	GHOSTDAGDataIndices []uint64
+	OraclePrices        *externalapi.OraclePrices
+	ReserveState        *externalapi.ReserveState
 }
 
 // Command returns the protocol command string for the message
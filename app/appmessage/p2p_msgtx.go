@@ -136,6 +136,7 @@ type MsgTx struct {
 	SubnetworkID externalapi.DomainSubnetworkID
 	Gas          uint64
 	Payload      []byte
+	Type         externalapi.DomainTransactionType
 }
 
 // AddTxIn adds a transaction input to the message.
@@ -180,6 +181,7 @@ func (msg *MsgTx) Copy() *MsgTx {
 		LockTime:     msg.LockTime,
 		SubnetworkID: msg.SubnetworkID,
 		Gas:          msg.Gas,
+		Type:         msg.Type,
 	}
 
 	if msg.Payload != nil {
@@ -6,25 +6,46 @@ package appmessage
 
 import (
 	"net"
+	"strconv"
 
 	"github.com/Kash-Protocol/kashd/util/mstime"
+	"github.com/Kash-Protocol/kashd/util/network"
+	"github.com/pkg/errors"
 )
 
 // NetAddress defines information about a peer on the network including the time
-// it was last seen, the services it supports, its IP address, and port.
+// it was last seen, the services it supports, its IP address or onion address, and port.
 type NetAddress struct {
 	// Last time the address was seen.
 	Timestamp mstime.Time
 
-	// IP address of the peer.
+	// IP address of the peer. It is nil if the peer is a Tor onion service.
 	IP net.IP
 
+	// OnionPublicKey is the public key of the Tor v3 onion service of the peer,
+	// if it is one.
+	OnionPublicKey []byte
+
 	// Port the peer is using. This is encoded in big endian on the appmessage
 	// which differs from most everything else.
 	Port uint16
 }
 
-// TCPAddress converts the NetAddress to *net.TCPAddr
+// IsOnion returns whether the peer is a Tor onion service
+func (na *NetAddress) IsOnion() bool {
+	return len(na.OnionPublicKey) != 0
+}
+
+// Host returns the IP address of the peer, or its onion host if it is a Tor onion service
+func (na *NetAddress) Host() string {
+	if na.IsOnion() {
+		return network.EncodeOnionHost(na.OnionPublicKey)
+	}
+	return na.IP.String()
+}
+
+// TCPAddress converts the NetAddress to *net.TCPAddr. It should not be used
+// for onion addresses, which have no IP address.
 func (na *NetAddress) TCPAddress() *net.TCPAddr {
 	return &net.TCPAddr{
 		IP:   na.IP,
@@ -53,6 +74,34 @@ func NewNetAddressTimestamp(
 	return &na
 }
 
+// NewNetAddressOnion returns a new NetAddress using the provided Tor v3 onion
+// service public key and port.
+func NewNetAddressOnion(onionPublicKey []byte, port uint16) *NetAddress {
+	return &NetAddress{
+		Timestamp:      mstime.Now(),
+		OnionPublicKey: onionPublicKey,
+		Port:           port,
+	}
+}
+
+// NewNetAddressHostPort returns a new NetAddress using the provided host, which
+// is either an IP address or a Tor v3 onion service host, and port.
+func NewNetAddressHostPort(host string, port uint16) (*NetAddress, error) {
+	if network.IsOnionHost(host) {
+		onionPublicKey, err := network.DecodeOnionHost(host)
+		if err != nil {
+			return nil, err
+		}
+		return NewNetAddressOnion(onionPublicKey, port), nil
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, errors.Errorf("%s is neither an IP address nor an onion host", host)
+	}
+	return NewNetAddressIPPort(ip, port), nil
+}
+
 // NewNetAddress returns a new NetAddress using the provided TCP address and
 // supported services with defaults for the remaining fields.
 func NewNetAddress(addr *net.TCPAddr) *NetAddress {
@@ -60,5 +109,5 @@ func NewNetAddress(addr *net.TCPAddr) *NetAddress {
 }
 
 func (na NetAddress) String() string {
-	return na.TCPAddress().String()
+	return net.JoinHostPort(na.Host(), strconv.Itoa(int(na.Port)))
 }
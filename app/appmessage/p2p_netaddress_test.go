@@ -26,3 +26,31 @@ func TestNetAddress(t *testing.T) {
 			port)
 	}
 }
+
+// TestNetAddressHostPort tests NewNetAddressHostPort with IP and onion hosts.
+func TestNetAddressHostPort(t *testing.T) {
+	na, err := NewNetAddressHostPort("127.0.0.1", 16111)
+	if err != nil {
+		t.Fatalf("NewNetAddressHostPort: %s", err)
+	}
+	if na.IsOnion() || na.String() != "127.0.0.1:16111" {
+		t.Errorf("NewNetAddressHostPort: unexpected address %s", na)
+	}
+
+	onionHost := "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion"
+	na, err = NewNetAddressHostPort(onionHost, 16111)
+	if err != nil {
+		t.Fatalf("NewNetAddressHostPort: %s", err)
+	}
+	if !na.IsOnion() || na.IP != nil {
+		t.Errorf("NewNetAddressHostPort: expected %s to be an onion address", na)
+	}
+	if na.String() != onionHost+":16111" {
+		t.Errorf("NewNetAddressHostPort: wrong address - got %s, want %s:16111", na, onionHost)
+	}
+
+	_, err = NewNetAddressHostPort("example.com", 16111)
+	if err == nil {
+		t.Errorf("NewNetAddressHostPort: expected an error for a host name")
+	}
+}
@@ -0,0 +1,44 @@
+package appmessage
+
+// GetFeeEstimateRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetFeeEstimateRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetFeeEstimateRequestMessage) Command() MessageCommand {
+	return CmdGetFeeEstimateRequestMessage
+}
+
+// NewGetFeeEstimateRequestMessage returns a instance of the message
+func NewGetFeeEstimateRequestMessage() *GetFeeEstimateRequestMessage {
+	return &GetFeeEstimateRequestMessage{}
+}
+
+// GetFeeEstimateResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetFeeEstimateResponseMessage struct {
+	baseMessage
+	LowFeeRate      float64
+	NormalFeeRate   float64
+	PriorityFeeRate float64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetFeeEstimateResponseMessage) Command() MessageCommand {
+	return CmdGetFeeEstimateResponseMessage
+}
+
+// NewGetFeeEstimateResponseMessage returns a instance of the message
+func NewGetFeeEstimateResponseMessage(lowFeeRate float64, normalFeeRate float64,
+	priorityFeeRate float64) *GetFeeEstimateResponseMessage {
+
+	return &GetFeeEstimateResponseMessage{
+		LowFeeRate:      lowFeeRate,
+		NormalFeeRate:   normalFeeRate,
+		PriorityFeeRate: priorityFeeRate,
+	}
+}
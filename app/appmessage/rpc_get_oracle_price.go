@@ -0,0 +1,52 @@
+package appmessage
+
+// GetOraclePriceRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetOraclePriceRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetOraclePriceRequestMessage) Command() MessageCommand {
+	return CmdGetOraclePriceRequestMessage
+}
+
+// NewGetOraclePriceRequestMessage returns a instance of the message
+func NewGetOraclePriceRequestMessage() *GetOraclePriceRequestMessage {
+	return &GetOraclePriceRequestMessage{}
+}
+
+// GetOraclePriceResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetOraclePriceResponseMessage struct {
+	baseMessage
+	KSHPrice          uint64
+	KRVPrice          uint64
+	KUSDPrice         uint64
+	KSHMovingAverage  uint64
+	KRVMovingAverage  uint64
+	KUSDMovingAverage uint64
+	Timestamp         int64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetOraclePriceResponseMessage) Command() MessageCommand {
+	return CmdGetOraclePriceResponseMessage
+}
+
+// NewGetOraclePriceResponseMessage returns a instance of the message
+func NewGetOraclePriceResponseMessage(kshPrice uint64, krvPrice uint64, kusdPrice uint64, kshMovingAverage uint64,
+	krvMovingAverage uint64, kusdMovingAverage uint64, timestamp int64) *GetOraclePriceResponseMessage {
+
+	return &GetOraclePriceResponseMessage{
+		KSHPrice:          kshPrice,
+		KRVPrice:          krvPrice,
+		KUSDPrice:         kusdPrice,
+		KSHMovingAverage:  kshMovingAverage,
+		KRVMovingAverage:  krvMovingAverage,
+		KUSDMovingAverage: kusdMovingAverage,
+		Timestamp:         timestamp,
+	}
+}
@@ -0,0 +1,70 @@
+package appmessage
+
+// GetReserveStateRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetReserveStateRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetReserveStateRequestMessage) Command() MessageCommand {
+	return CmdGetReserveStateRequestMessage
+}
+
+// NewGetReserveStateRequestMessage returns a instance of the message
+func NewGetReserveStateRequestMessage() *GetReserveStateRequestMessage {
+	return &GetReserveStateRequestMessage{}
+}
+
+// GetReserveStateResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetReserveStateResponseMessage struct {
+	baseMessage
+	ReserveSompi               uint64
+	KUSDSupplySompi            uint64
+	KRVSupplySompi             uint64
+	KUSDLiabilitiesSompi       uint64
+	KRVEquitySompi             uint64
+	ExchangeRate               uint64
+	ReserveRatioBasisPoints    uint64
+	MinReserveRatioBasisPoints uint64
+	MaxReserveRatioBasisPoints uint64
+	ConversionFeeBasisPoints   uint64
+	ConversionQuotes           []*ConversionQuote
+
+	Error *RPCError
+}
+
+// ConversionQuote is the price, in KSH sompi, at which the reserve
+// currently converts one whole unit of KUSD or KRV
+type ConversionQuote struct {
+	TransactionType uint32
+	KSHSompi        uint64
+	FeeSompi        uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetReserveStateResponseMessage) Command() MessageCommand {
+	return CmdGetReserveStateResponseMessage
+}
+
+// NewGetReserveStateResponseMessage returns a instance of the message
+func NewGetReserveStateResponseMessage(reserveSompi uint64, kusdSupplySompi uint64, krvSupplySompi uint64,
+	kusdLiabilitiesSompi uint64, krvEquitySompi uint64, exchangeRate uint64, reserveRatioBasisPoints uint64,
+	minReserveRatioBasisPoints uint64, maxReserveRatioBasisPoints uint64, conversionFeeBasisPoints uint64,
+	conversionQuotes []*ConversionQuote) *GetReserveStateResponseMessage {
+
+	return &GetReserveStateResponseMessage{
+		ReserveSompi:               reserveSompi,
+		KUSDSupplySompi:            kusdSupplySompi,
+		KRVSupplySompi:             krvSupplySompi,
+		KUSDLiabilitiesSompi:       kusdLiabilitiesSompi,
+		KRVEquitySompi:             krvEquitySompi,
+		ExchangeRate:               exchangeRate,
+		ReserveRatioBasisPoints:    reserveRatioBasisPoints,
+		MinReserveRatioBasisPoints: minReserveRatioBasisPoints,
+		MaxReserveRatioBasisPoints: maxReserveRatioBasisPoints,
+		ConversionFeeBasisPoints:   conversionFeeBasisPoints,
+		ConversionQuotes:           conversionQuotes,
+	}
+}
@@ -0,0 +1,56 @@
+package appmessage
+
+// GetTransactionRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionRequestMessage struct {
+	baseMessage
+	TxID string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionRequestMessage) Command() MessageCommand {
+	return CmdGetTransactionRequestMessage
+}
+
+// NewGetTransactionRequestMessage returns a instance of the message
+func NewGetTransactionRequestMessage(txID string) *GetTransactionRequestMessage {
+	return &GetTransactionRequestMessage{
+		TxID: txID,
+	}
+}
+
+// GetTransactionResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionResponseMessage struct {
+	baseMessage
+	Transaction           *RPCTransaction
+	AcceptingBlockHash    string
+	ContainingBlockHashes []string
+	TransactionType       string
+	InputAssetType        uint32
+	OutputAssetType       uint32
+	Confirmations         uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionResponseMessage) Command() MessageCommand {
+	return CmdGetTransactionResponseMessage
+}
+
+// NewGetTransactionResponseMessage returns a instance of the message
+func NewGetTransactionResponseMessage(transaction *RPCTransaction, acceptingBlockHash string,
+	containingBlockHashes []string, transactionType string, inputAssetType uint32, outputAssetType uint32,
+	confirmations uint64) *GetTransactionResponseMessage {
+
+	return &GetTransactionResponseMessage{
+		Transaction:           transaction,
+		AcceptingBlockHash:    acceptingBlockHash,
+		ContainingBlockHashes: containingBlockHashes,
+		TransactionType:       transactionType,
+		InputAssetType:        inputAssetType,
+		OutputAssetType:       outputAssetType,
+		Confirmations:         confirmations,
+	}
+}
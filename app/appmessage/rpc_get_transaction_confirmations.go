@@ -0,0 +1,41 @@
+package appmessage
+
+// GetTransactionConfirmationsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionConfirmationsRequestMessage struct {
+	baseMessage
+	TxID string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionConfirmationsRequestMessage) Command() MessageCommand {
+	return CmdGetTransactionConfirmationsRequestMessage
+}
+
+// NewGetTransactionConfirmationsRequestMessage returns a instance of the message
+func NewGetTransactionConfirmationsRequestMessage(txID string) *GetTransactionConfirmationsRequestMessage {
+	return &GetTransactionConfirmationsRequestMessage{
+		TxID: txID,
+	}
+}
+
+// GetTransactionConfirmationsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionConfirmationsResponseMessage struct {
+	baseMessage
+	Confirmations uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionConfirmationsResponseMessage) Command() MessageCommand {
+	return CmdGetTransactionConfirmationsResponseMessage
+}
+
+// NewGetTransactionConfirmationsResponseMessage returns a instance of the message
+func NewGetTransactionConfirmationsResponseMessage(confirmations uint64) *GetTransactionConfirmationsResponseMessage {
+	return &GetTransactionConfirmationsResponseMessage{
+		Confirmations: confirmations,
+	}
+}
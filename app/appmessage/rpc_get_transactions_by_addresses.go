@@ -0,0 +1,70 @@
+package appmessage
+
+// GetTransactionsByAddressesRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionsByAddressesRequestMessage struct {
+	baseMessage
+	Addresses  []string
+	StartAfter *TransactionsByAddressesPageKey
+	Limit      uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionsByAddressesRequestMessage) Command() MessageCommand {
+	return CmdGetTransactionsByAddressesRequestMessage
+}
+
+// NewGetTransactionsByAddressesRequestMessage returns a instance of the message
+func NewGetTransactionsByAddressesRequestMessage(addresses []string, startAfter *TransactionsByAddressesPageKey,
+	limit uint64) *GetTransactionsByAddressesRequestMessage {
+
+	return &GetTransactionsByAddressesRequestMessage{
+		Addresses:  addresses,
+		StartAfter: startAfter,
+		Limit:      limit,
+	}
+}
+
+// GetTransactionsByAddressesResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionsByAddressesResponseMessage struct {
+	baseMessage
+	Entries     []*TransactionsByAddressesEntry
+	NextPageKey *TransactionsByAddressesPageKey
+
+	Error *RPCError
+}
+
+// TransactionsByAddressesEntry represents the change an accepted
+// transaction made to the balance of an address in one asset type
+type TransactionsByAddressesEntry struct {
+	Address                 string
+	TransactionID           string
+	AcceptingBlockHash      string
+	AcceptingBlockBlueScore uint64
+	AssetType               uint32
+	AmountDelta             int64
+}
+
+// TransactionsByAddressesPageKey is the position of an entry
+// in the order entries are returned in
+type TransactionsByAddressesPageKey struct {
+	AcceptingBlockBlueScore uint64
+	TransactionID           string
+	AssetType               uint32
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionsByAddressesResponseMessage) Command() MessageCommand {
+	return CmdGetTransactionsByAddressesResponseMessage
+}
+
+// NewGetTransactionsByAddressesResponseMessage returns a instance of the message
+func NewGetTransactionsByAddressesResponseMessage(entries []*TransactionsByAddressesEntry,
+	nextPageKey *TransactionsByAddressesPageKey) *GetTransactionsByAddressesResponseMessage {
+
+	return &GetTransactionsByAddressesResponseMessage{
+		Entries:     entries,
+		NextPageKey: nextPageKey,
+	}
+}
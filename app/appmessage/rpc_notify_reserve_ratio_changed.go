@@ -0,0 +1,62 @@
+package appmessage
+
+// NotifyReserveRatioChangedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyReserveRatioChangedRequestMessage struct {
+	baseMessage
+	ThresholdsBasisPoints []uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyReserveRatioChangedRequestMessage) Command() MessageCommand {
+	return CmdNotifyReserveRatioChangedRequestMessage
+}
+
+// NewNotifyReserveRatioChangedRequestMessage returns a instance of the message
+func NewNotifyReserveRatioChangedRequestMessage(thresholdsBasisPoints []uint64) *NotifyReserveRatioChangedRequestMessage {
+	return &NotifyReserveRatioChangedRequestMessage{
+		ThresholdsBasisPoints: thresholdsBasisPoints,
+	}
+}
+
+// NotifyReserveRatioChangedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyReserveRatioChangedResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyReserveRatioChangedResponseMessage) Command() MessageCommand {
+	return CmdNotifyReserveRatioChangedResponseMessage
+}
+
+// NewNotifyReserveRatioChangedResponseMessage returns a instance of the message
+func NewNotifyReserveRatioChangedResponseMessage() *NotifyReserveRatioChangedResponseMessage {
+	return &NotifyReserveRatioChangedResponseMessage{}
+}
+
+// ReserveRatioChangedNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type ReserveRatioChangedNotificationMessage struct {
+	baseMessage
+	ReserveRatioBasisPoints         uint64
+	PreviousReserveRatioBasisPoints uint64
+	CrossedThresholdsBasisPoints    []uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *ReserveRatioChangedNotificationMessage) Command() MessageCommand {
+	return CmdReserveRatioChangedNotificationMessage
+}
+
+// NewReserveRatioChangedNotificationMessage returns a instance of the message
+func NewReserveRatioChangedNotificationMessage(reserveRatioBasisPoints uint64,
+	previousReserveRatioBasisPoints uint64, crossedThresholdsBasisPoints []uint64) *ReserveRatioChangedNotificationMessage {
+
+	return &ReserveRatioChangedNotificationMessage{
+		ReserveRatioBasisPoints:         reserveRatioBasisPoints,
+		PreviousReserveRatioBasisPoints: previousReserveRatioBasisPoints,
+		CrossedThresholdsBasisPoints:    crossedThresholdsBasisPoints,
+	}
+}
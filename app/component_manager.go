@@ -3,15 +3,21 @@ package app
 import (
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+	miningmanagermodel "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
 
 	"github.com/Kash-Protocol/kashd/domain/miningmanager/mempool"
 
 	"github.com/Kash-Protocol/kashd/app/protocol"
 	"github.com/Kash-Protocol/kashd/app/rpc"
 	"github.com/Kash-Protocol/kashd/domain"
+	"github.com/Kash-Protocol/kashd/domain/addressindex"
 	"github.com/Kash-Protocol/kashd/domain/consensus"
+	"github.com/Kash-Protocol/kashd/domain/mempoolstore"
+	"github.com/Kash-Protocol/kashd/domain/txindex"
 	"github.com/Kash-Protocol/kashd/domain/utxoindex"
 	"github.com/Kash-Protocol/kashd/infrastructure/config"
 	infrastructuredatabase "github.com/Kash-Protocol/kashd/infrastructure/db/database"
@@ -22,6 +28,12 @@ import (
 	"github.com/Kash-Protocol/kashd/util/panics"
 )
 
+// oracleMovingAverageWindow is the number of fetched prices the oracle's moving averages are taken over
+const oracleMovingAverageWindow = 60
+
+// mempoolSaveInterval is how often the mempool is saved to the database, in addition to being saved on shutdown
+const mempoolSaveInterval = 5 * time.Minute
+
 // ComponentManager is a wrapper for all the kashd services
 type ComponentManager struct {
 	cfg               *config.Config
@@ -30,6 +42,8 @@ type ComponentManager struct {
 	rpcManager        *rpc.Manager
 	connectionManager *connmanager.ConnectionManager
 	netAdapter        *netadapter.NetAdapter
+	oracle            *oracle.Oracle // nil if no price feeds are configured
+	mempoolStore      *mempoolstore.MempoolStore
 
 	started, shutdown int32
 }
@@ -49,6 +63,12 @@ func (a *ComponentManager) Start() {
 	}
 
 	a.connectionManager.Start()
+
+	if a.oracle != nil {
+		a.oracle.Start()
+	}
+
+	a.mempoolStore.Start()
 }
 
 // Stop gracefully shuts down all the kashd services.
@@ -61,6 +81,10 @@ func (a *ComponentManager) Stop() {
 
 	log.Warnf("Kashd shutting down")
 
+	if a.oracle != nil {
+		a.oracle.Stop()
+	}
+
 	a.connectionManager.Stop()
 
 	err := a.netAdapter.Stop()
@@ -68,6 +92,11 @@ func (a *ComponentManager) Stop() {
 		log.Errorf("Error stopping the net adapter: %+v", err)
 	}
 
+	err = a.mempoolStore.Stop()
+	if err != nil {
+		log.Errorf("Error saving the mempool: %+v", err)
+	}
+
 	a.protocolManager.Close()
 	close(a.protocolManager.Context().Domain().ConsensusEventsChannel())
 
@@ -88,11 +117,38 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 	mempoolConfig.MaximumOrphanTransactionCount = cfg.MaxOrphanTxs
 	mempoolConfig.MinimumRelayTransactionFee = cfg.MinRelayTxFee
 
-	domain, err := domain.New(&consensusConfig, mempoolConfig, db)
+	var priceOracle *oracle.Oracle
+	var priceSource miningmanagermodel.PriceSource
+	if len(cfg.OracleURLs) > 0 {
+		// The price feeds are connected to the same way as peers, through the proxy if one is configured
+		oracleInstance, err := oracle.NewOracle(&oracle.Config{
+			URLPool:             cfg.OracleURLs,
+			PublicKeys:          cfg.ActiveNetParams.OraclePublicKeys,
+			Transport:           oracle.NewHTTPTransport(cfg.OracleFetchInterval, cfg.Dial),
+			FetchInterval:       cfg.OracleFetchInterval,
+			FetchTimeout:        cfg.OracleFetchInterval,
+			MaxRecordAge:        cfg.ActiveNetParams.OracleMaxRecordAge,
+			MinRecords:          cfg.ActiveNetParams.OracleMinRecords,
+			MovingAverageWindow: oracleMovingAverageWindow,
+		})
+		if err != nil {
+			return nil, err
+		}
+		priceOracle = oracleInstance
+		priceSource = oracleInstance
+	}
+
+	domain, err := domain.New(&consensusConfig, mempoolConfig, priceSource, db)
 	if err != nil {
 		return nil, err
 	}
 
+	mempoolStore := mempoolstore.New(domain, db, mempoolSaveInterval)
+	err = mempoolStore.Restore()
+	if err != nil {
+		log.Warnf("Failed to restore the saved mempool: %s", err)
+	}
+
 	netAdapter, err := netadapter.NewNetAdapter(cfg)
 	if err != nil {
 		return nil, err
@@ -113,6 +169,26 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 		log.Infof("UTXO index started")
 	}
 
+	var txIndex *txindex.TXIndex
+	if cfg.TXIndex {
+		txIndex, err = txindex.New(domain, db, cfg.IsArchivalNode)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("Transaction index started")
+	}
+
+	var addressIndex *addressindex.AddressIndex
+	if cfg.AddressIndex {
+		addressIndex, err = addressindex.New(domain, db, cfg.IsArchivalNode)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("Address index started")
+	}
+
 	connectionManager, err := connmanager.New(cfg, netAdapter, addressManager)
 	if err != nil {
 		return nil, err
@@ -121,7 +197,7 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 	if err != nil {
 		return nil, err
 	}
-	rpcManager := setupRPC(cfg, domain, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex, domain.ConsensusEventsChannel(), interrupt)
+	rpcManager := setupRPC(cfg, domain, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex, txIndex, addressIndex, domain.ConsensusEventsChannel(), interrupt)
 
 	return &ComponentManager{
 		cfg:               cfg,
@@ -130,6 +206,8 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 		connectionManager: connectionManager,
 		netAdapter:        netAdapter,
 		addressManager:    addressManager,
+		oracle:            priceOracle,
+		mempoolStore:      mempoolStore,
 	}, nil
 
 }
@@ -142,6 +220,8 @@ func setupRPC(
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	txIndex *txindex.TXIndex,
+	addressIndex *addressindex.AddressIndex,
 	consensusEventsChan chan externalapi.ConsensusEvent,
 	shutDownChan chan<- struct{},
 ) *rpc.Manager {
@@ -154,6 +234,8 @@ func setupRPC(
 		connectionManager,
 		addressManager,
 		utxoIndex,
+		txIndex,
+		addressIndex,
 		consensusEventsChan,
 		shutDownChan,
 	)
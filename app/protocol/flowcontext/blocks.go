@@ -143,6 +143,7 @@ func (f *FlowContext) TrySetIBDRunning(ibdPeer *peerpkg.Peer) bool {
 		return false
 	}
 	f.ibdPeer = ibdPeer
+	ibdRunning.Set(1)
 	log.Infof("IBD started with peer %s", ibdPeer)
 
 	return true
@@ -158,6 +159,7 @@ func (f *FlowContext) UnsetIBDRunning() {
 	}
 
 	f.ibdPeer = nil
+	ibdRunning.Set(0)
 }
 
 // IBDPeer returns the current IBD peer or null if the node is not
@@ -55,6 +55,9 @@ type FlowContext struct {
 	peers      map[id.ID]*peerpkg.Peer
 	peersMutex sync.RWMutex
 
+	misbehaviorScores      map[string]*misbehaviorScore
+	misbehaviorScoresMutex sync.Mutex
+
 	orphans      map[externalapi.DomainHash]*externalapi.DomainBlock
 	orphansMutex sync.RWMutex
 
@@ -78,6 +81,7 @@ func New(cfg *config.Config, domain domain.Domain, addressManager *addressmanage
 		sharedRequestedTransactions:      NewSharedRequestedTransactions(),
 		sharedRequestedBlocks:            NewSharedRequestedBlocks(),
 		peers:                            make(map[id.ID]*peerpkg.Peer),
+		misbehaviorScores:                make(map[string]*misbehaviorScore),
 		orphans:                          make(map[externalapi.DomainHash]*externalapi.DomainBlock),
 		timeStarted:                      mstime.Now().UnixMilliseconds(),
 		transactionIDsToPropagate:        []*externalapi.DomainTransactionID{},
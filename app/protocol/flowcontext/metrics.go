@@ -0,0 +1,28 @@
+package flowcontext
+
+import (
+	"strconv"
+
+	"github.com/Kash-Protocol/kashd/infrastructure/metrics"
+)
+
+const (
+	directionInbound  = "inbound"
+	directionOutbound = "outbound"
+)
+
+var (
+	peerCount = metrics.NewGaugeVec("kashd_peers",
+		"The number of connected peers, by the direction of the connection and by protocol version",
+		"direction", "protocol_version")
+	ibdRunning = metrics.NewGauge("kashd_ibd_running",
+		"Whether an initial block download is currently running")
+)
+
+func peerCountGauge(isOutbound bool, protocolVersion uint32) *metrics.Gauge {
+	direction := directionInbound
+	if isOutbound {
+		direction = directionOutbound
+	}
+	return peerCount.WithLabelValues(direction, strconv.FormatUint(uint64(protocolVersion), 10))
+}
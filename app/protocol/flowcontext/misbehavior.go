@@ -0,0 +1,95 @@
+package flowcontext
+
+import (
+	"net"
+	"time"
+
+	"github.com/Kash-Protocol/kashd/app/protocol/protocolerrors"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter"
+)
+
+// misbehaviorScore is the misbehavior score of an address. It is kept by address rather
+// than by connection, so that a misbehaving peer cannot reset its score by reconnecting.
+type misbehaviorScore struct {
+	score           uint32
+	lastMisbehavior time.Time
+}
+
+// IsProxiedConnection returns whether the given connection is an inbound connection from a
+// loopback address. That's how inbound peers arrive through a local Tor daemon or another local
+// proxy, so the address of such a connection is shared by unrelated peers, and can't be
+// scored or banned.
+func IsProxiedConnection(netConnection *netadapter.NetConnection) bool {
+	return isProxiedAddress(netConnection.IsOutbound(), netConnection.NetAddress().IP)
+}
+
+func isProxiedAddress(isOutbound bool, ip net.IP) bool {
+	return !isOutbound && ip != nil && ip.IsLoopback()
+}
+
+// misbehaviorScoreKey returns the key the misbehavior score of the given connection is kept by.
+// Proxied connections are scored by connection, so that a misbehaving peer doesn't get the other
+// peers behind the same proxy disconnected.
+func misbehaviorScoreKey(netConnection *netadapter.NetConnection) string {
+	if IsProxiedConnection(netConnection) {
+		return netConnection.Address()
+	}
+	return netConnection.NetAddress().Host()
+}
+
+// isMisbehaviorScoreExpired returns whether the address did not misbehave for a whole ban duration,
+// in which case its score is forgotten
+func (f *FlowContext) isMisbehaviorScoreExpired(score *misbehaviorScore) bool {
+	return time.Since(score.lastMisbehavior) > f.cfg.BanDuration
+}
+
+// AddMisbehavior adds the weight of the given misbehavior to the misbehavior score of the
+// address of the given connection, and returns the new score. The score of the connected peers
+// of that address is updated as well.
+func (f *FlowContext) AddMisbehavior(netConnection *netadapter.NetConnection,
+	misbehavior protocolerrors.Misbehavior) uint32 {
+
+	key := misbehaviorScoreKey(netConnection)
+	score := f.addMisbehaviorScore(key, misbehavior.Weight())
+
+	for _, peer := range f.Peers() {
+		if misbehaviorScoreKey(peer.Connection()) == key {
+			peer.SetMisbehaviorScore(score)
+		}
+	}
+
+	return score
+}
+
+func (f *FlowContext) addMisbehaviorScore(key string, weight uint32) uint32 {
+	f.misbehaviorScoresMutex.Lock()
+	defer f.misbehaviorScoresMutex.Unlock()
+
+	for scoreKey, score := range f.misbehaviorScores {
+		if f.isMisbehaviorScoreExpired(score) {
+			delete(f.misbehaviorScores, scoreKey)
+		}
+	}
+
+	score, ok := f.misbehaviorScores[key]
+	if !ok {
+		score = &misbehaviorScore{}
+		f.misbehaviorScores[key] = score
+	}
+	score.score += weight
+	score.lastMisbehavior = time.Now()
+
+	return score.score
+}
+
+// MisbehaviorScore returns the misbehavior score of the address of the given connection
+func (f *FlowContext) MisbehaviorScore(netConnection *netadapter.NetConnection) uint32 {
+	f.misbehaviorScoresMutex.Lock()
+	defer f.misbehaviorScoresMutex.Unlock()
+
+	score, ok := f.misbehaviorScores[misbehaviorScoreKey(netConnection)]
+	if !ok || f.isMisbehaviorScoreExpired(score) {
+		return 0
+	}
+	return score.score
+}
@@ -0,0 +1,64 @@
+package flowcontext
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Kash-Protocol/kashd/app/protocol/protocolerrors"
+	"github.com/Kash-Protocol/kashd/infrastructure/config"
+)
+
+func TestAddMisbehaviorScore(t *testing.T) {
+	cfg := config.DefaultConfig()
+	flowContext := &FlowContext{
+		cfg:               cfg,
+		misbehaviorScores: make(map[string]*misbehaviorScore),
+	}
+
+	const address = "1.2.3.4"
+	const otherAddress = "5.6.7.8"
+
+	score := flowContext.addMisbehaviorScore(address, protocolerrors.MisbehaviorUnrequestedMessage.Weight())
+	score = flowContext.addMisbehaviorScore(address, protocolerrors.MisbehaviorSlowResponse.Weight())
+	expectedScore := protocolerrors.MisbehaviorUnrequestedMessage.Weight() + protocolerrors.MisbehaviorSlowResponse.Weight()
+	if score != expectedScore {
+		t.Fatalf("Expected a misbehavior score of %d, but got %d", expectedScore, score)
+	}
+	if score > cfg.BanThreshold {
+		t.Fatalf("Expected a single unrequested message and a single slow response not to exceed the ban threshold")
+	}
+
+	otherScore := flowContext.addMisbehaviorScore(otherAddress, protocolerrors.MisbehaviorInvalidBlock.Weight())
+	if otherScore != protocolerrors.MisbehaviorInvalidBlock.Weight() {
+		t.Fatalf("Expected the score of a different address to be kept separately, but got %d", otherScore)
+	}
+
+	// A score is forgotten once its address did not misbehave for a whole ban duration
+	flowContext.misbehaviorScores[address].lastMisbehavior = time.Now().Add(-cfg.BanDuration - time.Second)
+	score = flowContext.addMisbehaviorScore(address, protocolerrors.MisbehaviorSlowResponse.Weight())
+	if score != protocolerrors.MisbehaviorSlowResponse.Weight() {
+		t.Fatalf("Expected an expired score to be reset, but got %d", score)
+	}
+}
+
+func TestIsProxiedAddress(t *testing.T) {
+	tests := []struct {
+		name       string
+		isOutbound bool
+		ip         net.IP
+		expected   bool
+	}{
+		{name: "inbound from IPv4 loopback", ip: net.ParseIP("127.0.0.1"), expected: true},
+		{name: "inbound from IPv6 loopback", ip: net.ParseIP("::1"), expected: true},
+		{name: "outbound to loopback", isOutbound: true, ip: net.ParseIP("127.0.0.1"), expected: false},
+		{name: "inbound from a public address", ip: net.ParseIP("1.2.3.4"), expected: false},
+		{name: "outbound to an onion address", isOutbound: true, expected: false},
+	}
+
+	for _, test := range tests {
+		if isProxiedAddress(test.isOutbound, test.ip) != test.expected {
+			t.Errorf("%s: expected isProxiedAddress to return %t", test.name, test.expected)
+		}
+	}
+}
@@ -21,6 +21,8 @@ func (f *FlowContext) ConnectionManager() *connmanager.ConnectionManager {
 
 // AddToPeers marks this peer as ready and adds it to the ready peers list.
 func (f *FlowContext) AddToPeers(peer *peerpkg.Peer) error {
+	peer.SetMisbehaviorScore(f.MisbehaviorScore(peer.Connection()))
+
 	f.peersMutex.Lock()
 	defer f.peersMutex.Unlock()
 
@@ -29,6 +31,7 @@ func (f *FlowContext) AddToPeers(peer *peerpkg.Peer) error {
 	}
 
 	f.peers[*peer.ID()] = peer
+	peerCountGauge(peer.IsOutbound(), peer.ProtocolVersion()).Inc()
 
 	return nil
 }
@@ -38,7 +41,11 @@ func (f *FlowContext) RemoveFromPeers(peer *peerpkg.Peer) {
 	f.peersMutex.Lock()
 	defer f.peersMutex.Unlock()
 
+	if existingPeer, ok := f.peers[*peer.ID()]; !ok || existingPeer != peer {
+		return
+	}
 	delete(f.peers, *peer.ID())
+	peerCountGauge(peer.IsOutbound(), peer.ProtocolVersion()).Dec()
 }
 
 // readyPeerConnections returns the NetConnections of all the ready peers.
@@ -26,8 +26,9 @@ func (flow *handleRelayInvsFlow) receiveBlockLocator() (blockLocatorHashes []*ex
 			return message.BlockLocatorHashes, nil
 		default:
 			return nil,
-				protocolerrors.Errorf(true, "received unexpected message type. "+
-					"expected: %s, got: %s", appmessage.CmdBlockLocator, message.Command())
+				protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+					"received unexpected message type. "+
+						"expected: %s, got: %s", appmessage.CmdBlockLocator, message.Command())
 		}
 	}
 }
@@ -128,7 +128,22 @@ func HandlePruningPointAndItsAnticoneRequests(context PruningPointAndItsAnticone
 					return protocolerrors.Errorf(false, "pruning point anticone block %s not found", blockHash)
 				}
 
-				err = outgoingRoute.Enqueue(appmessage.DomainBlockWithTrustedDataToBlockWithTrustedDataV4(block, trustedDataDAABlockIndexes[*blockHash], trustedDataGHOSTDAGDataIndexes[*blockHash]))
+				oraclePrices, _, err := context.Domain().Consensus().TrustedOraclePrices(blockHash)
+				if err != nil {
+					return err
+				}
+
+				// The pruning point comes first, and only its reserve state is committed to and sent
+				var reserveState *externalapi.ReserveState
+				if i == 0 {
+					reserveState, err = context.Domain().Consensus().TrustedReserveState(blockHash)
+					if err != nil {
+						return err
+					}
+				}
+
+				err = outgoingRoute.Enqueue(appmessage.DomainBlockWithTrustedDataToBlockWithTrustedDataV4(block,
+					trustedDataDAABlockIndexes[*blockHash], trustedDataGHOSTDAGDataIndexes[*blockHash], oraclePrices, reserveState))
 				if err != nil {
 					return err
 				}
@@ -141,8 +156,9 @@ func HandlePruningPointAndItsAnticoneRequests(context PruningPointAndItsAnticone
 						return err
 					}
 					if _, ok := message.(*appmessage.MsgRequestNextPruningPointAndItsAnticoneBlocks); !ok {
-						return protocolerrors.Errorf(true, "received unexpected message type. "+
-							"expected: %s, got: %s", appmessage.CmdRequestNextPruningPointAndItsAnticoneBlocks, message.Command())
+						return protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+							"received unexpected message type. "+
+								"expected: %s, got: %s", appmessage.CmdRequestNextPruningPointAndItsAnticoneBlocks, message.Command())
 					}
 				}
 			}
@@ -85,7 +85,8 @@ func (flow *handleRelayInvsFlow) start() error {
 		}
 		if blockInfo.Exists && blockInfo.BlockStatus != externalapi.StatusHeaderOnly {
 			if blockInfo.BlockStatus == externalapi.StatusInvalid {
-				return protocolerrors.Errorf(true, "sent inv of an invalid block %s",
+				return protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorInvalidBlock,
+					"sent inv of an invalid block %s",
 					inv.Hash)
 			}
 			log.Debugf("Block %s already exists. continuing...", inv.Hash)
@@ -266,8 +267,9 @@ func (flow *handleRelayInvsFlow) readInv() (invRelayBlock, error) {
 
 	msgInv, ok := msg.(*appmessage.MsgInvRelayBlock)
 	if !ok {
-		return invRelayBlock{}, protocolerrors.Errorf(true, "unexpected %s message in the block relay handleRelayInvsFlow while "+
-			"expecting an inv message", msg.Command())
+		return invRelayBlock{}, protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+			"unexpected %s message in the block relay handleRelayInvsFlow while "+
+				"expecting an inv message", msg.Command())
 	}
 	return invRelayBlock{Hash: msgInv.Hash, IsOrphanRoot: false}, nil
 }
@@ -296,7 +298,8 @@ func (flow *handleRelayInvsFlow) requestBlock(requestHash *externalapi.DomainHas
 	block := appmessage.MsgBlockToDomainBlock(msgBlock)
 	blockHash := consensushashing.BlockHash(block)
 	if !blockHash.Equal(requestHash) {
-		return nil, false, protocolerrors.Errorf(true, "got unrequested block %s", blockHash)
+		return nil, false, protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+			"got unrequested block %s", blockHash)
 	}
 
 	return block, false, nil
@@ -339,7 +342,8 @@ func (flow *handleRelayInvsFlow) processBlock(block *externalapi.DomainBlock) ([
 		if !errors.Is(err, ruleerrors.ErrDuplicateBlock) {
 			log.Warnf("Rejected block %s from %s: %s", blockHash, flow.peer, err)
 		}
-		return nil, protocolerrors.Wrapf(true, err, "got invalid block %s from relay", blockHash)
+		return nil, protocolerrors.MisbehaviorWrapf(protocolerrors.MisbehaviorInvalidBlock, err,
+			"got invalid block %s from relay", blockHash)
 	}
 	return nil, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/ruleerrors"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/pow"
 	"github.com/Kash-Protocol/kashd/infrastructure/config"
 	"github.com/Kash-Protocol/kashd/infrastructure/logger"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
@@ -189,7 +190,8 @@ func (flow *handleIBDFlow) negotiateMissingSyncerChainSegment() (*externalapi.Do
 			}
 			if info.Exists {
 				if info.BlockStatus == externalapi.StatusInvalid {
-					return nil, nil, protocolerrors.Errorf(true, "Sent invalid chain block %s", syncerChainHash)
+					return nil, nil, protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorInvalidBlock,
+						"Sent invalid chain block %s", syncerChainHash)
 				}
 
 				isPruningPointOnSyncerChain, err := flow.Domain().Consensus().IsInSelectedParentChainOf(pruningPoint, syncerChainHash)
@@ -334,8 +336,9 @@ func (flow *handleIBDFlow) getSyncerChainBlockLocator(
 		}
 		return message.BlockLocatorHashes, nil
 	default:
-		return nil, protocolerrors.Errorf(true, "received unexpected message type. "+
-			"expected: %s, got: %s", appmessage.CmdIBDChainBlockLocator, message.Command())
+		return nil, protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+			"received unexpected message type. "+
+				"expected: %s, got: %s", appmessage.CmdIBDChainBlockLocator, message.Command())
 	}
 }
 
@@ -363,6 +366,8 @@ func (flow *handleIBDFlow) syncPruningPointFutureHeaders(consensus externalapi.C
 
 	// Keep a short queue of BlockHeadersMessages so that there's
 	// never a moment when the node is not validating and inserting
+	// headers. The proof of work of the queued headers is calculated
+	// before they're queued, in parallel to the insertion of previous
 	// headers
 	blockHeadersMessageChan := make(chan *appmessage.BlockHeadersMessage, 2)
 	errChan := make(chan error)
@@ -383,6 +388,11 @@ func (flow *handleIBDFlow) syncPruningPointFutureHeaders(consensus externalapi.C
 				return
 			}
 
+			err = calculateProofOfWorkValues(consensus, blockHeadersMessage.BlockHeaders)
+			if err != nil {
+				errChan <- err
+				return
+			}
 			blockHeadersMessageChan <- blockHeadersMessage
 
 			err = flow.outgoingRoute.Enqueue(appmessage.NewMsgRequestNextHeaders())
@@ -446,6 +456,10 @@ func (flow *handleIBDFlow) syncMissingRelayPast(consensus externalapi.Consensus,
 				"Expected only one anticone header chunk for past(%s) cap anticone(%s)",
 				relayBlockHash, syncerHeaderSelectedTipHash)
 		}
+		err = calculateProofOfWorkValues(consensus, anticoneHeadersMessage.BlockHeaders)
+		if err != nil {
+			return err
+		}
 		for _, header := range anticoneHeadersMessage.BlockHeaders {
 			err = flow.processHeader(consensus, header)
 			if err != nil {
@@ -492,8 +506,9 @@ func (flow *handleIBDFlow) receiveHeaders() (msgIBDBlock *appmessage.BlockHeader
 		return nil, true, nil
 	default:
 		return nil, false,
-			protocolerrors.Errorf(true, "received unexpected message type. "+
-				"expected: %s or %s, got: %s",
+			protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+				"received unexpected message type. "+
+					"expected: %s or %s, got: %s",
 				appmessage.CmdBlockHeaders,
 				appmessage.CmdDoneHeaders,
 				message.Command())
@@ -526,13 +541,37 @@ func (flow *handleIBDFlow) processHeader(consensus externalapi.Consensus, msgBlo
 			log.Debugf("Skipping block header %s as it is a duplicate", blockHash)
 		} else {
 			log.Infof("Rejected block header %s from %s during IBD: %s", blockHash, flow.peer, err)
-			return protocolerrors.Wrapf(true, err, "got invalid block header %s during IBD", blockHash)
+			return protocolerrors.MisbehaviorWrapf(protocolerrors.MisbehaviorInvalidBlock, err,
+				"got invalid block header %s during IBD", blockHash)
 		}
 	}
 
 	return nil
 }
 
+// calculateProofOfWorkValues calculates the proof of work values of the given headers in
+// parallel, so that processing them one by one doesn't wait on RandomX for each of them.
+//
+// The seed of a header's proof of work is its pruning point, so headers whose pruning point
+// is unknown are skipped, rather than having RandomX initialized for a seed a peer made up.
+// Their proof of work is calculated when they're processed, once their pruning point is validated.
+func calculateProofOfWorkValues(consensus externalapi.Consensus, msgBlockHeaders []*appmessage.MsgBlockHeader) error {
+	headers := make([]externalapi.BlockHeader, 0, len(msgBlockHeaders))
+	for _, msgBlockHeader := range msgBlockHeaders {
+		header := appmessage.BlockHeaderToDomainBlockHeader(msgBlockHeader)
+		pruningPointInfo, err := consensus.GetBlockInfo(header.PruningPoint())
+		if err != nil {
+			return err
+		}
+		if !pruningPointInfo.Exists {
+			continue
+		}
+		headers = append(headers, header)
+	}
+	pow.CalculateProofOfWorkValues(headers)
+	return nil
+}
+
 func (flow *handleIBDFlow) validatePruningPointFutureHeaderTimestamps() error {
 	headerSelectedTipHash, err := flow.Domain().StagingConsensus().GetHeadersSelectedTip()
 	if err != nil {
@@ -614,8 +653,9 @@ func (flow *handleIBDFlow) receiveAndInsertPruningPointUTXOSet(
 			return false, nil
 
 		default:
-			return false, protocolerrors.Errorf(true, "received unexpected message type. "+
-				"expected: %s or %s or %s, got: %s", appmessage.CmdPruningPointUTXOSetChunk,
+			return false, protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+				"received unexpected message type. "+
+					"expected: %s or %s or %s, got: %s", appmessage.CmdPruningPointUTXOSetChunk,
 				appmessage.CmdDonePruningPointUTXOSetChunks, appmessage.CmdUnexpectedPruningPoint, message.Command(),
 			)
 		}
@@ -673,14 +713,16 @@ func (flow *handleIBDFlow) syncMissingBlockBodies(highHash *externalapi.DomainHa
 
 			msgIBDBlock, ok := message.(*appmessage.MsgIBDBlock)
 			if !ok {
-				return protocolerrors.Errorf(true, "received unexpected message type. "+
-					"expected: %s, got: %s", appmessage.CmdIBDBlock, message.Command())
+				return protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+					"received unexpected message type. "+
+						"expected: %s, got: %s", appmessage.CmdIBDBlock, message.Command())
 			}
 
 			block := appmessage.MsgBlockToDomainBlock(msgIBDBlock.MsgBlock)
 			blockHash := consensushashing.BlockHash(block)
 			if !expectedHash.Equal(blockHash) {
-				return protocolerrors.Errorf(true, "expected block %s but got %s", expectedHash, blockHash)
+				return protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+					"expected block %s but got %s", expectedHash, blockHash)
 			}
 
 			err = flow.banIfBlockIsHeaderOnly(block)
@@ -14,6 +14,8 @@ func newIBDProgressReporter(lowDAAScore uint64, highDAAScore uint64, objectName
 		// Avoid a zero or negative diff
 		highDAAScore = lowDAAScore + 1
 	}
+	ibdProgress.WithLabelValues(objectName).Set(0)
+	ibdProcessed.WithLabelValues(objectName).Set(0)
 	return &ibdProgressReporter{
 		lowDAAScore:                 lowDAAScore,
 		highDAAScore:                highDAAScore,
@@ -37,7 +39,11 @@ func (ipr *ibdProgressReporter) reportProgress(processedDelta int, highestProces
 		// Avoid a negative diff
 		relativeDAAScore = highestProcessedDAAScore - ipr.lowDAAScore
 	}
-	progressPercent := int((float64(relativeDAAScore) / float64(ipr.totalDAAScoreDifference)) * 100)
+	progress := float64(relativeDAAScore) / float64(ipr.totalDAAScoreDifference)
+	ibdProgress.WithLabelValues(ipr.objectName).Set(progress)
+	ibdProcessed.WithLabelValues(ipr.objectName).Set(float64(ipr.processed))
+
+	progressPercent := int(progress * 100)
 	if progressPercent > ipr.lastReportedProgressPercent {
 		log.Infof("IBD: Processed %d %s (%d%%)", ipr.processed, ipr.objectName, progressPercent)
 		ipr.lastReportedProgressPercent = progressPercent
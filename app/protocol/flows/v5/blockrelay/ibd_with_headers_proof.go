@@ -126,14 +126,16 @@ func (flow *handleIBDFlow) syncAndValidatePruningPointProof() (*externalapi.Doma
 	}
 	pruningPointProofMessage, ok := message.(*appmessage.MsgPruningPointProof)
 	if !ok {
-		return nil, protocolerrors.Errorf(true, "received unexpected message type. "+
-			"expected: %s, got: %s", appmessage.CmdPruningPointProof, message.Command())
+		return nil, protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+			"received unexpected message type. "+
+				"expected: %s, got: %s", appmessage.CmdPruningPointProof, message.Command())
 	}
 	pruningPointProof := appmessage.MsgPruningPointProofToDomainPruningPointProof(pruningPointProofMessage)
 	err = flow.Domain().Consensus().ValidatePruningPointProof(pruningPointProof)
 	if err != nil {
 		if errors.As(err, &ruleerrors.RuleError{}) {
-			return nil, protocolerrors.Wrapf(true, err, "pruning point proof validation failed")
+			return nil, protocolerrors.MisbehaviorWrapf(protocolerrors.MisbehaviorInvalidBlock, err,
+				"pruning point proof validation failed")
 		}
 		return nil, err
 	}
@@ -220,8 +222,9 @@ func (flow *handleIBDFlow) syncPruningPointsAndPruningPointAnticone(proofPruning
 
 	msgTrustedData, ok := message.(*appmessage.MsgTrustedData)
 	if !ok {
-		return protocolerrors.Errorf(true, "received unexpected message type. "+
-			"expected: %s, got: %s", appmessage.CmdTrustedData, message.Command())
+		return protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+			"received unexpected message type. "+
+				"expected: %s, got: %s", appmessage.CmdTrustedData, message.Command())
 	}
 
 	pruningPointWithMetaData, done, err := flow.receiveBlockWithTrustedData()
@@ -280,6 +283,8 @@ func (flow *handleIBDFlow) processBlockWithTrustedData(
 		Block:        appmessage.MsgBlockToDomainBlock(block.Block),
 		DAAWindow:    make([]*externalapi.TrustedDataDataDAAHeader, 0, len(block.DAAWindowIndices)),
 		GHOSTDAGData: make([]*externalapi.BlockGHOSTDAGDataHashPair, 0, len(block.GHOSTDAGDataIndices)),
+		OraclePrices: block.OraclePrices,
+		ReserveState: block.ReserveState,
 	}
 
 	for _, index := range block.DAAWindowIndices {
@@ -293,7 +298,8 @@ func (flow *handleIBDFlow) processBlockWithTrustedData(
 	err := consensus.ValidateAndInsertBlockWithTrustedData(blockWithTrustedData, false)
 	if err != nil {
 		if errors.As(err, &ruleerrors.RuleError{}) {
-			return protocolerrors.Wrapf(true, err, "failed validating block with trusted data")
+			return protocolerrors.MisbehaviorWrapf(protocolerrors.MisbehaviorInvalidBlock, err,
+				"failed validating block with trusted data")
 		}
 		return err
 	}
@@ -313,8 +319,9 @@ func (flow *handleIBDFlow) receiveBlockWithTrustedData() (*appmessage.MsgBlockWi
 		return nil, true, nil
 	default:
 		return nil, false,
-			protocolerrors.Errorf(true, "received unexpected message type. "+
-				"expected: %s or %s, got: %s",
+			protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+				"received unexpected message type. "+
+					"expected: %s or %s, got: %s",
 				(&appmessage.MsgBlockWithTrustedData{}).Command(),
 				(&appmessage.MsgDoneBlocksWithTrustedData{}).Command(),
 				downCastedMessage.Command())
@@ -330,8 +337,9 @@ func (flow *handleIBDFlow) receivePruningPoints() (*appmessage.MsgPruningPoints,
 	msgPruningPoints, ok := message.(*appmessage.MsgPruningPoints)
 	if !ok {
 		return nil,
-			protocolerrors.Errorf(true, "received unexpected message type. "+
-				"expected: %s, got: %s", appmessage.CmdPruningPoints, message.Command())
+			protocolerrors.MisbehaviorErrorf(protocolerrors.MisbehaviorUnrequestedMessage,
+				"received unexpected message type. "+
+					"expected: %s, got: %s", appmessage.CmdPruningPoints, message.Command())
 	}
 
 	return msgPruningPoints, nil
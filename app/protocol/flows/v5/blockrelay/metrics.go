@@ -0,0 +1,14 @@
+package blockrelay
+
+import (
+	"github.com/Kash-Protocol/kashd/infrastructure/metrics"
+)
+
+var (
+	ibdProgress = metrics.NewGaugeVec("kashd_ibd_progress_ratio",
+		"The progress of the current or last initial block download, between 0 and 1, by the objects being downloaded",
+		"objects")
+	ibdProcessed = metrics.NewGaugeVec("kashd_ibd_processed",
+		"The number of objects processed by the current or last initial block download, by the objects being downloaded",
+		"objects")
+)
@@ -70,7 +70,7 @@ func TestHandleRelayedTransactionsNotFound(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to create a NetAdapter: %v", err)
 		}
-		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), tc.Database())
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), nil, tc.Database())
 		if err != nil {
 			t.Fatalf("Failed to set up a domain instance: %v", err)
 		}
@@ -138,6 +138,9 @@ func TestHandleRelayedTransactionsNotFound(t *testing.T) {
 			if !protocolErr.ShouldBan {
 				t.Fatalf("Exepcted shouldBan true, but got false.")
 			}
+			if protocolErr.Misbehavior != protocolerrors.MisbehaviorUnrequestedMessage {
+				t.Fatalf("Expected an unrequested message misbehavior, but got %s", protocolErr.Misbehavior)
+			}
 			if !strings.Contains(err.Error(), "unexpected Addresses [code 3] message in the block relay flow while expecting an inv message") {
 				t.Fatalf("Unexpected error: expected: an error due to existence of an Addresses message "+
 					"in the block relay flow, but got: %v", protocolErr.Cause)
@@ -163,7 +166,7 @@ func TestOnClosedIncomingRoute(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to creat a NetAdapter : %v", err)
 		}
-		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), tc.Database())
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), nil, tc.Database())
 		if err != nil {
 			t.Fatalf("Failed to set up a domain instance: %v", err)
 		}
@@ -37,7 +37,7 @@ func TestHandleRequestedTransactionsNotFound(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to create a NetAdapter: %v", err)
 		}
-		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), tc.Database())
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), nil, tc.Database())
 		if err != nil {
 			t.Fatalf("Failed to set up a domain Instance: %v", err)
 		}
@@ -32,6 +32,9 @@ type Peer struct {
 	lastPingTime     time.Time     // Time we sent last ping
 	lastPingDuration time.Duration // Time for last ping to return
 
+	misbehaviorScoreLock sync.RWMutex
+	misbehaviorScore     uint32 // The misbehavior score of the peer's address
+
 	ibdRequestChannel chan *externalapi.DomainBlock // A channel used to communicate IBD requests between flows
 }
 
@@ -129,6 +132,22 @@ func (p *Peer) SetPingIdle() {
 	p.lastPingDuration = time.Since(p.lastPingTime)
 }
 
+// MisbehaviorScore returns the misbehavior score of the peer
+func (p *Peer) MisbehaviorScore() uint32 {
+	p.misbehaviorScoreLock.RLock()
+	defer p.misbehaviorScoreLock.RUnlock()
+
+	return p.misbehaviorScore
+}
+
+// SetMisbehaviorScore sets the misbehavior score of the peer
+func (p *Peer) SetMisbehaviorScore(misbehaviorScore uint32) {
+	p.misbehaviorScoreLock.Lock()
+	defer p.misbehaviorScoreLock.Unlock()
+
+	p.misbehaviorScore = misbehaviorScore
+}
+
 func (p *Peer) String() string {
 	return p.connection.String()
 }
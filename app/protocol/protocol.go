@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"github.com/Kash-Protocol/kashd/app/protocol/common"
+	"github.com/Kash-Protocol/kashd/app/protocol/flowcontext"
 	"github.com/Kash-Protocol/kashd/app/protocol/flows/ready"
 	"github.com/Kash-Protocol/kashd/app/protocol/flows/v5"
 	"sync"
@@ -106,18 +107,8 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 
 func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection, outgoingRoute *routerpkg.Route) {
 	if protocolErr := (protocolerrors.ProtocolError{}); errors.As(err, &protocolErr) {
-		if m.context.Config().EnableBanning && protocolErr.ShouldBan {
-			log.Warnf("Banning %s (reason: %s)", netConnection, protocolErr.Cause)
-
-			err := m.context.ConnectionManager().Ban(netConnection)
-			if err != nil && !errors.Is(err, connmanager.ErrCannotBanPermanent) {
-				panic(err)
-			}
-
-			err = outgoingRoute.Enqueue(appmessage.NewMsgReject(protocolErr.Error()))
-			if err != nil && !errors.Is(err, routerpkg.ErrRouteClosed) {
-				panic(err)
-			}
+		if protocolErr.ShouldBan {
+			m.handleMisbehavior(netConnection, outgoingRoute, protocolErr.Misbehavior, protocolErr)
 		}
 		log.Infof("Disconnecting from %s (reason: %s)", netConnection, protocolErr.Cause)
 		netConnection.Disconnect()
@@ -125,6 +116,7 @@ func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection
 	}
 	if errors.Is(err, routerpkg.ErrTimeout) {
 		log.Warnf("Got timeout from %s. Disconnecting...", netConnection)
+		m.handleMisbehavior(netConnection, outgoingRoute, protocolerrors.MisbehaviorSlowResponse, err)
 		netConnection.Disconnect()
 		return
 	}
@@ -134,6 +126,39 @@ func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection
 	panic(err)
 }
 
+// handleMisbehavior adds the given misbehavior to the misbehavior score of the peer, and bans
+// the peer if its score exceeds the ban threshold
+func (m *Manager) handleMisbehavior(netConnection *netadapter.NetConnection, outgoingRoute *routerpkg.Route,
+	misbehavior protocolerrors.Misbehavior, reason error) {
+
+	misbehaviorScore := m.context.AddMisbehavior(netConnection, misbehavior)
+	if !m.context.Config().EnableBanning || misbehaviorScore <= m.context.Config().BanThreshold {
+		log.Infof("Misbehavior score of %s increased to %d (%s: %s)",
+			netConnection, misbehaviorScore, misbehavior, reason)
+		return
+	}
+
+	// Banning a proxied connection would ban the proxy, and with it every other peer behind it.
+	// The connection is disconnected regardless.
+	if flowcontext.IsProxiedConnection(netConnection) {
+		log.Warnf("Not banning proxied connection %s despite its misbehavior score of %d (reason: %s)",
+			netConnection, misbehaviorScore, reason)
+		return
+	}
+
+	log.Warnf("Banning %s (misbehavior score: %d, reason: %s)", netConnection, misbehaviorScore, reason)
+
+	err := m.context.ConnectionManager().Ban(netConnection)
+	if err != nil && !errors.Is(err, connmanager.ErrCannotBanPermanent) {
+		panic(err)
+	}
+
+	err = outgoingRoute.Enqueue(appmessage.NewMsgReject(reason.Error()))
+	if err != nil && !errors.Is(err, routerpkg.ErrRouteClosed) {
+		panic(err)
+	}
+}
+
 // RegisterFlow registers a flow to the given router.
 func (m *Manager) RegisterFlow(name string, router *routerpkg.Router, messageTypes []appmessage.MessageCommand, isStopping *uint32,
 	errChan chan error, initializeFunc common.FlowInitializeFunc) *common.Flow {
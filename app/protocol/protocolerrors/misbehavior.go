@@ -0,0 +1,53 @@
+package protocolerrors
+
+// Misbehavior is the kind of protocol violation a peer committed
+type Misbehavior uint8
+
+// These constants define the kinds of misbehavior, from the most severe to the least
+const (
+	// MisbehaviorProtocolViolation is any violation of the protocol that is not of a more specific kind
+	MisbehaviorProtocolViolation Misbehavior = iota
+
+	// MisbehaviorInvalidBlock is sending a block, a block header or a pruning point proof
+	// that violates the consensus rules
+	MisbehaviorInvalidBlock
+
+	// MisbehaviorBadTransaction is relaying a transaction that violates the consensus rules
+	MisbehaviorBadTransaction
+
+	// MisbehaviorUnrequestedMessage is sending a message that was not requested, or a message
+	// other than the one that was
+	MisbehaviorUnrequestedMessage
+
+	// MisbehaviorSlowResponse is not responding to a request in time
+	MisbehaviorSlowResponse
+)
+
+var misbehaviorWeights = map[Misbehavior]uint32{
+	MisbehaviorProtocolViolation:  50,
+	MisbehaviorInvalidBlock:       100,
+	MisbehaviorBadTransaction:     20,
+	MisbehaviorUnrequestedMessage: 20,
+	MisbehaviorSlowResponse:       10,
+}
+
+var misbehaviorStrings = map[Misbehavior]string{
+	MisbehaviorProtocolViolation:  "protocol violation",
+	MisbehaviorInvalidBlock:       "invalid block",
+	MisbehaviorBadTransaction:     "bad transaction",
+	MisbehaviorUnrequestedMessage: "unrequested message",
+	MisbehaviorSlowResponse:       "slow response",
+}
+
+// Weight returns how much the misbehavior adds to the misbehavior score of a peer
+func (m Misbehavior) Weight() uint32 {
+	return misbehaviorWeights[m]
+}
+
+func (m Misbehavior) String() string {
+	misbehaviorString, ok := misbehaviorStrings[m]
+	if !ok {
+		return "unknown misbehavior"
+	}
+	return misbehaviorString
+}
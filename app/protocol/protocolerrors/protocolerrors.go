@@ -6,10 +6,14 @@ import (
 )
 
 // ProtocolError is an error that signifies a violation
-// of the peer-to-peer protocol
+// of the peer-to-peer protocol. If ShouldBan is set, the
+// weight of Misbehavior is added to the misbehavior score
+// of the peer, which is banned once its score exceeds the
+// ban threshold.
 type ProtocolError struct {
-	ShouldBan bool
-	Cause     error
+	ShouldBan   bool
+	Misbehavior Misbehavior
+	Cause       error
 }
 
 func (e ProtocolError) Error() string {
@@ -63,5 +67,25 @@ func ConvertToBanningProtocolErrorIfRuleError(err error, format string, args ...
 		return err
 	}
 
-	return Wrapf(true, err, format, args...)
+	return MisbehaviorWrapf(MisbehaviorInvalidBlock, err, format, args...)
+}
+
+// MisbehaviorErrorf formats according to a format specifier and returns the string
+// as a banning ProtocolError of the given misbehavior.
+func MisbehaviorErrorf(misbehavior Misbehavior, format string, args ...interface{}) error {
+	return ProtocolError{
+		ShouldBan:   true,
+		Misbehavior: misbehavior,
+		Cause:       errors.Errorf(format, args...),
+	}
+}
+
+// MisbehaviorWrapf wraps the given error with the given format and returns it as a
+// banning ProtocolError of the given misbehavior.
+func MisbehaviorWrapf(misbehavior Misbehavior, err error, format string, args ...interface{}) error {
+	return ProtocolError{
+		ShouldBan:   true,
+		Misbehavior: misbehavior,
+		Cause:       errors.Wrapf(err, format, args...),
+	}
 }
@@ -5,7 +5,9 @@ import (
 	"github.com/Kash-Protocol/kashd/app/protocol"
 	"github.com/Kash-Protocol/kashd/app/rpc/rpccontext"
 	"github.com/Kash-Protocol/kashd/domain"
+	"github.com/Kash-Protocol/kashd/domain/addressindex"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/txindex"
 	"github.com/Kash-Protocol/kashd/domain/utxoindex"
 	"github.com/Kash-Protocol/kashd/infrastructure/config"
 	"github.com/Kash-Protocol/kashd/infrastructure/logger"
@@ -18,6 +20,11 @@ import (
 // Manager is an RPC manager
 type Manager struct {
 	context *rpccontext.Context
+
+	// virtualReserveRatioBasisPoints is the virtual's reserve ratio as of the
+	// last virtual change, and is only accessed by the consensus events handler
+	virtualReserveRatioBasisPoints    uint64
+	hasVirtualReserveRatioBasisPoints bool
 }
 
 // NewManager creates a new RPC Manager
@@ -29,6 +36,8 @@ func NewManager(
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	txIndex *txindex.TXIndex,
+	addressIndex *addressindex.AddressIndex,
 	consensusEventsChan chan externalapi.ConsensusEvent,
 	shutDownChan chan<- struct{}) *Manager {
 
@@ -41,6 +50,8 @@ func NewManager(
 			connectionManager,
 			addressManager,
 			utxoIndex,
+			txIndex,
+			addressIndex,
 			shutDownChan,
 		),
 	}
@@ -113,6 +124,20 @@ func (m *Manager) notifyVirtualChange(virtualChangeSet *externalapi.VirtualChang
 		}
 	}
 
+	if m.context.Config.TXIndex {
+		err := m.updateTXIndex(virtualChangeSet)
+		if err != nil {
+			return err
+		}
+	}
+
+	if m.context.Config.AddressIndex {
+		err := m.updateAddressIndex(virtualChangeSet)
+		if err != nil {
+			return err
+		}
+	}
+
 	err := m.notifyVirtualSelectedParentBlueScoreChanged(virtualChangeSet.VirtualSelectedParentBlueScore)
 	if err != nil {
 		return err
@@ -123,6 +148,13 @@ func (m *Manager) notifyVirtualChange(virtualChangeSet *externalapi.VirtualChang
 		return err
 	}
 
+	if virtualChangeSet.VirtualReserveInfo != nil {
+		err = m.notifyReserveRatioChanged(virtualChangeSet.VirtualReserveInfo.ReserveRatioBasisPoints)
+		if err != nil {
+			return err
+		}
+	}
+
 	if virtualChangeSet.VirtualSelectedParentChainChanges == nil ||
 		(len(virtualChangeSet.VirtualSelectedParentChainChanges.Added) == 0 &&
 			len(virtualChangeSet.VirtualSelectedParentChainChanges.Removed) == 0) {
@@ -158,6 +190,20 @@ func (m *Manager) NotifyPruningPointUTXOSetOverride() error {
 		}
 	}
 
+	if m.context.Config.TXIndex {
+		err := m.context.TXIndex.Reset()
+		if err != nil {
+			return err
+		}
+	}
+
+	if m.context.Config.AddressIndex {
+		err := m.context.AddressIndex.Reset()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -191,6 +237,20 @@ func (m *Manager) notifyUTXOsChanged(virtualChangeSet *externalapi.VirtualChange
 	return m.context.NotificationManager.NotifyUTXOsChanged(utxoIndexChanges)
 }
 
+func (m *Manager) updateTXIndex(virtualChangeSet *externalapi.VirtualChangeSet) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.updateTXIndex")
+	defer onEnd()
+
+	return m.context.TXIndex.Update(virtualChangeSet)
+}
+
+func (m *Manager) updateAddressIndex(virtualChangeSet *externalapi.VirtualChangeSet) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.updateAddressIndex")
+	defer onEnd()
+
+	return m.context.AddressIndex.Update(virtualChangeSet)
+}
+
 func (m *Manager) notifyPruningPointUTXOSetOverride() error {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.notifyPruningPointUTXOSetOverride")
 	defer onEnd()
@@ -219,6 +279,23 @@ func (m *Manager) notifyVirtualDaaScoreChanged(virtualDAAScore uint64) error {
 	return m.context.NotificationManager.NotifyVirtualDaaScoreChanged(notification)
 }
 
+func (m *Manager) notifyReserveRatioChanged(reserveRatioBasisPoints uint64) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.NotifyReserveRatioChanged")
+	defer onEnd()
+
+	previousReserveRatioBasisPoints := m.virtualReserveRatioBasisPoints
+	hadVirtualReserveRatioBasisPoints := m.hasVirtualReserveRatioBasisPoints
+	m.virtualReserveRatioBasisPoints = reserveRatioBasisPoints
+	m.hasVirtualReserveRatioBasisPoints = true
+
+	if !hadVirtualReserveRatioBasisPoints || previousReserveRatioBasisPoints == reserveRatioBasisPoints ||
+		!m.context.NotificationManager.HasReserveRatioChangedListeners() {
+
+		return nil
+	}
+	return m.context.NotificationManager.NotifyReserveRatioChanged(reserveRatioBasisPoints, previousReserveRatioBasisPoints)
+}
+
 func (m *Manager) notifyVirtualSelectedParentChainChanged(virtualChangeSet *externalapi.VirtualChangeSet) error {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.NotifyVirtualSelectedParentChainChanged")
 	defer onEnd()
@@ -0,0 +1,23 @@
+package rpc
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/infrastructure/metrics"
+)
+
+var (
+	requestCount = metrics.NewCounterVec("kashd_rpc_requests_total",
+		"The number of handled RPC requests, by command", "command")
+	requestDuration = metrics.NewHistogramVec("kashd_rpc_request_duration_seconds",
+		"The time it took to handle RPC requests, by command", metrics.DurationBuckets, "command")
+)
+
+// observeRequest records an RPC request of the given command that took the given number of seconds to handle
+func observeRequest(command appmessage.MessageCommand, seconds float64) {
+	commandName, ok := appmessage.RPCMessageCommandToString[command]
+	if !ok {
+		commandName = command.String()
+	}
+	requestCount.WithLabelValues(commandName).Inc()
+	requestDuration.WithLabelValues(commandName).Observe(seconds)
+}
@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	"time"
+
 	"github.com/Kash-Protocol/kashd/app/appmessage"
 	"github.com/Kash-Protocol/kashd/app/rpc/rpccontext"
 	"github.com/Kash-Protocol/kashd/app/rpc/rpchandlers"
@@ -50,6 +52,13 @@ var handlers = map[appmessage.MessageCommand]handler{
 	appmessage.CmdNotifyVirtualDaaScoreChangedRequestMessage:                rpchandlers.HandleNotifyVirtualDaaScoreChanged,
 	appmessage.CmdNotifyNewBlockTemplateRequestMessage:                      rpchandlers.HandleNotifyNewBlockTemplate,
 	appmessage.CmdGetCoinSupplyRequestMessage:                               rpchandlers.HandleGetCoinSupply,
+	appmessage.CmdGetReserveStateRequestMessage:                             rpchandlers.HandleGetReserveState,
+	appmessage.CmdGetOraclePriceRequestMessage:                              rpchandlers.HandleGetOraclePrice,
+	appmessage.CmdNotifyReserveRatioChangedRequestMessage:                   rpchandlers.HandleNotifyReserveRatioChanged,
+	appmessage.CmdGetFeeEstimateRequestMessage:                              rpchandlers.HandleGetFeeEstimate,
+	appmessage.CmdGetTransactionRequestMessage:                              rpchandlers.HandleGetTransaction,
+	appmessage.CmdGetTransactionConfirmationsRequestMessage:                 rpchandlers.HandleGetTransactionConfirmations,
+	appmessage.CmdGetTransactionsByAddressesRequestMessage:                  rpchandlers.HandleGetTransactionsByAddresses,
 	appmessage.CmdGetMempoolEntriesByAddressesRequestMessage:                rpchandlers.HandleGetMempoolEntriesByAddresses,
 }
 
@@ -63,9 +72,16 @@ func (m *Manager) routerInitializer(router *router.Router, netConnection *netada
 		panic(err)
 	}
 	m.context.NotificationManager.AddListener(router)
+	isLimitedRPCUser := netConnection.IsLimitedRPCUser()
+	if isLimitedRPCUser {
+		m.context.AddLimitedRouter(router)
+	}
 
 	spawn("routerInitializer-handleIncomingMessages", func() {
 		defer m.context.NotificationManager.RemoveListener(router)
+		if isLimitedRPCUser {
+			defer m.context.RemoveLimitedRouter(router)
+		}
 
 		err := m.handleIncomingMessages(router, incomingRoute)
 		m.handleError(err, netConnection)
@@ -83,10 +99,12 @@ func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *r
 		if !ok {
 			return err
 		}
+		start := time.Now()
 		response, err := handler(m.context, router, request)
 		if err != nil {
 			return err
 		}
+		observeRequest(request.Command(), time.Since(start).Seconds())
 		err = outgoingRoute.Enqueue(response)
 		if err != nil {
 			return err
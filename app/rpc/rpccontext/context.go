@@ -3,11 +3,14 @@ package rpccontext
 import (
 	"github.com/Kash-Protocol/kashd/app/protocol"
 	"github.com/Kash-Protocol/kashd/domain"
+	"github.com/Kash-Protocol/kashd/domain/addressindex"
+	"github.com/Kash-Protocol/kashd/domain/txindex"
 	"github.com/Kash-Protocol/kashd/domain/utxoindex"
 	"github.com/Kash-Protocol/kashd/infrastructure/config"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/addressmanager"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/connmanager"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
 )
 
 // Context represents the RPC context
@@ -19,9 +22,13 @@ type Context struct {
 	ConnectionManager *connmanager.ConnectionManager
 	AddressManager    *addressmanager.AddressManager
 	UTXOIndex         *utxoindex.UTXOIndex
+	TXIndex           *txindex.TXIndex
+	AddressIndex      *addressindex.AddressIndex
 	ShutDownChan      chan<- struct{}
 
 	NotificationManager *NotificationManager
+
+	limitedRouters limitedRouters
 }
 
 // NewContext creates a new RPC context
@@ -32,6 +39,8 @@ func NewContext(cfg *config.Config,
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	txIndex *txindex.TXIndex,
+	addressIndex *addressindex.AddressIndex,
 	shutDownChan chan<- struct{}) *Context {
 
 	context := &Context{
@@ -42,7 +51,10 @@ func NewContext(cfg *config.Config,
 		ConnectionManager: connectionManager,
 		AddressManager:    addressManager,
 		UTXOIndex:         utxoIndex,
+		TXIndex:           txIndex,
+		AddressIndex:      addressIndex,
 		ShutDownChan:      shutDownChan,
+		limitedRouters:    limitedRouters{routers: make(map[*router.Router]struct{})},
 	}
 	context.NotificationManager = NewNotificationManager(cfg.ActiveNetParams)
 
@@ -1,6 +1,7 @@
 package rpccontext
 
 import (
+	"math"
 	"sync"
 
 	"github.com/Kash-Protocol/kashd/domain/dagconfig"
@@ -41,7 +42,9 @@ type NotificationListener struct {
 	propagateVirtualDaaScoreChangedNotifications                bool
 	propagatePruningPointUTXOSetOverrideNotifications           bool
 	propagateNewBlockTemplateNotifications                      bool
+	propagateReserveRatioChangedNotifications                   bool
 
+	propagateReserveRatioChangedThresholdsBasisPoints                             []uint64
 	propagateUTXOsChangedNotificationAddresses                                    map[utxoindex.ScriptPublicKeyString]*UTXOsChangedNotificationAddress
 	includeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications bool
 }
@@ -265,6 +268,68 @@ func (nm *NotificationManager) NotifyVirtualDaaScoreChanged(
 	return nil
 }
 
+// HasReserveRatioChangedListeners returns true if there's any listener that is
+// currently interested in reserve ratio changed notifications
+func (nm *NotificationManager) HasReserveRatioChangedListeners() bool {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.listeners {
+		if listener.propagateReserveRatioChangedNotifications {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyReserveRatioChanged notifies the notification manager that the virtual's
+// reserve ratio has changed from previousReserveRatioBasisPoints to reserveRatioBasisPoints.
+// Each listener is only notified if the change crosses any of its thresholds
+func (nm *NotificationManager) NotifyReserveRatioChanged(
+	reserveRatioBasisPoints uint64, previousReserveRatioBasisPoints uint64) error {
+
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for router, listener := range nm.listeners {
+		if listener.propagateReserveRatioChangedNotifications {
+			crossedThresholds := crossedReserveRatioThresholds(listener.propagateReserveRatioChangedThresholdsBasisPoints,
+				previousReserveRatioBasisPoints, reserveRatioBasisPoints)
+			if len(crossedThresholds) == 0 {
+				continue
+			}
+
+			notification := appmessage.NewReserveRatioChangedNotificationMessage(
+				reserveRatioBasisPoints, previousReserveRatioBasisPoints, crossedThresholds)
+			err := router.OutgoingRoute().MaybeEnqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// crossedReserveRatioThresholds returns the thresholds that lie between the previous
+// and the current reserve ratio. A ratio of zero means that there are no KUSD
+// liabilities, and is treated as higher than any threshold
+func crossedReserveRatioThresholds(thresholds []uint64, previous uint64, current uint64) []uint64 {
+	if previous == 0 {
+		previous = math.MaxUint64
+	}
+	if current == 0 {
+		current = math.MaxUint64
+	}
+
+	var crossed []uint64
+	for _, threshold := range thresholds {
+		if (previous < threshold) != (current < threshold) {
+			crossed = append(crossed, threshold)
+		}
+	}
+	return crossed
+}
+
 // NotifyNewBlockTemplate notifies the notification manager that a new
 // block template is available for miners
 func (nm *NotificationManager) NotifyNewBlockTemplate(
@@ -473,6 +538,18 @@ func (nl *NotificationListener) PropagateVirtualDaaScoreChangedNotifications() {
 	nl.propagateVirtualDaaScoreChangedNotifications = true
 }
 
+// PropagateReserveRatioChangedNotifications instructs the listener to send reserve
+// ratio changed notifications to the remote listener whenever the virtual's reserve
+// ratio crosses any of the given thresholds. If no thresholds are given, the
+// network's minimal and maximal reserve ratios are used
+func (nl *NotificationListener) PropagateReserveRatioChangedNotifications(thresholdsBasisPoints []uint64) {
+	if len(thresholdsBasisPoints) == 0 {
+		thresholdsBasisPoints = []uint64{nl.params.MinReserveRatio * 100, nl.params.MaxReserveRatio * 100}
+	}
+	nl.propagateReserveRatioChangedNotifications = true
+	nl.propagateReserveRatioChangedThresholdsBasisPoints = thresholdsBasisPoints
+}
+
 // PropagateNewBlockTemplateNotifications instructs the listener to send
 // new block template notifications to the remote listener
 func (nl *NotificationListener) PropagateNewBlockTemplateNotifications() {
@@ -0,0 +1,31 @@
+package rpccontext
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCrossedReserveRatioThresholds(t *testing.T) {
+	thresholds := []uint64{40_000, 80_000}
+
+	tests := []struct {
+		name     string
+		previous uint64
+		current  uint64
+		expected []uint64
+	}{
+		{name: "no threshold crossed", previous: 50_000, current: 60_000, expected: nil},
+		{name: "dropped below the minimum", previous: 50_000, current: 39_999, expected: []uint64{40_000}},
+		{name: "rose back to the minimum", previous: 39_999, current: 40_000, expected: []uint64{40_000}},
+		{name: "crossed both thresholds", previous: 90_000, current: 30_000, expected: []uint64{40_000, 80_000}},
+		{name: "KUSD liabilities appeared", previous: 0, current: 70_000, expected: []uint64{80_000}},
+		{name: "KUSD liabilities disappeared", previous: 30_000, current: 0, expected: []uint64{40_000, 80_000}},
+	}
+
+	for _, test := range tests {
+		crossed := crossedReserveRatioThresholds(thresholds, test.previous, test.current)
+		if !reflect.DeepEqual(crossed, test.expected) {
+			t.Errorf("%s: expected crossed thresholds %v but got %v", test.name, test.expected, crossed)
+		}
+	}
+}
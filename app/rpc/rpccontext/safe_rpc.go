@@ -0,0 +1,45 @@
+package rpccontext
+
+import (
+	"sync"
+
+	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
+)
+
+// limitedRouters holds the routers of the RPC connections that authenticated with limited credentials
+type limitedRouters struct {
+	routers map[*router.Router]struct{}
+	sync.RWMutex
+}
+
+// AddLimitedRouter marks the given router as belonging to an RPC connection that authenticated
+// with limited credentials
+func (ctx *Context) AddLimitedRouter(router *router.Router) {
+	ctx.limitedRouters.Lock()
+	defer ctx.limitedRouters.Unlock()
+
+	ctx.limitedRouters.routers[router] = struct{}{}
+}
+
+// RemoveLimitedRouter removes a router that was added with AddLimitedRouter
+func (ctx *Context) RemoveLimitedRouter(router *router.Router) {
+	ctx.limitedRouters.Lock()
+	defer ctx.limitedRouters.Unlock()
+
+	delete(ctx.limitedRouters.routers, router)
+}
+
+// IsSafeRPC returns whether the RPC commands which affect the state of the node are disabled
+// for the given router. They are disabled for all routers when the node runs with --saferpc,
+// and for the routers of connections that authenticated with limited credentials otherwise.
+func (ctx *Context) IsSafeRPC(router *router.Router) bool {
+	if ctx.Config.SafeRPC {
+		return true
+	}
+
+	ctx.limitedRouters.RLock()
+	defer ctx.limitedRouters.RUnlock()
+
+	_, ok := ctx.limitedRouters.routers[router]
+	return ok
+}
@@ -8,8 +8,8 @@ import (
 )
 
 // HandleAddPeer handles the respectively named RPC command
-func HandleAddPeer(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
-	if context.Config.SafeRPC {
+func HandleAddPeer(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.IsSafeRPC(router) {
 		log.Warn("AddPeer RPC command called while node in safe RPC mode -- ignoring.")
 		response := appmessage.NewAddPeerResponseMessage()
 		response.Error =
@@ -8,8 +8,8 @@ import (
 )
 
 // HandleBan handles the respectively named RPC command
-func HandleBan(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
-	if context.Config.SafeRPC {
+func HandleBan(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.IsSafeRPC(router) {
 		log.Warn("Ban RPC command called while node in safe RPC mode -- ignoring.")
 		response := appmessage.NewBanResponseMessage()
 		response.Error =
@@ -10,7 +10,7 @@ import (
 
 // HandleEstimateNetworkHashesPerSecond handles the respectively named RPC command
 func HandleEstimateNetworkHashesPerSecond(
-	context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
 
 	estimateNetworkHashesPerSecondRequest := request.(*appmessage.EstimateNetworkHashesPerSecondRequestMessage)
 
@@ -27,7 +27,7 @@ func HandleEstimateNetworkHashesPerSecond(
 		}
 	}
 
-	if context.Config.SafeRPC {
+	if context.IsSafeRPC(router) {
 		const windowSizeLimit = 10000
 		if windowSize > windowSizeLimit {
 			response := &appmessage.EstimateNetworkHashesPerSecondResponseMessage{}
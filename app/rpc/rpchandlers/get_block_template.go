@@ -4,6 +4,7 @@ import (
 	"github.com/Kash-Protocol/kashd/app/appmessage"
 	"github.com/Kash-Protocol/kashd/app/rpc/rpccontext"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionhelper"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
@@ -34,7 +35,12 @@ func HandleGetBlockTemplate(context *rpccontext.Context, _ *router.Router, reque
 		return nil, err
 	}
 
-	if uint64(len(templateBlock.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload)) > context.Config.NetParams().MaxCoinbasePayloadLength {
+	// The oracle data committed to by the coinbase payload does not count towards its max length
+	coinbasePayload, _, err := oracle.ExtractFromCoinbasePayload(templateBlock.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(coinbasePayload)) > context.Config.NetParams().MaxCoinbasePayloadLength {
 		errorMessage := &appmessage.GetBlockTemplateResponseMessage{}
 		errorMessage.Error = appmessage.RPCErrorf("Coinbase payload is above max length (%d). Try to shorten the extra data.", context.Config.NetParams().MaxCoinbasePayloadLength)
 		return errorMessage, nil
@@ -0,0 +1,17 @@
+package rpchandlers
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/app/rpc/rpccontext"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
+)
+
+// HandleGetFeeEstimate handles the respectively named RPC command
+func HandleGetFeeEstimate(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	feeEstimate := context.Domain.MiningManager().GetFeeEstimate()
+	return appmessage.NewGetFeeEstimateResponseMessage(
+		feeEstimate.LowFeeRate,
+		feeEstimate.NormalFeeRate,
+		feeEstimate.PriorityFeeRate,
+	), nil
+}
@@ -0,0 +1,32 @@
+package rpchandlers
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/app/rpc/rpccontext"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
+)
+
+// HandleGetOraclePrice handles the respectively named RPC command
+func HandleGetOraclePrice(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	prices, found, err := context.Domain.Consensus().GetVirtualOraclePrices()
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		errorMessage := &appmessage.GetOraclePriceResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("No oracle price record is in effect at the virtual")
+		return errorMessage, nil
+	}
+
+	response := appmessage.NewGetOraclePriceResponseMessage(
+		prices.KSH,
+		prices.KRV,
+		prices.KUSD,
+		prices.KSHMA,
+		prices.KRVMA,
+		prices.KUSDMA,
+		prices.Timestamp,
+	)
+
+	return response, nil
+}
@@ -0,0 +1,62 @@
+package rpchandlers
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/app/rpc/rpccontext"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
+)
+
+var conversionTransactionTypes = []externalapi.DomainTransactionType{
+	externalapi.MintKUSD,
+	externalapi.StakeKSH,
+	externalapi.RedeemKSH,
+	externalapi.RedeemKUSD,
+}
+
+// HandleGetReserveState handles the respectively named RPC command
+func HandleGetReserveState(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	reserveInfo, err := context.Domain.Consensus().GetVirtualReserveInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	params := context.Config.ActiveNetParams
+	calculator := djed.NewCalculator(params.MinReserveRatio, params.MaxReserveRatio,
+		params.ConversionFeeBasisPoints, params.KRVMinimalPrice)
+
+	var conversionQuotes []*appmessage.ConversionQuote
+	if reserveInfo.ExchangeRate != 0 {
+		conversionQuotes = make([]*appmessage.ConversionQuote, len(conversionTransactionTypes))
+		for i, transactionType := range conversionTransactionTypes {
+			kshSompi, feeSompi, err := calculator.Quote(reserveInfo.ReserveState, reserveInfo.ExchangeRate,
+				transactionType, constants.SompiPerKash)
+			if err != nil {
+				return nil, err
+			}
+			conversionQuotes[i] = &appmessage.ConversionQuote{
+				TransactionType: uint32(transactionType),
+				KSHSompi:        kshSompi,
+				FeeSompi:        feeSompi,
+			}
+		}
+	}
+
+	response := appmessage.NewGetReserveStateResponseMessage(
+		reserveInfo.ReserveState.Reserve,
+		reserveInfo.ReserveState.KUSDSupply,
+		reserveInfo.ReserveState.KRVSupply,
+		reserveInfo.KUSDLiabilities,
+		reserveInfo.KRVEquity,
+		reserveInfo.ExchangeRate,
+		reserveInfo.ReserveRatioBasisPoints,
+		params.MinReserveRatio*100,
+		params.MaxReserveRatio*100,
+		params.ConversionFeeBasisPoints,
+		conversionQuotes,
+	)
+
+	return response, nil
+}
@@ -0,0 +1,90 @@
+package rpchandlers
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/app/rpc/rpccontext"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionid"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
+)
+
+// HandleGetTransaction handles the respectively named RPC command
+func HandleGetTransaction(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if !context.Config.TXIndex {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when kashd is run without --txindex")
+		return errorMessage, nil
+	}
+
+	getTransactionRequest := request.(*appmessage.GetTransactionRequestMessage)
+	transactionID, err := transactionid.FromString(getTransactionRequest.TxID)
+	if err != nil {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Transaction ID could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+
+	txAcceptance, found, err := context.TXIndex.TXAcceptance(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Transaction %s was not found in the transaction index", transactionID)
+		return errorMessage, nil
+	}
+
+	transaction, containingBlockHeader, found, err := findContainedTransaction(
+		context, transactionID, txAcceptance.ContainingBlockHashes)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		errorMessage := &appmessage.GetTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("The blocks that contain transaction %s were pruned", transactionID)
+		return errorMessage, nil
+	}
+
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transaction)
+	err = context.PopulateTransactionWithVerboseData(rpcTransaction, containingBlockHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmations, err := transactionConfirmations(context, txAcceptance)
+	if err != nil {
+		return nil, err
+	}
+
+	containingBlockHashes := make([]string, len(txAcceptance.ContainingBlockHashes))
+	for i, containingBlockHash := range txAcceptance.ContainingBlockHashes {
+		containingBlockHashes[i] = containingBlockHash.String()
+	}
+
+	return appmessage.NewGetTransactionResponseMessage(rpcTransaction, txAcceptance.AcceptingBlockHash.String(),
+		containingBlockHashes, transaction.Type.String(), transaction.InputUTXOAssetType().ToUint32(),
+		transaction.OutputUTXOAssetType().ToUint32(), confirmations), nil
+}
+
+// findContainedTransaction looks for the transaction with the given ID in the first of the given
+// blocks whose body wasn't pruned
+func findContainedTransaction(context *rpccontext.Context, transactionID *externalapi.DomainTransactionID,
+	containingBlockHashes []*externalapi.DomainHash) (*externalapi.DomainTransaction, externalapi.BlockHeader, bool, error) {
+
+	for _, containingBlockHash := range containingBlockHashes {
+		block, found, err := context.Domain.Consensus().GetBlock(containingBlockHash)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if !found {
+			continue
+		}
+		for _, transaction := range block.Transactions {
+			if consensushashing.TransactionID(transaction).Equal(transactionID) {
+				return transaction, block.Header, true, nil
+			}
+		}
+	}
+	return nil, nil, false, nil
+}
@@ -0,0 +1,62 @@
+package rpchandlers
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/app/rpc/rpccontext"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionid"
+	"github.com/Kash-Protocol/kashd/domain/txindex"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
+)
+
+// HandleGetTransactionConfirmations handles the respectively named RPC command
+func HandleGetTransactionConfirmations(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if !context.Config.TXIndex {
+		errorMessage := &appmessage.GetTransactionConfirmationsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when kashd is run without --txindex")
+		return errorMessage, nil
+	}
+
+	getTransactionConfirmationsRequest := request.(*appmessage.GetTransactionConfirmationsRequestMessage)
+	transactionID, err := transactionid.FromString(getTransactionConfirmationsRequest.TxID)
+	if err != nil {
+		errorMessage := &appmessage.GetTransactionConfirmationsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Transaction ID could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+
+	txAcceptance, found, err := context.TXIndex.TXAcceptance(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		errorMessage := &appmessage.GetTransactionConfirmationsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Transaction %s was not found in the transaction index", transactionID)
+		return errorMessage, nil
+	}
+
+	confirmations, err := transactionConfirmations(context, txAcceptance)
+	if err != nil {
+		return nil, err
+	}
+	return appmessage.NewGetTransactionConfirmationsResponseMessage(confirmations), nil
+}
+
+// transactionConfirmations returns the number of confirmations, in blue score, of the transaction accepted
+// as described by the given txAcceptance. The accepting block counts as the first confirmation.
+func transactionConfirmations(context *rpccontext.Context, txAcceptance *txindex.TXAcceptance) (uint64, error) {
+	virtualSelectedParent, err := context.Domain.Consensus().GetVirtualSelectedParent()
+	if err != nil {
+		return 0, err
+	}
+	virtualSelectedParentInfo, err := context.Domain.Consensus().GetBlockInfo(virtualSelectedParent)
+	if err != nil {
+		return 0, err
+	}
+
+	// The index is updated after consensus, so it may still refer to an accepting block that was just
+	// removed from the selected parent chain by a reorg to a chain with a lower blue score
+	if virtualSelectedParentInfo.BlueScore < txAcceptance.AcceptingBlockBlueScore {
+		return 1, nil
+	}
+	return virtualSelectedParentInfo.BlueScore - txAcceptance.AcceptingBlockBlueScore + 1, nil
+}
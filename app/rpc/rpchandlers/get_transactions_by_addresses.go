@@ -0,0 +1,107 @@
+package rpchandlers
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/app/rpc/rpccontext"
+	"github.com/Kash-Protocol/kashd/domain/addressindex"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
+	"github.com/Kash-Protocol/kashd/util"
+)
+
+// maxTransactionsByAddressesLimit is the maximum number of entries
+// a single GetTransactionsByAddresses call may return
+const maxTransactionsByAddressesLimit = 1000
+
+// HandleGetTransactionsByAddresses handles the respectively named RPC command
+func HandleGetTransactionsByAddresses(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if !context.Config.AddressIndex {
+		errorMessage := &appmessage.GetTransactionsByAddressesResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when kashd is run without --addressindex")
+		return errorMessage, nil
+	}
+
+	getTransactionsByAddressesRequest := request.(*appmessage.GetTransactionsByAddressesRequestMessage)
+	if getTransactionsByAddressesRequest.Limit == 0 || getTransactionsByAddressesRequest.Limit > maxTransactionsByAddressesLimit {
+		errorMessage := &appmessage.GetTransactionsByAddressesResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Limit must be between 1 and %d", maxTransactionsByAddressesLimit)
+		return errorMessage, nil
+	}
+
+	scriptPublicKeys := make([]*externalapi.ScriptPublicKey, 0, len(getTransactionsByAddressesRequest.Addresses))
+	addressesByScriptPublicKey := make(map[string]string, len(getTransactionsByAddressesRequest.Addresses))
+	for _, addressString := range getTransactionsByAddressesRequest.Addresses {
+		address, err := util.DecodeAddress(addressString, context.Config.ActiveNetParams.Prefix)
+		if err != nil {
+			errorMessage := &appmessage.GetTransactionsByAddressesResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not decode address '%s': %s", addressString, err)
+			return errorMessage, nil
+		}
+		scriptPublicKey, err := txscript.PayToAddrScript(address)
+		if err != nil {
+			errorMessage := &appmessage.GetTransactionsByAddressesResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not create a scriptPublicKey for address '%s': %s", addressString, err)
+			return errorMessage, nil
+		}
+
+		// Addresses that are requested more than once would otherwise have their transactions returned twice
+		if _, ok := addressesByScriptPublicKey[scriptPublicKey.String()]; ok {
+			continue
+		}
+		addressesByScriptPublicKey[scriptPublicKey.String()] = addressString
+		scriptPublicKeys = append(scriptPublicKeys, scriptPublicKey)
+	}
+
+	var startAfter *addressindex.PageKey
+	if getTransactionsByAddressesRequest.StartAfter != nil {
+		transactionID, err := externalapi.NewDomainTransactionIDFromString(
+			getTransactionsByAddressesRequest.StartAfter.TransactionID)
+		if err != nil {
+			errorMessage := &appmessage.GetTransactionsByAddressesResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not parse the transaction ID of the page key: %s", err)
+			return errorMessage, nil
+		}
+		assetType := externalapi.AssetTypeFromUint32(getTransactionsByAddressesRequest.StartAfter.AssetType)
+		if assetType == externalapi.UNKNOWN {
+			errorMessage := &appmessage.GetTransactionsByAddressesResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Unknown asset type %d in the page key",
+				getTransactionsByAddressesRequest.StartAfter.AssetType)
+			return errorMessage, nil
+		}
+		startAfter = &addressindex.PageKey{
+			AcceptingBlockBlueScore: getTransactionsByAddressesRequest.StartAfter.AcceptingBlockBlueScore,
+			TransactionID:           transactionID,
+			AssetType:               assetType,
+		}
+	}
+
+	addressTransactions, nextPageKey, err := context.AddressIndex.AddressTransactions(scriptPublicKeys,
+		startAfter, getTransactionsByAddressesRequest.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*appmessage.TransactionsByAddressesEntry, len(addressTransactions))
+	for i, addressTransaction := range addressTransactions {
+		entries[i] = &appmessage.TransactionsByAddressesEntry{
+			Address:                 addressesByScriptPublicKey[addressTransaction.ScriptPublicKey.String()],
+			TransactionID:           addressTransaction.TransactionID.String(),
+			AcceptingBlockHash:      addressTransaction.AcceptingBlockHash.String(),
+			AcceptingBlockBlueScore: addressTransaction.AcceptingBlockBlueScore,
+			AssetType:               addressTransaction.AssetType.ToUint32(),
+			AmountDelta:             addressTransaction.AmountDelta,
+		}
+	}
+
+	var nextPageKeyAsAppMessage *appmessage.TransactionsByAddressesPageKey
+	if nextPageKey != nil {
+		nextPageKeyAsAppMessage = &appmessage.TransactionsByAddressesPageKey{
+			AcceptingBlockBlueScore: nextPageKey.AcceptingBlockBlueScore,
+			TransactionID:           nextPageKey.TransactionID.String(),
+			AssetType:               nextPageKey.AssetType.ToUint32(),
+		}
+	}
+
+	return appmessage.NewGetTransactionsByAddressesResponseMessage(entries, nextPageKeyAsAppMessage), nil
+}
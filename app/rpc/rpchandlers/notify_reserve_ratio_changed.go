@@ -0,0 +1,21 @@
+package rpchandlers
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/app/rpc/rpccontext"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
+)
+
+// HandleNotifyReserveRatioChanged handles the respectively named RPC command
+func HandleNotifyReserveRatioChanged(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	notifyReserveRatioChangedRequest := request.(*appmessage.NotifyReserveRatioChangedRequestMessage)
+
+	listener, err := context.NotificationManager.Listener(router)
+	if err != nil {
+		return nil, err
+	}
+	listener.PropagateReserveRatioChangedNotifications(notifyReserveRatioChangedRequest.ThresholdsBasisPoints)
+
+	response := appmessage.NewNotifyReserveRatioChangedResponseMessage()
+	return response, nil
+}
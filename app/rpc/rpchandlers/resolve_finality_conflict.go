@@ -7,8 +7,8 @@ import (
 )
 
 // HandleResolveFinalityConflict handles the respectively named RPC command
-func HandleResolveFinalityConflict(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
-	if context.Config.SafeRPC {
+func HandleResolveFinalityConflict(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.IsSafeRPC(router) {
 		log.Warn("ResolveFinalityConflict RPC command called while node in safe RPC mode -- ignoring.")
 		response := &appmessage.ResolveFinalityConflictResponseMessage{}
 		response.Error =
@@ -11,8 +11,8 @@ import (
 const pauseBeforeShutDown = time.Second
 
 // HandleShutDown handles the respectively named RPC command
-func HandleShutDown(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
-	if context.Config.SafeRPC {
+func HandleShutDown(context *rpccontext.Context, router *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	if context.IsSafeRPC(router) {
 		log.Warn("ShutDown RPC command called while node in safe RPC mode -- ignoring.")
 		response := appmessage.NewShutDownResponseMessage()
 		response.Error =
@@ -8,8 +8,8 @@ import (
 )
 
 // HandleUnban handles the respectively named RPC command
-func HandleUnban(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
-	if context.Config.SafeRPC {
+func HandleUnban(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.IsSafeRPC(router) {
 		log.Warn("Unban RPC command called while node in safe RPC mode -- ignoring.")
 		response := appmessage.NewUnbanResponseMessage()
 		response.Error =
@@ -38,6 +38,12 @@ var commandTypes = []reflect.Type{
 	reflect.TypeOf(protowire.KashdMessage_GetUtxosByAddressesRequest{}),
 	reflect.TypeOf(protowire.KashdMessage_GetBalanceByAddressRequest{}),
 	reflect.TypeOf(protowire.KashdMessage_GetCoinSupplyRequest{}),
+	reflect.TypeOf(protowire.KashdMessage_GetReserveStateRequest{}),
+	reflect.TypeOf(protowire.KashdMessage_GetOraclePriceRequest{}),
+	reflect.TypeOf(protowire.KashdMessage_GetFeeEstimateRequest{}),
+	reflect.TypeOf(protowire.KashdMessage_GetTransactionRequest{}),
+	reflect.TypeOf(protowire.KashdMessage_GetTransactionConfirmationsRequest{}),
+	reflect.TypeOf(protowire.KashdMessage_GetTransactionsByAddressesRequest{}),
 
 	reflect.TypeOf(protowire.KashdMessage_BanRequest{}),
 	reflect.TypeOf(protowire.KashdMessage_UnbanRequest{}),
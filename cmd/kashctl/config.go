@@ -13,6 +13,10 @@ var (
 
 type configFlags struct {
 	RPCServer                          string `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	RPCCert                            string `long:"rpccert" description:"Certificate of the RPC server, for connecting to an RPC server that runs with --rpctls"`
+	RPCUser                            string `long:"rpcuser" description:"Username for RPC connections"`
+	RPCPassword                        string `long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
+	RPCAuthToken                       string `long:"rpcauthtoken" default-mask:"-" description:"Authentication token for RPC connections, used instead of --rpcuser and --rpcpass"`
 	Timeout                            uint64 `short:"t" long:"timeout" description:"Timeout for the request (in seconds)"`
 	RequestJSON                        string `short:"j" long:"json" description:"The request in JSON format"`
 	ListCommands                       bool   `short:"l" long:"list-commands" description:"List all commands and exit"`
@@ -28,7 +28,12 @@ func main() {
 	if err != nil {
 		printErrorAndExit(fmt.Sprintf("error parsing RPC server address: %s", err))
 	}
-	client, err := grpcclient.Connect(rpcAddress)
+	client, err := grpcclient.ConnectWithCredentials(rpcAddress, &grpcclient.Credentials{
+		TLSCertFile: cfg.RPCCert,
+		Token:       cfg.RPCAuthToken,
+		User:        cfg.RPCUser,
+		Password:    cfg.RPCPassword,
+	})
 	if err != nil {
 		printErrorAndExit(fmt.Sprintf("error connecting to the RPC server: %s", err))
 	}
@@ -4,6 +4,7 @@ import (
 	"github.com/Kash-Protocol/kashd/app/appmessage"
 	"github.com/Kash-Protocol/kashd/infrastructure/logger"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/rpcclient"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/rpcclient/grpcclient"
 	"github.com/pkg/errors"
 	"time"
 )
@@ -22,7 +23,12 @@ func (mc *minerClient) connect() error {
 	if err != nil {
 		return err
 	}
-	rpcClient, err := rpcclient.NewRPCClient(rpcAddress)
+	rpcClient, err := rpcclient.NewRPCClientWithCredentials(rpcAddress, &grpcclient.Credentials{
+		TLSCertFile: mc.cfg.RPCCert,
+		Token:       mc.cfg.RPCAuthToken,
+		User:        mc.cfg.RPCUser,
+		Password:    mc.cfg.RPCPassword,
+	})
 	if err != nil {
 		return err
 	}
@@ -24,29 +24,36 @@ const (
 
 var (
 	// Default configuration options
-	defaultAppDir     = util.AppDir("kashminer", false)
-	defaultLogFile    = filepath.Join(defaultAppDir, defaultLogFilename)
-	defaultErrLogFile = filepath.Join(defaultAppDir, defaultErrLogFilename)
-	defaultRPCServer  = "localhost"
-	defaultWorkers    = 2
+	defaultAppDir      = util.AppDir("kashminer", false)
+	defaultLogFile     = filepath.Join(defaultAppDir, defaultLogFilename)
+	defaultErrLogFile  = filepath.Join(defaultAppDir, defaultErrLogFilename)
+	defaultRPCServer   = "localhost"
+	defaultWorkers     = 2
+	defaultRandomXMode = "full"
 )
 
 type configFlags struct {
 	ShowVersion           bool     `short:"V" long:"version" description:"Display version information and exit"`
 	RPCServer             string   `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	RPCCert               string   `long:"rpccert" description:"Certificate of the RPC server, for connecting to an RPC server that runs with --rpctls"`
+	RPCUser               string   `long:"rpcuser" description:"Username for RPC connections"`
+	RPCPassword           string   `long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
+	RPCAuthToken          string   `long:"rpcauthtoken" default-mask:"-" description:"Authentication token for RPC connections, used instead of --rpcuser and --rpcpass"`
 	MiningAddr            string   `long:"miningaddr" description:"Address to mine to"`
 	NumberOfBlocks        uint64   `short:"n" long:"numblocks" description:"Number of blocks to mine. If omitted, will mine until the process is interrupted."`
 	MineWhenNotSynced     bool     `long:"mine-when-not-synced" description:"Mine even if the node is not synced with the rest of the network."`
 	Profile               string   `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 	TargetBlocksPerSecond *float64 `long:"target-blocks-per-second" description:"Sets a maximum block rate. 0 means no limit (The default one is 2 * target network block rate)"`
 	Workers               int      `long:"workers" description:"Number of concurrent mining workers"`
+	RandomXMode           string   `long:"randomxmode" description:"RandomX mode to mine in {light, full} -- full needs over 2 GiB of memory but hashes several times faster than light"`
 	config.NetworkFlags
 }
 
 func parseConfig() (*configFlags, error) {
 	cfg := &configFlags{
-		RPCServer: defaultRPCServer,
-		Workers:   defaultWorkers,
+		RPCServer:   defaultRPCServer,
+		Workers:     defaultWorkers,
+		RandomXMode: defaultRandomXMode,
 	}
 	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
 	_, err := parser.Parse()
@@ -85,6 +92,12 @@ func parseConfig() (*configFlags, error) {
 		return nil, errors.New("Currently mining is not supported on mainnet")
 	}
 
+	randomXMode, err := pow.ParseMode(cfg.RandomXMode)
+	if err != nil {
+		return nil, err
+	}
+	pow.SetGlobalMode(randomXMode)
+
 	err = pow.ResizeGlobalPool(cfg.Workers)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to resize global pool")
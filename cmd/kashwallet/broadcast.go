@@ -42,7 +42,7 @@ func broadcast(conf *broadcastConfig) error {
 		return err
 	}
 
-	response, err := daemonClient.Broadcast(ctx, &pb.BroadcastRequest{Transactions: transactions})
+	response, err := daemonClient.Broadcast(ctx, &pb.BroadcastRequest{Transactions: transactions, IsDomain: conf.Finalized})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/client"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
+)
+
+func bumpFee(conf *bumpFeeConfig) error {
+	if len(conf.Password) == 0 {
+		conf.Password = keys.GetPassword("Password:")
+	}
+
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+
+	response, err := daemonClient.BumpFee(ctx, &pb.BumpFeeRequest{
+		TxID:        conf.TxID,
+		Password:    conf.Password,
+		FeeRate:     conf.FeeRate,
+		FeePriority: conf.Priority,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Transaction was replaced successfully")
+	fmt.Printf("Replacement transaction ID: \n\t%s\n", response.TxID)
+
+	if conf.Verbose {
+		fmt.Println("Serialized Transaction (can be parsed via the `parse` command or resent via `broadcast`): ")
+		fmt.Printf("\t%x\n\n", response.SignedTransaction)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/pkg/errors"
+)
+
+func combine(conf *combineConfig) error {
+	// The keys file tells which signature scheme the signatures, which are verified before they're combined, use
+	keysFile, err := keys.ReadKeysFile(conf.NetParams(), conf.KeysFile)
+	if err != nil {
+		return err
+	}
+
+	transactionsHexes := append([]string{}, conf.Transactions...)
+	for _, transactionFile := range conf.TransactionFiles {
+		transactionHexBytes, err := ioutil.ReadFile(transactionFile)
+		if err != nil {
+			return errors.Wrapf(err, "Could not read hex from %s", transactionFile)
+		}
+		transactionsHexes = append(transactionsHexes, strings.TrimSpace(string(transactionHexBytes)))
+	}
+	if len(transactionsHexes) < 2 {
+		return errors.Errorf("At least two copies of the transaction(s) are required through --transaction " +
+			"or --transaction-file")
+	}
+
+	copies := make([][][]byte, len(transactionsHexes))
+	for i, transactionsHex := range transactionsHexes {
+		var err error
+		copies[i], err = decodeTransactionsFromHex(transactionsHex)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Each copy holds the same transactions in the same order, so the transactions are combined one by one
+	numTransactions := len(copies[0])
+	for i, transactions := range copies[1:] {
+		if len(transactions) != numTransactions {
+			return errors.Errorf("Copy #%d holds %d transactions, while copy #1 holds %d",
+				i+2, len(transactions), numTransactions)
+		}
+	}
+
+	combinedTransactions := make([][]byte, numTransactions)
+	areAllTransactionsFullySigned := true
+	for i := range combinedTransactions {
+		transactionCopies := make([][]byte, len(copies))
+		for j, transactions := range copies {
+			transactionCopies[j] = transactions[i]
+		}
+
+		var err error
+		combinedTransactions[i], err = libkashwallet.CombinePartiallySignedTransactions(transactionCopies, keysFile.ECDSA)
+		if err != nil {
+			return err
+		}
+
+		isFullySigned, err := libkashwallet.IsTransactionFullySigned(combinedTransactions[i])
+		if err != nil {
+			return err
+		}
+		if !isFullySigned {
+			areAllTransactionsFullySigned = false
+		}
+	}
+
+	if areAllTransactionsFullySigned {
+		fmt.Fprintln(os.Stderr, "The transaction is signed and ready to finalize")
+	} else {
+		fmt.Fprintln(os.Stderr, "Successfully combined the signatures, but more are required")
+	}
+
+	fmt.Println(encodeTransactionsToHex(combinedTransactions))
+	return nil
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"os"
+	"strings"
 
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/infrastructure/config"
 	"github.com/pkg/errors"
 
@@ -14,8 +16,16 @@ const (
 	balanceSubCmd                   = "balance"
 	sendSubCmd                      = "send"
 	sweepSubCmd                     = "sweep"
+	bumpFeeSubCmd                   = "bump-fee"
+	historySubCmd                   = "history"
+	mintKUSDSubCmd                  = "mint-kusd"
+	stakeKSHSubCmd                  = "stake-ksh"
+	redeemKSHSubCmd                 = "redeem-ksh"
 	createUnsignedTransactionSubCmd = "create-unsigned-transaction"
 	signSubCmd                      = "sign"
+	combineSubCmd                   = "combine"
+	inspectSubCmd                   = "inspect"
+	finalizeSubCmd                  = "finalize"
 	broadcastSubCmd                 = "broadcast"
 	parseSubCmd                     = "parse"
 	showAddressesSubCmd             = "show-addresses"
@@ -34,14 +44,16 @@ type configFlags struct {
 }
 
 type createConfig struct {
-	KeysFile          string `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kashwallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kashwallet\\key.json (Windows))"`
-	Password          string `long:"password" short:"p" description:"Wallet password"`
-	Yes               bool   `long:"yes" short:"y" description:"Assume \"yes\" to all questions"`
-	MinimumSignatures uint32 `long:"min-signatures" short:"m" description:"Minimum required signatures" default:"1"`
-	NumPrivateKeys    uint32 `long:"num-private-keys" short:"k" description:"Number of private keys" default:"1"`
-	NumPublicKeys     uint32 `long:"num-public-keys" short:"n" description:"Total number of keys" default:"1"`
-	ECDSA             bool   `long:"ecdsa" description:"Create an ECDSA wallet"`
-	Import            bool   `long:"import" short:"i" description:"Import private keys (as opposed to generating them)"`
+	KeysFile          string   `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kashwallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kashwallet\\key.json (Windows))"`
+	Password          string   `long:"password" short:"p" description:"Wallet password"`
+	Yes               bool     `long:"yes" short:"y" description:"Assume \"yes\" to all questions"`
+	MinimumSignatures uint32   `long:"min-signatures" short:"m" description:"Minimum required signatures" default:"1"`
+	NumPrivateKeys    uint32   `long:"num-private-keys" short:"k" description:"Number of private keys" default:"1"`
+	NumPublicKeys     uint32   `long:"num-public-keys" short:"n" description:"Total number of keys" default:"1"`
+	ECDSA             bool     `long:"ecdsa" description:"Create an ECDSA wallet"`
+	Import            bool     `long:"import" short:"i" description:"Import private keys (as opposed to generating them)"`
+	WatchOnly         bool     `long:"watch-only" description:"Create a watch-only wallet from extended public keys, without any private keys"`
+	PublicKeys        []string `long:"public-key" description:"Extended public key of a watch-only wallet (may be used multiple times for a multisig wallet). If none are given, --num-public-keys keys are asked for"`
 	config.NetworkFlags
 }
 
@@ -55,12 +67,17 @@ type sendConfig struct {
 	KeysFile                 string   `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kashwallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kashwallet\\key.json (Windows))"`
 	Password                 string   `long:"password" short:"p" description:"Wallet password"`
 	DaemonAddress            string   `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
-	ToAddress                string   `long:"to-address" short:"t" description:"The public address to send Kash to" required:"true"`
+	ToAddress                string   `long:"to-address" short:"t" description:"The public address to send Kash to"`
+	PayoutsFile              string   `long:"payouts-file" description:"A file of payouts to send, as CSV address,amount records or a JSON array of {\"address\", \"amount\"} objects, with amounts in Kash (mutually exclusive with --to-address)"`
 	FromAddresses            []string `long:"from-address" short:"a" description:"Specific public address to send Kash from. Use multiple times to accept several addresses" required:"false"`
 	SendAssetType            string   `long:"asset-type" description:"Asset type to send (KSH, KUSD, KRV)" required:"true"`
 	SendAmount               float64  `long:"send-amount" short:"v" description:"An amount to send in Kash (e.g. 1234.12345678)"`
+	ReceiveAssetType         string   `long:"receive-asset-type" description:"Asset type to convert the sent amount into through the reserve (KSH, KUSD, KRV). Defaults to --asset-type"`
+	ReceiveAmount            float64  `long:"receive-amount" description:"The amount of --receive-asset-type paid for the sent amount when converting (e.g. 1234.12345678)"`
 	IsSendAll                bool     `long:"send-all" description:"Send all the Kash in the wallet (mutually exclusive with --send-amount)"`
 	UseExistingChangeAddress bool     `long:"use-existing-change-address" short:"u" description:"Will use an existing change address (in case no change address was ever used, it will use a new one)"`
+	FeeRate                  float64  `long:"fee-rate" description:"Fee rate to pay, in sompi per gram of transaction mass (mutually exclusive with --priority)"`
+	Priority                 string   `long:"priority" description:"Fee priority to pay the fee rate the node estimates for: low, normal or priority (default: normal)"`
 	Verbose                  bool     `long:"show-serialized" short:"s" description:"Show a list of hex encoded sent transactions"`
 	config.NetworkFlags
 }
@@ -71,14 +88,52 @@ type sweepConfig struct {
 	config.NetworkFlags
 }
 
+type bumpFeeConfig struct {
+	DaemonAddress string  `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	Password      string  `long:"password" short:"p" description:"Wallet password"`
+	TxID          string  `long:"txid" short:"t" description:"The ID of the unconfirmed transaction to pay a higher fee for" required:"true"`
+	FeeRate       float64 `long:"fee-rate" description:"New fee rate to pay, in sompi per gram of transaction mass (mutually exclusive with --priority)"`
+	Priority      string  `long:"priority" description:"Fee priority to pay the fee rate the node estimates for: low, normal or priority (default: normal)"`
+	Verbose       bool    `long:"show-serialized" short:"s" description:"Show the hex encoded replacement transaction"`
+	config.NetworkFlags
+}
+
+type conversionConfig struct {
+	KeysFile      string   `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kashwallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kashwallet\\key.json (Windows))"`
+	Password      string   `long:"password" short:"p" description:"Wallet password"`
+	DaemonAddress string   `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	Amount        float64  `long:"amount" short:"v" description:"The amount to convert (e.g. 1234.12345678)" required:"true"`
+	ToAddress     string   `long:"to-address" short:"t" description:"The public address to pay the converted amount to (default: a new address of the current wallet)"`
+	FromAddresses []string `long:"from-address" short:"a" description:"Specific public address to convert from. Use multiple times to accept several addresses" required:"false"`
+	FeeRate       float64  `long:"fee-rate" description:"Miner fee rate to leave, in sompi per gram of transaction mass (mutually exclusive with --priority)"`
+	Priority      string   `long:"priority" description:"Fee priority to leave the fee rate the node estimates for: low, normal or priority (default: normal)"`
+	Yes           bool     `long:"yes" short:"y" description:"Assume \"yes\" to all questions"`
+	Verbose       bool     `long:"show-serialized" short:"s" description:"Show the hex encoded conversion transaction"`
+	config.NetworkFlags
+}
+
+type historyConfig struct {
+	DaemonAddress string   `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
+	Offset        uint32   `long:"offset" description:"Number of most recent transactions to skip"`
+	Limit         uint32   `long:"limit" short:"l" description:"Maximum number of transactions to show" default:"20"`
+	AssetTypes    []string `long:"asset-type" short:"a" description:"Show only transactions of this asset type (KSH, KUSD, KRV). Use multiple times to show several asset types"`
+	Verbose       bool     `long:"verbose" short:"v" description:"Verbose: show the addresses of each transaction"`
+	config.NetworkFlags
+}
+
 type createUnsignedTransactionConfig struct {
 	DaemonAddress            string   `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
-	ToAddress                string   `long:"to-address" short:"t" description:"The public address to send Kash to" required:"true"`
+	ToAddress                string   `long:"to-address" short:"t" description:"The public address to send Kash to"`
+	PayoutsFile              string   `long:"payouts-file" description:"A file of payouts to send, as CSV address,amount records or a JSON array of {\"address\", \"amount\"} objects, with amounts in Kash (mutually exclusive with --to-address)"`
 	FromAddresses            []string `long:"from-address" short:"a" description:"Specific public address to send Kash from. Use multiple times to accept several addresses" required:"false"`
 	AssetType                string   `long:"asset-type" short:"s" description:"Asset type to send (KSH, KUSD, KRV)" required:"true"`
 	SendAmount               float64  `long:"send-amount" short:"v" description:"An amount to send in Kash (e.g. 1234.12345678)"`
+	ReceiveAssetType         string   `long:"receive-asset-type" description:"Asset type to convert the sent amount into through the reserve (KSH, KUSD, KRV). Defaults to --asset-type"`
+	ReceiveAmount            float64  `long:"receive-amount" description:"The amount of --receive-asset-type paid for the sent amount when converting (e.g. 1234.12345678)"`
 	IsSendAll                bool     `long:"send-all" description:"Send all the Kash in the wallet (mutually exclusive with --send-amount)"`
 	UseExistingChangeAddress bool     `long:"use-existing-change-address" short:"u" description:"Will use an existing change address (in case no change address was ever used, it will use a new one)"`
+	FeeRate                  float64  `long:"fee-rate" description:"Fee rate to pay, in sompi per gram of transaction mass (mutually exclusive with --priority)"`
+	Priority                 string   `long:"priority" description:"Fee priority to pay the fee rate the node estimates for: low, normal or priority (default: normal)"`
 	config.NetworkFlags
 }
 
@@ -90,10 +145,31 @@ type signConfig struct {
 	config.NetworkFlags
 }
 
+type combineConfig struct {
+	KeysFile         string   `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kashwallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kashwallet\\key.json (Windows))"`
+	Transactions     []string `long:"transaction" short:"t" description:"A copy of the partially signed transaction(s) to combine (encoded in hex). May be used multiple times"`
+	TransactionFiles []string `long:"transaction-file" short:"F" description:"A file containing a copy of the partially signed transaction(s) to combine (encoded in hex). May be used multiple times"`
+	config.NetworkFlags
+}
+
+type inspectConfig struct {
+	Transaction     string `long:"transaction" short:"t" description:"The partially signed transaction(s) to inspect (encoded in hex)"`
+	TransactionFile string `long:"transaction-file" short:"F" description:"The file containing the partially signed transaction(s) to inspect (encoded in hex)"`
+	config.NetworkFlags
+}
+
+type finalizeConfig struct {
+	KeysFile        string `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kashwallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kashwallet\\key.json (Windows))"`
+	Transaction     string `long:"transaction" short:"t" description:"The fully signed transaction(s) to finalize (encoded in hex)"`
+	TransactionFile string `long:"transaction-file" short:"F" description:"The file containing the fully signed transaction(s) to finalize (encoded in hex)"`
+	config.NetworkFlags
+}
+
 type broadcastConfig struct {
 	DaemonAddress    string `long:"daemonaddress" short:"d" description:"Wallet daemon server to connect to"`
 	Transactions     string `long:"transaction" short:"t" description:"The signed transaction to broadcast (encoded in hex)"`
 	TransactionsFile string `long:"transaction-file" short:"F" description:"The file containing the unsigned transaction to sign on (encoded in hex)"`
+	Finalized        bool   `long:"finalized" description:"The transactions were finalized by the 'finalize' command"`
 	config.NetworkFlags
 }
 
@@ -115,12 +191,16 @@ type newAddressConfig struct {
 }
 
 type startDaemonConfig struct {
-	KeysFile  string `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kashwallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kashwallet\\key.json (Windows))"`
-	Password  string `long:"password" short:"p" description:"Wallet password"`
-	RPCServer string `long:"rpcserver" short:"s" description:"RPC server to connect to"`
-	Listen    string `long:"listen" short:"l" description:"Address to listen on (default: 0.0.0.0:8082)"`
-	Timeout   uint32 `long:"wait-timeout" short:"w" description:"Waiting timeout for RPC calls, seconds (default: 30 s)"`
-	Profile   string `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	KeysFile     string `long:"keys-file" short:"f" description:"Keys file location (default: ~/.kashwallet/keys.json (*nix), %USERPROFILE%\\AppData\\Local\\Kashwallet\\key.json (Windows))"`
+	Password     string `long:"password" short:"p" description:"Wallet password"`
+	RPCServer    string `long:"rpcserver" short:"s" description:"RPC server to connect to"`
+	RPCCert      string `long:"rpccert" description:"Certificate of the RPC server, for connecting to an RPC server that runs with --rpctls"`
+	RPCUser      string `long:"rpcuser" description:"Username for RPC connections"`
+	RPCPassword  string `long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
+	RPCAuthToken string `long:"rpcauthtoken" default-mask:"-" description:"Authentication token for RPC connections, used instead of --rpcuser and --rpcpass"`
+	Listen       string `long:"listen" short:"l" description:"Address to listen on (default: 0.0.0.0:8082)"`
+	Timeout      uint32 `long:"wait-timeout" short:"w" description:"Waiting timeout for RPC calls, seconds (default: 30 s)"`
+	Profile      string `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 	config.NetworkFlags
 }
 
@@ -153,6 +233,30 @@ func parseCommandLine() (subCommand string, config interface{}) {
 			"keyfile that is under the daemon's contol. Can be used with a private key generated with the genkeypair utilily "+
 			"to send funds to your main wallet.", sweepConf)
 
+	bumpFeeConf := &bumpFeeConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(bumpFeeSubCmd, "Replaces an unconfirmed transaction with one paying a higher fee",
+		"Replaces an unconfirmed transaction sent by this wallet with one paying a higher fee out of its change. "+
+			"The wallet password is sent to the wallet daemon, so use only with a daemon on a trusted connection.", bumpFeeConf)
+
+	historyConf := &historyConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(historySubCmd, "Shows the transaction history of the current wallet",
+		"Shows the transactions that moved assets in and out of the current wallet, from the most recent to the oldest", historyConf)
+
+	mintKUSDConf := &conversionConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(mintKUSDSubCmd, "Mints KUSD with KSH through the reserve",
+		"Converts the given amount of KSH into KUSD at the current price of the reserve. "+
+			"The wallet password is asked for after the quote is confirmed.", mintKUSDConf)
+
+	stakeKSHConf := &conversionConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(stakeKSHSubCmd, "Stakes KSH in the reserve for KRV",
+		"Converts the given amount of KSH into KRV at the current price of the reserve. "+
+			"The wallet password is asked for after the quote is confirmed.", stakeKSHConf)
+
+	redeemKSHConf := &conversionConfig{DaemonAddress: defaultListen}
+	parser.AddCommand(redeemKSHSubCmd, "Redeems KRV for KSH from the reserve",
+		"Converts the given amount of KRV back into KSH at the current price of the reserve. "+
+			"The wallet password is asked for after the quote is confirmed.", redeemKSHConf)
+
 	createUnsignedTransactionConf := &createUnsignedTransactionConfig{DaemonAddress: defaultListen}
 	parser.AddCommand(createUnsignedTransactionSubCmd, "Create an unsigned Kash transaction",
 		"Create an unsigned Kash transaction", createUnsignedTransactionConf)
@@ -161,6 +265,21 @@ func parseCommandLine() (subCommand string, config interface{}) {
 	parser.AddCommand(signSubCmd, "Sign the given partially signed transaction",
 		"Sign the given partially signed transaction", signConf)
 
+	combineConf := &combineConfig{}
+	parser.AddCommand(combineSubCmd, "Combine the signatures of copies of the same partially signed transaction",
+		"Combine the signatures of copies of the same partially signed transaction that were signed separately "+
+			"by different cosigners, in any order", combineConf)
+
+	inspectConf := &inspectConfig{}
+	parser.AddCommand(inspectSubCmd, "Inspect the given partially signed transaction",
+		"Print the inputs, outputs, amounts and asset types of the given partially signed transaction, "+
+			"along with the cosigners that have signed each of its inputs", inspectConf)
+
+	finalizeConf := &finalizeConfig{}
+	parser.AddCommand(finalizeSubCmd, "Finalize the given fully signed transaction",
+		"Verify the signatures of the given fully signed transaction and print the final transaction, "+
+			"which can be sent with 'broadcast --finalized'", finalizeConf)
+
 	broadcastConf := &broadcastConfig{DaemonAddress: defaultListen}
 	parser.AddCommand(broadcastSubCmd, "Broadcast the given transaction",
 		"Broadcast the given transaction", broadcastConf)
@@ -206,6 +325,10 @@ func parseCommandLine() (subCommand string, config interface{}) {
 		if err != nil {
 			printErrorAndExit(err)
 		}
+		err = validateCreateConfig(createConf)
+		if err != nil {
+			printErrorAndExit(err)
+		}
 		config = createConf
 	case balanceSubCmd:
 		combineNetworkFlags(&balanceConf.NetworkFlags, &cfg.NetworkFlags)
@@ -232,6 +355,62 @@ func parseCommandLine() (subCommand string, config interface{}) {
 			printErrorAndExit(err)
 		}
 		config = sweepConf
+	case bumpFeeSubCmd:
+		combineNetworkFlags(&bumpFeeConf.NetworkFlags, &cfg.NetworkFlags)
+		err := bumpFeeConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		err = validateFee(bumpFeeConf.FeeRate, bumpFeeConf.Priority, "", "")
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = bumpFeeConf
+	case mintKUSDSubCmd:
+		combineNetworkFlags(&mintKUSDConf.NetworkFlags, &cfg.NetworkFlags)
+		err := mintKUSDConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		err = validateConversionConfig(mintKUSDConf)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = mintKUSDConf
+	case stakeKSHSubCmd:
+		combineNetworkFlags(&stakeKSHConf.NetworkFlags, &cfg.NetworkFlags)
+		err := stakeKSHConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		err = validateConversionConfig(stakeKSHConf)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = stakeKSHConf
+	case redeemKSHSubCmd:
+		combineNetworkFlags(&redeemKSHConf.NetworkFlags, &cfg.NetworkFlags)
+		err := redeemKSHConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		err = validateConversionConfig(redeemKSHConf)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = redeemKSHConf
+	case historySubCmd:
+		combineNetworkFlags(&historyConf.NetworkFlags, &cfg.NetworkFlags)
+		err := historyConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		for _, assetType := range historyConf.AssetTypes {
+			if externalapi.AssetTypeFromString(assetType) == externalapi.UNKNOWN {
+				printErrorAndExit(errors.Errorf("unknown asset type %s: must be one of KSH, KUSD, KRV", assetType))
+			}
+		}
+		config = historyConf
 	case createUnsignedTransactionSubCmd:
 		combineNetworkFlags(&createUnsignedTransactionConf.NetworkFlags, &cfg.NetworkFlags)
 		err := createUnsignedTransactionConf.ResolveNetwork(parser)
@@ -250,6 +429,27 @@ func parseCommandLine() (subCommand string, config interface{}) {
 			printErrorAndExit(err)
 		}
 		config = signConf
+	case combineSubCmd:
+		combineNetworkFlags(&combineConf.NetworkFlags, &cfg.NetworkFlags)
+		err := combineConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = combineConf
+	case inspectSubCmd:
+		combineNetworkFlags(&inspectConf.NetworkFlags, &cfg.NetworkFlags)
+		err := inspectConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = inspectConf
+	case finalizeSubCmd:
+		combineNetworkFlags(&finalizeConf.NetworkFlags, &cfg.NetworkFlags)
+		err := finalizeConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = finalizeConf
 	case broadcastSubCmd:
 		combineNetworkFlags(&broadcastConf.NetworkFlags, &cfg.NetworkFlags)
 		err := broadcastConf.ResolveNetwork(parser)
@@ -297,24 +497,112 @@ func parseCommandLine() (subCommand string, config interface{}) {
 	return parser.Command.Active.Name, config
 }
 
+func validateCreateConfig(conf *createConfig) error {
+	if !conf.WatchOnly {
+		if len(conf.PublicKeys) > 0 {
+			return errors.New("'--public-key' can only be used with '--watch-only'")
+		}
+		return nil
+	}
+	if conf.Import {
+		return errors.New("'--import' cannot be used with '--watch-only', since a watch-only wallet holds no private keys")
+	}
+	return nil
+}
+
 func validateCreateUnsignedTransactionConf(conf *createUnsignedTransactionConfig) error {
+	if conf.AssetType != "KSH" && conf.AssetType != "KUSD" && conf.AssetType != "KRV" {
+		return errors.New("asset type must be one of KSH, KUSD, KRV")
+	}
+	err := validateFee(conf.FeeRate, conf.Priority, conf.AssetType, conf.ReceiveAssetType)
+	if err != nil {
+		return err
+	}
+	if conf.PayoutsFile != "" || conf.ToAddress == "" {
+		return validatePayoutsFile(conf.ToAddress, conf.PayoutsFile, conf.SendAmount, conf.IsSendAll,
+			conf.ReceiveAssetType)
+	}
 	if (!conf.IsSendAll && conf.SendAmount == 0) ||
 		(conf.IsSendAll && conf.SendAmount > 0) {
 
 		return errors.New("exactly one of '--send-amount' or '--all' must be specified")
 	}
-	if conf.AssetType != "KSH" && conf.AssetType != "KUSD" && conf.AssetType != "KRV" {
-		return errors.New("asset type must be one of KSH, KUSD, KRV")
+	return validateConversion(conf.AssetType, conf.ReceiveAssetType, conf.ReceiveAmount)
+}
+
+func validateConversionConfig(conf *conversionConfig) error {
+	if conf.Amount <= 0 {
+		return errors.New("'--amount' must be positive")
 	}
-	return nil
+	return validateFee(conf.FeeRate, conf.Priority, "", "")
 }
 
 func validateSendConfig(conf *sendConfig) error {
+	err := validateFee(conf.FeeRate, conf.Priority, conf.SendAssetType, conf.ReceiveAssetType)
+	if err != nil {
+		return err
+	}
+	if conf.PayoutsFile != "" || conf.ToAddress == "" {
+		return validatePayoutsFile(conf.ToAddress, conf.PayoutsFile, conf.SendAmount, conf.IsSendAll,
+			conf.ReceiveAssetType)
+	}
 	if (!conf.IsSendAll && conf.SendAmount == 0) ||
 		(conf.IsSendAll && conf.SendAmount > 0) {
 
 		return errors.New("exactly one of '--send-amount' or '--all' must be specified")
 	}
+	return validateConversion(conf.SendAssetType, conf.ReceiveAssetType, conf.ReceiveAmount)
+}
+
+func validatePayoutsFile(toAddress string, payoutsFile string, sendAmount float64, isSendAll bool,
+	receiveAssetType string) error {
+
+	if (toAddress == "") == (payoutsFile == "") {
+		return errors.New("exactly one of '--to-address' or '--payouts-file' must be specified")
+	}
+	if sendAmount != 0 || isSendAll {
+		return errors.New("'--send-amount' and '--send-all' cannot be used with '--payouts-file'")
+	}
+	if receiveAssetType != "" {
+		return errors.New("'--receive-asset-type' cannot be used with '--payouts-file'")
+	}
+	return nil
+}
+
+func validateFee(feeRate float64, priority string, assetType string, receiveAssetType string) error {
+	if feeRate < 0 {
+		return errors.New("'--fee-rate' cannot be negative")
+	}
+	if feeRate > 0 && priority != "" {
+		return errors.New("'--fee-rate' and '--priority' cannot be used together")
+	}
+	if priority != "" && priority != "low" && priority != "normal" && priority != "priority" {
+		return errors.New("'--priority' must be one of low, normal, priority")
+	}
+	isConversion := receiveAssetType != "" && !strings.EqualFold(receiveAssetType, assetType)
+	if isConversion && (feeRate > 0 || priority != "") {
+		return errors.New("conversion transactions don't pay fees, so '--fee-rate' and '--priority' cannot be used")
+	}
+	return nil
+}
+
+func validateConversion(assetType string, receiveAssetType string, receiveAmount float64) error {
+	if receiveAssetType == "" || strings.EqualFold(receiveAssetType, assetType) {
+		if receiveAmount != 0 {
+			return errors.New("'--receive-amount' is only used with a '--receive-asset-type' " +
+				"that is different from '--asset-type'")
+		}
+		return nil
+	}
+
+	_, ok := externalapi.GetDomainTransactionTypeFromAssetTypes(externalapi.AssetTypeFromString(assetType),
+		externalapi.AssetTypeFromString(receiveAssetType))
+	if !ok {
+		return errors.Errorf("%s cannot be converted into %s", assetType, receiveAssetType)
+	}
+	if receiveAmount <= 0 {
+		return errors.New("'--receive-amount' must be specified when converting")
+	}
 	return nil
 }
 
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/client"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/utils"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/pkg/errors"
+)
+
+// convert converts an amount of the input asset of txType into its output asset through the reserve.
+// The conversion is quoted at the current price of the reserve, and is only made once the quote is confirmed.
+func convert(conf *conversionConfig, txType externalapi.DomainTransactionType) error {
+	keysFile, err := keys.ReadKeysFile(conf.NetParams(), conf.KeysFile)
+	if err != nil {
+		return err
+	}
+
+	if keysFile.IsWatchOnly() {
+		return keys.ErrWatchOnly
+	}
+
+	if len(keysFile.ExtendedPublicKeys) > len(keysFile.EncryptedMnemonics) {
+		return errors.Errorf("Cannot convert with a multisig wallet without all of the keys")
+	}
+
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	amountSompi := uint64(conf.Amount * constants.SompiPerKash)
+
+	quoteCtx, quoteCancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer quoteCancel()
+	quote, err := daemonClient.QuoteConversion(quoteCtx, &pb.QuoteConversionRequest{
+		TransactionType: uint32(txType),
+		Amount:          amountSompi,
+		From:            conf.FromAddresses,
+		FeeRate:         conf.FeeRate,
+		FeePriority:     conf.Priority,
+	})
+	if err != nil {
+		return err
+	}
+
+	printConversionQuote(txType, amountSompi, quote)
+
+	if !conf.Yes {
+		err := confirmConversion()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Since we waited for the user to confirm, which could take unbound amount of time -
+	// create a new context for creating the transaction, to reset the timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+
+	toAddress := conf.ToAddress
+	if toAddress == "" {
+		newAddressResponse, err := daemonClient.NewAddress(ctx, &pb.NewAddressRequest{})
+		if err != nil {
+			return err
+		}
+		toAddress = newAddressResponse.Address
+	}
+
+	inputAssetType, _ := externalapi.GetAssetTypeFromDomainTransactionType(txType)
+	createUnsignedTransactionsResponse, err :=
+		daemonClient.CreateUnsignedTransactions(ctx, &pb.CreateUnsignedTransactionsRequest{
+			From:            conf.FromAddresses,
+			Address:         toAddress,
+			AssetType:       inputAssetType.ToUint32(),
+			Amount:          amountSompi,
+			TransactionType: uint32(txType),
+			ReceiveAmount:   quote.ReceiveAmount,
+		})
+	if err != nil {
+		return err
+	}
+
+	_, signedTransactions, err := signAndBroadcast(daemonClient, keysFile, conf.NetParams(), conf.Password,
+		createUnsignedTransactionsResponse.UnsignedTransactions)
+	if err != nil {
+		return err
+	}
+
+	if conf.Verbose {
+		fmt.Println("Serialized Transaction(s) (can be parsed via the `parse` command or resent via `broadcast`): ")
+		for _, signedTx := range signedTransactions {
+			fmt.Printf("\t%x\n\n", signedTx)
+		}
+	}
+
+	return nil
+}
+
+func printConversionQuote(txType externalapi.DomainTransactionType, amount uint64, quote *pb.QuoteConversionResponse) {
+	inputAssetType, outputAssetType := externalapi.GetAssetTypeFromDomainTransactionType(txType)
+
+	fmt.Printf("Exchange rate:     1 KSH = %s USD\n", utils.FormatKas(quote.ExchangeRate))
+	fmt.Printf("Reserve ratio:     %d.%02d%%\n", quote.ReserveRatioBasisPoints/100, quote.ReserveRatioBasisPoints%100)
+	fmt.Printf("Converting:        %s %s\n", utils.FormatKas(amount), inputAssetType)
+	fmt.Printf("Receiving:         %s %s\n", utils.FormatKas(quote.ReceiveAmount), outputAssetType)
+	if txType == externalapi.RedeemKSH || txType == externalapi.RedeemKUSD {
+		fmt.Printf("Reserve pays out:  %s KSH\n", utils.FormatKas(quote.KshAmount))
+	} else {
+		fmt.Printf("Reserve receives:  %s KSH\n", utils.FormatKas(quote.KshAmount))
+	}
+	fmt.Printf("Conversion fee:    %s KSH\n", utils.FormatKas(quote.ConversionFee))
+	fmt.Printf("Miner fee:         %s KSH\n", utils.FormatKas(quote.MinerFee))
+	if quote.Surplus > 0 {
+		fmt.Printf("The spent UTXOs hold another %s %s, which are converted at the same price and "+
+			"paid to a change address.\n", utils.FormatKas(quote.Surplus), inputAssetType)
+	}
+	fmt.Println("The quote is only valid while the exchange rate and the reserve stay the same. If they change " +
+		"before the conversion is accepted, the conversion might be rejected.")
+}
+
+func confirmConversion() error {
+	reader := bufio.NewReader(os.Stdin)
+	fmt.Printf("Do you want to make this conversion (y/N)? ")
+	line, err := utils.ReadLine(reader)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+
+	if string(line) != "y" {
+		return errors.Errorf("Conversion aborted by user")
+	}
+
+	return nil
+}
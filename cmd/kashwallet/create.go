@@ -14,6 +14,10 @@ import (
 )
 
 func create(conf *createConfig) error {
+	if conf.WatchOnly {
+		return createWatchOnly(conf)
+	}
+
 	var encryptedMnemonics []*keys.EncryptedMnemonic
 	var signerExtendedPublicKeys []string
 	var err error
@@ -64,7 +68,7 @@ func create(conf *createConfig) error {
 		}
 	}
 
-	file := keys.File{
+	file := &keys.File{
 		Version:            keys.LastVersion,
 		EncryptedMnemonics: encryptedMnemonics,
 		ExtendedPublicKeys: extendedPublicKeys,
@@ -73,7 +77,59 @@ func create(conf *createConfig) error {
 		ECDSA:              conf.ECDSA,
 	}
 
-	err = file.SetPath(conf.NetParams(), conf.KeysFile, conf.Yes)
+	return saveKeysFile(conf, file)
+}
+
+// createWatchOnly creates a wallet that holds no private keys, only the extended public keys given on
+// the command line or entered by the user. Such a wallet can show its balance, generate addresses and
+// create unsigned transactions, which have to be signed by the holders of the private keys.
+func createWatchOnly(conf *createConfig) error {
+	extendedPublicKeys := conf.PublicKeys
+	if len(extendedPublicKeys) == 0 {
+		reader := bufio.NewReader(os.Stdin)
+		for i := uint32(0); i < conf.NumPublicKeys; i++ {
+			fmt.Printf("Enter public key #%d here:\n", i+1)
+			extendedPublicKey, err := utils.ReadLine(reader)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println()
+
+			extendedPublicKeys = append(extendedPublicKeys, string(extendedPublicKey))
+		}
+	}
+
+	for i, extendedPublicKey := range extendedPublicKeys {
+		extendedKey, err := bip32.DeserializeExtendedKey(extendedPublicKey)
+		if err != nil {
+			return errors.Wrapf(err, "%s is invalid extended public key", extendedPublicKey)
+		}
+		if extendedKey.IsPrivate() {
+			return errors.Errorf("Key #%d is an extended private key. A watch-only wallet holds only "+
+				"extended public keys", i+1)
+		}
+	}
+
+	if conf.MinimumSignatures == 0 || int(conf.MinimumSignatures) > len(extendedPublicKeys) {
+		return errors.Errorf("The minimum required signatures must be between 1 and the number of "+
+			"public keys (%d)", len(extendedPublicKeys))
+	}
+
+	// For a read only wallet the cosigner index is 0
+	file := &keys.File{
+		Version:            keys.LastVersion,
+		ExtendedPublicKeys: extendedPublicKeys,
+		MinimumSignatures:  conf.MinimumSignatures,
+		CosignerIndex:      0,
+		ECDSA:              conf.ECDSA,
+	}
+
+	return saveKeysFile(conf, file)
+}
+
+func saveKeysFile(conf *createConfig, file *keys.File) error {
+	err := file.SetPath(conf.NetParams(), conf.KeysFile, conf.Yes)
 	if err != nil {
 		return err
 	}
@@ -21,6 +21,14 @@ func createUnsignedTransaction(conf *createUnsignedTransactionConfig) error {
 	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
 	defer cancel()
 
+	var payouts []*pb.Payout
+	if conf.PayoutsFile != "" {
+		payouts, err = readPayoutsFile(conf.PayoutsFile)
+		if err != nil {
+			return err
+		}
+	}
+
 	sendAmountSompi := uint64(conf.SendAmount * constants.SompiPerKash)
 	response, err := daemonClient.CreateUnsignedTransactions(ctx, &pb.CreateUnsignedTransactionsRequest{
 		From:                     conf.FromAddresses,
@@ -29,6 +37,11 @@ func createUnsignedTransaction(conf *createUnsignedTransactionConfig) error {
 		Amount:                   sendAmountSompi,
 		IsSendAll:                conf.IsSendAll,
 		UseExistingChangeAddress: conf.UseExistingChangeAddress,
+		TransactionType:          requestedTransactionType(conf.AssetType, conf.ReceiveAssetType),
+		ReceiveAmount:            uint64(conf.ReceiveAmount * constants.SompiPerKash),
+		Payouts:                  payouts,
+		FeeRate:                  conf.FeeRate,
+		FeePriority:              conf.Priority,
 	})
 	if err != nil {
 		return err
@@ -37,5 +50,12 @@ func createUnsignedTransaction(conf *createUnsignedTransactionConfig) error {
 	fmt.Fprintln(os.Stderr, "Created unsigned transaction")
 	fmt.Println(encodeTransactionsToHex(response.UnsignedTransactions))
 
+	if len(response.PayoutResults) > 0 {
+		// The transactions are printed to stdout, so the payouts report goes to stderr
+		printPayoutResults(os.Stderr, response.PayoutResults, func(transactionIndex uint32) string {
+			return fmt.Sprintf("transaction #%d", transactionIndex+1)
+		})
+	}
+
 	return nil
 }
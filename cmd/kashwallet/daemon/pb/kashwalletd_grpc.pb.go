@@ -28,6 +28,9 @@ const (
 	Kashwalletd_Broadcast_FullMethodName                  = "/kashwalletd.kashwalletd/Broadcast"
 	Kashwalletd_Send_FullMethodName                       = "/kashwalletd.kashwalletd/Send"
 	Kashwalletd_Sign_FullMethodName                       = "/kashwalletd.kashwalletd/Sign"
+	Kashwalletd_BumpFee_FullMethodName                    = "/kashwalletd.kashwalletd/BumpFee"
+	Kashwalletd_GetTransactionHistory_FullMethodName      = "/kashwalletd.kashwalletd/GetTransactionHistory"
+	Kashwalletd_QuoteConversion_FullMethodName            = "/kashwalletd.kashwalletd/QuoteConversion"
 )
 
 // KashwalletdClient is the client API for Kashwalletd service.
@@ -45,6 +48,10 @@ type KashwalletdClient interface {
 	Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
 	// Since SignRequest contains a password - this command should only be used on a trusted or secure connection
 	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+	// Since BumpFeeRequest contains a password - this command should only be used on a trusted or secure connection
+	BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error)
+	GetTransactionHistory(ctx context.Context, in *GetTransactionHistoryRequest, opts ...grpc.CallOption) (*GetTransactionHistoryResponse, error)
+	QuoteConversion(ctx context.Context, in *QuoteConversionRequest, opts ...grpc.CallOption) (*QuoteConversionResponse, error)
 }
 
 type kashwalletdClient struct {
@@ -136,6 +143,33 @@ func (c *kashwalletdClient) Sign(ctx context.Context, in *SignRequest, opts ...g
 	return out, nil
 }
 
+func (c *kashwalletdClient) BumpFee(ctx context.Context, in *BumpFeeRequest, opts ...grpc.CallOption) (*BumpFeeResponse, error) {
+	out := new(BumpFeeResponse)
+	err := c.cc.Invoke(ctx, Kashwalletd_BumpFee_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kashwalletdClient) GetTransactionHistory(ctx context.Context, in *GetTransactionHistoryRequest, opts ...grpc.CallOption) (*GetTransactionHistoryResponse, error) {
+	out := new(GetTransactionHistoryResponse)
+	err := c.cc.Invoke(ctx, Kashwalletd_GetTransactionHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kashwalletdClient) QuoteConversion(ctx context.Context, in *QuoteConversionRequest, opts ...grpc.CallOption) (*QuoteConversionResponse, error) {
+	out := new(QuoteConversionResponse)
+	err := c.cc.Invoke(ctx, Kashwalletd_QuoteConversion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KashwalletdServer is the server API for Kashwalletd service.
 // All implementations must embed UnimplementedKashwalletdServer
 // for forward compatibility
@@ -151,6 +185,10 @@ type KashwalletdServer interface {
 	Send(context.Context, *SendRequest) (*SendResponse, error)
 	// Since SignRequest contains a password - this command should only be used on a trusted or secure connection
 	Sign(context.Context, *SignRequest) (*SignResponse, error)
+	// Since BumpFeeRequest contains a password - this command should only be used on a trusted or secure connection
+	BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error)
+	GetTransactionHistory(context.Context, *GetTransactionHistoryRequest) (*GetTransactionHistoryResponse, error)
+	QuoteConversion(context.Context, *QuoteConversionRequest) (*QuoteConversionResponse, error)
 	mustEmbedUnimplementedKashwalletdServer()
 }
 
@@ -185,6 +223,15 @@ func (UnimplementedKashwalletdServer) Send(context.Context, *SendRequest) (*Send
 func (UnimplementedKashwalletdServer) Sign(context.Context, *SignRequest) (*SignResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
 }
+func (UnimplementedKashwalletdServer) BumpFee(context.Context, *BumpFeeRequest) (*BumpFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BumpFee not implemented")
+}
+func (UnimplementedKashwalletdServer) GetTransactionHistory(context.Context, *GetTransactionHistoryRequest) (*GetTransactionHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactionHistory not implemented")
+}
+func (UnimplementedKashwalletdServer) QuoteConversion(context.Context, *QuoteConversionRequest) (*QuoteConversionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteConversion not implemented")
+}
 func (UnimplementedKashwalletdServer) mustEmbedUnimplementedKashwalletdServer() {}
 
 // UnsafeKashwalletdServer may be embedded to opt out of forward compatibility for this service.
@@ -360,6 +407,60 @@ func _Kashwalletd_Sign_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Kashwalletd_BumpFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BumpFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KashwalletdServer).BumpFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Kashwalletd_BumpFee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KashwalletdServer).BumpFee(ctx, req.(*BumpFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kashwalletd_GetTransactionHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KashwalletdServer).GetTransactionHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Kashwalletd_GetTransactionHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KashwalletdServer).GetTransactionHistory(ctx, req.(*GetTransactionHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Kashwalletd_QuoteConversion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteConversionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KashwalletdServer).QuoteConversion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Kashwalletd_QuoteConversion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KashwalletdServer).QuoteConversion(ctx, req.(*QuoteConversionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Kashwalletd_ServiceDesc is the grpc.ServiceDesc for Kashwalletd service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -403,6 +504,18 @@ var Kashwalletd_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Sign",
 			Handler:    _Kashwalletd_Sign_Handler,
 		},
+		{
+			MethodName: "BumpFee",
+			Handler:    _Kashwalletd_BumpFee_Handler,
+		},
+		{
+			MethodName: "GetTransactionHistory",
+			Handler:    _Kashwalletd_GetTransactionHistory_Handler,
+		},
+		{
+			MethodName: "QuoteConversion",
+			Handler:    _Kashwalletd_QuoteConversion_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "kashwalletd.proto",
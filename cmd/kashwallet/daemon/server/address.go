@@ -35,6 +35,11 @@ func (s *server) changeAddress(useExisting bool, fromAddresses []*walletAddress)
 			cosignerIndex: s.keysFile.CosignerIndex,
 			keyChain:      libkashwallet.InternalKeychain,
 		}
+
+		err := s.watchAddress(walletAddr)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	path := s.walletAddressPath(walletAddr)
@@ -94,6 +99,11 @@ func (s *server) NewAddress(_ context.Context, request *pb.NewAddressRequest) (*
 		cosignerIndex: s.keysFile.CosignerIndex,
 		keyChain:      libkashwallet.ExternalKeychain,
 	}
+	err = s.watchAddress(walletAddr)
+	if err != nil {
+		return nil, err
+	}
+
 	path := s.walletAddressPath(walletAddr)
 	address, err := libkashwallet.Address(s.params, s.keysFile.ExtendedPublicKeys, s.keysFile.MinimumSignatures, path, s.keysFile.ECDSA)
 	if err != nil {
@@ -27,6 +27,10 @@ func (s *server) GetBalance(_ context.Context, _ *pb.GetBalanceRequest) (*pb.Get
 
 	assetBalancesMap := make(map[externalapi.AssetType]assetBalancesMapType)
 	for _, entry := range s.utxosSortedByAmount {
+		if s.isSpentByMempool(entry) {
+			continue
+		}
+
 		assetType := entry.UTXOEntry.AssetType()
 		amount := entry.UTXOEntry.Amount()
 		address := entry.address
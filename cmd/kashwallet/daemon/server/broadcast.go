@@ -31,6 +31,7 @@ func (s *server) broadcast(transactions [][]byte, isDomain bool) ([]string, erro
 	var err error
 
 	for i, transaction := range transactions {
+		var prevOutputs []*externalapi.DomainTransactionOutput
 
 		if isDomain {
 			tx, err = serialization.DeserializeDomainTransaction(transaction)
@@ -38,10 +39,18 @@ func (s *server) broadcast(transactions [][]byte, isDomain bool) ([]string, erro
 				return nil, err
 			}
 		} else if !isDomain { //default in proto3 is false
-			tx, err = libkashwallet.ExtractTransaction(transaction, s.keysFile.ECDSA)
+			partiallySignedTransaction, err := serialization.DeserializePartiallySignedTransaction(transaction)
 			if err != nil {
 				return nil, err
 			}
+			tx, err = libkashwallet.ExtractTransactionDeserialized(partiallySignedTransaction, s.keysFile.ECDSA)
+			if err != nil {
+				return nil, err
+			}
+			prevOutputs = make([]*externalapi.DomainTransactionOutput, len(partiallySignedTransaction.PartiallySignedInputs))
+			for j, input := range partiallySignedTransaction.PartiallySignedInputs {
+				prevOutputs[j] = input.PrevOutput
+			}
 		}
 
 		txIDs[i], err = sendTransaction(s.rpcClient, tx)
@@ -49,6 +58,12 @@ func (s *server) broadcast(transactions [][]byte, isDomain bool) ([]string, erro
 			return nil, err
 		}
 
+		// The transaction was already broadcast, so failing to record it must not fail the broadcast
+		err = s.recordBroadcastTransaction(tx, prevOutputs)
+		if err != nil {
+			log.Errorf("Error recording transaction %s in the transaction history: %s", txIDs[i], err)
+		}
+
 		for _, input := range tx.Inputs {
 			s.usedOutpoints[input.PreviousOutpoint] = time.Now()
 		}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet/serialization"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
+	"github.com/pkg/errors"
+)
+
+func (s *server) BumpFee(_ context.Context, request *pb.BumpFeeRequest) (*pb.BumpFeeResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.keysFile.IsWatchOnly() {
+		return nil, keys.ErrWatchOnly
+	}
+
+	unsignedTransaction, err := s.createUnsignedBumpFeeTransaction(request.TxID, request.FeeRate, request.FeePriority)
+	if err != nil {
+		return nil, err
+	}
+
+	signedTransactions, err := s.signTransactions([][]byte{unsignedTransaction}, request.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDs, err := s.broadcast(signedTransactions, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.BumpFeeResponse{TxID: txIDs[0], SignedTransaction: signedTransactions[0]}, nil
+}
+
+// createUnsignedBumpFeeTransaction rebuilds the unconfirmed transaction with the given ID so that it pays
+// feeRate, or the fee rate estimated for feePriority, instead. The rebuilt transaction spends the same UTXOs
+// and pays the same outputs, and the fee increase is taken from its change, so that the mempool replaces
+// the original transaction with it.
+func (s *server) createUnsignedBumpFeeTransaction(txID string, requestedFeeRate float64, feePriority string) (
+	[]byte, error) {
+
+	if !s.isSynced() {
+		return nil, errors.Errorf("wallet daemon is not synced yet, %s", s.formatSyncStateReport())
+	}
+
+	getMempoolEntryResponse, err := s.rpcClient.GetMempoolEntry(txID, false, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "transaction %s is not waiting in the mempool", txID)
+	}
+	transaction, err := appmessage.RPCTransactionToDomainTransaction(getMempoolEntryResponse.Entry.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.Type.IsConversion() {
+		return nil, errors.Errorf("%s transactions don't pay fees, so their fee cannot be bumped", transaction.Type)
+	}
+	originalFee := getMempoolEntryResponse.Entry.Fee
+
+	feeRate, err := s.feeRate(requestedFeeRate, feePriority)
+	if err != nil {
+		return nil, err
+	}
+
+	utxos, err := s.transactionInputUTXOs(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	_, outputAssetType := externalapi.GetAssetTypeFromDomainTransactionType(transaction.Type)
+	payments := make([]*libkashwallet.Payment, len(transaction.Outputs))
+	changeIndex := -1
+	for i, output := range transaction.Outputs {
+		_, address, err := txscript.ExtractScriptPubKeyAddress(output.ScriptPublicKey, s.params)
+		if err != nil {
+			return nil, err
+		}
+		if address == nil {
+			return nil, errors.Errorf("output #%d of transaction %s doesn't pay to an address", i, txID)
+		}
+		if walletAddress, ok := s.addressSet[address.String()]; ok && walletAddress.keyChain == libkashwallet.InternalKeychain {
+			changeIndex = i
+		}
+		payments[i] = &libkashwallet.Payment{
+			Address:   address,
+			AssetType: outputAssetType,
+			Amount:    output.Value,
+		}
+	}
+	if changeIndex == -1 {
+		return nil, errors.Errorf("transaction %s has no change output to pay a higher fee from", txID)
+	}
+
+	unsignedTransaction, err := libkashwallet.CreateUnsignedTransaction(s.keysFile.ExtendedPublicKeys,
+		s.keysFile.MinimumSignatures, payments, utxos, transaction.Type)
+	if err != nil {
+		return nil, err
+	}
+	partiallySignedTransaction, err := serialization.DeserializePartiallySignedTransaction(unsignedTransaction)
+	if err != nil {
+		return nil, err
+	}
+	// The output amounts don't affect the mass, so the mass of the rebuilt transaction is already known
+	mass, err := s.estimateMassAfterSignatures(partiallySignedTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := feeForMass(mass, feeRate)
+	if fee <= originalFee {
+		return nil, errors.Errorf("a fee rate of %f pays %d sompi, which doesn't raise the current fee of %d sompi "+
+			"of transaction %s", feeRate, fee, originalFee, txID)
+	}
+	feeIncrease := fee - originalFee
+	if payments[changeIndex].Amount <= feeIncrease {
+		return nil, errors.Errorf("the change of transaction %s, %d sompi, cannot pay the fee increase of %d sompi",
+			txID, payments[changeIndex].Amount, feeIncrease)
+	}
+	payments[changeIndex].Amount -= feeIncrease
+
+	return libkashwallet.CreateUnsignedTransaction(s.keysFile.ExtendedPublicKeys,
+		s.keysFile.MinimumSignatures, payments, utxos, transaction.Type)
+}
+
+// transactionInputUTXOs returns the UTXOs spent by transaction, all of which must be confirmed UTXOs of this wallet
+func (s *server) transactionInputUTXOs(transaction *externalapi.DomainTransaction) ([]*libkashwallet.UTXO, error) {
+	getUTXOsByAddressesResponse, err := s.rpcClient.GetUTXOsByAddresses(s.addressSet.strings())
+	if err != nil {
+		return nil, err
+	}
+	entriesByOutpoint := make(map[externalapi.DomainOutpoint]*appmessage.UTXOsByAddressesEntry,
+		len(getUTXOsByAddressesResponse.Entries))
+	for _, entry := range getUTXOsByAddressesResponse.Entries {
+		outpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry.Outpoint)
+		if err != nil {
+			return nil, err
+		}
+		entriesByOutpoint[*outpoint] = entry
+	}
+
+	utxos := make([]*libkashwallet.UTXO, len(transaction.Inputs))
+	for i, input := range transaction.Inputs {
+		entry, ok := entriesByOutpoint[input.PreviousOutpoint]
+		if !ok {
+			return nil, errors.Errorf("input %s is not a confirmed UTXO of this wallet", input.PreviousOutpoint)
+		}
+		utxoEntry, err := appmessage.RPCUTXOEntryToUTXOEntry(entry.UTXOEntry)
+		if err != nil {
+			return nil, err
+		}
+		outpoint := input.PreviousOutpoint
+		utxos[i] = &libkashwallet.UTXO{
+			Outpoint:       &outpoint,
+			UTXOEntry:      utxoEntry,
+			DerivationPath: s.walletAddressPath(s.addressSet[entry.Address]),
+		}
+	}
+
+	return utxos, nil
+}
@@ -8,49 +8,86 @@ import (
 
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet/serialization"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/miningmanager/mempool"
 	"github.com/Kash-Protocol/kashd/util"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/slices"
 )
 
-// TODO: Implement a better fee estimation mechanism
-const feePerInput = 10000
-
 func (s *server) CreateUnsignedTransactions(_ context.Context, request *pb.CreateUnsignedTransactionsRequest) (
 	*pb.CreateUnsignedTransactionsResponse, error,
 ) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	txType, err := transactionTypeFromRequest(request.AssetType, request.TransactionType)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request.Payouts) > 0 {
+		err := validatePayoutsRequest(request.Address, request.Amount, request.IsSendAll, txType)
+		if err != nil {
+			return nil, err
+		}
+		unsignedTransactions, payoutResults, err := s.createUnsignedPayoutTransactions(request.Payouts, txType,
+			request.From, request.UseExistingChangeAddress, request.FeeRate, request.FeePriority)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.CreateUnsignedTransactionsResponse{
+			UnsignedTransactions: unsignedTransactions,
+			PayoutResults:        payoutResults,
+		}, nil
+	}
+
+	unsignedTransactions, err := s.createUnsignedTransactions(request.Address,
+		txType, request.Amount, request.ReceiveAmount, request.IsSendAll,
+		request.From, request.UseExistingChangeAddress, request.FeeRate, request.FeePriority)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateUnsignedTransactionsResponse{UnsignedTransactions: unsignedTransactions}, nil
+}
+
+// transactionTypeFromRequest returns the DomainTransactionType requested by a wallet client.
+// Conversions must be requested explicitly, while transfers are derived from the asset type,
+// so that clients that never set the transaction type keep sending transfers.
+func transactionTypeFromRequest(assetType uint32, transactionType uint32) (externalapi.DomainTransactionType, error) {
+	requestedAssetType := externalapi.AssetTypeFromUint32(assetType)
+
+	txType := externalapi.DomainTransactionType(transactionType)
+	if txType.IsConversion() {
+		inputAssetType, _ := externalapi.GetAssetTypeFromDomainTransactionType(txType)
+		if inputAssetType != requestedAssetType {
+			return 0, errors.Errorf("%s transactions spend %s, but %s was requested",
+				txType, inputAssetType, requestedAssetType)
+		}
+		return txType, nil
+	}
+
 	// TODO: This mapping of AssetType to DomainTransactionType is a temporary solution.
 	// It allows wallet usage of the 'send' command for transfers. Currently, AssetType
 	// directly corresponds to a specific Transfer TxType. This may evolve with more complex
 	// transaction types in the future.
-	var txType externalapi.DomainTransactionType
-	switch externalapi.AssetTypeFromUint32(request.AssetType) {
+	switch requestedAssetType {
 	case externalapi.KSH:
-		txType = externalapi.TransferKSH
+		return externalapi.TransferKSH, nil
 	case externalapi.KUSD:
-		txType = externalapi.TransferKUSD
+		return externalapi.TransferKUSD, nil
 	case externalapi.KRV:
-		txType = externalapi.TransferKRV
+		return externalapi.TransferKRV, nil
 	default:
-		return nil, errors.Errorf("Unknown asset type %d", request.AssetType)
-	}
-
-	unsignedTransactions, err := s.createUnsignedTransactions(request.Address,
-		txType, request.Amount, request.IsSendAll,
-		request.From, request.UseExistingChangeAddress)
-	if err != nil {
-		return nil, err
+		return 0, errors.Errorf("Unknown asset type %d", assetType)
 	}
-
-	return &pb.CreateUnsignedTransactionsResponse{UnsignedTransactions: unsignedTransactions}, nil
 }
 
 func (s *server) createUnsignedTransactions(address string, txType externalapi.DomainTransactionType,
-	amount uint64, isSendAll bool, fromAddressesString []string, useExistingChangeAddress bool) ([][]byte, error) {
+	amount uint64, receiveAmount uint64, isSendAll bool, fromAddressesString []string,
+	useExistingChangeAddress bool, requestedFeeRate float64, feePriority string) ([][]byte, error) {
 	if !s.isSynced() {
 		return nil, errors.Errorf("wallet daemon is not synced yet, %s", s.formatSyncStateReport())
 	}
@@ -67,18 +104,36 @@ func (s *server) createUnsignedTransactions(address string, txType externalapi.D
 		return nil, err
 	}
 
-	var fromAddresses []*walletAddress
-	for _, from := range fromAddressesString {
-		fromAddress, exists := s.addressSet[from]
-		if !exists {
-			return nil, fmt.Errorf("Specified from address %s does not exists", from)
+	fromAddresses, err := s.fromWalletAddresses(fromAddressesString)
+	if err != nil {
+		return nil, err
+	}
+
+	if txType.IsConversion() {
+		if requestedFeeRate != 0 || feePriority != "" {
+			return nil, errors.Errorf("the fee of %s transactions is what the conversion is worth "+
+				"beyond the paid amounts, so a fee rate cannot be requested", txType)
 		}
-		fromAddresses = append(fromAddresses, fromAddress)
+		return s.createUnsignedConversionTransaction(toAddress, txType, amount, receiveAmount, isSendAll,
+			fromAddresses, useExistingChangeAddress)
+	}
+
+	feeRate, err := s.feeRate(requestedFeeRate, feePriority)
+	if err != nil {
+		return nil, err
 	}
 
 	fromAssetType, toAssetType := externalapi.GetAssetTypeFromDomainTransactionType(txType)
 
-	selectedUTXOs, spendValue, changeSompi, err := s.selectUTXOs(amount, isSendAll, feePerInput, fromAddresses, fromAssetType)
+	// Sending all leaves no change
+	feeEstimate, err := s.newTransactionFeeEstimate([]*libkashwallet.Payment{{Address: toAddress, AssetType: toAssetType}},
+		!isSendAll, txType, feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedUTXOs, spendValue, changeSompi, err := s.selectUTXOs(amount, isSendAll, feeEstimate, fromAddresses,
+		fromAssetType, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -112,15 +167,111 @@ func (s *server) createUnsignedTransactions(address string, txType externalapi.D
 		return nil, err
 	}
 
-	unsignedTransactions, err := s.maybeAutoCompoundTransaction(unsignedTransaction, toAddress,
-		changeAddress, changeWalletAddress)
+	unsignedTransactions, err := s.maybeAutoCompoundTransaction(unsignedTransaction, payments[:1],
+		changeAddress, changeWalletAddress, feeRate)
 	if err != nil {
 		return nil, err
 	}
 	return unsignedTransactions, nil
 }
 
-func (s *server) selectUTXOs(spendAmount uint64, isSendAll bool, feePerInput uint64, fromAddresses []*walletAddress, assetType externalapi.AssetType) (
+// createUnsignedConversionTransaction creates a transaction that converts amount of the
+// input asset of txType into receiveAmount of its output asset through the Djed reserve.
+//
+// All outputs of a conversion are of the output asset, so whatever the selected UTXOs hold
+// beyond amount cannot be returned as change. It is converted as well, and what it buys is
+// paid to the change address instead.
+// The miner fee is whatever the conversion is worth beyond the paid amounts.
+func (s *server) createUnsignedConversionTransaction(toAddress util.Address, txType externalapi.DomainTransactionType,
+	amount uint64, receiveAmount uint64, isSendAll bool, fromAddresses []*walletAddress,
+	useExistingChangeAddress bool) ([][]byte, error) {
+
+	if receiveAmount == 0 {
+		return nil, errors.Errorf("a receive amount is required for %s transactions", txType)
+	}
+
+	fromAssetType, toAssetType := externalapi.GetAssetTypeFromDomainTransactionType(txType)
+
+	// The fee of a conversion is implicit, so no fee is set aside when selecting UTXOs
+	selectedUTXOs, _, surplus, err := s.selectUTXOs(amount, isSendAll, nil, fromAddresses, fromAssetType, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(selectedUTXOs) == 0 {
+		return nil, errors.Errorf("couldn't find funds to spend")
+	}
+
+	changeAddress, _, err := s.changeAddress(useExistingChangeAddress, fromAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := []*libkashwallet.Payment{{
+		Address:   toAddress,
+		AssetType: toAssetType,
+		Amount:    receiveAmount,
+	}}
+	reserveStateResponse, reserveState, err := s.reserveState()
+	if err != nil {
+		return nil, err
+	}
+	surplusReceiveAmount, err := conversionSurplusReceiveAmount(s.djedCalculator(), reserveState,
+		reserveStateResponse.ExchangeRate, txType, amount, receiveAmount, surplus)
+	if err != nil {
+		return nil, err
+	}
+	if surplusReceiveAmount > 0 {
+		payments = append(payments, &libkashwallet.Payment{
+			Address:   changeAddress,
+			AssetType: toAssetType,
+			Amount:    surplusReceiveAmount,
+		})
+	}
+
+	unsignedTransaction, err := libkashwallet.CreateUnsignedTransaction(s.keysFile.ExtendedPublicKeys,
+		s.keysFile.MinimumSignatures,
+		payments, selectedUTXOs, txType)
+	if err != nil {
+		return nil, err
+	}
+
+	// A conversion cannot be split like a transfer, since every split transaction would
+	// be a conversion of its own
+	transaction, err := serialization.DeserializePartiallySignedTransaction(unsignedTransaction)
+	if err != nil {
+		return nil, err
+	}
+	transactionMass, err := s.estimateMassAfterSignatures(transaction)
+	if err != nil {
+		return nil, err
+	}
+	if transactionMass >= mempool.MaximumStandardTransactionMass {
+		return nil, errors.Errorf("a %s transaction spending %d UTXOs is too large, "+
+			"send the %s to yourself first in order to compound them", txType, len(selectedUTXOs), fromAssetType)
+	}
+
+	return [][]byte{unsignedTransaction}, nil
+}
+
+// fromWalletAddresses returns the wallet addresses of the given address strings
+func (s *server) fromWalletAddresses(fromAddressesString []string) ([]*walletAddress, error) {
+	var fromAddresses []*walletAddress
+	for _, from := range fromAddressesString {
+		fromAddress, exists := s.addressSet[from]
+		if !exists {
+			return nil, fmt.Errorf("Specified from address %s does not exists", from)
+		}
+		fromAddresses = append(fromAddresses, fromAddress)
+	}
+	return fromAddresses, nil
+}
+
+// selectUTXOs selects UTXOs of the given asset type to spend, skipping excludedOutpoints,
+// which may be nil. The selected UTXOs also pay the fee feeEstimate estimates for them,
+// unless it is nil.
+func (s *server) selectUTXOs(spendAmount uint64, isSendAll bool, feeEstimate *transactionFeeEstimate, fromAddresses []*walletAddress,
+	assetType externalapi.AssetType, excludedOutpoints map[externalapi.DomainOutpoint]struct{}) (
 	selectedUTXOs []*libkashwallet.UTXO, totalReceived uint64, changeSompi uint64, err error) {
 
 	selectedUTXOs = []*libkashwallet.UTXO{}
@@ -138,6 +289,14 @@ func (s *server) selectUTXOs(spendAmount uint64, isSendAll bool, feePerInput uin
 			continue
 		}
 
+		if _, ok := excludedOutpoints[*utxo.Outpoint]; ok {
+			continue
+		}
+
+		if s.isSpentByMempool(utxo) {
+			continue
+		}
+
 		if broadcastTime, ok := s.usedOutpoints[*utxo.Outpoint]; ok {
 			if time.Since(broadcastTime) > time.Minute {
 				delete(s.usedOutpoints, *utxo.Outpoint)
@@ -154,16 +313,20 @@ func (s *server) selectUTXOs(spendAmount uint64, isSendAll bool, feePerInput uin
 
 		totalValue += utxo.UTXOEntry.Amount()
 
-		fee := feePerInput * uint64(len(selectedUTXOs))
+		fee := feeEstimate.fee(len(selectedUTXOs))
 		totalSpend := spendAmount + fee
 		if !isSendAll && totalValue >= totalSpend {
 			break
 		}
 	}
 
-	fee := feePerInput * uint64(len(selectedUTXOs))
+	fee := feeEstimate.fee(len(selectedUTXOs))
 	var totalSpend uint64
 	if isSendAll {
+		if totalValue < fee {
+			return nil, 0, 0, errors.Errorf("Insufficient funds for send: %f are required for fees, "+
+				"while only %f are available", float64(fee)/constants.SompiPerKash, float64(totalValue)/constants.SompiPerKash)
+		}
 		totalSpend = totalValue
 		totalReceived = totalValue - fee
 	} else {
@@ -52,10 +52,13 @@ func (s *server) selectExternalSpendableUTXOs(externalUTXOs *appmessage.GetUTXOs
 	return selectedExternalUtxos, nil
 }
 
+// coinbaseDustAmount is the amount up to which coinbase UTXOs are not worth spending
+const coinbaseDustAmount = 10000
+
 func isExternalUTXOSpendable(entry *appmessage.UTXOsByAddressesEntry, virtualDAAScore uint64, coinbaseMaturity uint64) bool {
 	if !entry.UTXOEntry.IsCoinbase {
 		return true
-	} else if entry.UTXOEntry.Amount <= feePerInput {
+	} else if entry.UTXOEntry.Amount <= coinbaseDustAmount {
 		return false
 	}
 	return entry.UTXOEntry.BlockDAAScore+coinbaseMaturity < virtualDAAScore
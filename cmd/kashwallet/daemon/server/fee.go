@@ -0,0 +1,151 @@
+package server
+
+import (
+	"math"
+
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet/serialization"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/utxo"
+	"github.com/pkg/errors"
+)
+
+// The fee priorities a wallet client may request, each paying the fee rate the node estimates for it
+const (
+	lowFeePriority      = "low"
+	normalFeePriority   = "normal"
+	priorityFeePriority = "priority"
+)
+
+// feeRate returns the fee rate, in sompi per gram, to pay for transactions.
+// requestedFeeRate is used if it is set, and otherwise the node is asked for
+// the fee rate it estimates for feePriority.
+func (s *server) feeRate(requestedFeeRate float64, feePriority string) (float64, error) {
+	if requestedFeeRate < 0 || math.IsNaN(requestedFeeRate) || math.IsInf(requestedFeeRate, 0) {
+		return 0, errors.Errorf("invalid fee rate %f", requestedFeeRate)
+	}
+	if requestedFeeRate > 0 {
+		if feePriority != "" {
+			return 0, errors.New("a fee rate and a fee priority cannot both be requested")
+		}
+		return requestedFeeRate, nil
+	}
+
+	feeEstimate, err := s.rpcClient.GetFeeEstimate()
+	if err != nil {
+		return 0, err
+	}
+	switch feePriority {
+	case lowFeePriority:
+		return feeEstimate.LowFeeRate, nil
+	case "", normalFeePriority:
+		return feeEstimate.NormalFeeRate, nil
+	case priorityFeePriority:
+		return feeEstimate.PriorityFeeRate, nil
+	default:
+		return 0, errors.Errorf("unknown fee priority %s, expected one of %s, %s and %s",
+			feePriority, lowFeePriority, normalFeePriority, priorityFeePriority)
+	}
+}
+
+// feeForMass returns the fee for a transaction of the given mass at feeRate
+func feeForMass(mass uint64, feeRate float64) uint64 {
+	return uint64(math.Ceil(float64(mass) * feeRate))
+}
+
+// transactionFeeEstimate estimates the fee of a transaction from the number of its inputs
+type transactionFeeEstimate struct {
+	feeRate           float64
+	massWithoutInputs uint64
+	massPerInput      uint64
+}
+
+// fee returns the estimated fee of the transaction when it has inputCount inputs.
+// A nil transactionFeeEstimate estimates no fee.
+func (e *transactionFeeEstimate) fee(inputCount int) uint64 {
+	if e == nil {
+		return 0
+	}
+	return feeForMass(e.massWithoutInputs+uint64(inputCount)*e.massPerInput, e.feeRate)
+}
+
+// feePerInput returns how much adding an input adds to the fee at most
+func (e *transactionFeeEstimate) feePerInput() uint64 {
+	if e == nil {
+		return 0
+	}
+	return feeForMass(e.massPerInput, e.feeRate)
+}
+
+// newTransactionFeeEstimate returns a fee estimate at feeRate for transactions of txType paying payments,
+// and change as well if withChange is set.
+// The amounts of the payments don't affect the mass, so they don't have to be known yet.
+func (s *server) newTransactionFeeEstimate(payments []*libkashwallet.Payment, withChange bool,
+	txType externalapi.DomainTransactionType, feeRate float64) (*transactionFeeEstimate, error) {
+
+	if withChange {
+		// All change addresses have scripts of the same size, so the first one stands in for
+		// the change address that is eventually used, without reserving a new one
+		changeAddress, err := libkashwallet.Address(s.params, s.keysFile.ExtendedPublicKeys,
+			s.keysFile.MinimumSignatures, s.walletAddressPath(&walletAddress{
+				index:         0,
+				cosignerIndex: s.keysFile.CosignerIndex,
+				keyChain:      libkashwallet.InternalKeychain,
+			}), s.keysFile.ECDSA)
+		if err != nil {
+			return nil, err
+		}
+		_, outputAssetType := externalapi.GetAssetTypeFromDomainTransactionType(txType)
+		payments = append(payments[:len(payments):len(payments)], &libkashwallet.Payment{
+			Address:   changeAddress,
+			AssetType: outputAssetType,
+		})
+	}
+
+	massWithoutInputs, err := s.estimateMassOfTransactionWithInputs(payments, txType, 0)
+	if err != nil {
+		return nil, err
+	}
+	massWithInput, err := s.estimateMassOfTransactionWithInputs(payments, txType, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactionFeeEstimate{
+		feeRate:           feeRate,
+		massWithoutInputs: massWithoutInputs,
+		massPerInput:      massWithInput - massWithoutInputs,
+	}, nil
+}
+
+// estimateMassOfTransactionWithInputs estimates the mass after signatures of a transaction paying payments
+// and spending inputCount UTXOs of this wallet
+func (s *server) estimateMassOfTransactionWithInputs(payments []*libkashwallet.Payment,
+	txType externalapi.DomainTransactionType, inputCount int) (uint64, error) {
+
+	inputAssetType, _ := externalapi.GetAssetTypeFromDomainTransactionType(txType)
+	derivationPath := s.walletAddressPath(&walletAddress{
+		index:         0,
+		cosignerIndex: s.keysFile.CosignerIndex,
+		keyChain:      libkashwallet.ExternalKeychain,
+	})
+	utxos := make([]*libkashwallet.UTXO, inputCount)
+	for i := range utxos {
+		utxos[i] = &libkashwallet.UTXO{
+			Outpoint:       &externalapi.DomainOutpoint{Index: uint32(i)},
+			UTXOEntry:      utxo.NewUTXOEntry(0, inputAssetType, &externalapi.ScriptPublicKey{}, false, 0),
+			DerivationPath: derivationPath,
+		}
+	}
+
+	transactionBytes, err := libkashwallet.CreateUnsignedTransaction(s.keysFile.ExtendedPublicKeys,
+		s.keysFile.MinimumSignatures, payments, utxos, txType)
+	if err != nil {
+		return 0, err
+	}
+	transaction, err := serialization.DeserializePartiallySignedTransaction(transactionBytes)
+	if err != nil {
+		return 0, err
+	}
+	return s.estimateMassAfterSignatures(transaction)
+}
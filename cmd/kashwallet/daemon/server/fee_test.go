@@ -0,0 +1,72 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/dagconfig"
+	"github.com/Kash-Protocol/kashd/util/txmass"
+)
+
+func TestTransactionFeeEstimate(t *testing.T) {
+	params := &dagconfig.SimnetParams
+
+	const numKeys = 3
+	publicKeys := make([]string, numKeys)
+	for i := range publicKeys {
+		mnemonic, err := libkashwallet.CreateMnemonic()
+		if err != nil {
+			t.Fatalf("CreateMnemonic: %+v", err)
+		}
+		publicKeys[i], err = libkashwallet.MasterPublicKeyFromMnemonic(params, mnemonic, true)
+		if err != nil {
+			t.Fatalf("MasterPublicKeyFromMnemonic: %+v", err)
+		}
+	}
+
+	serverInstance := &server{
+		params:           params,
+		keysFile:         &keys.File{ExtendedPublicKeys: publicKeys, MinimumSignatures: 2},
+		txMassCalculator: txmass.NewCalculator(params.MassPerTxByte, params.MassPerScriptPubKeyByte, params.MassPerSigOp),
+	}
+
+	address, err := libkashwallet.Address(params, publicKeys, 2, "m/1/2/3", false)
+	if err != nil {
+		t.Fatalf("Address: %+v", err)
+	}
+	payments := []*libkashwallet.Payment{{Address: address, AssetType: externalapi.KSH, Amount: 1}}
+
+	const feeRate = 1.5
+	feeEstimate, err := serverInstance.newTransactionFeeEstimate(payments, false,
+		externalapi.TransferKSH, feeRate)
+	if err != nil {
+		t.Fatalf("newTransactionFeeEstimate: %+v", err)
+	}
+
+	const inputCount = 3
+	mass, err := serverInstance.estimateMassOfTransactionWithInputs(payments, externalapi.TransferKSH, inputCount)
+	if err != nil {
+		t.Fatalf("estimateMassOfTransactionWithInputs: %+v", err)
+	}
+	if feeEstimate.fee(inputCount) != feeForMass(mass, feeRate) {
+		t.Errorf("Estimated a fee of %d for %d inputs but a transaction of mass %d pays %d",
+			feeEstimate.fee(inputCount), inputCount, mass, feeForMass(mass, feeRate))
+	}
+
+	feeEstimateWithChange, err := serverInstance.newTransactionFeeEstimate(payments, true,
+		externalapi.TransferKSH, feeRate)
+	if err != nil {
+		t.Fatalf("newTransactionFeeEstimate: %+v", err)
+	}
+	if feeEstimateWithChange.fee(inputCount) <= feeEstimate.fee(inputCount) {
+		t.Errorf("Expected the change output to raise the fee above %d but got %d",
+			feeEstimate.fee(inputCount), feeEstimateWithChange.fee(inputCount))
+	}
+
+	var noFeeEstimate *transactionFeeEstimate
+	if noFeeEstimate.fee(inputCount) != 0 || noFeeEstimate.feePerInput() != 0 {
+		t.Errorf("Expected a nil fee estimate to estimate no fee")
+	}
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database/ldb"
+)
+
+var transactionHistoryBucket = database.MakeBucket([]byte("transaction-history"))
+
+const (
+	directionIncoming = "incoming"
+	directionOutgoing = "outgoing"
+)
+
+// historyRecord is the movement of a single asset in or out of the wallet by a single transaction
+type historyRecord struct {
+	Sequence              uint64   `json:"sequence"`
+	TxID                  string   `json:"txID"`
+	Direction             string   `json:"direction"`
+	AssetType             uint32   `json:"assetType"`
+	Amount                uint64   `json:"amount"`
+	Fee                   uint64   `json:"fee"`
+	CounterpartyAddresses []string `json:"counterpartyAddresses,omitempty"`
+	WalletAddresses       []string `json:"walletAddresses,omitempty"`
+	IsAccepted            bool     `json:"isAccepted"`
+	AcceptingDAAScore     uint64   `json:"acceptingDAAScore"`
+
+	// SpentOutpoints are the wallet outpoints spent by outgoing transactions, formatted by historyOutpoint
+	SpentOutpoints []string `json:"spentOutpoints,omitempty"`
+}
+
+// transactionHistory is the wallet's transaction history. All the records are kept in memory, ordered from
+// the oldest to the most recent, and every change to them is written to the database.
+type transactionHistory struct {
+	database      database.Database
+	records       []*historyRecord
+	recordsByTxID map[string][]*historyRecord
+	nextSequence  uint64
+}
+
+// transactionHistoryPath returns the path of the transaction history database of the given keys file
+func transactionHistoryPath(keysFilePath string) string {
+	base := filepath.Base(keysFilePath)
+	return filepath.Join(filepath.Dir(keysFilePath), strings.TrimSuffix(base, filepath.Ext(base))+"_history")
+}
+
+func openTransactionHistory(path string) (*transactionHistory, error) {
+	db, err := ldb.NewLevelDB(path, 8)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &transactionHistory{
+		database:      db,
+		recordsByTxID: make(map[string][]*historyRecord),
+	}
+	err = history.load()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return history, nil
+}
+
+func (th *transactionHistory) load() error {
+	cursor, err := th.database.Cursor(transactionHistoryBucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	// Keys are big endian sequence numbers, so the cursor goes over the records in the order they were added
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		serializedRecord, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		record := &historyRecord{}
+		err = json.Unmarshal(serializedRecord, record)
+		if err != nil {
+			return err
+		}
+		th.records = append(th.records, record)
+		th.recordsByTxID[record.TxID] = append(th.recordsByTxID[record.TxID], record)
+		th.nextSequence = record.Sequence + 1
+	}
+	return nil
+}
+
+func (th *transactionHistory) close() error {
+	return th.database.Close()
+}
+
+func recordKey(record *historyRecord) *database.Key {
+	var sequenceBytes [8]byte
+	binary.BigEndian.PutUint64(sequenceBytes[:], record.Sequence)
+	return transactionHistoryBucket.Key(sequenceBytes[:])
+}
+
+func (th *transactionHistory) put(record *historyRecord) error {
+	serializedRecord, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return th.database.Put(recordKey(record), serializedRecord)
+}
+
+func (th *transactionHistory) hasTransaction(txID string) bool {
+	_, ok := th.recordsByTxID[txID]
+	return ok
+}
+
+// add appends the given records to the history
+func (th *transactionHistory) add(records ...*historyRecord) error {
+	for _, record := range records {
+		record.Sequence = th.nextSequence
+		err := th.put(record)
+		if err != nil {
+			return err
+		}
+		th.records = append(th.records, record)
+		th.recordsByTxID[record.TxID] = append(th.recordsByTxID[record.TxID], record)
+		th.nextSequence++
+	}
+	return nil
+}
+
+// accept marks the pending records of the given transaction as accepted at the given DAA score. Pending
+// records of other transactions that spend the same outpoints can no longer be accepted, and are removed.
+func (th *transactionHistory) accept(txID string, acceptingDAAScore uint64) error {
+	spentOutpoints := make(map[string]struct{})
+	for _, record := range th.recordsByTxID[txID] {
+		if record.IsAccepted {
+			continue
+		}
+		record.IsAccepted = true
+		record.AcceptingDAAScore = acceptingDAAScore
+		err := th.put(record)
+		if err != nil {
+			return err
+		}
+		for _, outpoint := range record.SpentOutpoints {
+			spentOutpoints[outpoint] = struct{}{}
+		}
+	}
+	if len(spentOutpoints) == 0 {
+		return nil
+	}
+
+	conflictingTxIDs := make(map[string]struct{})
+	for _, record := range th.records {
+		if record.IsAccepted || record.TxID == txID {
+			continue
+		}
+		for _, outpoint := range record.SpentOutpoints {
+			if _, ok := spentOutpoints[outpoint]; ok {
+				conflictingTxIDs[record.TxID] = struct{}{}
+				break
+			}
+		}
+	}
+
+	records := make([]*historyRecord, 0, len(th.records))
+	for _, record := range th.records {
+		if _, ok := conflictingTxIDs[record.TxID]; ok && !record.IsAccepted {
+			err := th.database.Delete(recordKey(record))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		records = append(records, record)
+	}
+	th.records = records
+	for conflictingTxID := range conflictingTxIDs {
+		var acceptedRecords []*historyRecord
+		for _, record := range th.recordsByTxID[conflictingTxID] {
+			if record.IsAccepted {
+				acceptedRecords = append(acceptedRecords, record)
+			}
+		}
+		if len(acceptedRecords) == 0 {
+			delete(th.recordsByTxID, conflictingTxID)
+			continue
+		}
+		th.recordsByTxID[conflictingTxID] = acceptedRecords
+	}
+
+	return nil
+}
+
+// pendingSpender returns the ID of the pending transaction that spends the given outpoint. It returns
+// false if no pending transaction, or more than one, spends it.
+func (th *transactionHistory) pendingSpender(outpoint string) (string, bool) {
+	spenderTxID := ""
+	for _, record := range th.records {
+		if record.IsAccepted {
+			continue
+		}
+		for _, spentOutpoint := range record.SpentOutpoints {
+			if spentOutpoint != outpoint {
+				continue
+			}
+			if spenderTxID != "" && spenderTxID != record.TxID {
+				return "", false
+			}
+			spenderTxID = record.TxID
+		}
+	}
+	return spenderTxID, spenderTxID != ""
+}
+
+// query returns the records of the given asset types, from the most recent to the oldest, skipping the first
+// offset records and returning at most limit records. A limit of 0 means no limit, and an empty assetTypes means
+// all asset types. It also returns the number of records of the given asset types.
+func (th *transactionHistory) query(assetTypes []uint32, offset, limit uint32) ([]*historyRecord, uint32) {
+	assetTypeSet := make(map[uint32]struct{}, len(assetTypes))
+	for _, assetType := range assetTypes {
+		assetTypeSet[assetType] = struct{}{}
+	}
+
+	matchingRecords := []*historyRecord{}
+	for i := len(th.records) - 1; i >= 0; i-- {
+		record := th.records[i]
+		if len(assetTypeSet) > 0 {
+			if _, ok := assetTypeSet[record.AssetType]; !ok {
+				continue
+			}
+		}
+		matchingRecords = append(matchingRecords, record)
+	}
+
+	totalCount := uint32(len(matchingRecords))
+	if offset >= totalCount {
+		return []*historyRecord{}, totalCount
+	}
+	matchingRecords = matchingRecords[offset:]
+	if limit > 0 && uint32(len(matchingRecords)) > limit {
+		matchingRecords = matchingRecords[:limit]
+	}
+	return matchingRecords, totalCount
+}
+
+func historyOutpoint(transactionID string, index uint32) string {
+	return fmt.Sprintf("%s:%d", transactionID, index)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTransactionHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	history, err := openTransactionHistory(path)
+	if err != nil {
+		t.Fatalf("openTransactionHistory: %+v", err)
+	}
+
+	spentOutpoint := historyOutpoint("aa", 0)
+	err = history.add(
+		&historyRecord{TxID: "incoming", Direction: directionIncoming, AssetType: 1, Amount: 50,
+			IsAccepted: true, AcceptingDAAScore: 10},
+		&historyRecord{TxID: "original", Direction: directionOutgoing, Amount: 20, Fee: 1,
+			SpentOutpoints: []string{spentOutpoint}},
+		&historyRecord{TxID: "replacement", Direction: directionOutgoing, Amount: 20, Fee: 5,
+			SpentOutpoints: []string{spentOutpoint}},
+	)
+	if err != nil {
+		t.Fatalf("add: %+v", err)
+	}
+
+	if _, ok := history.pendingSpender(spentOutpoint); ok {
+		t.Fatalf("Expected no single pending spender while two pending transactions spend the same outpoint")
+	}
+
+	err = history.accept("replacement", 20)
+	if err != nil {
+		t.Fatalf("accept: %+v", err)
+	}
+	if history.hasTransaction("original") {
+		t.Fatalf("Expected the transaction replaced by the accepted one to be removed")
+	}
+
+	err = history.close()
+	if err != nil {
+		t.Fatalf("close: %+v", err)
+	}
+	history, err = openTransactionHistory(path)
+	if err != nil {
+		t.Fatalf("openTransactionHistory: %+v", err)
+	}
+	defer history.close()
+
+	if !history.hasTransaction("replacement") || history.hasTransaction("original") {
+		t.Fatalf("Expected the reopened history to have only the accepted replacement of the two transactions")
+	}
+
+	records, totalCount := history.query(nil, 0, 0)
+	if totalCount != 2 || len(records) != 2 {
+		t.Fatalf("Expected 2 records but got %d with a total count of %d", len(records), totalCount)
+	}
+	if records[0].TxID != "replacement" || !records[0].IsAccepted || records[0].AcceptingDAAScore != 20 {
+		t.Fatalf("Expected the most recent record to be the accepted replacement, but got %+v", records[0])
+	}
+
+	records, totalCount = history.query([]uint32{1}, 0, 0)
+	if totalCount != 1 || records[0].TxID != "incoming" {
+		t.Fatalf("Expected the asset filter to return only the incoming record, but got %d records", totalCount)
+	}
+
+	records, totalCount = history.query(nil, 1, 1)
+	if totalCount != 2 || len(records) != 1 || records[0].TxID != "incoming" {
+		t.Fatalf("Expected paging to return the second most recent record")
+	}
+
+	err = history.add(&historyRecord{TxID: "new"})
+	if err != nil {
+		t.Fatalf("add: %+v", err)
+	}
+	if history.records[len(history.records)-1].Sequence != 3 {
+		t.Fatalf("Expected the sequence to continue after reopening the history")
+	}
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet/serialization"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
+	"github.com/Kash-Protocol/kashd/domain/miningmanager/mempool"
+	"github.com/Kash-Protocol/kashd/util"
+	"github.com/pkg/errors"
+)
+
+// maxPayoutOutputsMass is the mass the payout outputs of a single transaction may have.
+// The rest of the transaction's mass is left for its inputs and change output, and if the inputs
+// don't fit, the transaction is split, with the merge transaction paying the payouts.
+const maxPayoutOutputsMass = mempool.MaximumStandardTransactionMass / 2
+
+// validatePayoutsRequest makes sure a request with payouts doesn't also ask for a single payment
+func validatePayoutsRequest(address string, amount uint64, isSendAll bool, txType externalapi.DomainTransactionType) error {
+	if address != "" || amount != 0 || isSendAll {
+		return errors.New("payouts cannot be combined with an address, an amount or send all")
+	}
+	if txType.IsConversion() {
+		return errors.Errorf("payouts cannot be %s transactions", txType)
+	}
+	return nil
+}
+
+// createUnsignedPayoutTransactions creates transactions paying all the given payouts.
+// The payouts are packed into as few transactions as the mass limit allows, and every
+// transaction that is too large because of its inputs is split like a single payment would be.
+// The returned payout results hold the index of the transaction paying each payout.
+func (s *server) createUnsignedPayoutTransactions(payouts []*pb.Payout, txType externalapi.DomainTransactionType,
+	fromAddressesString []string, useExistingChangeAddress bool, requestedFeeRate float64, feePriority string) (
+	[][]byte, []*pb.PayoutResult, error) {
+
+	if !s.isSynced() {
+		return nil, nil, errors.Errorf("wallet daemon is not synced yet, %s", s.formatSyncStateReport())
+	}
+
+	fromAssetType, toAssetType := externalapi.GetAssetTypeFromDomainTransactionType(txType)
+
+	// make sure all addresses are correct before proceeding to a
+	// potentially long UTXO refreshment operation
+	payments := make([]*libkashwallet.Payment, len(payouts))
+	for i, payout := range payouts {
+		address, err := util.DecodeAddress(payout.Address, s.params.Prefix)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid address of payout #%d", i+1)
+		}
+		if payout.Amount == 0 {
+			return nil, nil, errors.Errorf("payout #%d to %s has no amount", i+1, payout.Address)
+		}
+		payments[i] = &libkashwallet.Payment{
+			Address:   address,
+			AssetType: toAssetType,
+			Amount:    payout.Amount,
+		}
+	}
+
+	batches, err := s.batchPayments(payments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	feeRate, err := s.feeRate(requestedFeeRate, feePriority)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.refreshUTXOs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fromAddresses, err := s.fromWalletAddresses(fromAddressesString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changeAddress, changeWalletAddress, err := s.changeAddress(useExistingChangeAddress, fromAddresses)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var unsignedTransactions [][]byte
+	payoutResults := make([]*pb.PayoutResult, 0, len(payouts))
+	spentOutpoints := make(map[externalapi.DomainOutpoint]struct{})
+	for _, batch := range batches {
+		batchAmount := uint64(0)
+		for _, payment := range batch {
+			batchAmount += payment.Amount
+		}
+
+		feeEstimate, err := s.newTransactionFeeEstimate(batch, true, txType, feeRate)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		selectedUTXOs, _, changeSompi, err := s.selectUTXOs(batchAmount, false, feeEstimate, fromAddresses,
+			fromAssetType, spentOutpoints)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if len(selectedUTXOs) == 0 {
+			return nil, nil, errors.Errorf("couldn't find funds to spend")
+		}
+
+		batchPayments := batch
+		if changeSompi > 0 {
+			batchPayments = append(batchPayments[:len(batch):len(batch)], &libkashwallet.Payment{
+				Address:   changeAddress,
+				AssetType: toAssetType,
+				Amount:    changeSompi,
+			})
+		}
+
+		unsignedTransaction, err := libkashwallet.CreateUnsignedTransaction(s.keysFile.ExtendedPublicKeys,
+			s.keysFile.MinimumSignatures, batchPayments, selectedUTXOs, txType)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		batchTransactions, err := s.maybeAutoCompoundTransaction(unsignedTransaction, batch,
+			changeAddress, changeWalletAddress, feeRate)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Later batches must not spend what this batch does, including UTXOs added to a merge transaction
+		for _, batchTransaction := range batchTransactions {
+			transaction, err := serialization.DeserializePartiallySignedTransaction(batchTransaction)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, input := range transaction.Tx.Inputs {
+				spentOutpoints[input.PreviousOutpoint] = struct{}{}
+			}
+		}
+
+		unsignedTransactions = append(unsignedTransactions, batchTransactions...)
+		// The payouts are paid by the last transaction of the batch, which is its merge transaction if it was split
+		payingTransactionIndex := uint32(len(unsignedTransactions) - 1)
+		for _, payment := range batch {
+			payoutResults = append(payoutResults, &pb.PayoutResult{
+				Address:          payment.Address.String(),
+				Amount:           payment.Amount,
+				TransactionIndex: payingTransactionIndex,
+			})
+		}
+	}
+
+	return unsignedTransactions, payoutResults, nil
+}
+
+// batchPayments packs the given payments, in order, into batches whose outputs fit in maxPayoutOutputsMass
+func (s *server) batchPayments(payments []*libkashwallet.Payment) ([][]*libkashwallet.Payment, error) {
+	emptyTransactionMass := s.txMassCalculator.CalculateTransactionMass(&externalapi.DomainTransaction{})
+
+	var batches [][]*libkashwallet.Payment
+	var batch []*libkashwallet.Payment
+	batchMass := uint64(0)
+	for _, payment := range payments {
+		scriptPublicKey, err := txscript.PayToAddrScript(payment.Address)
+		if err != nil {
+			return nil, err
+		}
+		outputMass := s.txMassCalculator.CalculateTransactionMass(&externalapi.DomainTransaction{
+			Outputs: []*externalapi.DomainTransactionOutput{{
+				Value:           payment.Amount,
+				ScriptPublicKey: scriptPublicKey,
+			}},
+		}) - emptyTransactionMass
+
+		if len(batch) > 0 && batchMass+outputMass > maxPayoutOutputsMass {
+			batches = append(batches, batch)
+			batch = nil
+			batchMass = 0
+		}
+		batch = append(batch, payment)
+		batchMass += outputMass
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
@@ -0,0 +1,253 @@
+package server
+
+import (
+	"context"
+
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
+	"github.com/pkg/errors"
+)
+
+func (s *server) QuoteConversion(_ context.Context, request *pb.QuoteConversionRequest) (*pb.QuoteConversionResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	txType := externalapi.DomainTransactionType(request.TransactionType)
+	if !txType.IsConversion() {
+		return nil, errors.Errorf("%s is not a conversion", txType)
+	}
+	if request.Amount == 0 {
+		return nil, errors.New("an amount to convert is required")
+	}
+	if !s.isSynced() {
+		return nil, errors.Errorf("wallet daemon is not synced yet, %s", s.formatSyncStateReport())
+	}
+
+	reserveStateResponse, reserveState, err := s.reserveState()
+	if err != nil {
+		return nil, err
+	}
+
+	minerFee, surplus, err := s.estimateConversionMinerFee(txType, request.Amount, request.From, request.FeeRate, request.FeePriority)
+	if err != nil {
+		return nil, err
+	}
+
+	calculator := s.djedCalculator()
+	receiveAmount, kshAmount, conversionFee, err := quoteConversion(calculator, reserveState,
+		reserveStateResponse.ExchangeRate, txType, request.Amount, minerFee)
+	if err != nil {
+		return nil, err
+	}
+
+	// The surplus of the spent UTXOs is converted along with the amount, so the total amount that is
+	// converted is checked against the reserve ratio bounds, the same way consensus checks the conversion
+	spendValue := request.Amount + surplus
+	surplusReceiveAmount, err := conversionSurplusReceiveAmount(calculator, reserveState,
+		reserveStateResponse.ExchangeRate, txType, request.Amount, receiveAmount, surplus)
+	if err != nil {
+		return nil, err
+	}
+	_, minerFee, err = calculator.ApplyConversion(reserveState, reserveStateResponse.ExchangeRate, txType,
+		spendValue, receiveAmount+surplusReceiveAmount)
+	if err != nil {
+		return nil, errors.Wrapf(err, "the reserve does not currently allow this conversion")
+	}
+
+	return &pb.QuoteConversionResponse{
+		ReceiveAmount:           receiveAmount,
+		KshAmount:               kshAmount,
+		ConversionFee:           conversionFee,
+		MinerFee:                minerFee,
+		ExchangeRate:            reserveStateResponse.ExchangeRate,
+		ReserveRatioBasisPoints: reserveStateResponse.ReserveRatioBasisPoints,
+		Surplus:                 surplus,
+	}, nil
+}
+
+// reserveState returns the reserve state kashd reports, along with the response it was reported in
+func (s *server) reserveState() (*appmessage.GetReserveStateResponseMessage, *externalapi.ReserveState, error) {
+	reserveStateResponse, err := s.rpcClient.GetReserveState()
+	if err != nil {
+		return nil, nil, err
+	}
+	if reserveStateResponse.ExchangeRate == 0 {
+		return nil, nil, errors.New("no exchange rate is in effect, so conversions cannot be made")
+	}
+	return reserveStateResponse, &externalapi.ReserveState{
+		Reserve:    reserveStateResponse.ReserveSompi,
+		KUSDSupply: reserveStateResponse.KUSDSupplySompi,
+		KRVSupply:  reserveStateResponse.KRVSupplySompi,
+	}, nil
+}
+
+func (s *server) djedCalculator() *djed.Calculator {
+	return djed.NewCalculator(s.params.MinReserveRatio, s.params.MaxReserveRatio,
+		s.params.ConversionFeeBasisPoints, s.params.KRVMinimalPrice)
+}
+
+// estimateConversionMinerFee estimates the miner fee a conversion of amount should leave in order to pay the
+// requested fee rate. It also returns the surplus of the UTXOs the conversion would spend, which is converted
+// and paid to a change address.
+func (s *server) estimateConversionMinerFee(txType externalapi.DomainTransactionType, amount uint64,
+	fromAddressesString []string, requestedFeeRate float64, feePriority string) (minerFee uint64, surplus uint64, err error) {
+
+	feeRate, err := s.feeRate(requestedFeeRate, feePriority)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = s.refreshUTXOs()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fromAddresses, err := s.fromWalletAddresses(fromAddressesString)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	inputAssetType, outputAssetType := externalapi.GetAssetTypeFromDomainTransactionType(txType)
+	selectedUTXOs, _, surplus, err := s.selectUTXOs(amount, false, nil, fromAddresses, inputAssetType, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The converted amount is usually paid to the wallet itself, so a wallet address stands in for its
+	// destination
+	toAddress, err := libkashwallet.Address(s.params, s.keysFile.ExtendedPublicKeys, s.keysFile.MinimumSignatures,
+		s.walletAddressPath(&walletAddress{
+			index:         0,
+			cosignerIndex: s.keysFile.CosignerIndex,
+			keyChain:      libkashwallet.ExternalKeychain,
+		}), s.keysFile.ECDSA)
+	if err != nil {
+		return 0, 0, err
+	}
+	feeEstimate, err := s.newTransactionFeeEstimate(
+		[]*libkashwallet.Payment{{Address: toAddress, AssetType: outputAssetType}}, true, txType, feeRate)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return feeEstimate.fee(len(selectedUTXOs)), surplus, nil
+}
+
+// quoteConversion returns the amount of the output asset of txType that is paid for amount of its input
+// asset, while leaving minerFee KSH sompi for the miner. It also returns the KSH, in sompi, paid into
+// or out of the reserve, along with the part of it that is the conversion fee.
+func quoteConversion(calculator *djed.Calculator, reserveState *externalapi.ReserveState, exchangeRate uint64,
+	txType externalapi.DomainTransactionType, amount uint64, minerFee uint64) (
+	receiveAmount uint64, kshAmount uint64, conversionFee uint64, err error) {
+
+	switch txType {
+	case externalapi.MintKUSD, externalapi.StakeKSH:
+		if amount <= minerFee {
+			return 0, 0, 0, errors.Errorf("converting %d sompi does not cover the miner fee of %d sompi",
+				amount, minerFee)
+		}
+		receiveAmount, err = maxConversionOutput(calculator, reserveState, exchangeRate, txType, amount-minerFee)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if receiveAmount == 0 {
+			return 0, 0, 0, errors.Errorf("converting %d sompi does not buy any %s", amount, txType)
+		}
+		kshAmount, conversionFee, err = calculator.Quote(reserveState, exchangeRate, txType, receiveAmount)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		return receiveAmount, kshAmount, conversionFee, nil
+
+	case externalapi.RedeemKSH, externalapi.RedeemKUSD:
+		kshAmount, conversionFee, err = calculator.Quote(reserveState, exchangeRate, txType, amount)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if kshAmount <= minerFee {
+			return 0, 0, 0, errors.Errorf("redeeming %d sompi is worth %d KSH sompi, which does not "+
+				"cover the miner fee of %d sompi", amount, kshAmount, minerFee)
+		}
+		return kshAmount - minerFee, kshAmount, conversionFee, nil
+
+	default:
+		return 0, 0, 0, errors.Wrapf(djed.ErrNotConversion, "transaction type %s", txType)
+	}
+}
+
+// conversionSurplusReceiveAmount returns the amount of the output asset of txType that converting the
+// surplus of the spent UTXOs pays to the change address, when converting amount pays receiveAmount.
+// The whole spent value is quoted while leaving the same miner fee that converting amount alone leaves,
+// so that the surplus is converted in full rather than left to the miner.
+func conversionSurplusReceiveAmount(calculator *djed.Calculator, reserveState *externalapi.ReserveState,
+	exchangeRate uint64, txType externalapi.DomainTransactionType, amount uint64, receiveAmount uint64,
+	surplus uint64) (uint64, error) {
+
+	if surplus == 0 {
+		return 0, nil
+	}
+
+	var minerFee uint64
+	switch txType {
+	case externalapi.MintKUSD, externalapi.StakeKSH:
+		cost, _, err := calculator.Quote(reserveState, exchangeRate, txType, receiveAmount)
+		if err != nil {
+			return 0, err
+		}
+		if cost > amount {
+			return 0, errors.Errorf("receiving %d sompi costs %d sompi, which is more than the %d sompi converted",
+				receiveAmount, cost, amount)
+		}
+		minerFee = amount - cost
+
+	case externalapi.RedeemKSH, externalapi.RedeemKUSD:
+		kshAmount, _, err := calculator.Quote(reserveState, exchangeRate, txType, amount)
+		if err != nil {
+			return 0, err
+		}
+		if receiveAmount > kshAmount {
+			return 0, errors.Errorf("redeeming %d sompi is worth %d KSH sompi, which is less than the "+
+				"%d sompi to receive", amount, kshAmount, receiveAmount)
+		}
+		minerFee = kshAmount - receiveAmount
+
+	default:
+		return 0, errors.Wrapf(djed.ErrNotConversion, "transaction type %s", txType)
+	}
+
+	totalReceiveAmount, _, _, err := quoteConversion(calculator, reserveState, exchangeRate, txType,
+		amount+surplus, minerFee)
+	if err != nil {
+		return 0, errors.Wrapf(err, "converting the %d sompi surplus of the selected UTXOs", surplus)
+	}
+	if totalReceiveAmount < receiveAmount {
+		return 0, nil
+	}
+	return totalReceiveAmount - receiveAmount, nil
+}
+
+// maxConversionOutput returns the largest amount of the output asset of txType, which is either a mint or
+// a stake, that costs at most budget KSH sompi
+func maxConversionOutput(calculator *djed.Calculator, reserveState *externalapi.ReserveState, exchangeRate uint64,
+	txType externalapi.DomainTransactionType, budget uint64) (uint64, error) {
+
+	// The cost only grows with the output amount, so the largest affordable output is searched for
+	low, high := uint64(0), uint64(constants.MaxSompi)
+	for low < high {
+		middle := low + (high-low+1)/2
+		cost, _, err := calculator.Quote(reserveState, exchangeRate, txType, middle)
+		if err != nil && !errors.Is(err, djed.ErrAmountOutOfRange) {
+			return 0, err
+		}
+		if err == nil && cost <= budget {
+			low = middle
+		} else {
+			high = middle - 1
+		}
+	}
+	return low, nil
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
+)
+
+func TestQuoteConversion(t *testing.T) {
+	calculator := djed.NewCalculator(400, 800, 100, constants.SompiPerKash)
+
+	// One KSH is worth two USD
+	const exchangeRate = 2 * constants.SompiPerKash
+	const minerFee = 10_000
+
+	// Minting 100 KUSD costs 50.5 KSH, so converting it along with the miner fee mints exactly 100 KUSD
+	state := &externalapi.ReserveState{}
+	receiveAmount, kshAmount, conversionFee, err := quoteConversion(calculator, state, exchangeRate,
+		externalapi.MintKUSD, 5_050_000_000+minerFee, minerFee)
+	if err != nil {
+		t.Fatalf("quoteConversion: %+v", err)
+	}
+	if receiveAmount != 100*constants.SompiPerKash || kshAmount != 5_050_000_000 || conversionFee != 50_000_000 {
+		t.Fatalf("Expected to mint 100 KUSD for 5050000000 KSH sompi with a conversion fee of 50000000, "+
+			"but got %d KUSD sompi for %d with a fee of %d", receiveAmount, kshAmount, conversionFee)
+	}
+
+	// One sompi less does not afford the same amount
+	receiveAmount, kshAmount, _, err = quoteConversion(calculator, state, exchangeRate,
+		externalapi.MintKUSD, 5_050_000_000+minerFee-1, minerFee)
+	if err != nil {
+		t.Fatalf("quoteConversion: %+v", err)
+	}
+	if receiveAmount >= 100*constants.SompiPerKash || kshAmount > 5_050_000_000-1 {
+		t.Fatalf("Expected to mint less than 100 KUSD, but got %d KUSD sompi for %d KSH sompi",
+			receiveAmount, kshAmount)
+	}
+
+	state = &externalapi.ReserveState{Reserve: 400 * constants.SompiPerKash, KUSDSupply: 100 * constants.SompiPerKash}
+	receiveAmount, kshAmount, _, err = quoteConversion(calculator, state, exchangeRate,
+		externalapi.RedeemKUSD, 100*constants.SompiPerKash, minerFee)
+	if err != nil {
+		t.Fatalf("quoteConversion: %+v", err)
+	}
+	if kshAmount != 4_950_000_000 || receiveAmount != 4_950_000_000-minerFee {
+		t.Fatalf("Expected redeeming 100 KUSD to pay 4950000000 KSH sompi minus the miner fee, "+
+			"but got %d out of %d", receiveAmount, kshAmount)
+	}
+
+	_, _, _, err = quoteConversion(calculator, state, exchangeRate, externalapi.MintKUSD, minerFee, minerFee)
+	if err == nil {
+		t.Fatalf("Expected quoteConversion to fail when the amount does not cover the miner fee")
+	}
+}
+
+func TestConversionSurplusReceiveAmount(t *testing.T) {
+	calculator := djed.NewCalculator(400, 800, 100, constants.SompiPerKash)
+	const exchangeRate = 2 * constants.SompiPerKash
+	const minerFee = 10_000
+
+	// Converting a small amount out of a large UTXO converts the whole UTXO, leaving only the miner fee
+	const amount = 10 * constants.SompiPerKash
+	const surplus = 990 * constants.SompiPerKash
+	const spendValue = amount + surplus
+
+	state := &externalapi.ReserveState{}
+	receiveAmount, _, _, err := quoteConversion(calculator, state, exchangeRate, externalapi.MintKUSD, amount, minerFee)
+	if err != nil {
+		t.Fatalf("quoteConversion: %+v", err)
+	}
+	surplusReceiveAmount, err := conversionSurplusReceiveAmount(calculator, state, exchangeRate,
+		externalapi.MintKUSD, amount, receiveAmount, surplus)
+	if err != nil {
+		t.Fatalf("conversionSurplusReceiveAmount: %+v", err)
+	}
+	cost, _, err := calculator.Quote(state, exchangeRate, externalapi.MintKUSD, receiveAmount+surplusReceiveAmount)
+	if err != nil {
+		t.Fatalf("Quote: %+v", err)
+	}
+	nextCost, _, err := calculator.Quote(state, exchangeRate, externalapi.MintKUSD, receiveAmount+surplusReceiveAmount+1)
+	if err != nil {
+		t.Fatalf("Quote: %+v", err)
+	}
+	if cost > spendValue-minerFee || nextCost <= spendValue-minerFee {
+		t.Fatalf("Expected minting %d KUSD sompi to cost all of the %d KSH sompi spent except the miner fee, "+
+			"but it costs %d", receiveAmount+surplusReceiveAmount, spendValue, cost)
+	}
+
+	state = &externalapi.ReserveState{Reserve: 4000 * constants.SompiPerKash, KUSDSupply: 1000 * constants.SompiPerKash}
+	receiveAmount, _, _, err = quoteConversion(calculator, state, exchangeRate, externalapi.RedeemKUSD, amount, minerFee)
+	if err != nil {
+		t.Fatalf("quoteConversion: %+v", err)
+	}
+	surplusReceiveAmount, err = conversionSurplusReceiveAmount(calculator, state, exchangeRate,
+		externalapi.RedeemKUSD, amount, receiveAmount, surplus)
+	if err != nil {
+		t.Fatalf("conversionSurplusReceiveAmount: %+v", err)
+	}
+	kshAmount, _, err := calculator.Quote(state, exchangeRate, externalapi.RedeemKUSD, spendValue)
+	if err != nil {
+		t.Fatalf("Quote: %+v", err)
+	}
+	if receiveAmount+surplusReceiveAmount != kshAmount-minerFee {
+		t.Fatalf("Expected redeeming %d KUSD sompi to pay %d KSH sompi, but got %d",
+			spendValue, kshAmount-minerFee, receiveAmount+surplusReceiveAmount)
+	}
+
+	surplusReceiveAmount, err = conversionSurplusReceiveAmount(calculator, state, exchangeRate,
+		externalapi.RedeemKUSD, amount, receiveAmount, 0)
+	if err != nil {
+		t.Fatalf("conversionSurplusReceiveAmount: %+v", err)
+	}
+	if surplusReceiveAmount != 0 {
+		t.Fatalf("Expected no surplus to receive nothing, but got %d", surplusReceiveAmount)
+	}
+}
@@ -5,15 +5,18 @@ import (
 
 	"github.com/Kash-Protocol/kashd/domain/dagconfig"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/rpcclient"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/rpcclient/grpcclient"
 )
 
-func connectToRPC(params *dagconfig.Params, rpcServer string, timeout uint32) (*rpcclient.RPCClient, error) {
+func connectToRPC(params *dagconfig.Params, rpcServer string, rpcCredentials *grpcclient.Credentials,
+	timeout uint32) (*rpcclient.RPCClient, error) {
+
 	rpcAddress, err := params.NormalizeRPCServerAddress(rpcServer)
 	if err != nil {
 		return nil, err
 	}
 
-	rpcClient, err := rpcclient.NewRPCClient(rpcAddress)
+	rpcClient, err := rpcclient.NewRPCClientWithCredentials(rpcAddress, rpcCredentials)
 	if err != nil {
 		return nil, err
 	}
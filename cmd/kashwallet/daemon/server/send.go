@@ -2,33 +2,38 @@ package server
 
 import (
 	"context"
+
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
-	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
-	"github.com/pkg/errors"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
 )
 
 func (s *server) Send(_ context.Context, request *pb.SendRequest) (*pb.SendResponse, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	// TODO: This mapping of AssetType to DomainTransactionType is a temporary solution.
-	// It allows wallet usage of the 'send' command for transfers. Currently, AssetType
-	// directly corresponds to a specific Transfer TxType. This may evolve with more complex
-	// transaction types in the future.
-	var txType externalapi.DomainTransactionType
-	switch externalapi.AssetTypeFromUint32(request.AssetType) {
-	case externalapi.KSH:
-		txType = externalapi.TransferKSH
-	case externalapi.KUSD:
-		txType = externalapi.TransferKUSD
-	case externalapi.KRV:
-		txType = externalapi.TransferKRV
-	default:
-		return nil, errors.Errorf("Unknown asset type %d", request.AssetType)
+	if s.keysFile.IsWatchOnly() {
+		return nil, keys.ErrWatchOnly
+	}
+
+	txType, err := transactionTypeFromRequest(request.AssetType, request.TransactionType)
+	if err != nil {
+		return nil, err
 	}
 
-	unsignedTransactions, err := s.createUnsignedTransactions(request.ToAddress, txType,
-		request.Amount, request.IsSendAll, request.From, request.UseExistingChangeAddress)
+	var unsignedTransactions [][]byte
+	var payoutResults []*pb.PayoutResult
+	if len(request.Payouts) > 0 {
+		err = validatePayoutsRequest(request.ToAddress, request.Amount, request.IsSendAll, txType)
+		if err != nil {
+			return nil, err
+		}
+		unsignedTransactions, payoutResults, err = s.createUnsignedPayoutTransactions(request.Payouts, txType,
+			request.From, request.UseExistingChangeAddress, request.FeeRate, request.FeePriority)
+	} else {
+		unsignedTransactions, err = s.createUnsignedTransactions(request.ToAddress, txType,
+			request.Amount, request.ReceiveAmount, request.IsSendAll, request.From, request.UseExistingChangeAddress,
+			request.FeeRate, request.FeePriority)
+	}
 
 	if err != nil {
 		return nil, err
@@ -44,5 +49,5 @@ func (s *server) Send(_ context.Context, request *pb.SendRequest) (*pb.SendRespo
 		return nil, err
 	}
 
-	return &pb.SendResponse{TxIDs: txIDs, SignedTransactions: signedTransactions}, nil
+	return &pb.SendResponse{TxIDs: txIDs, SignedTransactions: signedTransactions, PayoutResults: payoutResults}, nil
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
 	"github.com/Kash-Protocol/kashd/domain/dagconfig"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/rpcclient"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/rpcclient/grpcclient"
 	"github.com/Kash-Protocol/kashd/infrastructure/os/signal"
 	"github.com/Kash-Protocol/kashd/util/panics"
 	"github.com/pkg/errors"
@@ -30,14 +31,19 @@ type server struct {
 	rpcClient *rpcclient.RPCClient
 	params    *dagconfig.Params
 
-	lock                sync.RWMutex
-	utxosSortedByAmount []*walletUTXO
-	nextSyncStartIndex  uint32
-	keysFile            *keys.File
-	shutdown            chan struct{}
-	addressSet          walletAddressSet
-	txMassCalculator    *txmass.Calculator
-	usedOutpoints       map[externalapi.DomainOutpoint]time.Time
+	lock                  sync.RWMutex
+	utxosSortedByAmount   []*walletUTXO
+	nextSyncStartIndex    uint32
+	keysFile              *keys.File
+	shutdown              chan struct{}
+	addressSet            walletAddressSet
+	watchedAddresses      walletAddressSet
+	reconnected           chan struct{}
+	rescanRequests        chan struct{}
+	txMassCalculator      *txmass.Calculator
+	usedOutpoints         map[externalapi.DomainOutpoint]time.Time
+	mempoolSpentOutpoints map[externalapi.DomainOutpoint]struct{}
+	history               *transactionHistory
 
 	isLogFinalProgressLineShown bool
 	maxUsedAddressesForLog      uint32
@@ -49,7 +55,9 @@ type server struct {
 const MaxDaemonSendMsgSize = 100_000_000
 
 // Start starts the kashwalletd server
-func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath string, profile string, timeout uint32) error {
+func Start(params *dagconfig.Params, listen, rpcServer string, rpcCredentials *grpcclient.Credentials,
+	keysFilePath string, profile string, timeout uint32) error {
+
 	initLog(defaultLogFile, defaultErrLogFile)
 
 	defer panics.HandlePanic(log, "MAIN", nil)
@@ -66,7 +74,7 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 	log.Infof("Listening to TCP on %s", listen)
 
 	log.Infof("Connecting to a node at %s...", rpcServer)
-	rpcClient, err := connectToRPC(params, rpcServer, timeout)
+	rpcClient, err := connectToRPC(params, rpcServer, rpcCredentials, timeout)
 	if err != nil {
 		return (errors.Wrapf(err, "Error connecting to RPC server %s", rpcServer))
 	}
@@ -82,6 +90,11 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 		return err
 	}
 
+	history, err := openTransactionHistory(transactionHistoryPath(keysFile.Path()))
+	if err != nil {
+		return errors.Wrapf(err, "Error opening the transaction history of keys file %s", keysFilePath)
+	}
+
 	serverInstance := &server{
 		rpcClient:                   rpcClient,
 		params:                      params,
@@ -90,13 +103,20 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 		keysFile:                    keysFile,
 		shutdown:                    make(chan struct{}),
 		addressSet:                  make(walletAddressSet),
+		watchedAddresses:            make(walletAddressSet),
+		reconnected:                 make(chan struct{}, 1),
+		rescanRequests:              make(chan struct{}, 1),
 		txMassCalculator:            txmass.NewCalculator(params.MassPerTxByte, params.MassPerScriptPubKeyByte, params.MassPerSigOp),
 		usedOutpoints:               map[externalapi.DomainOutpoint]time.Time{},
+		mempoolSpentOutpoints:       map[externalapi.DomainOutpoint]struct{}{},
+		history:                     history,
 		isLogFinalProgressLineShown: false,
 		maxUsedAddressesForLog:      0,
 		maxProcessedAddressesForLog: 0,
 	}
 
+	rpcClient.SetOnReconnectedHandler(serverInstance.onReconnected)
+
 	log.Infof("Read, syncing the wallet...")
 	spawn("serverInstance.sync", func() {
 		err := serverInstance.sync()
@@ -134,7 +154,9 @@ func Start(params *dagconfig.Params, listen, rpcServer string, keysFilePath stri
 		}
 	}
 
-	return nil
+	// The lock is kept until the process exits, so the history isn't written to once it's closed
+	serverInstance.lock.Lock()
+	return history.close()
 }
 
 func printErrorAndExit(err error) {
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+func TestWatchOnlyWalletCannotSign(t *testing.T) {
+	params := &dagconfig.SimnetParams
+	mnemonic, err := libkashwallet.CreateMnemonic()
+	if err != nil {
+		t.Fatalf("CreateMnemonic: %+v", err)
+	}
+	publicKey, err := libkashwallet.MasterPublicKeyFromMnemonic(params, mnemonic, false)
+	if err != nil {
+		t.Fatalf("MasterPublicKeyFromMnemonic: %+v", err)
+	}
+
+	serverInstance := &server{
+		params:   params,
+		keysFile: &keys.File{ExtendedPublicKeys: []string{publicKey}, MinimumSignatures: 1},
+	}
+	if !serverInstance.keysFile.IsWatchOnly() {
+		t.Fatalf("Expected a keys file without mnemonics to be watch-only")
+	}
+
+	_, err = serverInstance.Sign(context.Background(), &pb.SignRequest{UnsignedTransactions: [][]byte{{}}})
+	if !errors.Is(err, keys.ErrWatchOnly) {
+		t.Fatalf("Expected Sign to fail with ErrWatchOnly, but got %v", err)
+	}
+
+	_, err = serverInstance.Send(context.Background(), &pb.SendRequest{})
+	if !errors.Is(err, keys.ErrWatchOnly) {
+		t.Fatalf("Expected Send to fail with ErrWatchOnly, but got %v", err)
+	}
+}
@@ -18,16 +18,17 @@ import (
 // transaction.
 // If it is - the transaction is split into multiple transactions, each with a portion of the inputs and a single output
 // into a change address.
-// An additional `mergeTransaction` is generated - which merges the outputs of the above splits into a single output
-// paying to the original transaction's payee.
-func (s *server) maybeAutoCompoundTransaction(transactionBytes []byte, toAddress util.Address,
-	changeAddress util.Address, changeWalletAddress *walletAddress) ([][]byte, error) {
+// An additional `mergeTransaction` is generated - which merges the outputs of the above splits into the
+// original transaction's payments.
+func (s *server) maybeAutoCompoundTransaction(transactionBytes []byte, payments []*libkashwallet.Payment,
+	changeAddress util.Address, changeWalletAddress *walletAddress, feeRate float64) ([][]byte, error) {
 	transaction, err := serialization.DeserializePartiallySignedTransaction(transactionBytes)
 	if err != nil {
 		return nil, err
 	}
 
-	splitTransactions, err := s.maybeSplitAndMergeTransaction(transaction, toAddress, changeAddress, changeWalletAddress)
+	splitTransactions, err := s.maybeSplitAndMergeTransaction(transaction, payments, changeAddress, changeWalletAddress,
+		feeRate)
 	if err != nil {
 		return nil, err
 	}
@@ -44,21 +45,25 @@ func (s *server) maybeAutoCompoundTransaction(transactionBytes []byte, toAddress
 func (s *server) mergeTransaction(
 	splitTransactions []*serialization.PartiallySignedTransaction,
 	originalTransaction *serialization.PartiallySignedTransaction,
-	toAddress util.Address,
+	payments []*libkashwallet.Payment,
 	changeAddress util.Address,
 	changeWalletAddress *walletAddress,
+	feeRate float64,
 ) (*serialization.PartiallySignedTransaction, error) {
 	numOutputs := len(originalTransaction.Tx.Outputs)
-	if numOutputs > 2 || numOutputs == 0 {
-		// This is a sanity check to make sure originalTransaction has either 1 or 2 outputs:
-		// 1. For the payment itself
-		// 2. (optional) for change
-		return nil, errors.Errorf("original transaction has %d outputs, while 1 or 2 are expected",
-			len(originalTransaction.Tx.Outputs))
+	if numOutputs > len(payments)+1 || numOutputs < len(payments) {
+		// This is a sanity check to make sure originalTransaction has:
+		// 1. An output for every payment
+		// 2. (optional) An output for change
+		return nil, errors.Errorf("original transaction has %d outputs, while %d or %d are expected",
+			len(originalTransaction.Tx.Outputs), len(payments), len(payments)+1)
 	}
 
 	totalValue := uint64(0)
-	sentValue := originalTransaction.Tx.Outputs[0].Value
+	sentValue := uint64(0)
+	for _, payment := range payments {
+		sentValue += payment.Amount
+	}
 	utxos := make([]*libkashwallet.UTXO, len(splitTransactions))
 	for i, splitTransaction := range splitTransactions {
 		output := splitTransaction.Tx.Outputs[0]
@@ -72,35 +77,41 @@ func (s *server) mergeTransaction(
 			DerivationPath: s.walletAddressPath(changeWalletAddress),
 		}
 		totalValue += output.Value
-		totalValue -= feePerInput
 	}
 
-	if totalValue < sentValue {
+	feeEstimate, err := s.newTransactionFeeEstimate(payments, true, originalTransaction.Tx.Type, feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	if totalValue < sentValue+feeEstimate.fee(len(utxos)) {
 		// sometimes the fees from compound transactions make the total output higher than what's available from selected
 		// utxos, in such cases - find one more UTXO and use it.
-		additionalUTXOs, totalValueAdded, err := s.moreUTXOsForMergeTransaction(utxos, sentValue-totalValue)
+		additionalUTXOs, totalValueAdded, err := s.moreUTXOsForMergeTransaction(utxos,
+			sentValue+feeEstimate.fee(len(utxos))-totalValue, feeEstimate.feePerInput())
 		if err != nil {
 			return nil, err
 		}
 		utxos = append(utxos, additionalUTXOs...)
 		totalValue += totalValueAdded
 	}
+	fee := feeEstimate.fee(len(utxos))
+	if totalValue < sentValue+fee {
+		return nil, errors.Errorf("Insufficient funds for merge transaction")
+	}
 
-	payments := []*libkashwallet.Payment{{
-		Address:   toAddress,
-		AssetType: originalTransaction.Tx.OutputUTXOAssetType(),
-		Amount:    sentValue,
-	}}
-	if totalValue > sentValue {
-		payments = append(payments, &libkashwallet.Payment{
+	mergePayments := make([]*libkashwallet.Payment, len(payments), len(payments)+1)
+	copy(mergePayments, payments)
+	if totalValue > sentValue+fee {
+		mergePayments = append(mergePayments, &libkashwallet.Payment{
 			Address:   changeAddress,
 			AssetType: originalTransaction.Tx.OutputUTXOAssetType(),
-			Amount:    totalValue - sentValue,
+			Amount:    totalValue - sentValue - fee,
 		})
 	}
 
 	mergeTransactionBytes, err := libkashwallet.CreateUnsignedTransaction(s.keysFile.ExtendedPublicKeys,
-		s.keysFile.MinimumSignatures, payments, utxos, originalTransaction.Tx.Type) // Use the original transaction type
+		s.keysFile.MinimumSignatures, mergePayments, utxos, originalTransaction.Tx.Type) // Use the original transaction type
 	if err != nil {
 		return nil, err
 	}
@@ -108,8 +119,9 @@ func (s *server) mergeTransaction(
 	return serialization.DeserializePartiallySignedTransaction(mergeTransactionBytes)
 }
 
-func (s *server) maybeSplitAndMergeTransaction(transaction *serialization.PartiallySignedTransaction, toAddress util.Address,
-	changeAddress util.Address, changeWalletAddress *walletAddress) ([]*serialization.PartiallySignedTransaction, error) {
+func (s *server) maybeSplitAndMergeTransaction(transaction *serialization.PartiallySignedTransaction,
+	payments []*libkashwallet.Payment, changeAddress util.Address, changeWalletAddress *walletAddress,
+	feeRate float64) ([]*serialization.PartiallySignedTransaction, error) {
 
 	transactionMass, err := s.estimateMassAfterSignatures(transaction)
 	if err != nil {
@@ -132,21 +144,21 @@ func (s *server) maybeSplitAndMergeTransaction(transaction *serialization.Partia
 		endIndex := startIndex + inputCountPerSplit
 		var err error
 		splitTransactions[i], err = s.createSplitTransaction(transaction, changeAddress,
-			startIndex, endIndex)
+			startIndex, endIndex, feeRate)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if len(splitTransactions) > 1 {
-		mergeTransaction, err := s.mergeTransaction(splitTransactions, transaction, toAddress,
-			changeAddress, changeWalletAddress)
+		mergeTransaction, err := s.mergeTransaction(splitTransactions, transaction, payments,
+			changeAddress, changeWalletAddress, feeRate)
 		if err != nil {
 			return nil, err
 		}
 		// Recursion will be 2-3 iterations deep even in the rarest` cases, so considered safe..
-		splitMergeTransaction, err := s.maybeSplitAndMergeTransaction(mergeTransaction, toAddress,
-			changeAddress, changeWalletAddress)
+		splitMergeTransaction, err := s.maybeSplitAndMergeTransaction(mergeTransaction, payments,
+			changeAddress, changeWalletAddress, feeRate)
 		if err != nil {
 			return nil, err
 		}
@@ -179,7 +191,7 @@ func (s *server) splitAndInputPerSplitCounts(transaction *serialization.Partiall
 	// Create another dummy transaction, this time one similar to the split transactions we wish to generate,
 	// but with 0 inputs, to calculate how much mass for inputs do we have available in the split transactions
 	splitTransactionWithoutInputs, err := s.createSplitTransaction(transaction, changeAddress,
-		0, 0) // Use the original transaction type
+		0, 0, 0) // Use the original transaction type
 	if err != nil {
 		return 0, 0, err
 	}
@@ -197,7 +209,8 @@ func (s *server) splitAndInputPerSplitCounts(transaction *serialization.Partiall
 }
 
 func (s *server) createSplitTransaction(transaction *serialization.PartiallySignedTransaction,
-	changeAddress util.Address, startIndex int, endIndex int) (*serialization.PartiallySignedTransaction, error) {
+	changeAddress util.Address, startIndex int, endIndex int, feeRate float64) (
+	*serialization.PartiallySignedTransaction, error) {
 
 	selectedUTXOs := make([]*libkashwallet.UTXO, 0, endIndex-startIndex)
 	totalSompi := uint64(0)
@@ -214,7 +227,6 @@ func (s *server) createSplitTransaction(transaction *serialization.PartiallySign
 		})
 
 		totalSompi += selectedUTXOs[i-startIndex].UTXOEntry.Amount()
-		totalSompi -= feePerInput
 	}
 	unsignedTransactionBytes, err := libkashwallet.CreateUnsignedTransaction(s.keysFile.ExtendedPublicKeys,
 		s.keysFile.MinimumSignatures,
@@ -227,7 +239,24 @@ func (s *server) createSplitTransaction(transaction *serialization.PartiallySign
 		return nil, err
 	}
 
-	return serialization.DeserializePartiallySignedTransaction(unsignedTransactionBytes)
+	splitTransaction, err := serialization.DeserializePartiallySignedTransaction(unsignedTransactionBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// The output value doesn't affect the mass, so the fee can be deducted from it after the mass is known
+	mass, err := s.estimateMassAfterSignatures(splitTransaction)
+	if err != nil {
+		return nil, err
+	}
+	fee := feeForMass(mass, feeRate)
+	if fee > totalSompi {
+		return nil, errors.Errorf("the inputs of a split transaction are worth %d sompi, "+
+			"which doesn't cover its %d sompi fee", totalSompi, fee)
+	}
+	splitTransaction.Tx.Outputs[0].Value -= fee
+
+	return splitTransaction, nil
 }
 
 func (s *server) estimateMassAfterSignatures(transaction *serialization.PartiallySignedTransaction) (uint64, error) {
@@ -257,8 +286,8 @@ func (s *server) estimateMassAfterSignatures(transaction *serialization.Partiall
 	return s.txMassCalculator.CalculateTransactionMass(transactionWithSignatures), nil
 }
 
-func (s *server) moreUTXOsForMergeTransaction(alreadySelectedUTXOs []*libkashwallet.UTXO, requiredAmount uint64) (
-	additionalUTXOs []*libkashwallet.UTXO, totalValueAdded uint64, err error) {
+func (s *server) moreUTXOsForMergeTransaction(alreadySelectedUTXOs []*libkashwallet.UTXO, requiredAmount uint64,
+	feePerInput uint64) (additionalUTXOs []*libkashwallet.UTXO, totalValueAdded uint64, err error) {
 
 	dagInfo, err := s.rpcClient.GetBlockDAGInfo()
 	if err != nil {
@@ -269,10 +298,19 @@ func (s *server) moreUTXOsForMergeTransaction(alreadySelectedUTXOs []*libkashwal
 		alreadySelectedUTXOsMap[*alreadySelectedUTXO.Outpoint] = struct{}{}
 	}
 
+	// totalValueAdded is returned as is, since the caller pays the fee of the added inputs, but
+	// only their value beyond their fee counts towards requiredAmount
+	valueAddedBeyondFees := uint64(0)
 	for _, utxo := range s.utxosSortedByAmount {
 		if _, ok := alreadySelectedUTXOsMap[*utxo.Outpoint]; ok {
 			continue
 		}
+		if utxo.UTXOEntry.Amount() <= feePerInput {
+			continue
+		}
+		if s.isSpentByMempool(utxo) {
+			continue
+		}
 		if !isUTXOSpendable(utxo, dagInfo.VirtualDAAScore, s.params.BlockCoinbaseMaturity) {
 			continue
 		}
@@ -283,12 +321,13 @@ func (s *server) moreUTXOsForMergeTransaction(alreadySelectedUTXOs []*libkashwal
 			Outpoint:       utxo.Outpoint,
 			UTXOEntry:      utxo.UTXOEntry,
 			DerivationPath: s.walletAddressPath(utxo.address)})
-		totalValueAdded += utxo.UTXOEntry.Amount() - feePerInput
-		if totalValueAdded >= requiredAmount {
+		totalValueAdded += utxo.UTXOEntry.Amount()
+		valueAddedBeyondFees += utxo.UTXOEntry.Amount() - feePerInput
+		if valueAddedBeyondFees >= requiredAmount {
 			break
 		}
 	}
-	if totalValueAdded < requiredAmount {
+	if valueAddedBeyondFees < requiredAmount {
 		return nil, 0, errors.Errorf("Insufficient funds for merge transaction")
 	}
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 
 	"github.com/Kash-Protocol/kashd/app/appmessage"
 	"github.com/pkg/errors"
@@ -23,44 +24,250 @@ func (was walletAddressSet) strings() []string {
 	return addresses
 }
 
+// sync scans the wallet's addresses and UTXOs, and then keeps them up to date by applying the UTXO changes
+// the node notifies about. The full scan is repeated whenever the node reconnects or overrides its pruning
+// point UTXO set, since notifications might have been missed. The outputs spent by the wallet's pending
+// transactions are refreshed from the mempool periodically, since UTXO changes only reflect consensus.
 func (s *server) sync() error {
-	ticker := time.NewTicker(time.Second)
+	err := s.registerForNotifications()
+	if err != nil {
+		return err
+	}
+
+	err = s.rescan()
+	if err != nil {
+		return err
+	}
+
+	// Checking the address window makes no RPC calls unless new addresses were used
+	ticker := time.NewTicker(refreshInterval)
 	defer ticker.Stop()
 
-	err := s.collectRecentAddresses()
+	for {
+		select {
+		case <-s.reconnected:
+			err = s.registerForNotifications()
+			if err != nil {
+				return err
+			}
+
+			err = s.rescan()
+			if err != nil {
+				return err
+			}
+		case <-s.rescanRequests:
+			err = s.rescan()
+			if err != nil {
+				return err
+			}
+		case <-ticker.C:
+			err = s.collectNewAddressesWithLock()
+			if err != nil {
+				return err
+			}
+
+			err = s.refreshMempoolSpentOutpointsWithLock()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+const (
+	numIndexesToQueryForRecentAddresses = 1000
+	refreshInterval                     = time.Second
+)
+
+var allAssetTypes = []externalapi.AssetType{externalapi.KSH, externalapi.KUSD, externalapi.KRV}
+
+// registerForNotifications registers for UTXO changes of the wallet's addresses and for pruning point
+// UTXO set overrides. Further addresses UTXO changes are sent for are added as they are collected.
+func (s *server) registerForNotifications() error {
+	addresses, err := s.addressesToRegisterWithLock()
+	if err != nil {
+		return err
+	}
+
+	err = s.rpcClient.RegisterForUTXOsChangedNotifications(addresses, allAssetTypes, s.onUTXOsChanged)
 	if err != nil {
 		return err
 	}
 
-	err = s.refreshExistingUTXOsWithLock()
+	return s.rpcClient.RegisterPruningPointUTXOSetNotifications(s.requestRescan)
+}
+
+// addressesToRegisterWithLock returns the addresses to register for UTXO changes with: the ones already
+// watched, or the first addresses to collect if there are none yet. Note that registering with no
+// addresses at all would make the node send the UTXO changes of every address.
+func (s *server) addressesToRegisterWithLock() ([]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.watchedAddresses) == 0 {
+		addressSet, err := s.addressesToQuery(0, numIndexesToQueryForRecentAddresses)
+		if err != nil {
+			return nil, err
+		}
+		for addressString, address := range addressSet {
+			s.watchedAddresses[addressString] = address
+		}
+	}
+
+	return s.watchedAddresses.strings(), nil
+}
+
+// watchAddress registers a newly derived wallet address for UTXO change notifications, unless
+// it's already watched
+func (s *server) watchAddress(address *walletAddress) error {
+	addressString, err := s.walletAddressString(address)
 	if err != nil {
 		return err
 	}
+	if _, ok := s.watchedAddresses[addressString]; ok {
+		return nil
+	}
+
+	err = s.rpcClient.AddUTXOsChangedNotificationAddresses([]string{addressString}, allAssetTypes)
+	if err != nil {
+		return err
+	}
+	s.watchedAddresses[addressString] = address
+	return nil
+}
+
+func (s *server) onReconnected() {
+	select {
+	case s.reconnected <- struct{}{}:
+	default:
+	}
+}
+
+func (s *server) requestRescan() {
+	select {
+	case s.rescanRequests <- struct{}{}:
+	default:
+	}
+}
+
+// rescan collects the wallet's addresses and re-fills its UTXO set from scratch
+func (s *server) rescan() error {
+	err := s.collectRecentAddresses()
+	if err != nil {
+		return err
+	}
+
+	return s.refreshExistingUTXOsWithLock()
+}
+
+func (s *server) onUTXOsChanged(notification *appmessage.UTXOsChangedNotificationMessage) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// A change that failed to apply is recovered from by rescanning, rather than by killing the daemon
+	err := s.applyUTXOChanges(notification.Added, notification.Removed)
+	if err != nil {
+		log.Errorf("Error applying UTXO changes, rescanning: %s", err)
+		s.requestRescan()
+		return
+	}
+
+	err = s.recordUTXOChanges(notification.Added, notification.Removed)
+	if err != nil {
+		log.Errorf("Error recording UTXO changes in the transaction history, rescanning: %s", err)
+		s.requestRescan()
+	}
+}
+
+// applyUTXOChanges removes the removed entries from the UTXO set and adds the added ones to it.
+// Entries already in the UTXO set are not added again, so it's safe to apply changes the set
+// already reflects. Entries of addresses that aren't watched are skipped.
+func (s *server) applyUTXOChanges(added, removed []*appmessage.UTXOsByAddressesEntry) error {
+	removedOutpoints := make(map[externalapi.DomainOutpoint]struct{}, len(removed))
+	for _, entry := range removed {
+		outpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry.Outpoint)
+		if err != nil {
+			return err
+		}
+		removedOutpoints[*outpoint] = struct{}{}
+	}
+
+	utxos := make([]*walletUTXO, 0, len(s.utxosSortedByAmount)+len(added))
+	existingOutpoints := make(map[externalapi.DomainOutpoint]struct{}, len(s.utxosSortedByAmount))
+	for _, utxo := range s.utxosSortedByAmount {
+		if _, ok := removedOutpoints[*utxo.Outpoint]; ok {
+			continue
+		}
+		utxos = append(utxos, utxo)
+		existingOutpoints[*utxo.Outpoint] = struct{}{}
+	}
 
-	for range ticker.C {
-		err = s.collectFarAddresses()
+	for _, entry := range added {
+		outpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry.Outpoint)
 		if err != nil {
 			return err
 		}
+		if _, ok := removedOutpoints[*outpoint]; ok {
+			continue
+		}
+		if _, ok := existingOutpoints[*outpoint]; ok {
+			continue
+		}
 
-		err = s.collectRecentAddresses()
+		utxoEntry, err := appmessage.RPCUTXOEntryToUTXOEntry(entry.UTXOEntry)
 		if err != nil {
 			return err
 		}
 
-		err = s.refreshExistingUTXOsWithLock()
+		address, ok, err := s.useWatchedAddress(entry.Address)
 		if err != nil {
 			return err
 		}
+		if !ok {
+			log.Warnf("Got UTXO change for address %s even though it isn't watched", entry.Address)
+			continue
+		}
+		utxos = append(utxos, &walletUTXO{
+			Outpoint:  outpoint,
+			UTXOEntry: utxoEntry,
+			address:   address,
+		})
+		existingOutpoints[*outpoint] = struct{}{}
 	}
 
+	sort.Slice(utxos, func(i, j int) bool { return utxos[i].UTXOEntry.Amount() > utxos[j].UTXOEntry.Amount() })
+
+	s.utxosSortedByAmount = utxos
+
 	return nil
 }
 
-const (
-	numIndexesToQueryForFarAddresses    = 100
-	numIndexesToQueryForRecentAddresses = 1000
-)
+// useWatchedAddress returns the wallet address of the given watched address, or false if it isn't
+// watched. Addresses that weren't used so far are added to the address set and update the last used
+// indexes.
+func (s *server) useWatchedAddress(addressString string) (*walletAddress, bool, error) {
+	if address, ok := s.addressSet[addressString]; ok {
+		return address, true, nil
+	}
+
+	address, ok := s.watchedAddresses[addressString]
+	if !ok {
+		return nil, false, nil
+	}
+	s.addressSet[addressString] = address
+
+	if address.keyChain == libkashwallet.ExternalKeychain {
+		if address.index > s.keysFile.LastUsedExternalIndex() {
+			return address, true, s.keysFile.SetLastUsedExternalIndex(address.index)
+		}
+		return address, true, nil
+	}
+
+	if address.index > s.keysFile.LastUsedInternalIndex() {
+		return address, true, s.keysFile.SetLastUsedInternalIndex(address.index)
+	}
+	return address, true, nil
+}
 
 // addressesToQuery scans the addresses in the given range. Because
 // each cosigner in a multisig has its own unique path for generating
@@ -88,18 +295,33 @@ func (s *server) addressesToQuery(start, end uint32) (walletAddressSet, error) {
 	return addresses, nil
 }
 
-// collectFarAddresses collects numIndexesToQueryForFarAddresses addresses
-// from the last point it stopped in the previous call.
-func (s *server) collectFarAddresses() error {
+func (s *server) collectNewAddressesWithLock() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	err := s.collectAddresses(s.nextSyncStartIndex, s.nextSyncStartIndex+numIndexesToQueryForFarAddresses)
+
+	return s.collectNewAddresses()
+}
+
+// collectNewAddresses extends the collected addresses up to the address with the index of
+// the last used address + numIndexesToQueryForRecentAddresses, in case new addresses were used
+// since they were last collected.
+func (s *server) collectNewAddresses() error {
+	end := s.maxUsedIndex() + numIndexesToQueryForRecentAddresses
+	if s.nextSyncStartIndex >= end {
+		return nil
+	}
+
+	addressSetSize := len(s.addressSet)
+	err := s.collectAddresses(s.nextSyncStartIndex, end)
 	if err != nil {
 		return err
 	}
+	s.nextSyncStartIndex = end
 
-	s.nextSyncStartIndex += numIndexesToQueryForFarAddresses
-	return nil
+	if len(s.addressSet) == addressSetSize {
+		return nil
+	}
+	return s.refreshUTXOs()
 }
 
 func (s *server) maxUsedIndexWithLock() uint32 {
@@ -152,12 +374,23 @@ func (s *server) collectAddressesWithLock(start, end uint32) error {
 	return s.collectAddresses(start, end)
 }
 
+// collectAddresses registers the addresses in the given range for UTXO change notifications,
+// and adds the ones that were used to the address set
 func (s *server) collectAddresses(start, end uint32) error {
 	addressSet, err := s.addressesToQuery(start, end)
 	if err != nil {
 		return err
 	}
 
+	// Addresses are registered before their balances are queried, so that no change is missed in between
+	err = s.rpcClient.AddUTXOsChangedNotificationAddresses(addressSet.strings(), allAssetTypes)
+	if err != nil {
+		return err
+	}
+	for addressString, address := range addressSet {
+		s.watchedAddresses[addressString] = address
+	}
+
 	getBalancesByAddressesResponse, err := s.rpcClient.GetBalancesByAddresses(addressSet.strings())
 	if err != nil {
 		return err
@@ -219,20 +452,12 @@ func (s *server) refreshExistingUTXOsWithLock() error {
 func (s *server) updateUTXOSet(entries []*appmessage.UTXOsByAddressesEntry, mempoolEntries []*appmessage.MempoolEntryByAddress) error {
 	utxos := make([]*walletUTXO, 0, len(entries))
 
-	exclude := make(map[appmessage.RPCOutpoint]struct{})
-	for _, entriesByAddress := range mempoolEntries {
-		for _, entry := range entriesByAddress.Sending {
-			for _, input := range entry.Transaction.Inputs {
-				exclude[*input.PreviousOutpoint] = struct{}{}
-			}
-		}
+	err := s.updateMempoolSpentOutpoints(mempoolEntries)
+	if err != nil {
+		return err
 	}
 
 	for _, entry := range entries {
-		if _, ok := exclude[*entry.Outpoint]; ok {
-			continue
-		}
-
 		outpoint, err := appmessage.RPCOutpointToDomainOutpoint(entry.Outpoint)
 		if err != nil {
 			return err
@@ -277,7 +502,51 @@ func (s *server) refreshUTXOs() error {
 		return err
 	}
 
-	return s.updateUTXOSet(getUTXOsByAddressesResponse.Entries, mempoolEntriesByAddresses.Entries)
+	err = s.updateUTXOSet(getUTXOsByAddressesResponse.Entries, mempoolEntriesByAddresses.Entries)
+	if err != nil {
+		return err
+	}
+
+	return s.recordAcceptedEntries(getUTXOsByAddressesResponse.Entries)
+}
+
+func (s *server) refreshMempoolSpentOutpointsWithLock() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	mempoolEntriesByAddresses, err := s.rpcClient.GetMempoolEntriesByAddresses(s.addressSet.strings(), true, true)
+	if err != nil {
+		return err
+	}
+
+	return s.updateMempoolSpentOutpoints(mempoolEntriesByAddresses.Entries)
+}
+
+// updateMempoolSpentOutpoints replaces the outpoints spent by the wallet's pending transactions with
+// the ones spent by the given mempool entries. The UTXO set keeps these outpoints until the spending
+// transactions are accepted, so they must not be selected as inputs in the meantime.
+func (s *server) updateMempoolSpentOutpoints(mempoolEntries []*appmessage.MempoolEntryByAddress) error {
+	mempoolSpentOutpoints := make(map[externalapi.DomainOutpoint]struct{})
+	for _, entriesByAddress := range mempoolEntries {
+		for _, entry := range entriesByAddress.Sending {
+			for _, input := range entry.Transaction.Inputs {
+				outpoint, err := appmessage.RPCOutpointToDomainOutpoint(input.PreviousOutpoint)
+				if err != nil {
+					return err
+				}
+				mempoolSpentOutpoints[*outpoint] = struct{}{}
+			}
+		}
+	}
+
+	s.mempoolSpentOutpoints = mempoolSpentOutpoints
+	return nil
+}
+
+// isSpentByMempool returns whether the given UTXO is spent by a pending transaction of the wallet
+func (s *server) isSpentByMempool(utxo *walletUTXO) bool {
+	_, ok := s.mempoolSpentOutpoints[*utxo.Outpoint]
+	return ok
 }
 
 func (s *server) isSynced() bool {
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+)
+
+func TestApplyUTXOChanges(t *testing.T) {
+	const usedAddress = "kash:used"
+	const watchedAddress = "kash:watched"
+
+	serverInstance := &server{
+		keysFile: &keys.File{},
+		addressSet: walletAddressSet{
+			usedAddress: {index: 0, keyChain: libkashwallet.ExternalKeychain},
+		},
+		watchedAddresses: walletAddressSet{
+			usedAddress:    {index: 0, keyChain: libkashwallet.ExternalKeychain},
+			watchedAddress: {index: 0, keyChain: libkashwallet.InternalKeychain},
+		},
+	}
+
+	entry := func(address string, transactionIDByte string, amount uint64) *appmessage.UTXOsByAddressesEntry {
+		return &appmessage.UTXOsByAddressesEntry{
+			Address: address,
+			Outpoint: &appmessage.RPCOutpoint{
+				TransactionID: strings.Repeat(transactionIDByte, 64),
+				Index:         0,
+			},
+			UTXOEntry: &appmessage.RPCUTXOEntry{
+				Amount:          amount,
+				ScriptPublicKey: &appmessage.RPCScriptPublicKey{Script: "00"},
+			},
+		}
+	}
+
+	err := serverInstance.applyUTXOChanges(
+		[]*appmessage.UTXOsByAddressesEntry{entry(usedAddress, "1", 10), entry(usedAddress, "2", 30)}, nil)
+	if err != nil {
+		t.Fatalf("applyUTXOChanges: %+v", err)
+	}
+
+	// Applying a change twice should have no further effect
+	err = serverInstance.applyUTXOChanges(
+		[]*appmessage.UTXOsByAddressesEntry{entry(usedAddress, "2", 30), entry(watchedAddress, "3", 20)},
+		[]*appmessage.UTXOsByAddressesEntry{entry(usedAddress, "1", 10)})
+	if err != nil {
+		t.Fatalf("applyUTXOChanges: %+v", err)
+	}
+
+	expectedAmounts := []uint64{30, 20}
+	if len(serverInstance.utxosSortedByAmount) != len(expectedAmounts) {
+		t.Fatalf("Expected %d UTXOs but got %d", len(expectedAmounts), len(serverInstance.utxosSortedByAmount))
+	}
+	for i, utxo := range serverInstance.utxosSortedByAmount {
+		if utxo.UTXOEntry.Amount() != expectedAmounts[i] {
+			t.Fatalf("Expected UTXO #%d to have an amount of %d but got %d", i, expectedAmounts[i], utxo.UTXOEntry.Amount())
+		}
+	}
+
+	if _, ok := serverInstance.addressSet[watchedAddress]; !ok {
+		t.Fatalf("Expected the watched address to be added to the address set once it received a UTXO")
+	}
+
+	// Changes of addresses that aren't watched should be skipped
+	err = serverInstance.applyUTXOChanges([]*appmessage.UTXOsByAddressesEntry{entry("kash:unknown", "4", 1)}, nil)
+	if err != nil {
+		t.Fatalf("applyUTXOChanges: %+v", err)
+	}
+	if len(serverInstance.utxosSortedByAmount) != len(expectedAmounts) {
+		t.Fatalf("Expected the UTXO of an address that isn't watched to be skipped")
+	}
+	if _, ok := serverInstance.addressSet["kash:unknown"]; ok {
+		t.Fatalf("Expected an address that isn't watched not to be added to the address set")
+	}
+}
@@ -0,0 +1,249 @@
+package server
+
+import (
+	"context"
+
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
+)
+
+func (s *server) GetTransactionHistory(_ context.Context, request *pb.GetTransactionHistoryRequest) (
+	*pb.GetTransactionHistoryResponse, error) {
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	dagInfo, err := s.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	records, totalCount := s.history.query(request.AssetTypes, request.Offset, request.Limit)
+	entries := make([]*pb.TransactionHistoryEntry, len(records))
+	for i, record := range records {
+		entries[i] = &pb.TransactionHistoryEntry{
+			TxID:                  record.TxID,
+			Direction:             record.Direction,
+			AssetType:             record.AssetType,
+			Amount:                record.Amount,
+			Fee:                   record.Fee,
+			CounterpartyAddresses: record.CounterpartyAddresses,
+			WalletAddresses:       record.WalletAddresses,
+		}
+		if record.IsAccepted {
+			entries[i].AcceptingDAAScore = record.AcceptingDAAScore
+			if dagInfo.VirtualDAAScore > record.AcceptingDAAScore {
+				entries[i].Confirmations = dagInfo.VirtualDAAScore - record.AcceptingDAAScore
+			}
+		}
+	}
+
+	return &pb.GetTransactionHistoryResponse{
+		Entries:    entries,
+		TotalCount: totalCount,
+	}, nil
+}
+
+// recordBroadcastTransaction adds the transaction the wallet has just broadcast to the history as pending.
+// prevOutputs are the outputs spent by the transaction's inputs, if they are known.
+func (s *server) recordBroadcastTransaction(tx *externalapi.DomainTransaction,
+	prevOutputs []*externalapi.DomainTransactionOutput) error {
+
+	txID := consensushashing.TransactionID(tx).String()
+	if s.history.hasTransaction(txID) {
+		return nil
+	}
+
+	utxoAmounts := make(map[externalapi.DomainOutpoint]uint64, len(s.utxosSortedByAmount))
+	for _, utxo := range s.utxosSortedByAmount {
+		utxoAmounts[*utxo.Outpoint] = utxo.UTXOEntry.Amount()
+	}
+
+	spentAmount := uint64(0)
+	areInputAmountsKnown := true
+	spentOutpoints := make([]string, len(tx.Inputs))
+	for i, input := range tx.Inputs {
+		spentOutpoints[i] = historyOutpoint(input.PreviousOutpoint.TransactionID.String(), input.PreviousOutpoint.Index)
+
+		if prevOutputs != nil {
+			spentAmount += prevOutputs[i].Value
+			continue
+		}
+		amount, ok := utxoAmounts[input.PreviousOutpoint]
+		if !ok {
+			areInputAmountsKnown = false
+			continue
+		}
+		spentAmount += amount
+	}
+
+	paidAmount, receivedAmount := uint64(0), uint64(0)
+	counterpartyAddresses, walletAddresses := []string{}, []string{}
+	for _, output := range tx.Outputs {
+		address, isWalletAddress := s.outputAddress(output)
+		if isWalletAddress {
+			receivedAmount += output.Value
+			walletAddresses = appendUnique(walletAddresses, address)
+			continue
+		}
+		paidAmount += output.Value
+		if address != "" {
+			counterpartyAddresses = appendUnique(counterpartyAddresses, address)
+		}
+	}
+
+	inputAssetType, outputAssetType := externalapi.GetAssetTypeFromDomainTransactionType(tx.Type)
+	outgoingRecord := &historyRecord{
+		TxID:                  txID,
+		Direction:             directionOutgoing,
+		AssetType:             inputAssetType.ToUint32(),
+		CounterpartyAddresses: counterpartyAddresses,
+		SpentOutpoints:        spentOutpoints,
+	}
+
+	if !tx.Type.IsConversion() {
+		outgoingRecord.Amount = paidAmount
+		if areInputAmountsKnown && spentAmount >= paidAmount+receivedAmount {
+			outgoingRecord.Fee = spentAmount - paidAmount - receivedAmount
+		}
+		return s.history.add(outgoingRecord)
+	}
+
+	// A conversion spends all of its input amount, and the converted amount it pays back to the wallet is received
+	outgoingRecord.Amount = spentAmount
+	if receivedAmount == 0 {
+		return s.history.add(outgoingRecord)
+	}
+	return s.history.add(outgoingRecord, &historyRecord{
+		TxID:            txID,
+		Direction:       directionIncoming,
+		AssetType:       outputAssetType.ToUint32(),
+		Amount:          receivedAmount,
+		WalletAddresses: walletAddresses,
+	})
+}
+
+// outputAddress returns the address the given output pays to, and whether it's an address of this wallet
+func (s *server) outputAddress(output *externalapi.DomainTransactionOutput) (string, bool) {
+	_, address, err := txscript.ExtractScriptPubKeyAddress(output.ScriptPublicKey, s.params)
+	if err != nil || address == nil {
+		return "", false
+	}
+
+	addressString := address.String()
+	if _, ok := s.addressSet[addressString]; ok {
+		return addressString, true
+	}
+	_, ok := s.watchedAddresses[addressString]
+	return addressString, ok
+}
+
+// recordUTXOChanges updates the history with UTXO changes of the wallet's addresses
+func (s *server) recordUTXOChanges(added, removed []*appmessage.UTXOsByAddressesEntry) error {
+	err := s.recordAcceptedEntries(added)
+	if err != nil {
+		return err
+	}
+
+	// Transactions that pay nothing back to the wallet are only noticed through the outputs they spend.
+	// The UTXO changes don't say when they were accepted, so the current virtual DAA score is used.
+	virtualDAAScore := uint64(0)
+	for _, entry := range removed {
+		txID, ok := s.history.pendingSpender(historyOutpoint(entry.Outpoint.TransactionID, entry.Outpoint.Index))
+		if !ok {
+			continue
+		}
+		if virtualDAAScore == 0 {
+			dagInfo, err := s.rpcClient.GetBlockDAGInfo()
+			if err != nil {
+				return err
+			}
+			virtualDAAScore = dagInfo.VirtualDAAScore
+		}
+		err := s.history.accept(txID, virtualDAAScore)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordAcceptedEntries updates the history with the given accepted UTXOs of the wallet. UTXOs created by
+// transactions in the history accept them, and UTXOs of other transactions that pay to external addresses
+// of the wallet are recorded as incoming transactions.
+func (s *server) recordAcceptedEntries(entries []*appmessage.UTXOsByAddressesEntry) error {
+	type incomingAsset struct {
+		amount            uint64
+		walletAddresses   []string
+		acceptingDAAScore uint64
+	}
+	incomingTxIDs := []string{}
+	incomingAssets := make(map[string]map[uint32]*incomingAsset)
+
+	for _, entry := range entries {
+		txID := entry.Outpoint.TransactionID
+		if s.history.hasTransaction(txID) {
+			err := s.history.accept(txID, entry.UTXOEntry.BlockDAAScore)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		address, ok := s.addressSet[entry.Address]
+		if !ok || address.keyChain != libkashwallet.ExternalKeychain {
+			continue
+		}
+
+		assets, ok := incomingAssets[txID]
+		if !ok {
+			assets = make(map[uint32]*incomingAsset)
+			incomingAssets[txID] = assets
+			incomingTxIDs = append(incomingTxIDs, txID)
+		}
+		asset, ok := assets[entry.UTXOEntry.AssetType]
+		if !ok {
+			asset = &incomingAsset{acceptingDAAScore: entry.UTXOEntry.BlockDAAScore}
+			assets[entry.UTXOEntry.AssetType] = asset
+		}
+		asset.amount += entry.UTXOEntry.Amount
+		asset.walletAddresses = appendUnique(asset.walletAddresses, entry.Address)
+	}
+
+	for _, txID := range incomingTxIDs {
+		for _, assetType := range allAssetTypes {
+			asset, ok := incomingAssets[txID][assetType.ToUint32()]
+			if !ok {
+				continue
+			}
+			err := s.history.add(&historyRecord{
+				TxID:              txID,
+				Direction:         directionIncoming,
+				AssetType:         assetType.ToUint32(),
+				Amount:            asset.amount,
+				WalletAddresses:   asset.walletAddresses,
+				IsAccepted:        true,
+				AcceptingDAAScore: asset.acceptingDAAScore,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func appendUnique(strings []string, s string) []string {
+	for _, existing := range strings {
+		if existing == s {
+			return strings
+		}
+	}
+	return append(strings, s)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet/serialization"
+	"github.com/pkg/errors"
+)
+
+func finalize(conf *finalizeConfig) error {
+	keysFile, err := keys.ReadKeysFile(conf.NetParams(), conf.KeysFile)
+	if err != nil {
+		return err
+	}
+
+	partiallySignedTransactions, err := readTransactions(conf.Transaction, conf.TransactionFile)
+	if err != nil {
+		return err
+	}
+
+	finalizedTransactions := make([][]byte, len(partiallySignedTransactions))
+	for i, partiallySignedTransaction := range partiallySignedTransactions {
+		tx, err := libkashwallet.FinalizeTransaction(partiallySignedTransaction, keysFile.ECDSA)
+		if err != nil {
+			return errors.Wrapf(err, "Could not finalize transaction #%d", i+1)
+		}
+
+		finalizedTransactions[i], err = serialization.SerializeDomainTransaction(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "The transaction is finalized. Send it with 'kashwallet broadcast --finalized'")
+	fmt.Println(encodeTransactionsToHex(finalizedTransactions))
+	return nil
+}
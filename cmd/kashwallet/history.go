@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/client"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/utils"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+)
+
+func history(conf *historyConfig) error {
+	daemonClient, tearDown, err := client.Connect(conf.DaemonAddress)
+	if err != nil {
+		return err
+	}
+	defer tearDown()
+
+	assetTypes := make([]uint32, len(conf.AssetTypes))
+	for i, assetType := range conf.AssetTypes {
+		assetTypes[i] = externalapi.AssetTypeFromString(assetType).ToUint32()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), daemonTimeout)
+	defer cancel()
+	response, err := daemonClient.GetTransactionHistory(ctx, &pb.GetTransactionHistoryRequest{
+		Offset:     conf.Offset,
+		Limit:      conf.Limit,
+		AssetTypes: assetTypes,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(response.Entries) == 0 {
+		fmt.Println("No transactions found")
+		return nil
+	}
+
+	fmt.Printf("%-64s %-8s %-5s %20s %20s %14s\n", "Transaction ID", "", "Asset", "Amount", "Fee", "Confirmations")
+	println("--------------------------------------------------------------------------------------------------------------------------------------")
+	for _, entry := range response.Entries {
+		feeStr := "--"
+		if entry.Fee > 0 {
+			feeStr = utils.FormatKas(entry.Fee)
+		}
+		confirmationsStr := "pending"
+		if entry.AcceptingDAAScore > 0 {
+			confirmationsStr = fmt.Sprintf("%d", entry.Confirmations)
+		}
+		fmt.Printf("%-64s %-8s %-5s %20s %20s %14s\n", entry.TxID, entry.Direction,
+			externalapi.AssetTypeFromUint32(entry.AssetType), utils.FormatKas(entry.Amount), feeStr, confirmationsStr)
+
+		if conf.Verbose {
+			for _, address := range entry.CounterpartyAddresses {
+				fmt.Printf("    To: %s\n", address)
+			}
+			for _, address := range entry.WalletAddresses {
+				fmt.Printf("    Received by: %s\n", address)
+			}
+		}
+	}
+
+	fmt.Printf("\nShowing transactions %d-%d out of %d\n",
+		conf.Offset+1, conf.Offset+uint32(len(response.Entries)), response.TotalCount)
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet/serialization"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
+)
+
+func inspect(conf *inspectConfig) error {
+	transactions, err := readTransactions(conf.Transaction, conf.TransactionFile)
+	if err != nil {
+		return err
+	}
+
+	for i, transaction := range transactions {
+		partiallySignedTransaction, err := serialization.DeserializePartiallySignedTransaction(transaction)
+		if err != nil {
+			return err
+		}
+		tx := partiallySignedTransaction.Tx
+		inputAssetType, outputAssetType := tx.InputUTXOAssetType(), tx.OutputUTXOAssetType()
+
+		fmt.Printf("Transaction #%d ID: \t%s\n", i+1, consensushashing.TransactionID(tx))
+		fmt.Printf("Type: \t\t\t%s\n", tx.Type)
+		fmt.Println()
+
+		allInputSompi := uint64(0)
+		isFullySigned := true
+		for index, input := range tx.Inputs {
+			partiallySignedInput := partiallySignedTransaction.PartiallySignedInputs[index]
+
+			fmt.Printf("Input %d: \tOutpoint: %s:%d \tAmount: %.8f %s \tDerivation path: %s\n", index,
+				input.PreviousOutpoint.TransactionID, input.PreviousOutpoint.Index,
+				float64(partiallySignedInput.PrevOutput.Value)/float64(constants.SompiPerKash),
+				partiallySignedInput.PrevOutputAssetType, partiallySignedInput.DerivationPath)
+			if partiallySignedInput.PrevOutputAssetType != inputAssetType {
+				fmt.Printf("\t\tWarning: a %s transaction spends %s, but this input spends %s\n",
+					tx.Type, inputAssetType, partiallySignedInput.PrevOutputAssetType)
+			}
+
+			// The public keys of every input are ordered the same way, so the cosigners are numbered
+			// consistently across the inputs
+			numSignatures := uint32(0)
+			for cosignerIndex, pair := range partiallySignedInput.PubKeySignaturePairs {
+				status := "not signed"
+				if pair.Signature != nil {
+					status = "signed"
+					numSignatures++
+				}
+				fmt.Printf("\t\tCosigner #%d: %s\n", cosignerIndex+1, status)
+			}
+			fmt.Printf("\t\tSignatures: %d out of %d required\n", numSignatures, partiallySignedInput.MinimumSignatures)
+			if numSignatures < partiallySignedInput.MinimumSignatures {
+				isFullySigned = false
+			}
+
+			allInputSompi += partiallySignedInput.PrevOutput.Value
+		}
+		fmt.Println()
+
+		allOutputSompi := uint64(0)
+		for index, output := range tx.Outputs {
+			scriptPublicKeyType, scriptPublicKeyAddress, err := txscript.ExtractScriptPubKeyAddress(output.ScriptPublicKey, conf.ActiveNetParams)
+			if err != nil {
+				return err
+			}
+
+			// Non-standard script public keys have no address
+			var addressString string
+			if scriptPublicKeyType == txscript.NonStandardTy {
+				scriptPublicKeyHex := hex.EncodeToString(output.ScriptPublicKey.Script)
+				addressString = fmt.Sprintf("<Non-standard transaction script public key: %s>", scriptPublicKeyHex)
+			} else {
+				addressString = scriptPublicKeyAddress.EncodeAddress()
+			}
+
+			fmt.Printf("Output %d: \tRecipient: %s \tAmount: %.8f %s\n",
+				index, addressString, float64(output.Value)/float64(constants.SompiPerKash), outputAssetType)
+
+			allOutputSompi += output.Value
+		}
+		fmt.Println()
+
+		fmt.Printf("Total input:\t%d %s Sompi\n", allInputSompi, inputAssetType)
+		fmt.Printf("Total output:\t%d %s Sompi\n", allOutputSompi, outputAssetType)
+		if inputAssetType == outputAssetType {
+			if allInputSompi >= allOutputSompi {
+				fmt.Printf("Fee:\t\t%d %s Sompi\n", allInputSompi-allOutputSompi, inputAssetType)
+			} else {
+				fmt.Printf("Warning: the outputs exceed the inputs by %d %s Sompi\n",
+					allOutputSompi-allInputSompi, inputAssetType)
+			}
+		}
+
+		if isFullySigned {
+			fmt.Printf("Status:\t\tSigned and ready to finalize\n\n")
+		} else {
+			fmt.Printf("Status:\t\tMissing signatures\n\n")
+		}
+	}
+
+	return nil
+}
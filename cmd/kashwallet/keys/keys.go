@@ -25,6 +25,9 @@ var (
 	defaultAppDir = util.AppDir("kashwallet", false)
 )
 
+// ErrWatchOnly is returned when an operation that requires the private keys is attempted on a watch-only wallet
+var ErrWatchOnly = errors.New("this is a watch-only wallet, which holds no private keys and cannot sign transactions")
+
 // LastVersion is the most up to date file format version
 const LastVersion = 1
 
@@ -205,6 +208,12 @@ func (d *File) LastUsedInternalIndex() uint32 {
 	return d.lastUsedInternalIndex
 }
 
+// IsWatchOnly returns whether the wallet holds no private keys, and only
+// watches the addresses derived from its extended public keys
+func (d *File) IsWatchOnly() bool {
+	return len(d.EncryptedMnemonics) == 0
+}
+
 // DecryptMnemonics asks the user to enter the password for the private keys and
 // returns the decrypted private keys.
 func (d *File) DecryptMnemonics(password string) ([]string, error) {
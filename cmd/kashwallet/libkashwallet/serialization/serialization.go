@@ -1,6 +1,8 @@
 package serialization
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"math"
 
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet/serialization/protoserialization"
@@ -10,6 +12,14 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// PartiallySignedTransactionVersion is the version of the container format
+// SerializePartiallySignedTransaction serializes partially signed transactions with
+const PartiallySignedTransactionVersion = 1
+
+// partiallySignedTransactionMagic prefixes every serialized container. It tells containers apart from
+// partially signed transactions that were serialized without one, which never begin with these bytes.
+var partiallySignedTransactionMagic = []byte("kpst")
+
 // PartiallySignedTransaction is a type that is intended
 // to be transferred between multiple parties so each
 // party will be able to sign the transaction before
@@ -23,6 +33,7 @@ type PartiallySignedTransaction struct {
 // only by some of the relevant parties.
 type PartiallySignedInput struct {
 	PrevOutput           *externalapi.DomainTransactionOutput
+	PrevOutputAssetType  externalapi.AssetType
 	MinimumSignatures    uint32
 	PubKeySignaturePairs []*PubKeySignaturePair
 	DerivationPath       string
@@ -50,6 +61,7 @@ func (pst *PartiallySignedTransaction) Clone() *PartiallySignedTransaction {
 func (psi PartiallySignedInput) Clone() *PartiallySignedInput {
 	clone := &PartiallySignedInput{
 		PrevOutput:           psi.PrevOutput.Clone(),
+		PrevOutputAssetType:  psi.PrevOutputAssetType,
 		MinimumSignatures:    psi.MinimumSignatures,
 		PubKeySignaturePairs: make([]*PubKeySignaturePair, len(psi.PubKeySignaturePairs)),
 		DerivationPath:       psi.DerivationPath,
@@ -73,9 +85,29 @@ func (psp PubKeySignaturePair) Clone() *PubKeySignaturePair {
 }
 
 // DeserializePartiallySignedTransaction deserializes a byte slice into PartiallySignedTransaction.
+// It verifies the version and the checksum of the container, and also accepts partially signed
+// transactions that were serialized without a container.
 func DeserializePartiallySignedTransaction(serializedPartiallySignedTransaction []byte) (*PartiallySignedTransaction, error) {
+	if !bytes.HasPrefix(serializedPartiallySignedTransaction, partiallySignedTransactionMagic) {
+		return deserializeUncontainedPartiallySignedTransaction(serializedPartiallySignedTransaction)
+	}
+
+	protoContainer := &protoserialization.PartiallySignedTransactionContainer{}
+	err := proto.Unmarshal(serializedPartiallySignedTransaction[len(partiallySignedTransactionMagic):], protoContainer)
+	if err != nil {
+		return nil, err
+	}
+	if protoContainer.Version != PartiallySignedTransactionVersion {
+		return nil, errors.Errorf("partially signed transaction version %d is not supported, the supported "+
+			"version is %d", protoContainer.Version, PartiallySignedTransactionVersion)
+	}
+	checksum := sha256.Sum256(protoContainer.PartiallySignedTransaction)
+	if !bytes.Equal(checksum[:], protoContainer.Checksum) {
+		return nil, errors.New("the checksum of the partially signed transaction does not match its content")
+	}
+
 	protoPartiallySignedTransaction := &protoserialization.PartiallySignedTransaction{}
-	err := proto.Unmarshal(serializedPartiallySignedTransaction, protoPartiallySignedTransaction)
+	err = proto.Unmarshal(protoContainer.PartiallySignedTransaction, protoPartiallySignedTransaction)
 	if err != nil {
 		return nil, err
 	}
@@ -83,9 +115,46 @@ func DeserializePartiallySignedTransaction(serializedPartiallySignedTransaction
 	return partiallySignedTransactionFromProto(protoPartiallySignedTransaction)
 }
 
-// SerializePartiallySignedTransaction serializes a PartiallySignedTransaction.
+// deserializeUncontainedPartiallySignedTransaction deserializes a partially signed transaction that was
+// serialized without a container. Such transactions do not record the asset type of the outputs their
+// inputs spend, so it is inferred from the transaction type.
+func deserializeUncontainedPartiallySignedTransaction(serializedPartiallySignedTransaction []byte) (
+	*PartiallySignedTransaction, error) {
+
+	protoPartiallySignedTransaction := &protoserialization.PartiallySignedTransaction{}
+	err := proto.Unmarshal(serializedPartiallySignedTransaction, protoPartiallySignedTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	partiallySignedTransaction, err := partiallySignedTransactionFromProto(protoPartiallySignedTransaction)
+	if err != nil {
+		return nil, err
+	}
+	for _, input := range partiallySignedTransaction.PartiallySignedInputs {
+		input.PrevOutputAssetType = partiallySignedTransaction.Tx.InputUTXOAssetType()
+	}
+	return partiallySignedTransaction, nil
+}
+
+// SerializePartiallySignedTransaction serializes a PartiallySignedTransaction into a versioned container.
 func SerializePartiallySignedTransaction(partiallySignedTransaction *PartiallySignedTransaction) ([]byte, error) {
-	return proto.Marshal(partiallySignedTransactionToProto(partiallySignedTransaction))
+	serializedPartiallySignedTransaction, err := proto.Marshal(partiallySignedTransactionToProto(partiallySignedTransaction))
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(serializedPartiallySignedTransaction)
+	serializedContainer, err := proto.Marshal(&protoserialization.PartiallySignedTransactionContainer{
+		Version:                    PartiallySignedTransactionVersion,
+		PartiallySignedTransaction: serializedPartiallySignedTransaction,
+		Checksum:                   checksum[:],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, partiallySignedTransactionMagic...), serializedContainer...), nil
 }
 
 // DeserializeDomainTransaction Deserialize a Transaction to an *externalapi.DomainTransaction
@@ -110,6 +179,11 @@ func partiallySignedTransactionFromProto(protoPartiallySignedTransaction *protos
 		return nil, err
 	}
 
+	if len(protoPartiallySignedTransaction.PartiallySignedInputs) != len(tx.Inputs) {
+		return nil, errors.Errorf("the partially signed transaction has %d partially signed inputs, but its "+
+			"transaction has %d inputs", len(protoPartiallySignedTransaction.PartiallySignedInputs), len(tx.Inputs))
+	}
+
 	inputs := make([]*PartiallySignedInput, len(protoPartiallySignedTransaction.PartiallySignedInputs))
 	for i, protoInput := range protoPartiallySignedTransaction.PartiallySignedInputs {
 		inputs[i], err = partiallySignedInputFromProto(protoInput)
@@ -149,6 +223,7 @@ func partiallySignedInputFromProto(protoPartiallySignedInput *protoserialization
 
 	return &PartiallySignedInput{
 		PrevOutput:           output,
+		PrevOutputAssetType:  externalapi.AssetTypeFromUint32(protoPartiallySignedInput.PrevOutputAssetType),
 		MinimumSignatures:    protoPartiallySignedInput.MinimumSignatures,
 		PubKeySignaturePairs: pubKeySignaturePairs,
 		DerivationPath:       protoPartiallySignedInput.DerivationPath,
@@ -163,6 +238,7 @@ func partiallySignedInputToProto(partiallySignedInput *PartiallySignedInput) *pr
 
 	return &protoserialization.PartiallySignedInput{
 		PrevOutput:           transactionOutputToProto(partiallySignedInput.PrevOutput),
+		PrevOutputAssetType:  partiallySignedInput.PrevOutputAssetType.ToUint32(),
 		MinimumSignatures:    partiallySignedInput.MinimumSignatures,
 		PubKeySignaturePairs: protoPairs,
 		DerivationPath:       partiallySignedInput.DerivationPath,
@@ -219,6 +295,7 @@ func transactionFromProto(protoTransaction *protoserialization.TransactionMessag
 		SubnetworkID: *subnetworkID,
 		Gas:          protoTransaction.Gas,
 		Payload:      protoTransaction.Payload,
+		Type:         externalapi.DomainTransactionType(protoTransaction.Type),
 	}, nil
 }
 
@@ -241,6 +318,7 @@ func transactionToProto(tx *externalapi.DomainTransaction) *protoserialization.T
 		SubnetworkId: &protoserialization.SubnetworkId{Bytes: tx.SubnetworkID[:]},
 		Gas:          tx.Gas,
 		Payload:      tx.Payload,
+		Type:         uint32(tx.Type),
 	}
 }
 
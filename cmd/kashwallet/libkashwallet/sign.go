@@ -8,6 +8,7 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/utxo"
 	"github.com/Kash-Protocol/kashd/domain/dagconfig"
+	"github.com/kaspanet/go-secp256k1"
 	"github.com/pkg/errors"
 )
 
@@ -49,17 +50,7 @@ func sign(params *dagconfig.Params, mnemonic string, partiallySignedTransaction
 	}
 
 	sighashReusedValues := &consensushashing.SighashReusedValues{}
-	for i, partiallySignedInput := range partiallySignedTransaction.PartiallySignedInputs {
-		prevOut := partiallySignedInput.PrevOutput
-		partiallySignedTransaction.Tx.Inputs[i].UTXOEntry = utxo.NewUTXOEntry(
-			prevOut.Value,
-			partiallySignedTransaction.Tx.InputUTXOAssetType(),
-			prevOut.ScriptPublicKey,
-			false, // This is a fake value, because it's irrelevant for the signature
-			0,     // This is a fake value, because it's irrelevant for the signature
-		)
-		partiallySignedTransaction.Tx.Inputs[i].SigOpCount = byte(len(partiallySignedInput.PubKeySignaturePairs))
-	}
+	setInputsSigningData(partiallySignedTransaction)
 
 	signed := false
 	for i, partiallySignedInput := range partiallySignedTransaction.PartiallySignedInputs {
@@ -98,3 +89,77 @@ func sign(params *dagconfig.Params, mnemonic string, partiallySignedTransaction
 
 	return nil
 }
+
+// verifySignature verifies the signature of the given pair over input idx of tx, whose signing data must
+// already be set
+func verifySignature(tx *externalapi.DomainTransaction, idx int, pair *serialization.PubKeySignaturePair,
+	sighashReusedValues *consensushashing.SighashReusedValues, ecdsa bool) error {
+
+	if len(pair.Signature) == 0 {
+		return errors.Errorf("the signature of input %d is empty", idx)
+	}
+	hashType := consensushashing.SigHashType(pair.Signature[len(pair.Signature)-1])
+	signatureBytes := pair.Signature[:len(pair.Signature)-1]
+	if !hashType.IsStandardSigHashType() {
+		return errors.Errorf("the signature of input %d has an invalid hash type 0x%x", idx, hashType)
+	}
+
+	extendedKey, err := bip32.DeserializeExtendedKey(pair.ExtendedPublicKey)
+	if err != nil {
+		return err
+	}
+	publicKey, err := extendedKey.PublicKey()
+	if err != nil {
+		return err
+	}
+
+	var isValid bool
+	if ecdsa {
+		sigHash, err := consensushashing.CalculateSignatureHashECDSA(tx, idx, hashType, sighashReusedValues)
+		if err != nil {
+			return err
+		}
+		signature, err := secp256k1.DeserializeECDSASignatureFromSlice(signatureBytes)
+		if err != nil {
+			return errors.Wrapf(err, "the signature of input %d is malformed", idx)
+		}
+		secpHash := secp256k1.Hash(*sigHash.ByteArray())
+		isValid = publicKey.ECDSAVerify(&secpHash, signature)
+	} else {
+		schnorrPublicKey, err := publicKey.ToSchnorr()
+		if err != nil {
+			return err
+		}
+		sigHash, err := consensushashing.CalculateSignatureHashSchnorr(tx, idx, hashType, sighashReusedValues)
+		if err != nil {
+			return err
+		}
+		signature, err := secp256k1.DeserializeSchnorrSignatureFromSlice(signatureBytes)
+		if err != nil {
+			return errors.Wrapf(err, "the signature of input %d is malformed", idx)
+		}
+		secpHash := secp256k1.Hash(*sigHash.ByteArray())
+		isValid = schnorrPublicKey.SchnorrVerify(&secpHash, signature)
+	}
+
+	if !isValid {
+		return errors.Errorf("the signature of input %d by public key %s is invalid", idx, pair.ExtendedPublicKey)
+	}
+	return nil
+}
+
+// setInputsSigningData sets the UTXO entries and the signature operation counts of the inputs of the
+// transaction, which are both covered by the signatures
+func setInputsSigningData(partiallySignedTransaction *serialization.PartiallySignedTransaction) {
+	for i, partiallySignedInput := range partiallySignedTransaction.PartiallySignedInputs {
+		prevOut := partiallySignedInput.PrevOutput
+		partiallySignedTransaction.Tx.Inputs[i].UTXOEntry = utxo.NewUTXOEntry(
+			prevOut.Value,
+			partiallySignedInput.PrevOutputAssetType,
+			prevOut.ScriptPublicKey,
+			false, // This is a fake value, because it's irrelevant for the signature
+			0,     // This is a fake value, because it's irrelevant for the signature
+		)
+		partiallySignedTransaction.Tx.Inputs[i].SigOpCount = byte(len(partiallySignedInput.PubKeySignaturePairs))
+	}
+}
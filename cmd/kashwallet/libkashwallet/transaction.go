@@ -4,6 +4,7 @@ import (
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet/bip32"
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet/serialization"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/subnetworks"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
@@ -129,6 +130,7 @@ func createUnsignedTransaction(
 				Value:           utxo.UTXOEntry.Amount(),
 				ScriptPublicKey: utxo.UTXOEntry.ScriptPublicKey(),
 			},
+			PrevOutputAssetType:  utxo.UTXOEntry.AssetType(),
 			MinimumSignatures:    minimumSignatures,
 			PubKeySignaturePairs: emptyPubKeySignaturePairs,
 			DerivationPath:       utxo.DerivationPath,
@@ -255,6 +257,120 @@ func ExtractTransactionDeserialized(partiallySignedTransaction *serialization.Pa
 	return partiallySignedTransaction.Tx, nil
 }
 
+// CombinePartiallySignedTransactions merges the signatures of several copies of the same partially signed
+// transaction, each signed separately by some of the cosigners, into a single partially signed transaction.
+// Every signature is verified against the input it signs before it's merged.
+func CombinePartiallySignedTransactions(serializedPSTxs [][]byte, ecdsa bool) ([]byte, error) {
+	if len(serializedPSTxs) == 0 {
+		return nil, errors.New("no partially signed transactions to combine")
+	}
+
+	combined, err := serialization.DeserializePartiallySignedTransaction(serializedPSTxs[0])
+	if err != nil {
+		return nil, err
+	}
+	setInputsSigningData(combined)
+
+	sighashReusedValues := &consensushashing.SighashReusedValues{}
+	for i, input := range combined.PartiallySignedInputs {
+		for _, pair := range input.PubKeySignaturePairs {
+			if pair.Signature == nil {
+				continue
+			}
+			err = verifySignature(combined.Tx, i, pair, sighashReusedValues, ecdsa)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, serializedPSTx := range serializedPSTxs[1:] {
+		partiallySignedTransaction, err := serialization.DeserializePartiallySignedTransaction(serializedPSTx)
+		if err != nil {
+			return nil, err
+		}
+		setInputsSigningData(partiallySignedTransaction)
+
+		err = combinePartiallySignedTransaction(combined, partiallySignedTransaction, sighashReusedValues, ecdsa)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return serialization.SerializePartiallySignedTransaction(combined)
+}
+
+func combinePartiallySignedTransaction(combined *serialization.PartiallySignedTransaction,
+	partiallySignedTransaction *serialization.PartiallySignedTransaction,
+	sighashReusedValues *consensushashing.SighashReusedValues, ecdsa bool) error {
+
+	combinedTxID := consensushashing.TransactionID(combined.Tx)
+	txID := consensushashing.TransactionID(partiallySignedTransaction.Tx)
+	if !combinedTxID.Equal(txID) {
+		return errors.Errorf("cannot combine transaction %s with a different transaction %s", combinedTxID, txID)
+	}
+
+	for i, input := range combined.PartiallySignedInputs {
+		otherInput := partiallySignedTransaction.PartiallySignedInputs[i]
+		if !input.PrevOutput.Equal(otherInput.PrevOutput) ||
+			input.PrevOutputAssetType != otherInput.PrevOutputAssetType ||
+			input.MinimumSignatures != otherInput.MinimumSignatures ||
+			input.DerivationPath != otherInput.DerivationPath ||
+			len(input.PubKeySignaturePairs) != len(otherInput.PubKeySignaturePairs) {
+
+			return errors.Errorf("input %d of transaction %s differs between the combined copies", i, txID)
+		}
+
+		for j, pair := range input.PubKeySignaturePairs {
+			otherPair := otherInput.PubKeySignaturePairs[j]
+			if pair.ExtendedPublicKey != otherPair.ExtendedPublicKey {
+				return errors.Errorf("the public keys of input %d of transaction %s differ between "+
+					"the combined copies", i, txID)
+			}
+			if pair.Signature == nil && otherPair.Signature != nil {
+				err := verifySignature(combined.Tx, i, otherPair, sighashReusedValues, ecdsa)
+				if err != nil {
+					return err
+				}
+				pair.Signature = otherPair.Signature
+			}
+		}
+	}
+
+	return nil
+}
+
+// FinalizeTransaction extracts a domain transaction from a fully signed partially signed transaction, and
+// verifies the signature script of each of its inputs against the output it spends, so that it can be
+// broadcast as is.
+func FinalizeTransaction(serializedPSTx []byte, ecdsa bool) (*externalapi.DomainTransaction, error) {
+	partiallySignedTransaction, err := serialization.DeserializePartiallySignedTransaction(serializedPSTx)
+	if err != nil {
+		return nil, err
+	}
+	setInputsSigningData(partiallySignedTransaction)
+
+	tx, err := ExtractTransactionDeserialized(partiallySignedTransaction, ecdsa)
+	if err != nil {
+		return nil, err
+	}
+
+	sighashReusedValues := &consensushashing.SighashReusedValues{}
+	for i, input := range tx.Inputs {
+		vm, err := txscript.NewEngine(input.UTXOEntry.ScriptPublicKey(), tx, i, txscript.ScriptNoFlags,
+			nil, nil, sighashReusedValues)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the script of input %d", i)
+		}
+		err = vm.Execute()
+		if err != nil {
+			return nil, errors.Wrapf(err, "the signatures of input %d are invalid", i)
+		}
+	}
+
+	return tx, nil
+}
+
 func partiallySignedInputMultisigRedeemScript(input *serialization.PartiallySignedInput, ecdsa bool) ([]byte, error) {
 	extendedPublicKeys := make([]string, len(input.PubKeySignaturePairs))
 	for i, pair := range input.PubKeySignaturePairs {
@@ -7,12 +7,14 @@ import (
 	"testing"
 
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet/serialization"
 	"github.com/Kash-Protocol/kashd/domain/consensus"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/testutils"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/utxo"
+	"github.com/Kash-Protocol/kashd/domain/dagconfig"
 	"github.com/Kash-Protocol/kashd/util"
 )
 
@@ -516,3 +518,137 @@ func TestMaxSompi(t *testing.T) {
 		}
 	})
 }
+
+func TestCombineAndFinalize(t *testing.T) {
+	params := &dagconfig.SimnetParams
+	forSchnorrAndECDSA(t, func(t *testing.T, ecdsa bool) {
+		const numKeys = 3
+		mnemonics := make([]string, numKeys)
+		publicKeys := make([]string, numKeys)
+		for i := 0; i < numKeys; i++ {
+			var err error
+			mnemonics[i], err = libkashwallet.CreateMnemonic()
+			if err != nil {
+				t.Fatalf("CreateMnemonic: %+v", err)
+			}
+
+			publicKeys[i], err = libkashwallet.MasterPublicKeyFromMnemonic(params, mnemonics[i], true)
+			if err != nil {
+				t.Fatalf("MasterPublicKeyFromMnemonic: %+v", err)
+			}
+		}
+
+		const minimumSignatures = 2
+		path := "m/1/2/3"
+		address, err := libkashwallet.Address(params, publicKeys, minimumSignatures, path, ecdsa)
+		if err != nil {
+			t.Fatalf("Address: %+v", err)
+		}
+
+		scriptPublicKey, err := txscript.PayToAddrScript(address)
+		if err != nil {
+			t.Fatalf("PayToAddrScript: %+v", err)
+		}
+
+		selectedUTXOs := []*libkashwallet.UTXO{
+			{
+				Outpoint:       &externalapi.DomainOutpoint{Index: 0},
+				UTXOEntry:      utxo.NewUTXOEntry(100, externalapi.KSH, scriptPublicKey, false, 0),
+				DerivationPath: path,
+			},
+		}
+
+		unsignedTransaction, err := libkashwallet.CreateUnsignedTransaction(publicKeys, minimumSignatures,
+			[]*libkashwallet.Payment{{
+				Address: address,
+				Amount:  10,
+			}}, selectedUTXOs, externalapi.TransferKSH)
+		if err != nil {
+			t.Fatalf("CreateUnsignedTransactions: %+v", err)
+		}
+
+		// The first and the last cosigners sign separate copies of the transaction
+		signedByFirst, err := libkashwallet.Sign(params, mnemonics[:1], unsignedTransaction, ecdsa)
+		if err != nil {
+			t.Fatalf("Sign: %+v", err)
+		}
+		signedByLast, err := libkashwallet.Sign(params, mnemonics[2:], unsignedTransaction, ecdsa)
+		if err != nil {
+			t.Fatalf("Sign: %+v", err)
+		}
+
+		_, err = libkashwallet.FinalizeTransaction(signedByFirst, ecdsa)
+		if err == nil {
+			t.Fatalf("Expected a transaction that is missing signatures to fail to finalize")
+		}
+
+		combined, err := libkashwallet.CombinePartiallySignedTransactions(
+			[][]byte{unsignedTransaction, signedByLast, signedByFirst}, ecdsa)
+		if err != nil {
+			t.Fatalf("CombinePartiallySignedTransactions: %+v", err)
+		}
+
+		isFullySigned, err := libkashwallet.IsTransactionFullySigned(combined)
+		if err != nil {
+			t.Fatalf("IsTransactionFullySigned: %+v", err)
+		}
+		if !isFullySigned {
+			t.Fatalf("Expected the combined transaction to be fully signed")
+		}
+
+		_, err = libkashwallet.FinalizeTransaction(combined, ecdsa)
+		if err != nil {
+			t.Fatalf("FinalizeTransaction: %+v", err)
+		}
+
+		otherTransaction, err := libkashwallet.CreateUnsignedTransaction(publicKeys, minimumSignatures,
+			[]*libkashwallet.Payment{{
+				Address: address,
+				Amount:  20,
+			}}, selectedUTXOs, externalapi.TransferKSH)
+		if err != nil {
+			t.Fatalf("CreateUnsignedTransactions: %+v", err)
+		}
+		_, err = libkashwallet.CombinePartiallySignedTransactions([][]byte{signedByFirst, otherTransaction}, ecdsa)
+		if err == nil {
+			t.Fatalf("Expected combining different transactions to fail")
+		}
+
+		// A copy signed for a different transaction is made to look like a copy of this one, which
+		// must not get its signature merged
+		signedOtherByLast, err := libkashwallet.Sign(params, mnemonics[2:], otherTransaction, ecdsa)
+		if err != nil {
+			t.Fatalf("Sign: %+v", err)
+		}
+		forged, err := serialization.DeserializePartiallySignedTransaction(signedByFirst)
+		if err != nil {
+			t.Fatalf("DeserializePartiallySignedTransaction: %+v", err)
+		}
+		signedOther, err := serialization.DeserializePartiallySignedTransaction(signedOtherByLast)
+		if err != nil {
+			t.Fatalf("DeserializePartiallySignedTransaction: %+v", err)
+		}
+		for i, input := range forged.PartiallySignedInputs {
+			for j, pair := range input.PubKeySignaturePairs {
+				if pair.Signature == nil {
+					pair.Signature = signedOther.PartiallySignedInputs[i].PubKeySignaturePairs[j].Signature
+				}
+			}
+		}
+		forgedTransaction, err := serialization.SerializePartiallySignedTransaction(forged)
+		if err != nil {
+			t.Fatalf("SerializePartiallySignedTransaction: %+v", err)
+		}
+		_, err = libkashwallet.CombinePartiallySignedTransactions([][]byte{unsignedTransaction, forgedTransaction}, ecdsa)
+		if err == nil {
+			t.Fatalf("Expected combining a copy with an invalid signature to fail")
+		}
+
+		corrupted := append([]byte{}, combined...)
+		corrupted[len(corrupted)/2] ^= 0xff
+		_, err = serialization.DeserializePartiallySignedTransaction(corrupted)
+		if err == nil {
+			t.Fatalf("Expected a corrupted transaction to fail to deserialize")
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package main
 
-import "github.com/pkg/errors"
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
 
 func main() {
 	subCmd, config := parseCommandLine()
@@ -17,6 +20,12 @@ func main() {
 		err = createUnsignedTransaction(config.(*createUnsignedTransactionConfig))
 	case signSubCmd:
 		err = sign(config.(*signConfig))
+	case combineSubCmd:
+		err = combine(config.(*combineConfig))
+	case inspectSubCmd:
+		err = inspect(config.(*inspectConfig))
+	case finalizeSubCmd:
+		err = finalize(config.(*finalizeConfig))
 	case broadcastSubCmd:
 		err = broadcast(config.(*broadcastConfig))
 	case parseSubCmd:
@@ -31,6 +40,16 @@ func main() {
 		err = startDaemon(config.(*startDaemonConfig))
 	case sweepSubCmd:
 		err = sweep(config.(*sweepConfig))
+	case bumpFeeSubCmd:
+		err = bumpFee(config.(*bumpFeeConfig))
+	case mintKUSDSubCmd:
+		err = convert(config.(*conversionConfig), externalapi.MintKUSD)
+	case stakeKSHSubCmd:
+		err = convert(config.(*conversionConfig), externalapi.StakeKSH)
+	case redeemKSHSubCmd:
+		err = convert(config.(*conversionConfig), externalapi.RedeemKSH)
+	case historySubCmd:
+		err = history(config.(*historyConfig))
 	default:
 		err = errors.Errorf("Unknown sub-command '%s'\n", subCmd)
 	}
@@ -65,10 +65,13 @@ func parse(conf *parseConfig) error {
 				return err
 			}
 
-			addressString := scriptPublicKeyAddress.EncodeAddress()
+			// Non-standard script public keys have no address
+			var addressString string
 			if scriptPublicKeyType == txscript.NonStandardTy {
 				scriptPublicKeyHex := hex.EncodeToString(output.ScriptPublicKey.Script)
 				addressString = fmt.Sprintf("<Non-standard transaction script public key: %s>", scriptPublicKeyHex)
+			} else {
+				addressString = scriptPublicKeyAddress.EncodeAddress()
 			}
 
 			fmt.Printf("Output %d: \tRecipient: %s \tAmount: %.2f Kash\n",
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/pb"
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/utils"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/pkg/errors"
+)
+
+// payoutJSON is a single payout in a JSON payouts file
+type payoutJSON struct {
+	Address string  `json:"address"`
+	Amount  float64 `json:"amount"`
+}
+
+// readPayoutsFile reads the payouts in the given file, where every payout has an address and an
+// amount in Kash. Files with a .json extension hold an array of {"address": ..., "amount": ...}
+// objects, and any other file is read as CSV with an "address,amount" record per payout and an
+// optional header.
+func readPayoutsFile(path string) ([]*pb.Payout, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var payouts []*pb.Payout
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		payouts, err = parsePayoutsJSON(file)
+	} else {
+		payouts, err = parsePayoutsCSV(file)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading payouts file %s", path)
+	}
+	if len(payouts) == 0 {
+		return nil, errors.Errorf("payouts file %s has no payouts", path)
+	}
+	return payouts, nil
+}
+
+func parsePayoutsJSON(reader io.Reader) ([]*pb.Payout, error) {
+	var payoutsJSON []payoutJSON
+	err := json.NewDecoder(reader).Decode(&payoutsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	payouts := make([]*pb.Payout, len(payoutsJSON))
+	for i, payout := range payoutsJSON {
+		payouts[i], err = newPayout(payout.Address, payout.Amount)
+		if err != nil {
+			return nil, errors.Wrapf(err, "payout #%d", i+1)
+		}
+	}
+	return payouts, nil
+}
+
+func parsePayoutsCSV(reader io.Reader) ([]*pb.Payout, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = 2
+	csvReader.TrimLeadingSpace = true
+	csvReader.Comment = '#'
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) > 0 && strings.EqualFold(records[0][0], "address") {
+		records = records[1:]
+	}
+
+	payouts := make([]*pb.Payout, len(records))
+	for i, record := range records {
+		amount, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "payout #%d has an invalid amount", i+1)
+		}
+		payouts[i], err = newPayout(record[0], amount)
+		if err != nil {
+			return nil, errors.Wrapf(err, "payout #%d", i+1)
+		}
+	}
+	return payouts, nil
+}
+
+func newPayout(address string, amount float64) (*pb.Payout, error) {
+	if address == "" {
+		return nil, errors.New("address is missing")
+	}
+	if amount <= 0 {
+		return nil, errors.Errorf("amount of %s must be positive", address)
+	}
+	return &pb.Payout{
+		Address: address,
+		Amount:  uint64(amount * constants.SompiPerKash),
+	}, nil
+}
+
+// printPayoutResults prints every payout to writer along with the transaction paying it, as
+// described by describeTransaction
+func printPayoutResults(writer io.Writer, payoutResults []*pb.PayoutResult,
+	describeTransaction func(transactionIndex uint32) string) {
+
+	fmt.Fprintln(writer, "Payouts:")
+	for _, payoutResult := range payoutResults {
+		fmt.Fprintf(writer, "\t%s\t%s\t%s\n", payoutResult.Address, utils.FormatKas(payoutResult.Amount),
+			describeTransaction(payoutResult.TransactionIndex))
+	}
+}
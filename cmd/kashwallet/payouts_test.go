@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePayouts(t *testing.T) {
+	const csvPayouts = `address,amount
+kashtest:qz1, 1.5
+# a comment
+kashtest:qz2,0.00000001
+`
+	const jsonPayouts = `[{"address": "kashtest:qz1", "amount": 1.5}, {"address": "kashtest:qz2", "amount": 0.00000001}]`
+
+	for name, payoutsString := range map[string]string{"csv": csvPayouts, "json": jsonPayouts} {
+		parse := parsePayoutsCSV
+		if name == "json" {
+			parse = parsePayoutsJSON
+		}
+		payouts, err := parse(strings.NewReader(payoutsString))
+		if err != nil {
+			t.Fatalf("%s: %+v", name, err)
+		}
+		if len(payouts) != 2 {
+			t.Fatalf("%s: expected 2 payouts but got %d", name, len(payouts))
+		}
+		if payouts[0].Address != "kashtest:qz1" || payouts[0].Amount != 150_000_000 {
+			t.Errorf("%s: unexpected first payout %s", name, payouts[0])
+		}
+		if payouts[1].Address != "kashtest:qz2" || payouts[1].Amount != 1 {
+			t.Errorf("%s: unexpected second payout %s", name, payouts[1])
+		}
+	}
+
+	for name, invalidPayouts := range map[string]string{
+		"missing amount":  "kashtest:qz1\n",
+		"invalid amount":  "kashtest:qz1,abc\n",
+		"negative amount": "kashtest:qz1,-1\n",
+		"missing address": ",1\n",
+	} {
+		_, err := parsePayoutsCSV(strings.NewReader(invalidPayouts))
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/keys"
 	"github.com/Kash-Protocol/kashd/cmd/kashwallet/libkashwallet"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/dagconfig"
 	"github.com/pkg/errors"
 )
 
@@ -21,6 +22,10 @@ func send(conf *sendConfig) error {
 		return err
 	}
 
+	if keysFile.IsWatchOnly() {
+		return keys.ErrWatchOnly
+	}
+
 	if len(keysFile.ExtendedPublicKeys) > len(keysFile.EncryptedMnemonics) {
 		return errors.Errorf("Cannot use 'send' command for multisig wallet without all of the keys")
 	}
@@ -39,6 +44,14 @@ func send(conf *sendConfig) error {
 		sendAmountSompi = uint64(conf.SendAmount * constants.SompiPerKash)
 	}
 
+	var payouts []*pb.Payout
+	if conf.PayoutsFile != "" {
+		payouts, err = readPayoutsFile(conf.PayoutsFile)
+		if err != nil {
+			return err
+		}
+	}
+
 	createUnsignedTransactionsResponse, err :=
 		daemonClient.CreateUnsignedTransactions(ctx, &pb.CreateUnsignedTransactionsRequest{
 			From:                     conf.FromAddresses,
@@ -47,28 +60,60 @@ func send(conf *sendConfig) error {
 			Amount:                   sendAmountSompi,
 			IsSendAll:                conf.IsSendAll,
 			UseExistingChangeAddress: conf.UseExistingChangeAddress,
+			TransactionType:          requestedTransactionType(conf.SendAssetType, conf.ReceiveAssetType),
+			ReceiveAmount:            uint64(conf.ReceiveAmount * constants.SompiPerKash),
+			Payouts:                  payouts,
+			FeeRate:                  conf.FeeRate,
+			FeePriority:              conf.Priority,
 		})
 	if err != nil {
 		return err
 	}
 
-	if len(conf.Password) == 0 {
-		conf.Password = keys.GetPassword("Password:")
+	txIDs, signedTransactions, err := signAndBroadcast(daemonClient, keysFile, conf.NetParams(), conf.Password,
+		createUnsignedTransactionsResponse.UnsignedTransactions)
+	if err != nil {
+		return err
+	}
+
+	if len(createUnsignedTransactionsResponse.PayoutResults) > 0 {
+		printPayoutResults(os.Stdout, createUnsignedTransactionsResponse.PayoutResults, func(transactionIndex uint32) string {
+			return txIDs[transactionIndex]
+		})
+	}
+
+	if conf.Verbose {
+		fmt.Println("Serialized Transaction(s) (can be parsed via the `parse` command or resent via `broadcast`): ")
+		for _, signedTx := range signedTransactions {
+			fmt.Printf("\t%x\n\n", signedTx)
+		}
 	}
-	mnemonics, err := keysFile.DecryptMnemonics(conf.Password)
+
+	return nil
+}
+
+// signAndBroadcast signs the given unsigned transactions with the keys of keysFile, asking for the wallet
+// password if it is not given, and broadcasts them through the wallet daemon
+func signAndBroadcast(daemonClient pb.KashwalletdClient, keysFile *keys.File, params *dagconfig.Params,
+	password string, unsignedTransactions [][]byte) (txIDs []string, signedTransactions [][]byte, err error) {
+
+	if len(password) == 0 {
+		password = keys.GetPassword("Password:")
+	}
+	mnemonics, err := keysFile.DecryptMnemonics(password)
 	if err != nil {
 		if strings.Contains(err.Error(), "message authentication failed") {
 			fmt.Fprintf(os.Stderr, "Password decryption failed. Sometimes this is a result of not "+
 				"specifying the same keys file used by the wallet daemon process.\n")
 		}
-		return err
+		return nil, nil, err
 	}
 
-	signedTransactions := make([][]byte, len(createUnsignedTransactionsResponse.UnsignedTransactions))
-	for i, unsignedTransaction := range createUnsignedTransactionsResponse.UnsignedTransactions {
-		signedTransaction, err := libkashwallet.Sign(conf.NetParams(), mnemonics, unsignedTransaction, keysFile.ECDSA)
+	signedTransactions = make([][]byte, len(unsignedTransactions))
+	for i, unsignedTransaction := range unsignedTransactions {
+		signedTransaction, err := libkashwallet.Sign(params, mnemonics, unsignedTransaction, keysFile.ECDSA)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 		signedTransactions[i] = signedTransaction
 	}
@@ -84,7 +129,7 @@ func send(conf *sendConfig) error {
 
 	response, err := daemonClient.Broadcast(broadcastCtx, &pb.BroadcastRequest{Transactions: signedTransactions})
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	fmt.Println("Transactions were sent successfully")
 	fmt.Println("Transaction ID(s): ")
@@ -92,12 +137,16 @@ func send(conf *sendConfig) error {
 		fmt.Printf("\t%s\n", txID)
 	}
 
-	if conf.Verbose {
-		fmt.Println("Serialized Transaction(s) (can be parsed via the `parse` command or resent via `broadcast`): ")
-		for _, signedTx := range signedTransactions {
-			fmt.Printf("\t%x\n\n", signedTx)
-		}
-	}
+	return response.TxIDs, signedTransactions, nil
+}
 
-	return nil
+// requestedTransactionType returns the transaction type to request from the wallet daemon.
+// It is left unset for transfers, which the daemon derives from the asset type.
+func requestedTransactionType(assetType string, receiveAssetType string) uint32 {
+	if receiveAssetType == "" {
+		return 0
+	}
+	txType, _ := externalapi.GetDomainTransactionTypeFromAssetTypes(externalapi.AssetTypeFromString(assetType),
+		externalapi.AssetTypeFromString(receiveAssetType))
+	return uint32(txType)
 }
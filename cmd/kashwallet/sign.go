@@ -24,6 +24,10 @@ func sign(conf *signConfig) error {
 		return err
 	}
 
+	if keysFile.IsWatchOnly() {
+		return keys.ErrWatchOnly
+	}
+
 	if len(conf.Password) == 0 {
 		conf.Password = keys.GetPassword("Password:")
 	}
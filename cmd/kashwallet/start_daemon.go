@@ -1,7 +1,17 @@
 package main
 
-import "github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/server"
+import (
+	"github.com/Kash-Protocol/kashd/cmd/kashwallet/daemon/server"
+	"github.com/Kash-Protocol/kashd/infrastructure/network/rpcclient/grpcclient"
+)
 
 func startDaemon(conf *startDaemonConfig) error {
-	return server.Start(conf.NetParams(), conf.Listen, conf.RPCServer, conf.KeysFile, conf.Profile, conf.Timeout)
+	rpcCredentials := &grpcclient.Credentials{
+		TLSCertFile: conf.RPCCert,
+		Token:       conf.RPCAuthToken,
+		User:        conf.RPCUser,
+		Password:    conf.RPCPassword,
+	}
+	return server.Start(conf.NetParams(), conf.Listen, conf.RPCServer, rpcCredentials, conf.KeysFile, conf.Profile,
+		conf.Timeout)
 }
@@ -2,7 +2,10 @@ package main
 
 import (
 	"encoding/hex"
+	"io/ioutil"
 	"strings"
+
+	"github.com/pkg/errors"
 )
 
 // hexTransactionsSeparator is used to mark the end of one transaction and the beginning of the next one.
@@ -31,3 +34,23 @@ func decodeTransactionsFromHex(transactionsHex string) ([][]byte, error) {
 
 	return transactions, nil
 }
+
+// readTransactions decodes the hex encoded transactions that are given either directly or in a file
+func readTransactions(transactionsHex string, transactionsFile string) ([][]byte, error) {
+	if transactionsHex == "" && transactionsFile == "" {
+		return nil, errors.Errorf("Either --transaction or --transaction-file is required")
+	}
+	if transactionsHex != "" && transactionsFile != "" {
+		return nil, errors.Errorf("Both --transaction and --transaction-file cannot be passed at the same time")
+	}
+
+	if transactionsFile != "" {
+		transactionsHexBytes, err := ioutil.ReadFile(transactionsFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not read hex from %s", transactionsFile)
+		}
+		transactionsHex = strings.TrimSpace(string(transactionsHexBytes))
+	}
+
+	return decodeTransactionsFromHex(transactionsHex)
+}
@@ -0,0 +1,145 @@
+package addressindex
+
+import (
+	"github.com/Kash-Protocol/kashd/domain"
+	"github.com/Kash-Protocol/kashd/domain/chainindex"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+)
+
+// AddressIndex maintains an index between scriptPublicKeys and
+// the accepted transactions that spent from or paid to them
+type AddressIndex struct {
+	store      *addressIndexStore
+	chainIndex *chainindex.ChainIndex
+}
+
+// New creates a new address index. The transactions accepted below the pruning
+// point remain in the index, even though their blocks may be pruned.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func New(domain domain.Domain, database database.Database, isArchivalNode bool) (*AddressIndex, error) {
+	store := newAddressIndexStore(database)
+	chainIndex, err := chainindex.New(domain, database, &addressIndexer{store: store}, "address index",
+		"address-index", isArchivalNode, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddressIndex{
+		store:      store,
+		chainIndex: chainIndex,
+	}, nil
+}
+
+// Reset catches the address index up with consensus after its state was replaced,
+// such as by syncing from a new pruning point. The index is not deleted.
+func (ai *AddressIndex) Reset() error {
+	return ai.chainIndex.CatchUp()
+}
+
+// Update updates the address index with the given DAG selected parent chain changes
+func (ai *AddressIndex) Update(virtualChangeSet *externalapi.VirtualChangeSet) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "AddressIndex.Update")
+	defer onEnd()
+
+	return ai.chainIndex.Update(virtualChangeSet)
+}
+
+// addressIndexer adds the transactions accepted by selected parent chain blocks to the address index
+type addressIndexer struct {
+	store *addressIndexStore
+}
+
+func (air *addressIndexer) AddAcceptingBlock(dataAccessor database.DataAccessor, blockHash *externalapi.DomainHash,
+	blueScore uint64, acceptanceData externalapi.AcceptanceData) ([]byte, error) {
+
+	addressTransactions := collectAddressTransactions(blockHash, blueScore, acceptanceData)
+	indexedKeys, err := air.store.addAddressTransactions(dataAccessor, addressTransactions)
+	if err != nil {
+		return nil, err
+	}
+	return serializeIndexedKeys(indexedKeys), nil
+}
+
+func (air *addressIndexer) RemoveAcceptingBlock(dataAccessor database.DataAccessor, rollbackData []byte) error {
+	indexedKeys, err := deserializeIndexedKeys(rollbackData)
+	if err != nil {
+		return err
+	}
+	return air.store.removeIndexedKeys(dataAccessor, indexedKeys)
+}
+
+// addressTransactionKey identifies the balance of a scriptPublicKey in one asset type
+type addressTransactionKey struct {
+	scriptPublicKey string
+	assetType       externalapi.AssetType
+}
+
+// collectAddressTransactions returns the changes that the transactions accepted by the given
+// block made to the balances of the scriptPublicKeys they spent from or paid to
+func collectAddressTransactions(acceptingBlockHash *externalapi.DomainHash, acceptingBlockBlueScore uint64,
+	acceptanceData externalapi.AcceptanceData) []*AddressTransaction {
+
+	var addressTransactions []*AddressTransaction
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if !transactionAcceptanceData.IsAccepted {
+				continue
+			}
+			transaction := transactionAcceptanceData.Transaction
+			transactionID := consensushashing.TransactionID(transaction)
+
+			// The keys are kept in the order they're first seen in, so that the
+			// address transactions are collected in a deterministic order
+			var keys []addressTransactionKey
+			amountDeltas := make(map[addressTransactionKey]int64)
+			addAmountDelta := func(scriptPublicKey *externalapi.ScriptPublicKey,
+				assetType externalapi.AssetType, amountDelta int64) {
+
+				key := addressTransactionKey{scriptPublicKey: scriptPublicKey.String(), assetType: assetType}
+				if _, ok := amountDeltas[key]; !ok {
+					keys = append(keys, key)
+				}
+				amountDeltas[key] += amountDelta
+			}
+
+			for _, spentUTXOEntry := range transactionAcceptanceData.TransactionInputUTXOEntries {
+				addAmountDelta(spentUTXOEntry.ScriptPublicKey(), spentUTXOEntry.AssetType(),
+					-int64(spentUTXOEntry.Amount()))
+			}
+			for _, output := range transaction.Outputs {
+				addAmountDelta(output.ScriptPublicKey, transaction.OutputUTXOAssetType(), int64(output.Value))
+			}
+
+			for _, key := range keys {
+				addressTransactions = append(addressTransactions, &AddressTransaction{
+					ScriptPublicKey:         externalapi.NewScriptPublicKeyFromString(key.scriptPublicKey),
+					TransactionID:           transactionID,
+					AcceptingBlockHash:      acceptingBlockHash,
+					AcceptingBlockBlueScore: acceptingBlockBlueScore,
+					AssetType:               key.assetType,
+					AmountDelta:             amountDeltas[key],
+				})
+			}
+		}
+	}
+
+	return addressTransactions
+}
+
+// AddressTransactions returns the transactions that spent from or paid to any of the given
+// scriptPublicKeys, in the order they were accepted in, starting after the given page key, or
+// from the first transaction if it's nil. At least `limit` transactions are returned if there
+// are enough of them, along with the page key to request the next page with, which is nil if
+// there are no more transactions.
+func (ai *AddressIndex) AddressTransactions(scriptPublicKeys []*externalapi.ScriptPublicKey,
+	startAfter *PageKey, limit uint64) (addressTransactions []*AddressTransaction, nextPageKey *PageKey, err error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "AddressIndex.AddressTransactions")
+	defer onEnd()
+
+	return ai.store.getAddressTransactions(scriptPublicKeys, startAfter, limit)
+}
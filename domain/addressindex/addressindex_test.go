@@ -0,0 +1,73 @@
+package addressindex
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/utxo"
+)
+
+func TestCollectAddressTransactions(t *testing.T) {
+	acceptingBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	senderScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1}, Version: 0}
+	recipientScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{2}, Version: 0}
+
+	// Mint KUSD with KSH, paying part of it back to the sender
+	mintTransaction := &externalapi.DomainTransaction{
+		Inputs: []*externalapi.DomainTransactionInput{{}},
+		Outputs: []*externalapi.DomainTransactionOutput{
+			{Value: 50, ScriptPublicKey: recipientScriptPublicKey},
+			{Value: 30, ScriptPublicKey: senderScriptPublicKey},
+		},
+		Type: externalapi.MintKUSD,
+	}
+	rejectedTransaction := &externalapi.DomainTransaction{
+		Outputs: []*externalapi.DomainTransactionOutput{{Value: 10, ScriptPublicKey: recipientScriptPublicKey}},
+	}
+	acceptanceData := externalapi.AcceptanceData{
+		{
+			BlockHash: acceptingBlockHash,
+			TransactionAcceptanceData: []*externalapi.TransactionAcceptanceData{
+				{
+					Transaction: mintTransaction,
+					IsAccepted:  true,
+					TransactionInputUTXOEntries: []externalapi.UTXOEntry{
+						utxo.NewUTXOEntry(100, externalapi.KSH, senderScriptPublicKey, false, 0),
+					},
+				},
+				{Transaction: rejectedTransaction, IsAccepted: false},
+			},
+		},
+	}
+
+	addressTransactions := collectAddressTransactions(acceptingBlockHash, 10, acceptanceData)
+
+	expected := []struct {
+		scriptPublicKey *externalapi.ScriptPublicKey
+		assetType       externalapi.AssetType
+		amountDelta     int64
+	}{
+		{senderScriptPublicKey, externalapi.KSH, -100},
+		{recipientScriptPublicKey, externalapi.KUSD, 50},
+		{senderScriptPublicKey, externalapi.KUSD, 30},
+	}
+	if len(addressTransactions) != len(expected) {
+		t.Fatalf("Expected %d address transactions, but got %d", len(expected), len(addressTransactions))
+	}
+	mintTransactionID := consensushashing.TransactionID(mintTransaction)
+	for i, addressTransaction := range addressTransactions {
+		if !addressTransaction.ScriptPublicKey.Equal(expected[i].scriptPublicKey) ||
+			addressTransaction.AssetType != expected[i].assetType ||
+			addressTransaction.AmountDelta != expected[i].amountDelta {
+
+			t.Fatalf("Address transaction %d: expected %+v, but got %+v", i, expected[i], addressTransaction)
+		}
+		if !addressTransaction.TransactionID.Equal(mintTransactionID) ||
+			!addressTransaction.AcceptingBlockHash.Equal(acceptingBlockHash) ||
+			addressTransaction.AcceptingBlockBlueScore != 10 {
+
+			t.Fatalf("Address transaction %d has unexpected acceptance data: %+v", i, addressTransaction)
+		}
+	}
+}
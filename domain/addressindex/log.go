@@ -0,0 +1,7 @@
+package addressindex
+
+import (
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("ADIN")
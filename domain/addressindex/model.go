@@ -0,0 +1,35 @@
+package addressindex
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+)
+
+// AddressTransaction is the change an accepted transaction made to the
+// balance of a scriptPublicKey in one asset type
+type AddressTransaction struct {
+	ScriptPublicKey         *externalapi.ScriptPublicKey
+	TransactionID           *externalapi.DomainTransactionID
+	AcceptingBlockHash      *externalapi.DomainHash
+	AcceptingBlockBlueScore uint64
+	AssetType               externalapi.AssetType
+
+	// AmountDelta is the amount the transaction's outputs paid to the scriptPublicKey minus the amount
+	// its inputs spent from it. It's zero if the transaction spent as much as it paid back.
+	AmountDelta int64
+}
+
+// PageKey is the position of an address transaction in the order AddressTransactions returns them in
+type PageKey struct {
+	AcceptingBlockBlueScore uint64
+	TransactionID           *externalapi.DomainTransactionID
+	AssetType               externalapi.AssetType
+}
+
+// PageKey returns the position of the address transaction in the order AddressTransactions returns them in
+func (at *AddressTransaction) PageKey() *PageKey {
+	return &PageKey{
+		AcceptingBlockBlueScore: at.AcceptingBlockBlueScore,
+		TransactionID:           at.TransactionID,
+		AssetType:               at.AssetType,
+	}
+}
@@ -0,0 +1,141 @@
+package addressindex
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+const (
+	blueScoreSize   = 8
+	assetTypeSize   = 4
+	amountDeltaSize = 8
+
+	// addressTransactionKeySize is the size of the key of an address transaction within the
+	// bucket of its scriptPublicKey
+	addressTransactionKeySize = blueScoreSize + externalapi.DomainHashSize + assetTypeSize
+)
+
+// serializePageKey serializes the accepting block blue score in big endian before the
+// transaction ID and the asset type, so that iterating over the transactions of a
+// scriptPublicKey goes in the order they were accepted in. The serialized page key of
+// an address transaction is its key within the bucket of its scriptPublicKey.
+func serializePageKey(pageKey *PageKey) []byte {
+	serializedKey := make([]byte, addressTransactionKeySize)
+	binary.BigEndian.PutUint64(serializedKey[:blueScoreSize], pageKey.AcceptingBlockBlueScore)
+	copy(serializedKey[blueScoreSize:], pageKey.TransactionID.ByteSlice())
+	binary.BigEndian.PutUint32(serializedKey[blueScoreSize+externalapi.DomainHashSize:], pageKey.AssetType.ToUint32())
+	return serializedKey
+}
+
+func serializeAddressTransactionValue(addressTransaction *AddressTransaction) []byte {
+	serializedValue := make([]byte, externalapi.DomainHashSize+amountDeltaSize)
+	copy(serializedValue[:externalapi.DomainHashSize], addressTransaction.AcceptingBlockHash.ByteSlice())
+	binary.LittleEndian.PutUint64(serializedValue[externalapi.DomainHashSize:], uint64(addressTransaction.AmountDelta))
+	return serializedValue
+}
+
+func deserializeAddressTransaction(scriptPublicKey *externalapi.ScriptPublicKey,
+	serializedKey []byte, serializedValue []byte) (*AddressTransaction, error) {
+
+	if len(serializedKey) != addressTransactionKeySize {
+		return nil, errors.Errorf("address transaction key has unexpected length %d", len(serializedKey))
+	}
+	if len(serializedValue) != externalapi.DomainHashSize+amountDeltaSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing an address transaction")
+	}
+
+	transactionID, err := externalapi.NewDomainTransactionIDFromByteSlice(
+		serializedKey[blueScoreSize : blueScoreSize+externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+	acceptingBlockHash, err := externalapi.NewDomainHashFromByteSlice(serializedValue[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddressTransaction{
+		ScriptPublicKey:         scriptPublicKey,
+		TransactionID:           transactionID,
+		AcceptingBlockHash:      acceptingBlockHash,
+		AcceptingBlockBlueScore: binary.BigEndian.Uint64(serializedKey[:blueScoreSize]),
+		AssetType: externalapi.AssetTypeFromUint32(
+			binary.BigEndian.Uint32(serializedKey[blueScoreSize+externalapi.DomainHashSize:])),
+		AmountDelta: int64(binary.LittleEndian.Uint64(serializedValue[externalapi.DomainHashSize:])),
+	}, nil
+}
+
+// serializeScriptPublicKey serializes a scriptPublicKey into the name of its bucket. The script
+// is prefixed by its length, so that no bucket is a prefix of the bucket of another scriptPublicKey.
+func serializeScriptPublicKey(scriptPublicKey *externalapi.ScriptPublicKey) []byte {
+	serializedScriptPublicKey := make([]byte, 2+4+len(scriptPublicKey.Script)) // uint16 + uint32
+	binary.LittleEndian.PutUint16(serializedScriptPublicKey[:2], scriptPublicKey.Version)
+	binary.LittleEndian.PutUint32(serializedScriptPublicKey[2:6], uint32(len(scriptPublicKey.Script)))
+	copy(serializedScriptPublicKey[6:], scriptPublicKey.Script)
+	return serializedScriptPublicKey
+}
+
+// indexedKey is the location of an address transaction in the index: the serialized
+// scriptPublicKey that names its bucket, and its key within that bucket
+type indexedKey struct {
+	serializedScriptPublicKey []byte
+	serializedKey             []byte
+}
+
+const (
+	indexedKeysLengthSize     = 8
+	scriptPublicKeyLengthSize = 4
+)
+
+// serializeIndexedKeys serializes the keys an accepting block added to the index, so
+// that they can be removed if the block is removed from the selected parent chain
+func serializeIndexedKeys(indexedKeys []indexedKey) []byte {
+	size := indexedKeysLengthSize
+	for _, key := range indexedKeys {
+		size += scriptPublicKeyLengthSize + len(key.serializedScriptPublicKey) + addressTransactionKeySize
+	}
+
+	serializedIndexedKeys := make([]byte, size)
+	binary.LittleEndian.PutUint64(serializedIndexedKeys[:indexedKeysLengthSize], uint64(len(indexedKeys)))
+	offset := indexedKeysLengthSize
+	for _, key := range indexedKeys {
+		binary.LittleEndian.PutUint32(serializedIndexedKeys[offset:], uint32(len(key.serializedScriptPublicKey)))
+		offset += scriptPublicKeyLengthSize
+		offset += copy(serializedIndexedKeys[offset:], key.serializedScriptPublicKey)
+		offset += copy(serializedIndexedKeys[offset:], key.serializedKey)
+	}
+	return serializedIndexedKeys
+}
+
+func deserializeIndexedKeys(serializedIndexedKeys []byte) ([]indexedKey, error) {
+	errUnexpectedEOF := errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing indexed keys")
+	if len(serializedIndexedKeys) < indexedKeysLengthSize {
+		return nil, errUnexpectedEOF
+	}
+	length := binary.LittleEndian.Uint64(serializedIndexedKeys[:indexedKeysLengthSize])
+
+	var indexedKeys []indexedKey
+	offset := uint64(indexedKeysLengthSize)
+	for i := uint64(0); i < length; i++ {
+		if offset+scriptPublicKeyLengthSize > uint64(len(serializedIndexedKeys)) {
+			return nil, errUnexpectedEOF
+		}
+		scriptPublicKeyLength := uint64(binary.LittleEndian.Uint32(serializedIndexedKeys[offset:]))
+		offset += scriptPublicKeyLengthSize
+
+		end := offset + scriptPublicKeyLength + addressTransactionKeySize
+		if end > uint64(len(serializedIndexedKeys)) {
+			return nil, errUnexpectedEOF
+		}
+		indexedKeys = append(indexedKeys, indexedKey{
+			serializedScriptPublicKey: serializedIndexedKeys[offset : offset+scriptPublicKeyLength],
+			serializedKey:             serializedIndexedKeys[offset+scriptPublicKeyLength : end],
+		})
+		offset = end
+	}
+
+	return indexedKeys, nil
+}
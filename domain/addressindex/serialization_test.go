@@ -0,0 +1,63 @@
+package addressindex
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+func TestAddressTransactionSerialization(t *testing.T) {
+	addressTransaction := &AddressTransaction{
+		ScriptPublicKey:         &externalapi.ScriptPublicKey{Script: []byte{1, 2, 3}, Version: 0},
+		TransactionID:           externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		AcceptingBlockHash:      externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+		AcceptingBlockBlueScore: 1234,
+		AssetType:               externalapi.KUSD,
+		AmountDelta:             -5000,
+	}
+
+	result, err := deserializeAddressTransaction(addressTransaction.ScriptPublicKey,
+		serializePageKey(addressTransaction.PageKey()), serializeAddressTransactionValue(addressTransaction))
+	if err != nil {
+		t.Fatalf("Failed deserializing the address transaction: %+v", err)
+	}
+	if !reflect.DeepEqual(addressTransaction, result) {
+		t.Fatalf("Expected %+v, but got %+v", addressTransaction, result)
+	}
+}
+
+func TestScriptPublicKeyBucketsArePrefixFree(t *testing.T) {
+	scriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1, 2, 3}, Version: 0}
+	// A script that extends the first one with the bucket separator
+	otherScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1, 2, 3, '/', 4}, Version: 0}
+
+	bucketPath := addressIndexBucket.Bucket(serializeScriptPublicKey(scriptPublicKey)).Path()
+	otherBucketPath := addressIndexBucket.Bucket(serializeScriptPublicKey(otherScriptPublicKey)).Path()
+	if bytes.HasPrefix(otherBucketPath, bucketPath) {
+		t.Fatalf("Expected the bucket of a scriptPublicKey not to be a prefix of the bucket of another")
+	}
+}
+
+func TestIndexedKeysSerialization(t *testing.T) {
+	indexedKeys := []indexedKey{
+		{serializedScriptPublicKey: []byte{1, 2}, serializedKey: bytes.Repeat([]byte{3}, addressTransactionKeySize)},
+		{serializedScriptPublicKey: []byte{4, 5, 6}, serializedKey: bytes.Repeat([]byte{7}, addressTransactionKeySize)},
+	}
+	serialized := serializeIndexedKeys(indexedKeys)
+	result, err := deserializeIndexedKeys(serialized)
+	if err != nil {
+		t.Fatalf("Failed deserializing indexed keys: %+v", err)
+	}
+	if !reflect.DeepEqual(indexedKeys, result) {
+		t.Fatalf("Expected %+v, but got %+v", indexedKeys, result)
+	}
+
+	_, err = deserializeIndexedKeys(serialized[:len(serialized)-1])
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected error to be EOF, instead got: %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package addressindex
+
+import (
+	"bytes"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
+)
+
+// addressIndexBucket holds a bucket for every scriptPublicKey, which maps the
+// transactions that touched the scriptPublicKey to their AddressTransaction
+var addressIndexBucket = database.MakeBucket([]byte("address-index"))
+
+type addressIndexStore struct {
+	database database.Database
+}
+
+func newAddressIndexStore(database database.Database) *addressIndexStore {
+	return &addressIndexStore{
+		database: database,
+	}
+}
+
+func (ais *addressIndexStore) bucketForScriptPublicKey(scriptPublicKey *externalapi.ScriptPublicKey) *database.Bucket {
+	return addressIndexBucket.Bucket(serializeScriptPublicKey(scriptPublicKey))
+}
+
+// addAddressTransactions indexes the given address transactions, and returns the keys they were indexed under
+func (ais *addressIndexStore) addAddressTransactions(dataAccessor database.DataAccessor,
+	addressTransactions []*AddressTransaction) ([]indexedKey, error) {
+
+	indexedKeys := make([]indexedKey, len(addressTransactions))
+	for i, addressTransaction := range addressTransactions {
+		indexedKeys[i] = indexedKey{
+			serializedScriptPublicKey: serializeScriptPublicKey(addressTransaction.ScriptPublicKey),
+			serializedKey:             serializePageKey(addressTransaction.PageKey()),
+		}
+		key := addressIndexBucket.Bucket(indexedKeys[i].serializedScriptPublicKey).Key(indexedKeys[i].serializedKey)
+		err := dataAccessor.Put(key, serializeAddressTransactionValue(addressTransaction))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return indexedKeys, nil
+}
+
+func (ais *addressIndexStore) removeIndexedKeys(dataAccessor database.DataAccessor, indexedKeys []indexedKey) error {
+	for _, indexedKey := range indexedKeys {
+		err := dataAccessor.Delete(
+			addressIndexBucket.Bucket(indexedKey.serializedScriptPublicKey).Key(indexedKey.serializedKey))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getAddressTransactions returns the transactions that touched any of the given scriptPublicKeys, in the order
+// they were accepted in, starting after the given page key. The transactions of different scriptPublicKeys may
+// share a page key, so the page is extended past `limit` until all the transactions with the page key of its
+// last transaction are returned. The returned next page key is nil if there are no more transactions.
+func (ais *addressIndexStore) getAddressTransactions(scriptPublicKeys []*externalapi.ScriptPublicKey,
+	startAfter *PageKey, limit uint64) ([]*AddressTransaction, *PageKey, error) {
+
+	cursors := make([]database.Cursor, len(scriptPublicKeys))
+	hasCurrent := make([]bool, len(scriptPublicKeys))
+	for i, scriptPublicKey := range scriptPublicKeys {
+		bucket := ais.bucketForScriptPublicKey(scriptPublicKey)
+		cursor, err := ais.database.Cursor(bucket)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer cursor.Close()
+
+		cursors[i] = cursor
+		if startAfter == nil {
+			hasCurrent[i] = cursor.Next()
+			continue
+		}
+		hasCurrent[i], err = seekAfter(cursor, bucket.Key(serializePageKey(startAfter)))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var addressTransactions []*AddressTransaction
+	var lastKey []byte
+	for {
+		// Merge the transactions of the scriptPublicKeys by picking the lowest key among the cursors
+		lowestCursorIndex := -1
+		var lowestKey []byte
+		for i, cursor := range cursors {
+			if !hasCurrent[i] {
+				continue
+			}
+			key, err := cursor.Key()
+			if err != nil {
+				return nil, nil, err
+			}
+			if lowestCursorIndex == -1 || bytes.Compare(key.Suffix(), lowestKey) < 0 {
+				lowestCursorIndex = i
+				lowestKey = key.Suffix()
+			}
+		}
+		if lowestCursorIndex == -1 {
+			return addressTransactions, nil, nil
+		}
+		if len(addressTransactions) > 0 && uint64(len(addressTransactions)) >= limit &&
+			!bytes.Equal(lowestKey, lastKey) {
+
+			return addressTransactions, addressTransactions[len(addressTransactions)-1].PageKey(), nil
+		}
+
+		cursor := cursors[lowestCursorIndex]
+		serializedValue, err := cursor.Value()
+		if err != nil {
+			return nil, nil, err
+		}
+		addressTransaction, err := deserializeAddressTransaction(
+			scriptPublicKeys[lowestCursorIndex], lowestKey, serializedValue)
+		if err != nil {
+			return nil, nil, err
+		}
+		addressTransactions = append(addressTransactions, addressTransaction)
+
+		// The key is copied since the cursor may reuse its buffer once it moves
+		lastKey = append(lastKey[:0], lowestKey...)
+		hasCurrent[lowestCursorIndex] = cursor.Next()
+	}
+}
+
+// seekAfter moves the cursor to the first key that is greater than the given key, and returns
+// whether there is such a key
+func seekAfter(cursor database.Cursor, key *database.Key) (bool, error) {
+	err := cursor.Seek(key)
+	if err == nil {
+		return cursor.Next(), nil
+	}
+	if !database.IsNotFoundError(err) {
+		return false, err
+	}
+
+	// Seek leaves the cursor at the first greater key if there's no exact match
+	_, err = cursor.Key()
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,103 @@
+package addressindex
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database/ldb"
+)
+
+func TestAddressIndexStore(t *testing.T) {
+	database, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("Could not create a database: %s", err)
+	}
+	defer database.Close()
+	store := newAddressIndexStore(database)
+
+	firstScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1}, Version: 0}
+	secondScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{2}, Version: 0}
+	newAddressTransaction := func(scriptPublicKey *externalapi.ScriptPublicKey, transactionIDByte byte,
+		acceptingBlockHash *externalapi.DomainHash, blueScore uint64) *AddressTransaction {
+
+		return &AddressTransaction{
+			ScriptPublicKey:         scriptPublicKey,
+			TransactionID:           externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{transactionIDByte}),
+			AcceptingBlockHash:      acceptingBlockHash,
+			AcceptingBlockBlueScore: blueScore,
+			AssetType:               externalapi.KSH,
+			AmountDelta:             1,
+		}
+	}
+
+	firstBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	secondBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2})
+	firstBlockTransactions := []*AddressTransaction{
+		newAddressTransaction(secondScriptPublicKey, 1, firstBlockHash, 1),
+		newAddressTransaction(firstScriptPublicKey, 2, firstBlockHash, 1),
+	}
+	secondBlockTransactions := []*AddressTransaction{
+		newAddressTransaction(firstScriptPublicKey, 3, secondBlockHash, 2),
+		newAddressTransaction(secondScriptPublicKey, 3, secondBlockHash, 2),
+	}
+	_, err = store.addAddressTransactions(database, firstBlockTransactions)
+	if err != nil {
+		t.Fatalf("addAddressTransactions: %+v", err)
+	}
+	secondBlockIndexedKeys, err := store.addAddressTransactions(database, secondBlockTransactions)
+	if err != nil {
+		t.Fatalf("addAddressTransactions: %+v", err)
+	}
+
+	scriptPublicKeys := []*externalapi.ScriptPublicKey{firstScriptPublicKey, secondScriptPublicKey}
+	checkAddressTransactions := func(startAfter *PageKey, limit uint64, expected []*AddressTransaction,
+		expectedNextPageKey *PageKey) {
+
+		addressTransactions, nextPageKey, err := store.getAddressTransactions(scriptPublicKeys, startAfter, limit)
+		if err != nil {
+			t.Fatalf("getAddressTransactions: %+v", err)
+		}
+		if len(addressTransactions) != len(expected) {
+			t.Fatalf("Expected %d address transactions after %+v, but got %d",
+				len(expected), startAfter, len(addressTransactions))
+		}
+		for i, addressTransaction := range addressTransactions {
+			if !addressTransaction.ScriptPublicKey.Equal(expected[i].ScriptPublicKey) ||
+				!addressTransaction.TransactionID.Equal(expected[i].TransactionID) {
+
+				t.Fatalf("Address transaction %d after %+v: expected %+v, but got %+v",
+					i, startAfter, expected[i], addressTransaction)
+			}
+		}
+		if !reflect.DeepEqual(nextPageKey, expectedNextPageKey) {
+			t.Fatalf("Expected the next page key after %+v to be %+v, but got %+v",
+				startAfter, expectedNextPageKey, nextPageKey)
+		}
+	}
+
+	// The transactions of both scriptPublicKeys are merged in the order they were accepted in
+	allTransactions := append(append([]*AddressTransaction{}, firstBlockTransactions...), secondBlockTransactions...)
+	checkAddressTransactions(nil, 10, allTransactions, nil)
+	checkAddressTransactions(nil, 1, allTransactions[:1], allTransactions[0].PageKey())
+	checkAddressTransactions(allTransactions[0].PageKey(), 1, allTransactions[1:2], allTransactions[1].PageKey())
+
+	// The transaction of the second block touched both scriptPublicKeys, so the page is
+	// extended until both are returned
+	checkAddressTransactions(allTransactions[0].PageKey(), 2, allTransactions[1:], nil)
+	checkAddressTransactions(allTransactions[1].PageKey(), 1, allTransactions[2:], nil)
+	checkAddressTransactions(allTransactions[3].PageKey(), 10, nil, nil)
+
+	// A page may start after a key that isn't in the index
+	checkAddressTransactions(&PageKey{
+		AcceptingBlockBlueScore: 1,
+		TransactionID:           externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{0xff}),
+		AssetType:               externalapi.KSH,
+	}, 10, allTransactions[2:], nil)
+
+	err = store.removeIndexedKeys(database, secondBlockIndexedKeys)
+	if err != nil {
+		t.Fatalf("removeIndexedKeys: %+v", err)
+	}
+	checkAddressTransactions(nil, 10, firstBlockTransactions, nil)
+}
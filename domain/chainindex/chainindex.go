@@ -0,0 +1,456 @@
+// Package chainindex keeps indexes of the transactions accepted by the virtual selected parent chain
+// in sync with consensus.
+package chainindex
+
+import (
+	"math"
+	"sync"
+
+	"github.com/Kash-Protocol/kashd/domain"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+// Indexer adds the transactions accepted by selected parent chain blocks to an index
+type Indexer interface {
+	// AddAcceptingBlock indexes the transactions accepted by the given block, and returns
+	// the data RemoveAcceptingBlock requires to remove them from the index again
+	AddAcceptingBlock(dataAccessor database.DataAccessor, blockHash *externalapi.DomainHash, blueScore uint64,
+		acceptanceData externalapi.AcceptanceData) (rollbackData []byte, err error)
+
+	// RemoveAcceptingBlock removes the transactions an accepting block added to the index,
+	// given the rollback data AddAcceptingBlock returned for it
+	RemoveAcceptingBlock(dataAccessor database.DataAccessor, rollbackData []byte) error
+}
+
+// ChainIndex keeps the index of an Indexer in sync with the virtual selected parent chain
+type ChainIndex struct {
+	name    string
+	domain  domain.Domain
+	store   *chainIndexStore
+	indexer Indexer
+
+	isArchivalNode   bool
+	removePrunedData bool
+
+	// lastPruningPoint is the pruning point as of the last time the
+	// accepting blocks below it were removed from the store
+	lastPruningPoint *externalapi.DomainHash
+
+	mutex sync.Mutex
+}
+
+// New creates a new chain index for the given indexer and catches it up with the virtual selected parent
+// chain. name is used in log messages, and bucketPrefix prefixes the database keys of the chain index.
+// The rollback data of accepting blocks below the pruning point is removed, since they can no longer be
+// removed from the selected parent chain. If removePrunedData is set, the transactions they accepted are
+// removed from the index as well.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func New(domain domain.Domain, database database.Database, indexer Indexer, name string, bucketPrefix string,
+	isArchivalNode bool, removePrunedData bool) (*ChainIndex, error) {
+
+	chainIndex := &ChainIndex{
+		name:             name,
+		domain:           domain,
+		store:            newChainIndexStore(database, bucketPrefix),
+		indexer:          indexer,
+		isArchivalNode:   isArchivalNode,
+		removePrunedData: removePrunedData,
+	}
+	isSynced, err := chainIndex.isSynced()
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSynced {
+		err := chainIndex.CatchUp()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return chainIndex, nil
+}
+
+func (ci *ChainIndex) isSynced() (bool, error) {
+	tip, found, err := ci.store.tip()
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+
+	virtualSelectedParent, err := ci.domain.Consensus().GetVirtualSelectedParent()
+	if err != nil {
+		return false, err
+	}
+
+	return tip.Equal(virtualSelectedParent), nil
+}
+
+// CatchUp brings the index up to date with the virtual selected parent chain. It continues from the
+// last chain block the index was caught up with if possible. Otherwise, it removes the accepting blocks
+// that are no longer in the selected parent chain, and continues from the highest remaining one, or
+// starts from the pruning point if there's none. Archival nodes start from the earliest pruning point
+// they have the data of instead. The index is never deleted.
+func (ci *ChainIndex) CatchUp() error {
+	ci.mutex.Lock()
+	defer ci.mutex.Unlock()
+
+	log.Infof("Catching the %s up with the selected parent chain", ci.name)
+
+	startingPoint, err := ci.catchUpStartingPoint()
+	if err != nil {
+		return err
+	}
+	chainPath, err := ci.domain.Consensus().GetVirtualSelectedParentChainFromBlock(startingPoint)
+	if err != nil {
+		return err
+	}
+
+	if len(chainPath.Removed) > 0 {
+		err := ci.updateAndCommit(chainPath.Removed, nil)
+		if err != nil {
+			return err
+		}
+		log.Debugf("Removed %d chain blocks from the %s", len(chainPath.Removed), ci.name)
+	}
+
+	const step = 100
+	for start := 0; start < len(chainPath.Added); start += step {
+		end := start + step
+		if end > len(chainPath.Added) {
+			end = len(chainPath.Added)
+		}
+
+		err := ci.updateAndCommit(nil, chainPath.Added[start:end])
+		if err != nil {
+			return err
+		}
+		log.Debugf("Added %d out of %d chain blocks to the %s", end, len(chainPath.Added), ci.name)
+	}
+
+	ci.lastPruningPoint = nil
+	err = ci.removePrunedAcceptingBlocks()
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Finished catching the %s up", ci.name)
+	return nil
+}
+
+// catchUpStartingPoint returns the selected parent chain block to catch the index up from, and
+// marks it as the tip of the index
+func (ci *ChainIndex) catchUpStartingPoint() (*externalapi.DomainHash, error) {
+	tip, found, err := ci.store.tip()
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		canContinue, err := ci.canContinueFrom(tip)
+		if err != nil {
+			return nil, err
+		}
+		if canContinue {
+			return tip, nil
+		}
+		log.Infof("The %s can't continue from %s, which is no longer available", ci.name, tip)
+	}
+
+	pruningPoint, err := ci.domain.Consensus().PruningPoint()
+	if err != nil {
+		return nil, err
+	}
+	ci.lastPruningPoint = nil
+	err = ci.removePrunedAcceptingBlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	dbTransaction, err := ci.store.database.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	// The accepting blocks are removed from the top down, so the remaining ones are the
+	// selected parent chain up to the highest remaining one
+	acceptingBlocks, err := ci.store.acceptingBlocksBelow(math.MaxUint64)
+	if err != nil {
+		return nil, err
+	}
+	var startingPoint *externalapi.DomainHash
+	for i := len(acceptingBlocks) - 1; i >= 0; i-- {
+		isChainBlock, err := ci.isChainBlock(acceptingBlocks[i].hash)
+		if err != nil {
+			return nil, err
+		}
+		if isChainBlock {
+			startingPoint = acceptingBlocks[i].hash
+			break
+		}
+
+		log.Debugf("Removing block %s, which is no longer in the selected parent chain, from the %s",
+			acceptingBlocks[i].hash, ci.name)
+		err = ci.removeAcceptingBlock(dbTransaction, acceptingBlocks[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if startingPoint == nil {
+		startingPoint, err = ci.initialStartingPoint(pruningPoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = ci.store.updateTip(dbTransaction, startingPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	err = dbTransaction.Commit()
+	if err != nil {
+		return nil, err
+	}
+	return startingPoint, nil
+}
+
+// canContinueFrom returns whether the index can be caught up from the given tip. This requires
+// the acceptance data of the selected parent chain blocks above it, which archival nodes keep,
+// while other nodes only keep it above the pruning point.
+func (ci *ChainIndex) canContinueFrom(tip *externalapi.DomainHash) (bool, error) {
+	tipInfo, err := ci.domain.Consensus().GetBlockInfo(tip)
+	if err != nil {
+		return false, err
+	}
+	if !tipInfo.Exists {
+		return false, nil
+	}
+	if ci.isArchivalNode {
+		return true, nil
+	}
+
+	pruningPoint, err := ci.domain.Consensus().PruningPoint()
+	if err != nil {
+		return false, err
+	}
+	return ci.domain.Consensus().IsInSelectedParentChainOf(pruningPoint, tip)
+}
+
+func (ci *ChainIndex) isChainBlock(blockHash *externalapi.DomainHash) (bool, error) {
+	blockInfo, err := ci.domain.Consensus().GetBlockInfo(blockHash)
+	if err != nil {
+		return false, err
+	}
+	if !blockInfo.Exists {
+		return false, nil
+	}
+	return ci.domain.Consensus().IsChainBlock(blockHash)
+}
+
+// initialStartingPoint returns the block an index without any accepting blocks starts from. Other than
+// on archival nodes, that's the pruning point. Archival nodes start from the earliest pruning point they
+// have the acceptance data of, which is the genesis if they synced from it.
+func (ci *ChainIndex) initialStartingPoint(pruningPoint *externalapi.DomainHash) (*externalapi.DomainHash, error) {
+	if !ci.isArchivalNode {
+		return pruningPoint, nil
+	}
+
+	pruningPointHeaders, err := ci.domain.Consensus().PruningPointHeaders()
+	if err != nil {
+		return nil, err
+	}
+	for _, pruningPointHeader := range pruningPointHeaders {
+		pruningPointHash := consensushashing.HeaderHash(pruningPointHeader)
+		_, err := ci.domain.Consensus().GetBlockAcceptanceData(pruningPointHash)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				continue
+			}
+			return nil, err
+		}
+		return pruningPointHash, nil
+	}
+	return pruningPoint, nil
+}
+
+func (ci *ChainIndex) updateAndCommit(removedBlockHashes []*externalapi.DomainHash,
+	addedBlockHashes []*externalapi.DomainHash) error {
+
+	dbTransaction, err := ci.store.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	err = ci.update(dbTransaction, removedBlockHashes, addedBlockHashes)
+	if err != nil {
+		return err
+	}
+
+	return dbTransaction.Commit()
+}
+
+// Update updates the index with the given DAG selected parent chain changes
+func (ci *ChainIndex) Update(virtualChangeSet *externalapi.VirtualChangeSet) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "ChainIndex.Update")
+	defer onEnd()
+
+	ci.mutex.Lock()
+	defer ci.mutex.Unlock()
+
+	chainChanges := virtualChangeSet.VirtualSelectedParentChainChanges
+	if chainChanges != nil && (len(chainChanges.Removed) > 0 || len(chainChanges.Added) > 0) {
+		err := ci.updateAndCommit(chainChanges.Removed, chainChanges.Added)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ci.removePrunedAcceptingBlocks()
+}
+
+// update removes the given blocks from the index, adds the given blocks to it, and marks the new
+// selected parent chain tip as the tip of the index. The removed blocks are removed first, so that
+// transactions that were accepted by a removed block and are accepted again by an added block remain
+// in the index.
+func (ci *ChainIndex) update(dbTransaction database.Transaction, removedBlockHashes []*externalapi.DomainHash,
+	addedBlockHashes []*externalapi.DomainHash) error {
+
+	var tip *externalapi.DomainHash
+	for _, removedBlockHash := range removedBlockHashes {
+		blockInfo, err := ci.domain.Consensus().GetBlockInfo(removedBlockHash)
+		if err != nil {
+			return err
+		}
+		if !blockInfo.Exists {
+			return errors.Errorf("removed block %s does not exist", removedBlockHash)
+		}
+
+		log.Tracef("Removing the transactions accepted by block %s from the %s", removedBlockHash, ci.name)
+		err = ci.removeAcceptingBlock(dbTransaction,
+			&acceptingBlock{hash: removedBlockHash, blueScore: blockInfo.BlueScore})
+		if err != nil {
+			return err
+		}
+		tip = blockInfo.SelectedParent
+	}
+
+	if len(addedBlockHashes) > 0 {
+		err := ci.addAcceptingBlocks(dbTransaction, addedBlockHashes)
+		if err != nil {
+			return err
+		}
+		tip = addedBlockHashes[len(addedBlockHashes)-1]
+	}
+
+	if tip == nil {
+		return nil
+	}
+	return ci.store.updateTip(dbTransaction, tip)
+}
+
+// addAcceptingBlocks indexes the transactions accepted by the given selected parent chain blocks
+func (ci *ChainIndex) addAcceptingBlocks(dataAccessor database.DataAccessor,
+	acceptingBlockHashes []*externalapi.DomainHash) error {
+
+	acceptanceData, err := ci.domain.Consensus().GetBlocksAcceptanceData(acceptingBlockHashes)
+	if err != nil {
+		return err
+	}
+
+	for i, acceptingBlockHash := range acceptingBlockHashes {
+		blockInfo, err := ci.domain.Consensus().GetBlockInfo(acceptingBlockHash)
+		if err != nil {
+			return err
+		}
+
+		log.Tracef("Adding the transactions accepted by block %s to the %s", acceptingBlockHash, ci.name)
+		rollbackData, err := ci.indexer.AddAcceptingBlock(dataAccessor, acceptingBlockHash, blockInfo.BlueScore,
+			acceptanceData[i])
+		if err != nil {
+			return err
+		}
+		err = ci.store.addAcceptingBlock(dataAccessor, acceptingBlockHash, blockInfo.BlueScore, rollbackData)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeAcceptingBlock removes the transactions accepted by the given block from the index.
+// Nothing is removed if the block isn't indexed.
+func (ci *ChainIndex) removeAcceptingBlock(dataAccessor database.DataAccessor, block *acceptingBlock) error {
+	rollbackData, found, err := ci.store.rollbackData(dataAccessor, block.hash, block.blueScore)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	err = ci.indexer.RemoveAcceptingBlock(dataAccessor, rollbackData)
+	if err != nil {
+		return err
+	}
+	return ci.store.removeAcceptingBlock(dataAccessor, block.hash, block.blueScore)
+}
+
+// removePrunedAcceptingBlocks removes the rollback data of the accepting blocks below the pruning
+// point if it moved, along with the transactions they accepted if removePrunedData is set
+func (ci *ChainIndex) removePrunedAcceptingBlocks() error {
+	pruningPoint, err := ci.domain.Consensus().PruningPoint()
+	if err != nil {
+		return err
+	}
+	if ci.lastPruningPoint != nil && ci.lastPruningPoint.Equal(pruningPoint) {
+		return nil
+	}
+
+	pruningPointInfo, err := ci.domain.Consensus().GetBlockInfo(pruningPoint)
+	if err != nil {
+		return err
+	}
+	prunedAcceptingBlocks, err := ci.store.acceptingBlocksBelow(pruningPointInfo.BlueScore)
+	if err != nil {
+		return err
+	}
+
+	dbTransaction, err := ci.store.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for _, prunedAcceptingBlock := range prunedAcceptingBlocks {
+		if ci.removePrunedData {
+			err = ci.removeAcceptingBlock(dbTransaction, prunedAcceptingBlock)
+		} else {
+			err = ci.store.removeAcceptingBlock(dbTransaction, prunedAcceptingBlock.hash, prunedAcceptingBlock.blueScore)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	err = dbTransaction.Commit()
+	if err != nil {
+		return err
+	}
+	if len(prunedAcceptingBlocks) > 0 {
+		log.Debugf("Removed %d accepting blocks below the pruning point from the %s",
+			len(prunedAcceptingBlocks), ci.name)
+	}
+
+	ci.lastPruningPoint = pruningPoint
+	return nil
+}
@@ -0,0 +1,105 @@
+package chainindex_test
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain"
+	"github.com/Kash-Protocol/kashd/domain/chainindex"
+	"github.com/Kash-Protocol/kashd/domain/consensus"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/testutils"
+	"github.com/Kash-Protocol/kashd/domain/miningmanager/mempool"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database/ldb"
+)
+
+// recordingIndexer records the blocks that were added to the index
+type recordingIndexer struct {
+	addedBlocks map[externalapi.DomainHash]int
+}
+
+func (ri *recordingIndexer) AddAcceptingBlock(_ database.DataAccessor, blockHash *externalapi.DomainHash,
+	_ uint64, _ externalapi.AcceptanceData) ([]byte, error) {
+
+	ri.addedBlocks[*blockHash]++
+	return blockHash.ByteSlice(), nil
+}
+
+func (ri *recordingIndexer) RemoveAcceptingBlock(_ database.DataAccessor, rollbackData []byte) error {
+	blockHash, err := externalapi.NewDomainHashFromByteSlice(rollbackData)
+	if err != nil {
+		return err
+	}
+	delete(ri.addedBlocks, *blockHash)
+	return nil
+}
+
+func TestChainIndexCatchUp(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		db, err := ldb.NewLevelDB(t.TempDir(), 8)
+		if err != nil {
+			t.Fatalf("NewLevelDB: %+v", err)
+		}
+		defer db.Close()
+
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), nil, db)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+
+		addBlocks := func(count int) {
+			coinbaseData := &externalapi.DomainCoinbaseData{
+				ScriptPublicKey: &externalapi.ScriptPublicKey{},
+				ExtraData:       []byte{},
+			}
+			for i := 0; i < count; i++ {
+				block, err := domainInstance.Consensus().BuildBlock(coinbaseData, nil)
+				if err != nil {
+					t.Fatalf("BuildBlock: %+v", err)
+				}
+				err = domainInstance.Consensus().ValidateAndInsertBlock(block, true)
+				if err != nil {
+					t.Fatalf("ValidateAndInsertBlock: %+v", err)
+				}
+			}
+		}
+		checkIndexedChain := func(indexer *recordingIndexer) {
+			chainPath, err := domainInstance.Consensus().GetVirtualSelectedParentChainFromBlock(consensusConfig.GenesisHash)
+			if err != nil {
+				t.Fatalf("GetVirtualSelectedParentChainFromBlock: %+v", err)
+			}
+			if len(indexer.addedBlocks) != len(chainPath.Added) {
+				t.Fatalf("Expected %d indexed blocks, but got %d", len(chainPath.Added), len(indexer.addedBlocks))
+			}
+			for _, blockHash := range chainPath.Added {
+				if indexer.addedBlocks[*blockHash] != 1 {
+					t.Fatalf("Expected block %s to be indexed once, but it was indexed %d times",
+						blockHash, indexer.addedBlocks[*blockHash])
+				}
+			}
+		}
+
+		addBlocks(3)
+		indexer := &recordingIndexer{addedBlocks: make(map[externalapi.DomainHash]int)}
+		_, err = chainindex.New(domainInstance, db, indexer, "test index", "test-index", true, false)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+		checkIndexedChain(indexer)
+
+		// A chain index that was left behind continues from where it stopped, rather than starting over
+		addBlocks(2)
+		chainIndex, err := chainindex.New(domainInstance, db, indexer, "test index", "test-index", true, false)
+		if err != nil {
+			t.Fatalf("New: %+v", err)
+		}
+		checkIndexedChain(indexer)
+
+		addBlocks(1)
+		err = chainIndex.CatchUp()
+		if err != nil {
+			t.Fatalf("CatchUp: %+v", err)
+		}
+		checkIndexedChain(indexer)
+	})
+}
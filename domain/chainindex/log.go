@@ -0,0 +1,7 @@
+package chainindex
+
+import (
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("CHIN")
@@ -0,0 +1,69 @@
+package chainindex
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+const blueScoreSize = 8
+
+// serializeAcceptingBlockKey serializes the blue score of an accepting block in big endian
+// before its hash, so that iterating over the accepting blocks goes in blue score order
+func serializeAcceptingBlockKey(blockHash *externalapi.DomainHash, blueScore uint64) []byte {
+	serializedKey := make([]byte, blueScoreSize+externalapi.DomainHashSize)
+	binary.BigEndian.PutUint64(serializedKey[:blueScoreSize], blueScore)
+	copy(serializedKey[blueScoreSize:], blockHash.ByteSlice())
+	return serializedKey
+}
+
+func deserializeAcceptingBlockKey(serializedKey []byte) (blockHash *externalapi.DomainHash, blueScore uint64, err error) {
+	if len(serializedKey) != blueScoreSize+externalapi.DomainHashSize {
+		return nil, 0, errors.Errorf("accepting block key has unexpected length %d", len(serializedKey))
+	}
+	blockHash, err = externalapi.NewDomainHashFromByteSlice(serializedKey[blueScoreSize:])
+	if err != nil {
+		return nil, 0, err
+	}
+	return blockHash, binary.BigEndian.Uint64(serializedKey[:blueScoreSize]), nil
+}
+
+const hashesLengthSize = 8
+
+// SerializeHashes serializes the given hashes, prefixed by their count
+func SerializeHashes(hashes []*externalapi.DomainHash) []byte {
+	serializedHashes := make([]byte, hashesLengthSize+externalapi.DomainHashSize*len(hashes))
+	binary.LittleEndian.PutUint64(serializedHashes[:hashesLengthSize], uint64(len(hashes)))
+	for i, hash := range hashes {
+		start := hashesLengthSize + externalapi.DomainHashSize*i
+		end := start + externalapi.DomainHashSize
+		copy(serializedHashes[start:end], hash.ByteSlice())
+	}
+	return serializedHashes
+}
+
+// DeserializeHashes deserializes hashes that were serialized by SerializeHashes
+func DeserializeHashes(serializedHashes []byte) ([]*externalapi.DomainHash, error) {
+	if len(serializedHashes) < hashesLengthSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing hashes")
+	}
+	length := binary.LittleEndian.Uint64(serializedHashes[:hashesLengthSize])
+	if length > uint64(len(serializedHashes)-hashesLengthSize)/externalapi.DomainHashSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing hashes")
+	}
+	hashes := make([]*externalapi.DomainHash, length)
+	for i := uint64(0); i < length; i++ {
+		start := hashesLengthSize + externalapi.DomainHashSize*i
+		end := start + externalapi.DomainHashSize
+
+		var err error
+		hashes[i], err = externalapi.NewDomainHashFromByteSlice(serializedHashes[start:end])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return hashes, nil
+}
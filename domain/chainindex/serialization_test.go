@@ -0,0 +1,54 @@
+package chainindex
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+func TestAcceptingBlockKeyOrder(t *testing.T) {
+	blockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{0xff})
+	otherBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{0x01})
+
+	// Keys are ordered by blue score before they are ordered by hash
+	lowerKey := serializeAcceptingBlockKey(blockHash, 255)
+	higherKey := serializeAcceptingBlockKey(otherBlockHash, 256)
+	if bytes.Compare(lowerKey, higherKey) >= 0 {
+		t.Fatalf("Expected the key of the lower blue score to be ordered first")
+	}
+
+	resultHash, resultBlueScore, err := deserializeAcceptingBlockKey(higherKey)
+	if err != nil {
+		t.Fatalf("Failed deserializing the accepting block key: %+v", err)
+	}
+	if !resultHash.Equal(otherBlockHash) || resultBlueScore != 256 {
+		t.Fatalf("Expected %s with blue score 256, but got %s with blue score %d",
+			otherBlockHash, resultHash, resultBlueScore)
+	}
+}
+
+func TestDeserializeHashesFailure(t *testing.T) {
+	hashes := []*externalapi.DomainHash{
+		externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+	}
+	serialized := SerializeHashes(hashes)
+	result, err := DeserializeHashes(serialized)
+	if err != nil {
+		t.Fatalf("Failed deserializing hashes: %+v", err)
+	}
+	if !reflect.DeepEqual(hashes, result) {
+		t.Fatalf("Expected %s, but got %s", hashes, result)
+	}
+
+	binary.LittleEndian.PutUint64(serialized[:hashesLengthSize], uint64(len(hashes)+1))
+	_, err = DeserializeHashes(serialized)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected error to be EOF, instead got: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package chainindex
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
+)
+
+// acceptingBlock is a selected parent chain block whose accepted transactions are indexed
+type acceptingBlock struct {
+	hash      *externalapi.DomainHash
+	blueScore uint64
+}
+
+type chainIndexStore struct {
+	database database.Database
+
+	// acceptingBlocksBucket maps the blue score and hash of every indexed accepting block to the
+	// rollback data of the transactions it accepted, so that they can be removed on reorgs
+	acceptingBlocksBucket *database.Bucket
+
+	// tipKey holds the hash of the last selected parent chain block the index was caught up with
+	tipKey *database.Key
+}
+
+func newChainIndexStore(db database.Database, bucketPrefix string) *chainIndexStore {
+	return &chainIndexStore{
+		database:              db,
+		acceptingBlocksBucket: database.MakeBucket([]byte(bucketPrefix + "-accepting-blocks")),
+		tipKey:                database.MakeBucket([]byte("")).Key([]byte(bucketPrefix + "-tip")),
+	}
+}
+
+func (cis *chainIndexStore) acceptingBlockKey(blockHash *externalapi.DomainHash, blueScore uint64) *database.Key {
+	return cis.acceptingBlocksBucket.Key(serializeAcceptingBlockKey(blockHash, blueScore))
+}
+
+func (cis *chainIndexStore) addAcceptingBlock(dataAccessor database.DataAccessor, blockHash *externalapi.DomainHash,
+	blueScore uint64, rollbackData []byte) error {
+
+	return dataAccessor.Put(cis.acceptingBlockKey(blockHash, blueScore), rollbackData)
+}
+
+// rollbackData returns the rollback data of the given accepting block, or false if the block isn't indexed
+// or its rollback data was already removed
+func (cis *chainIndexStore) rollbackData(dataAccessor database.DataAccessor, blockHash *externalapi.DomainHash,
+	blueScore uint64) ([]byte, bool, error) {
+
+	rollbackData, err := dataAccessor.Get(cis.acceptingBlockKey(blockHash, blueScore))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return rollbackData, true, nil
+}
+
+func (cis *chainIndexStore) removeAcceptingBlock(dataAccessor database.DataAccessor,
+	blockHash *externalapi.DomainHash, blueScore uint64) error {
+
+	return dataAccessor.Delete(cis.acceptingBlockKey(blockHash, blueScore))
+}
+
+// acceptingBlocksBelow returns the accepting blocks with a blue score lower than the given one,
+// in blue score order
+func (cis *chainIndexStore) acceptingBlocksBelow(blueScore uint64) ([]*acceptingBlock, error) {
+	cursor, err := cis.database.Cursor(cis.acceptingBlocksBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	var acceptingBlocks []*acceptingBlock
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		blockHash, blockBlueScore, err := deserializeAcceptingBlockKey(key.Suffix())
+		if err != nil {
+			return nil, err
+		}
+		// The accepting blocks are ordered by blue score, so all the following ones are higher as well
+		if blockBlueScore >= blueScore {
+			break
+		}
+		acceptingBlocks = append(acceptingBlocks, &acceptingBlock{hash: blockHash, blueScore: blockBlueScore})
+	}
+
+	return acceptingBlocks, nil
+}
+
+func (cis *chainIndexStore) updateTip(dataAccessor database.DataAccessor, tip *externalapi.DomainHash) error {
+	return dataAccessor.Put(cis.tipKey, tip.ByteSlice())
+}
+
+// tip returns the last selected parent chain block the index was caught up with, or false if the index
+// was never caught up
+func (cis *chainIndexStore) tip() (*externalapi.DomainHash, bool, error) {
+	serializedTip, err := cis.database.Get(cis.tipKey)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	tip, err := externalapi.NewDomainHashFromByteSlice(serializedTip)
+	if err != nil {
+		return nil, false, err
+	}
+	return tip, true, nil
+}
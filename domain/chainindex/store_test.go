@@ -0,0 +1,89 @@
+package chainindex
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database/ldb"
+)
+
+func TestChainIndexStore(t *testing.T) {
+	database, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("Could not create a database: %s", err)
+	}
+	defer database.Close()
+	store := newChainIndexStore(database, "test-index")
+
+	_, found, err := store.tip()
+	if err != nil {
+		t.Fatalf("tip: %+v", err)
+	}
+	if found {
+		t.Fatalf("Expected a new store not to have a tip")
+	}
+
+	firstBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2})
+	secondBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	err = store.addAcceptingBlock(database, firstBlockHash, 1, []byte{1})
+	if err != nil {
+		t.Fatalf("addAcceptingBlock: %+v", err)
+	}
+	err = store.addAcceptingBlock(database, secondBlockHash, 2, []byte{2})
+	if err != nil {
+		t.Fatalf("addAcceptingBlock: %+v", err)
+	}
+	err = store.updateTip(database, secondBlockHash)
+	if err != nil {
+		t.Fatalf("updateTip: %+v", err)
+	}
+
+	tip, found, err := store.tip()
+	if err != nil {
+		t.Fatalf("tip: %+v", err)
+	}
+	if !found || !tip.Equal(secondBlockHash) {
+		t.Fatalf("Expected the tip to be %s, but got %s", secondBlockHash, tip)
+	}
+
+	// The accepting blocks are ordered by blue score rather than by hash
+	acceptingBlocks, err := store.acceptingBlocksBelow(math.MaxUint64)
+	if err != nil {
+		t.Fatalf("acceptingBlocksBelow: %+v", err)
+	}
+	if len(acceptingBlocks) != 2 || !acceptingBlocks[0].hash.Equal(firstBlockHash) ||
+		!acceptingBlocks[1].hash.Equal(secondBlockHash) {
+
+		t.Fatalf("Expected the accepting blocks to be [%s %s], but got %+v",
+			firstBlockHash, secondBlockHash, acceptingBlocks)
+	}
+	acceptingBlocks, err = store.acceptingBlocksBelow(2)
+	if err != nil {
+		t.Fatalf("acceptingBlocksBelow: %+v", err)
+	}
+	if len(acceptingBlocks) != 1 || acceptingBlocks[0].blueScore != 1 {
+		t.Fatalf("Expected only the accepting block with blue score 1, but got %+v", acceptingBlocks)
+	}
+
+	rollbackData, found, err := store.rollbackData(database, secondBlockHash, 2)
+	if err != nil {
+		t.Fatalf("rollbackData: %+v", err)
+	}
+	if !found || !bytes.Equal(rollbackData, []byte{2}) {
+		t.Fatalf("Expected the rollback data of %s to be [2], but got %v", secondBlockHash, rollbackData)
+	}
+
+	err = store.removeAcceptingBlock(database, secondBlockHash, 2)
+	if err != nil {
+		t.Fatalf("removeAcceptingBlock: %+v", err)
+	}
+	_, found, err = store.rollbackData(database, secondBlockHash, 2)
+	if err != nil {
+		t.Fatalf("rollbackData: %+v", err)
+	}
+	if found {
+		t.Fatalf("Expected the rollback data of %s to be removed", secondBlockHash)
+	}
+}
@@ -3,6 +3,7 @@ package consensus
 import (
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/Kash-Protocol/kashd/util/mstime"
 
@@ -42,6 +43,7 @@ type consensus struct {
 	reachabilityManager   model.ReachabilityManager
 	finalityManager       model.FinalityManager
 	pruningProofManager   model.PruningProofManager
+	djedManager           model.DjedManager
 
 	acceptanceDataStore                 model.AcceptanceDataStore
 	blockStore                          model.BlockStore
@@ -59,6 +61,7 @@ type consensus struct {
 	headersSelectedChainStore           model.HeadersSelectedChainStore
 	daaBlocksStore                      model.DAABlocksStore
 	blocksWithTrustedDataDAAWindowStore model.BlocksWithTrustedDataDAAWindowStore
+	reserveStateStore                   model.ReserveStateStore
 
 	consensusEventsChan chan externalapi.ConsensusEvent
 	virtualNotUpdated   bool
@@ -251,10 +254,17 @@ func (s *consensus) validateAndInsertBlockWithLock(block *externalapi.DomainBloc
 }
 
 func (s *consensus) validateAndInsertBlockNoLock(block *externalapi.DomainBlock, updateVirtual bool) (*externalapi.VirtualChangeSet, error) {
+	start := time.Now()
 	virtualChangeSet, blockStatus, err := s.blockProcessor.ValidateAndInsertBlock(block, updateVirtual)
 	if err != nil {
 		return nil, err
 	}
+	blockProcessingDuration.ObserveSince(start)
+	if blockStatus == externalapi.StatusHeaderOnly {
+		headersProcessed.Inc()
+	} else {
+		blocksProcessed.Inc()
+	}
 
 	// If block has a body, and yet virtual was not updated -- signify that virtual is in non-updated state
 	if !updateVirtual && blockStatus != externalapi.StatusHeaderOnly {
@@ -312,10 +322,17 @@ func (s *consensus) sendVirtualChangedEvent(virtualChangeSet *externalapi.Virtua
 	if err != nil {
 		return err
 	}
+	virtualDAAScoreGauge.Set(float64(virtualDAAScore))
+
+	virtualReserveInfo, err := s.djedManager.ReserveInfo(stagingArea, model.VirtualBlockHash)
+	if err != nil {
+		return err
+	}
 
 	// Populate the change set with additional data before sending
 	virtualChangeSet.VirtualSelectedParentBlueScore = virtualSelectedParentGHOSTDAGData.BlueScore()
 	virtualChangeSet.VirtualDAAScore = virtualDAAScore
+	virtualChangeSet.VirtualReserveInfo = virtualReserveInfo
 
 	s.consensusEventsChan <- virtualChangeSet
 	return nil
@@ -741,6 +758,35 @@ func (s *consensus) GetVirtualDAAScore() (uint64, error) {
 	return s.daaBlocksStore.DAAScore(s.databaseContext, stagingArea, model.VirtualBlockHash)
 }
 
+func (s *consensus) GetVirtualReserveInfo() (*externalapi.ReserveInfo, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+
+	return s.djedManager.ReserveInfo(stagingArea, model.VirtualBlockHash)
+}
+
+func (s *consensus) GetVirtualOraclePrices() (*externalapi.OraclePrices, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+
+	virtualGHOSTDAGData, err := s.ghostdagDataStores[0].Get(s.databaseContext, stagingArea, model.VirtualBlockHash, false)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Like the exchange rate, the price record in effect at the virtual is the one of its selected parent
+	priceRecord, found, err := s.djedManager.PriceRecord(stagingArea, virtualGHOSTDAGData.SelectedParent())
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	return priceRecord.OraclePrices(), true, nil
+}
+
 func (s *consensus) CreateBlockLocatorFromPruningPoint(highHash *externalapi.DomainHash, limit uint32) (externalapi.BlockLocator, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -1087,6 +1133,27 @@ func (s *consensus) TrustedGHOSTDAGData(blockHash *externalapi.DomainHash) (*ext
 	return ghostdagData, nil
 }
 
+func (s *consensus) TrustedOraclePrices(blockHash *externalapi.DomainHash) (*externalapi.OraclePrices, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+	priceRecord, found, err := s.djedManager.PriceRecord(stagingArea, blockHash)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	return priceRecord.OraclePrices(), true, nil
+}
+
+func (s *consensus) TrustedReserveState(blockHash *externalapi.DomainHash) (*externalapi.ReserveState, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+	return s.reserveStateStore.Get(s.databaseContext, stagingArea, blockHash)
+}
+
 func (s *consensus) IsChainBlock(blockHash *externalapi.DomainHash) (bool, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
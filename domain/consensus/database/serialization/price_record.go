@@ -0,0 +1,31 @@
+package serialization
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+)
+
+// PriceRecordToDbPriceRecord converts *oracle.PriceRecord to *DbPriceRecord
+func PriceRecordToDbPriceRecord(priceRecord *oracle.PriceRecord) *DbPriceRecord {
+	return &DbPriceRecord{
+		Ksh:       priceRecord.KSH,
+		Krv:       priceRecord.KRV,
+		Kusd:      priceRecord.KUSD,
+		KshMA:     priceRecord.KSHMA,
+		KrvMA:     priceRecord.KRVMA,
+		KusdMA:    priceRecord.KUSDMA,
+		Timestamp: priceRecord.Timestamp,
+	}
+}
+
+// DbPriceRecordToPriceRecord converts *DbPriceRecord to *oracle.PriceRecord
+func DbPriceRecordToPriceRecord(dbPriceRecord *DbPriceRecord) *oracle.PriceRecord {
+	return &oracle.PriceRecord{
+		KSH:       dbPriceRecord.Ksh,
+		KRV:       dbPriceRecord.Krv,
+		KUSD:      dbPriceRecord.Kusd,
+		KSHMA:     dbPriceRecord.KshMA,
+		KRVMA:     dbPriceRecord.KrvMA,
+		KUSDMA:    dbPriceRecord.KusdMA,
+		Timestamp: dbPriceRecord.Timestamp,
+	}
+}
@@ -0,0 +1,23 @@
+package serialization
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+)
+
+// ReserveStateToDbReserveState converts *externalapi.ReserveState to *DbReserveState
+func ReserveStateToDbReserveState(reserveState *externalapi.ReserveState) *DbReserveState {
+	return &DbReserveState{
+		Reserve:    reserveState.Reserve,
+		KusdSupply: reserveState.KUSDSupply,
+		KrvSupply:  reserveState.KRVSupply,
+	}
+}
+
+// DbReserveStateToReserveState converts *DbReserveState to *externalapi.ReserveState
+func DbReserveStateToReserveState(dbReserveState *DbReserveState) *externalapi.ReserveState {
+	return &externalapi.ReserveState{
+		Reserve:    dbReserveState.Reserve,
+		KUSDSupply: dbReserveState.KusdSupply,
+		KRVSupply:  dbReserveState.KrvSupply,
+	}
+}
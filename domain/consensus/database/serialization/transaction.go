@@ -36,6 +36,7 @@ func DomainTransactionToDbTransaction(domainTransaction *externalapi.DomainTrans
 		SubnetworkID: DomainSubnetworkIDToDbSubnetworkID(&domainTransaction.SubnetworkID),
 		Gas:          domainTransaction.Gas,
 		Payload:      domainTransaction.Payload,
+		Type:         uint32(domainTransaction.Type),
 	}
 }
 
@@ -83,5 +84,6 @@ func DbTransactionToDomainTransaction(dbTransaction *DbTransaction) (*externalap
 		SubnetworkID: *domainSubnetworkID,
 		Gas:          dbTransaction.Gas,
 		Payload:      dbTransaction.Payload,
+		Type:         externalapi.DomainTransactionType(dbTransaction.Type),
 	}, nil
 }
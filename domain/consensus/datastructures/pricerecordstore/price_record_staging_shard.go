@@ -0,0 +1,51 @@
+package pricerecordstore
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+)
+
+type priceRecordStagingShard struct {
+	store    *priceRecordStore
+	toAdd    map[externalapi.DomainHash]*oracle.PriceRecord
+	toDelete map[externalapi.DomainHash]struct{}
+}
+
+func (prs *priceRecordStore) stagingShard(stagingArea *model.StagingArea) *priceRecordStagingShard {
+	return stagingArea.GetOrCreateShard(prs.shardID, func() model.StagingShard {
+		return &priceRecordStagingShard{
+			store:    prs,
+			toAdd:    make(map[externalapi.DomainHash]*oracle.PriceRecord),
+			toDelete: make(map[externalapi.DomainHash]struct{}),
+		}
+	}).(*priceRecordStagingShard)
+}
+
+func (prss *priceRecordStagingShard) Commit(dbTx model.DBTransaction) error {
+	for hash, priceRecord := range prss.toAdd {
+		priceRecordBytes, err := prss.store.serializePriceRecord(priceRecord)
+		if err != nil {
+			return err
+		}
+		err = dbTx.Put(prss.store.hashAsKey(&hash), priceRecordBytes)
+		if err != nil {
+			return err
+		}
+		prss.store.cache.Add(&hash, priceRecord)
+	}
+
+	for hash := range prss.toDelete {
+		err := dbTx.Delete(prss.store.hashAsKey(&hash))
+		if err != nil {
+			return err
+		}
+		prss.store.cache.Remove(&hash)
+	}
+
+	return nil
+}
+
+func (prss *priceRecordStagingShard) isStaged() bool {
+	return len(prss.toAdd) != 0 || len(prss.toDelete) != 0
+}
@@ -0,0 +1,114 @@
+package pricerecordstore
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/database/serialization"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/lrucache"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+	"github.com/Kash-Protocol/kashd/util/staging"
+	"github.com/golang/protobuf/proto"
+)
+
+var bucketName = []byte("price-records")
+
+// priceRecordStore represents a store of the price records in effect at blocks
+type priceRecordStore struct {
+	shardID model.StagingShardID
+	cache   *lrucache.LRUCache
+	bucket  model.DBBucket
+}
+
+// New instantiates a new PriceRecordStore
+func New(prefixBucket model.DBBucket, cacheSize int, preallocate bool) model.PriceRecordStore {
+	return &priceRecordStore{
+		shardID: staging.GenerateShardingID(),
+		cache:   lrucache.New(cacheSize, preallocate),
+		bucket:  prefixBucket.Bucket(bucketName),
+	}
+}
+
+// Stage stages the given priceRecord for the given blockHash
+func (prs *priceRecordStore) Stage(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash, priceRecord *oracle.PriceRecord) {
+	stagingShard := prs.stagingShard(stagingArea)
+
+	delete(stagingShard.toDelete, *blockHash)
+	stagingShard.toAdd[*blockHash] = priceRecord.Clone()
+}
+
+func (prs *priceRecordStore) IsStaged(stagingArea *model.StagingArea) bool {
+	return prs.stagingShard(stagingArea).isStaged()
+}
+
+// Get gets the price record in effect at the given blockHash
+func (prs *priceRecordStore) Get(dbContext model.DBReader, stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (*oracle.PriceRecord, error) {
+	stagingShard := prs.stagingShard(stagingArea)
+
+	if priceRecord, ok := stagingShard.toAdd[*blockHash]; ok {
+		return priceRecord.Clone(), nil
+	}
+
+	if priceRecord, ok := prs.cache.Get(blockHash); ok {
+		return priceRecord.(*oracle.PriceRecord).Clone(), nil
+	}
+
+	priceRecordBytes, err := dbContext.Get(prs.hashAsKey(blockHash))
+	if err != nil {
+		return nil, err
+	}
+
+	priceRecord, err := prs.deserializePriceRecord(priceRecordBytes)
+	if err != nil {
+		return nil, err
+	}
+	prs.cache.Add(blockHash, priceRecord)
+	return priceRecord.Clone(), nil
+}
+
+// Has returns whether a price record is in effect at the given blockHash
+func (prs *priceRecordStore) Has(dbContext model.DBReader, stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (bool, error) {
+	stagingShard := prs.stagingShard(stagingArea)
+
+	if _, ok := stagingShard.toAdd[*blockHash]; ok {
+		return true, nil
+	}
+
+	if _, ok := stagingShard.toDelete[*blockHash]; ok {
+		return false, nil
+	}
+
+	if prs.cache.Has(blockHash) {
+		return true, nil
+	}
+
+	return dbContext.Has(prs.hashAsKey(blockHash))
+}
+
+// Delete deletes the price record associated with the given blockHash
+func (prs *priceRecordStore) Delete(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) {
+	stagingShard := prs.stagingShard(stagingArea)
+
+	if _, ok := stagingShard.toAdd[*blockHash]; ok {
+		delete(stagingShard.toAdd, *blockHash)
+		return
+	}
+	stagingShard.toDelete[*blockHash] = struct{}{}
+}
+
+func (prs *priceRecordStore) hashAsKey(hash *externalapi.DomainHash) model.DBKey {
+	return prs.bucket.Key(hash.ByteSlice())
+}
+
+func (prs *priceRecordStore) serializePriceRecord(priceRecord *oracle.PriceRecord) ([]byte, error) {
+	return proto.Marshal(serialization.PriceRecordToDbPriceRecord(priceRecord))
+}
+
+func (prs *priceRecordStore) deserializePriceRecord(priceRecordBytes []byte) (*oracle.PriceRecord, error) {
+	dbPriceRecord := &serialization.DbPriceRecord{}
+	err := proto.Unmarshal(priceRecordBytes, dbPriceRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	return serialization.DbPriceRecordToPriceRecord(dbPriceRecord), nil
+}
@@ -0,0 +1,50 @@
+package reservestatestore
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+)
+
+type reserveStateStagingShard struct {
+	store    *reserveStateStore
+	toAdd    map[externalapi.DomainHash]*externalapi.ReserveState
+	toDelete map[externalapi.DomainHash]struct{}
+}
+
+func (rss *reserveStateStore) stagingShard(stagingArea *model.StagingArea) *reserveStateStagingShard {
+	return stagingArea.GetOrCreateShard(rss.shardID, func() model.StagingShard {
+		return &reserveStateStagingShard{
+			store:    rss,
+			toAdd:    make(map[externalapi.DomainHash]*externalapi.ReserveState),
+			toDelete: make(map[externalapi.DomainHash]struct{}),
+		}
+	}).(*reserveStateStagingShard)
+}
+
+func (rsss *reserveStateStagingShard) Commit(dbTx model.DBTransaction) error {
+	for hash, reserveState := range rsss.toAdd {
+		reserveStateBytes, err := rsss.store.serializeReserveState(reserveState)
+		if err != nil {
+			return err
+		}
+		err = dbTx.Put(rsss.store.hashAsKey(&hash), reserveStateBytes)
+		if err != nil {
+			return err
+		}
+		rsss.store.cache.Add(&hash, reserveState)
+	}
+
+	for hash := range rsss.toDelete {
+		err := dbTx.Delete(rsss.store.hashAsKey(&hash))
+		if err != nil {
+			return err
+		}
+		rsss.store.cache.Remove(&hash)
+	}
+
+	return nil
+}
+
+func (rsss *reserveStateStagingShard) isStaged() bool {
+	return len(rsss.toAdd) != 0 || len(rsss.toDelete) != 0
+}
@@ -0,0 +1,113 @@
+package reservestatestore
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/database/serialization"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/lrucache"
+	"github.com/Kash-Protocol/kashd/util/staging"
+	"github.com/golang/protobuf/proto"
+)
+
+var bucketName = []byte("reserve-states")
+
+// reserveStateStore represents a store of the Djed reserve states at blocks
+type reserveStateStore struct {
+	shardID model.StagingShardID
+	cache   *lrucache.LRUCache
+	bucket  model.DBBucket
+}
+
+// New instantiates a new ReserveStateStore
+func New(prefixBucket model.DBBucket, cacheSize int, preallocate bool) model.ReserveStateStore {
+	return &reserveStateStore{
+		shardID: staging.GenerateShardingID(),
+		cache:   lrucache.New(cacheSize, preallocate),
+		bucket:  prefixBucket.Bucket(bucketName),
+	}
+}
+
+// Stage stages the given reserveState for the given blockHash
+func (rss *reserveStateStore) Stage(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash, reserveState *externalapi.ReserveState) {
+	stagingShard := rss.stagingShard(stagingArea)
+
+	delete(stagingShard.toDelete, *blockHash)
+	stagingShard.toAdd[*blockHash] = reserveState.Clone()
+}
+
+func (rss *reserveStateStore) IsStaged(stagingArea *model.StagingArea) bool {
+	return rss.stagingShard(stagingArea).isStaged()
+}
+
+// Get gets the reserve state at the given blockHash
+func (rss *reserveStateStore) Get(dbContext model.DBReader, stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (*externalapi.ReserveState, error) {
+	stagingShard := rss.stagingShard(stagingArea)
+
+	if reserveState, ok := stagingShard.toAdd[*blockHash]; ok {
+		return reserveState.Clone(), nil
+	}
+
+	if reserveState, ok := rss.cache.Get(blockHash); ok {
+		return reserveState.(*externalapi.ReserveState).Clone(), nil
+	}
+
+	reserveStateBytes, err := dbContext.Get(rss.hashAsKey(blockHash))
+	if err != nil {
+		return nil, err
+	}
+
+	reserveState, err := rss.deserializeReserveState(reserveStateBytes)
+	if err != nil {
+		return nil, err
+	}
+	rss.cache.Add(blockHash, reserveState)
+	return reserveState.Clone(), nil
+}
+
+// Has returns whether a reserve state is stored for the given blockHash
+func (rss *reserveStateStore) Has(dbContext model.DBReader, stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (bool, error) {
+	stagingShard := rss.stagingShard(stagingArea)
+
+	if _, ok := stagingShard.toAdd[*blockHash]; ok {
+		return true, nil
+	}
+
+	if _, ok := stagingShard.toDelete[*blockHash]; ok {
+		return false, nil
+	}
+
+	if rss.cache.Has(blockHash) {
+		return true, nil
+	}
+
+	return dbContext.Has(rss.hashAsKey(blockHash))
+}
+
+// Delete deletes the reserve state associated with the given blockHash
+func (rss *reserveStateStore) Delete(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) {
+	stagingShard := rss.stagingShard(stagingArea)
+
+	if _, ok := stagingShard.toAdd[*blockHash]; ok {
+		delete(stagingShard.toAdd, *blockHash)
+		return
+	}
+	stagingShard.toDelete[*blockHash] = struct{}{}
+}
+
+func (rss *reserveStateStore) hashAsKey(hash *externalapi.DomainHash) model.DBKey {
+	return rss.bucket.Key(hash.ByteSlice())
+}
+
+func (rss *reserveStateStore) serializeReserveState(reserveState *externalapi.ReserveState) ([]byte, error) {
+	return proto.Marshal(serialization.ReserveStateToDbReserveState(reserveState))
+}
+
+func (rss *reserveStateStore) deserializeReserveState(reserveStateBytes []byte) (*externalapi.ReserveState, error) {
+	dbReserveState := &serialization.DbReserveState{}
+	err := proto.Unmarshal(reserveStateBytes, dbReserveState)
+	if err != nil {
+		return nil, err
+	}
+
+	return serialization.DbReserveStateToReserveState(dbReserveState), nil
+}
@@ -31,8 +31,10 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/datastructures/headersselectedchainstore"
 	"github.com/Kash-Protocol/kashd/domain/consensus/datastructures/headersselectedtipstore"
 	"github.com/Kash-Protocol/kashd/domain/consensus/datastructures/multisetstore"
+	"github.com/Kash-Protocol/kashd/domain/consensus/datastructures/pricerecordstore"
 	"github.com/Kash-Protocol/kashd/domain/consensus/datastructures/pruningstore"
 	"github.com/Kash-Protocol/kashd/domain/consensus/datastructures/reachabilitydatastore"
+	"github.com/Kash-Protocol/kashd/domain/consensus/datastructures/reservestatestore"
 	"github.com/Kash-Protocol/kashd/domain/consensus/datastructures/utxodiffstore"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/testapi"
@@ -44,6 +46,7 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/processes/dagtopologymanager"
 	"github.com/Kash-Protocol/kashd/domain/consensus/processes/dagtraversalmanager"
 	"github.com/Kash-Protocol/kashd/domain/consensus/processes/difficultymanager"
+	"github.com/Kash-Protocol/kashd/domain/consensus/processes/djedmanager"
 	"github.com/Kash-Protocol/kashd/domain/consensus/processes/finalitymanager"
 	"github.com/Kash-Protocol/kashd/domain/consensus/processes/ghostdagmanager"
 	"github.com/Kash-Protocol/kashd/domain/consensus/processes/headersselectedtipmanager"
@@ -53,6 +56,8 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/processes/reachabilitymanager"
 	"github.com/Kash-Protocol/kashd/domain/consensus/processes/syncmanager"
 	"github.com/Kash-Protocol/kashd/domain/consensus/processes/transactionvalidator"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
 	"github.com/Kash-Protocol/kashd/domain/dagconfig"
 	infrastructuredatabase "github.com/Kash-Protocol/kashd/infrastructure/db/database"
 	"github.com/Kash-Protocol/kashd/infrastructure/db/database/ldb"
@@ -153,6 +158,8 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 	finalityStore := finalitystore.New(prefixBucket, 200, preallocateCaches)
 	headersSelectedChainStore := headersselectedchainstore.New(prefixBucket, pruningWindowSizeForCaches, preallocateCaches)
 	daaBlocksStore := daablocksstore.New(prefixBucket, pruningWindowSizeForCaches, int(config.FinalityDepth()), preallocateCaches)
+	priceRecordStore := pricerecordstore.New(prefixBucket, 200, preallocateCaches)
+	reserveStateStore := reservestatestore.New(prefixBucket, 200, preallocateCaches)
 	windowHeapSliceStore := blockwindowheapslicestore.New(2000, preallocateCaches)
 
 	newReachabilityDataStore := reachabilitydatastore.New(prefixBucket, pruningWindowSizePlusFinalityDepthForCache*2, preallocateCaches)
@@ -205,6 +212,26 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 	)
 
 	txMassCalculator := txmass.NewCalculator(config.MassPerTxByte, config.MassPerScriptPubKeyByte, config.MassPerSigOp)
+	djedCalculator := djed.NewCalculator(config.MinReserveRatio, config.MaxReserveRatio,
+		config.ConversionFeeBasisPoints, config.KRVMinimalPrice)
+	oraclePublicKeys, err := oracle.ParsePublicKeySet(config.OraclePublicKeys)
+	if err != nil {
+		return nil, false, err
+	}
+	djedManager := djedmanager.New(
+		dbManager,
+
+		oraclePublicKeys,
+		config.OracleMinRecords,
+		config.OracleMaxRecordAge,
+		config.OracleMaxPriceDeviation,
+		djedCalculator,
+
+		blockStore,
+		ghostdagDataStore,
+		priceRecordStore,
+		reserveStateStore,
+	)
 
 	pastMedianTimeManager := f.pastMedianTimeConsructor(
 		config.TimestampDeviationTolerance,
@@ -222,6 +249,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		pastMedianTimeManager,
 		ghostdagDataStore,
 		daaBlocksStore,
+		djedManager,
 		txMassCalculator)
 	difficultyManager := f.difficultyConstructor(
 		dbManager,
@@ -292,6 +320,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		mergeDepthManager,
 		finalityManager,
 		difficultyManager,
+		djedManager,
 
 		blockStatusStore,
 		ghostdagDataStore,
@@ -304,7 +333,8 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		blockHeaderStore,
 		headersSelectedTipStore,
 		pruningStore,
-		daaBlocksStore)
+		daaBlocksStore,
+		reserveStateStore)
 	if err != nil {
 		return nil, false, err
 	}
@@ -329,6 +359,8 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		daaBlocksStore,
 		reachabilityDataStore,
 		daaWindowStore,
+		priceRecordStore,
+		reserveStateStore,
 
 		config.IsArchival,
 		genesisHash,
@@ -365,6 +397,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		blockParentBuilder,
 		pruningManager,
 		parentsManager,
+		djedManager,
 
 		pruningStore,
 		blockStore,
@@ -407,12 +440,14 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		finalityManager,
 		blockParentBuilder,
 		pruningManager,
+		djedManager,
 
 		acceptanceDataStore,
 		blockRelationStore,
 		multisetStore,
 		ghostdagDataStore,
 		daaBlocksStore,
+		reserveStateStore,
 	)
 
 	blockProcessor := blockprocessor.New(
@@ -430,6 +465,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		coinbaseManager,
 		headerTipsManager,
 		syncManager,
+		djedManager,
 
 		acceptanceDataStore,
 		blockStore,
@@ -446,7 +482,8 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		finalityStore,
 		headersSelectedChainStore,
 		daaBlocksStore,
-		daaWindowStore)
+		daaWindowStore,
+		reserveStateStore)
 
 	pruningProofManager := pruningproofmanager.New(
 		dbManager,
@@ -500,6 +537,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		reachabilityManager:   reachabilityManager,
 		finalityManager:       finalityManager,
 		pruningProofManager:   pruningProofManager,
+		djedManager:           djedManager,
 
 		acceptanceDataStore:                 acceptanceDataStore,
 		blockStore:                          blockStore,
@@ -517,6 +555,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		headersSelectedChainStore:           headersSelectedChainStore,
 		daaBlocksStore:                      daaBlocksStore,
 		blocksWithTrustedDataDAAWindowStore: daaWindowStore,
+		reserveStateStore:                   reserveStateStore,
 
 		consensusEventsChan: consensusEventsChan,
 		virtualNotUpdated:   true,
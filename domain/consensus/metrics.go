@@ -0,0 +1,16 @@
+package consensus
+
+import (
+	"github.com/Kash-Protocol/kashd/infrastructure/metrics"
+)
+
+var (
+	blocksProcessed = metrics.NewCounter("kashd_blocks_processed_total",
+		"The number of blocks that were validated and inserted into the DAG along with their transactions")
+	headersProcessed = metrics.NewCounter("kashd_headers_processed_total",
+		"The number of block headers that were validated and inserted into the DAG without their transactions")
+	blockProcessingDuration = metrics.NewHistogram("kashd_block_processing_duration_seconds",
+		"The time it took to validate and insert a block or a block header into the DAG", metrics.DurationBuckets)
+	virtualDAAScoreGauge = metrics.NewGauge("kashd_virtual_daa_score",
+		"The DAA score of the virtual block")
+)
@@ -113,7 +113,21 @@ func GetAssetTypeFromDomainTransactionType(domainTransactionType DomainTransacti
 		return KSH, KRV
 	case RedeemKSH:
 		return KRV, KSH
+	case RedeemKUSD:
+		return KUSD, KSH
 	default:
 		return UNKNOWN, UNKNOWN
 	}
 }
+
+// GetDomainTransactionTypeFromAssetTypes returns the DomainTransactionType that spends UTXOs of
+// inputAssetType and creates UTXOs of outputAssetType. It returns false if there is no such type.
+func GetDomainTransactionTypeFromAssetTypes(inputAssetType, outputAssetType AssetType) (DomainTransactionType, bool) {
+	for txType := TransferKSH; txType <= RedeemKUSD; txType++ {
+		txInputAssetType, txOutputAssetType := GetAssetTypeFromDomainTransactionType(txType)
+		if txInputAssetType == inputAssetType && txOutputAssetType == outputAssetType {
+			return txType, true
+		}
+	}
+	return 0, false
+}
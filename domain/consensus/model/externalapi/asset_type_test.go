@@ -0,0 +1,21 @@
+package externalapi
+
+import "testing"
+
+func TestGetDomainTransactionTypeFromAssetTypes(t *testing.T) {
+	for txType := TransferKSH; txType <= RedeemKUSD; txType++ {
+		inputAssetType, outputAssetType := GetAssetTypeFromDomainTransactionType(txType)
+		gotTxType, ok := GetDomainTransactionTypeFromAssetTypes(inputAssetType, outputAssetType)
+		if !ok {
+			t.Fatalf("no transaction type found for %s -> %s", inputAssetType, outputAssetType)
+		}
+		if gotTxType != txType {
+			t.Fatalf("expected %s for %s -> %s but got %s", txType, inputAssetType, outputAssetType, gotTxType)
+		}
+	}
+
+	_, ok := GetDomainTransactionTypeFromAssetTypes(KUSD, KRV)
+	if ok {
+		t.Fatalf("KUSD is not expected to be convertible into KRV")
+	}
+}
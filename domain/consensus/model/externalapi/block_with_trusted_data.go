@@ -8,6 +8,14 @@ type BlockWithTrustedData struct {
 	Block        *DomainBlock
 	DAAWindow    []*TrustedDataDataDAAHeader
 	GHOSTDAGData []*BlockGHOSTDAGDataHashPair
+
+	// OraclePrices are the prices of the oracle price record in effect at Block,
+	// or nil if there is none
+	OraclePrices *OraclePrices
+
+	// ReserveState is the reserve state after the conversions accepted by Block. It's
+	// only provided for the pruning point, whose UTXO commitment commits to it.
+	ReserveState *ReserveState
 }
 
 // TrustedDataDataDAAHeader is a block that belongs to BlockWithTrustedData.DAAWindow
@@ -7,6 +7,9 @@ import "bytes"
 type DomainCoinbaseData struct {
 	ScriptPublicKey *ScriptPublicKey
 	ExtraData       []byte
+
+	// OracleData holds the serialized signed price records the coinbase commits to
+	OracleData []byte
 }
 
 // Clone returns a clone of DomainCoinbaseData
@@ -18,9 +21,16 @@ func (dcd *DomainCoinbaseData) Clone() *DomainCoinbaseData {
 	extraDataClone := make([]byte, len(dcd.ExtraData))
 	copy(extraDataClone, dcd.ExtraData)
 
+	var oracleDataClone []byte
+	if dcd.OracleData != nil {
+		oracleDataClone = make([]byte, len(dcd.OracleData))
+		copy(oracleDataClone, dcd.OracleData)
+	}
+
 	return &DomainCoinbaseData{
 		ScriptPublicKey: &ScriptPublicKey{Script: scriptPubKeyClone, Version: dcd.ScriptPublicKey.Version},
 		ExtraData:       extraDataClone,
+		OracleData:      oracleDataClone,
 	}
 }
 
@@ -34,5 +44,9 @@ func (dcd *DomainCoinbaseData) Equal(other *DomainCoinbaseData) bool {
 		return false
 	}
 
+	if !bytes.Equal(dcd.OracleData, other.OracleData) {
+		return false
+	}
+
 	return dcd.ScriptPublicKey.Equal(other.ScriptPublicKey)
 }
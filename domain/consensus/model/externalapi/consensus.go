@@ -40,6 +40,8 @@ type Consensus interface {
 	Tips() ([]*DomainHash, error)
 	GetVirtualInfo() (*VirtualInfo, error)
 	GetVirtualDAAScore() (uint64, error)
+	GetVirtualReserveInfo() (*ReserveInfo, error)
+	GetVirtualOraclePrices() (prices *OraclePrices, found bool, err error)
 	IsValidPruningPoint(blockHash *DomainHash) (bool, error)
 	ArePruningPointsViolatingFinality(pruningPoints []BlockHeader) (bool, error)
 	GetVirtualSelectedParentChainFromBlock(blockHash *DomainHash) (*SelectedChainPath, error)
@@ -53,6 +55,8 @@ type Consensus interface {
 	TrustedDataDataDAAHeader(trustedBlockHash, daaBlockHash *DomainHash, daaBlockWindowIndex uint64) (*TrustedDataDataDAAHeader, error)
 	TrustedBlockAssociatedGHOSTDAGDataBlockHashes(blockHash *DomainHash) ([]*DomainHash, error)
 	TrustedGHOSTDAGData(blockHash *DomainHash) (*BlockGHOSTDAGData, error)
+	TrustedOraclePrices(blockHash *DomainHash) (prices *OraclePrices, found bool, err error)
+	TrustedReserveState(blockHash *DomainHash) (*ReserveState, error)
 	IsChainBlock(blockHash *DomainHash) (bool, error)
 	VirtualMergeDepthRoot() (*DomainHash, error)
 	IsNearlySynced() (bool, error)
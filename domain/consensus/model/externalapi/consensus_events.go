@@ -19,6 +19,7 @@ type VirtualChangeSet struct {
 	VirtualParents                    []*DomainHash
 	VirtualSelectedParentBlueScore    uint64
 	VirtualDAAScore                   uint64
+	VirtualReserveInfo                *ReserveInfo
 }
 
 func (*VirtualChangeSet) isConsensusEvent() {}
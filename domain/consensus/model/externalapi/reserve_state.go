@@ -0,0 +1,66 @@
+package externalapi
+
+// ReserveState is the state of the Djed reserve: the KSH held in reserve
+// and the KUSD and KRV issued against it
+type ReserveState struct {
+	Reserve    uint64
+	KUSDSupply uint64
+	KRVSupply  uint64
+}
+
+// If this doesn't compile, it means the type definition has been changed, so it's
+// an indication to update Equal and Clone accordingly.
+var _ = ReserveState{0, 0, 0}
+
+// Clone returns a clone of ReserveState
+func (rs *ReserveState) Clone() *ReserveState {
+	return &ReserveState{
+		Reserve:    rs.Reserve,
+		KUSDSupply: rs.KUSDSupply,
+		KRVSupply:  rs.KRVSupply,
+	}
+}
+
+// Equal returns whether rs equals to other
+func (rs *ReserveState) Equal(other *ReserveState) bool {
+	if rs == nil || other == nil {
+		return rs == other
+	}
+
+	return rs.Reserve == other.Reserve &&
+		rs.KUSDSupply == other.KUSDSupply &&
+		rs.KRVSupply == other.KRVSupply
+}
+
+// ReserveInfo describes the Djed reserve state at some block, valued at the
+// exchange rate in effect at that block
+type ReserveInfo struct {
+	ReserveState *ReserveState
+
+	// ExchangeRate is the value of one KSH in KUSD sompi, or zero if no
+	// exchange rate is in effect. The fields below are zero without one.
+	ExchangeRate uint64
+
+	// KUSDLiabilities is the KSH, in sompi, owed to KUSD holders at the target price
+	KUSDLiabilities uint64
+
+	// KRVEquity is the KSH, in sompi, of the reserve that is not owed to KUSD holders
+	KRVEquity uint64
+
+	// ReserveRatioBasisPoints is the reserve divided by the KUSD liabilities, in
+	// basis points. It is zero if there are no KUSD liabilities.
+	ReserveRatioBasisPoints uint64
+}
+
+// OraclePrices are the prices of the oracle price record in effect at some block.
+// Prices are the USD price of one unit multiplied by constants.SompiPerKash, and
+// Timestamp is in milliseconds since the epoch.
+type OraclePrices struct {
+	KSH       uint64
+	KRV       uint64
+	KUSD      uint64
+	KSHMA     uint64
+	KRVMA     uint64
+	KUSDMA    uint64
+	Timestamp int64
+}
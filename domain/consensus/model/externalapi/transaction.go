@@ -141,7 +141,7 @@ func (tx *DomainTransaction) InputUTXOAssetType() AssetType {
 	switch tx.Type {
 	case TransferKSH, MintKUSD, StakeKSH:
 		return KSH
-	case TransferKUSD:
+	case TransferKUSD, RedeemKUSD:
 		return KUSD
 	case TransferKRV, RedeemKSH:
 		return KRV
@@ -153,7 +153,7 @@ func (tx *DomainTransaction) InputUTXOAssetType() AssetType {
 // OutputUTXOAssetType returns the asset type of the output UTXOs based on the transaction type.
 func (tx *DomainTransaction) OutputUTXOAssetType() AssetType {
 	switch tx.Type {
-	case TransferKSH, RedeemKSH:
+	case TransferKSH, RedeemKSH, RedeemKUSD:
 		return KSH
 	case TransferKUSD, MintKUSD:
 		return KUSD
@@ -410,6 +410,7 @@ const (
 	MintKUSD                                  // KSH -> KUSD: Minting KUSD using KSH
 	StakeKSH                                  // KSH -> KRV: Staking KSH to get KRV
 	RedeemKSH                                 // KRV -> KSH: Redeeming KSH using KRV
+	RedeemKUSD                                // KUSD -> KSH: Redeeming KSH using KUSD
 )
 
 func (t DomainTransactionType) String() string {
@@ -426,7 +427,20 @@ func (t DomainTransactionType) String() string {
 		return "StakeKSH"
 	case RedeemKSH:
 		return "RedeemKSH"
+	case RedeemKUSD:
+		return "RedeemKUSD"
 	default:
 		return "Unknown"
 	}
 }
+
+// IsConversion returns whether transactions of this type convert one asset
+// into another through the Djed reserve
+func (t DomainTransactionType) IsConversion() bool {
+	switch t {
+	case MintKUSD, StakeKSH, RedeemKSH, RedeemKUSD:
+		return true
+	default:
+		return false
+	}
+}
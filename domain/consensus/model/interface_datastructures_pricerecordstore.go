@@ -0,0 +1,16 @@
+package model
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+)
+
+// PriceRecordStore represents a store of the price records in effect at blocks
+type PriceRecordStore interface {
+	Store
+	Stage(stagingArea *StagingArea, blockHash *externalapi.DomainHash, priceRecord *oracle.PriceRecord)
+	IsStaged(stagingArea *StagingArea) bool
+	Get(dbContext DBReader, stagingArea *StagingArea, blockHash *externalapi.DomainHash) (*oracle.PriceRecord, error)
+	Has(dbContext DBReader, stagingArea *StagingArea, blockHash *externalapi.DomainHash) (bool, error)
+	Delete(stagingArea *StagingArea, blockHash *externalapi.DomainHash)
+}
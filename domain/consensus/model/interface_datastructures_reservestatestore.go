@@ -0,0 +1,13 @@
+package model
+
+import "github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+
+// ReserveStateStore represents a store of the Djed reserve states at blocks
+type ReserveStateStore interface {
+	Store
+	Stage(stagingArea *StagingArea, blockHash *externalapi.DomainHash, reserveState *externalapi.ReserveState)
+	IsStaged(stagingArea *StagingArea) bool
+	Get(dbContext DBReader, stagingArea *StagingArea, blockHash *externalapi.DomainHash) (*externalapi.ReserveState, error)
+	Has(dbContext DBReader, stagingArea *StagingArea, blockHash *externalapi.DomainHash) (bool, error)
+	Delete(stagingArea *StagingArea, blockHash *externalapi.DomainHash)
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+)
+
+// DjedManager provides the exchange rate and the reserve state that
+// Djed conversion transactions are validated against
+type DjedManager interface {
+	ExchangeRate(stagingArea *StagingArea, blockHash *externalapi.DomainHash) (uint64, error)
+	ReserveState(stagingArea *StagingArea, blockHash *externalapi.DomainHash) (*externalapi.ReserveState, error)
+	ApplyConversion(stagingArea *StagingArea, povBlockHash *externalapi.DomainHash, reserveState *externalapi.ReserveState,
+		tx *externalapi.DomainTransaction, totalSompiIn uint64) (newReserveState *externalapi.ReserveState, fee uint64, err error)
+	CalculateReserveState(stagingArea *StagingArea, blockHash *externalapi.DomainHash,
+		acceptanceData externalapi.AcceptanceData) (*externalapi.ReserveState, error)
+	ReserveInfo(stagingArea *StagingArea, blockHash *externalapi.DomainHash) (*externalapi.ReserveInfo, error)
+
+	PriceRecord(stagingArea *StagingArea, blockHash *externalapi.DomainHash) (priceRecord *oracle.PriceRecord, found bool, err error)
+	ValidateOracleData(oracleData []byte, blockTimeInMilliseconds int64) (*oracle.PriceRecord, error)
+	ValidatePriceDeviation(stagingArea *StagingArea, blockHash *externalapi.DomainHash, priceRecord *oracle.PriceRecord) error
+	StagePriceRecord(stagingArea *StagingArea, blockHash *externalapi.DomainHash, isBlockWithTrustedData bool) error
+	CalculatePriceRecord(stagingArea *StagingArea, blockHash *externalapi.DomainHash, coinbasePayload []byte,
+		blockTimeInMilliseconds int64) (*oracle.PriceRecord, error)
+	StageTrustedPriceRecord(stagingArea *StagingArea, blockHash *externalapi.DomainHash, priceRecord *oracle.PriceRecord)
+}
@@ -74,6 +74,7 @@ type TestConsensus interface {
 	UTXODiffStore() model.UTXODiffStore
 	HeadersSelectedChainStore() model.HeadersSelectedChainStore
 	DAABlocksStore() model.DAABlocksStore
+	ReserveStateStore() model.ReserveStateStore
 
 	BlockBuilder() TestBlockBuilder
 	BlockProcessor() model.BlockProcessor
@@ -84,6 +85,7 @@ type TestConsensus interface {
 	DAGTopologyManager() model.DAGTopologyManager
 	DAGTraversalManager() model.DAGTraversalManager
 	DifficultyManager() model.DifficultyManager
+	DjedManager() model.DjedManager
 	GHOSTDAGManager() model.GHOSTDAGManager
 	HeaderTipsManager() model.HeadersSelectedTipManager
 	MergeDepthManager() model.MergeDepthManager
@@ -12,7 +12,9 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/merkle"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionhelper"
 	"github.com/Kash-Protocol/kashd/infrastructure/logger"
 	"github.com/Kash-Protocol/kashd/util/mstime"
 )
@@ -30,12 +32,14 @@ type blockBuilder struct {
 	finalityManager       model.FinalityManager
 	pruningManager        model.PruningManager
 	blockParentBuilder    model.BlockParentBuilder
+	djedManager           model.DjedManager
 
 	acceptanceDataStore model.AcceptanceDataStore
 	blockRelationStore  model.BlockRelationStore
 	multisetStore       model.MultisetStore
 	ghostdagDataStore   model.GHOSTDAGDataStore
 	daaBlocksStore      model.DAABlocksStore
+	reserveStateStore   model.ReserveStateStore
 }
 
 // New creates a new instance of a BlockBuilder
@@ -52,12 +56,14 @@ func New(
 	finalityManager model.FinalityManager,
 	blockParentBuilder model.BlockParentBuilder,
 	pruningManager model.PruningManager,
+	djedManager model.DjedManager,
 
 	acceptanceDataStore model.AcceptanceDataStore,
 	blockRelationStore model.BlockRelationStore,
 	multisetStore model.MultisetStore,
 	ghostdagDataStore model.GHOSTDAGDataStore,
 	daaBlocksStore model.DAABlocksStore,
+	reserveStateStore model.ReserveStateStore,
 ) model.BlockBuilder {
 
 	return &blockBuilder{
@@ -73,12 +79,14 @@ func New(
 		finalityManager:       finalityManager,
 		blockParentBuilder:    blockParentBuilder,
 		pruningManager:        pruningManager,
+		djedManager:           djedManager,
 
 		acceptanceDataStore: acceptanceDataStore,
 		blockRelationStore:  blockRelationStore,
 		multisetStore:       multisetStore,
 		ghostdagDataStore:   ghostdagDataStore,
 		daaBlocksStore:      daaBlocksStore,
+		reserveStateStore:   reserveStateStore,
 	}
 }
 
@@ -107,13 +115,21 @@ func (bb *blockBuilder) buildBlock(stagingArea *model.StagingArea, coinbaseData
 	if err != nil {
 		return nil, false, err
 	}
+	timeInMilliseconds, err := bb.newBlockTime(stagingArea)
+	if err != nil {
+		return nil, false, err
+	}
+	coinbaseData, err = bb.newBlockCoinbaseData(stagingArea, coinbaseData, timeInMilliseconds)
+	if err != nil {
+		return nil, false, err
+	}
 	coinbase, coinbaseHasRedReward, err := bb.newBlockCoinbaseTransaction(stagingArea, coinbaseData)
 	if err != nil {
 		return nil, false, err
 	}
 	transactionsWithCoinbase := append([]*externalapi.DomainTransaction{coinbase}, transactions...)
 
-	header, err := bb.buildHeader(stagingArea, transactionsWithCoinbase, newBlockPruningPoint)
+	header, err := bb.buildHeader(stagingArea, transactionsWithCoinbase, newBlockPruningPoint, timeInMilliseconds)
 	if err != nil {
 		return nil, false, err
 	}
@@ -180,6 +196,32 @@ func (bb *blockBuilder) validateTransaction(
 	return bb.transactionValidator.ValidateTransactionInContextAndPopulateFee(stagingArea, transaction, model.VirtualBlockHash)
 }
 
+// newBlockCoinbaseData returns the given coinbaseData, without its oracle data if
+// a block with the given timestamp cannot commit to it. A stale price record should
+// not prevent a miner from mining, so it's dropped rather than failing the build.
+func (bb *blockBuilder) newBlockCoinbaseData(stagingArea *model.StagingArea,
+	coinbaseData *externalapi.DomainCoinbaseData, timeInMilliseconds int64) (*externalapi.DomainCoinbaseData, error) {
+
+	if len(coinbaseData.OracleData) == 0 {
+		return coinbaseData, nil
+	}
+
+	priceRecord, err := bb.djedManager.ValidateOracleData(coinbaseData.OracleData, timeInMilliseconds)
+	if err == nil {
+		err = bb.djedManager.ValidatePriceDeviation(stagingArea, model.VirtualBlockHash, priceRecord)
+	}
+	if err != nil {
+		if !errors.As(err, &ruleerrors.RuleError{}) {
+			return nil, err
+		}
+		log.Warnf("Building a block that commits to no price record: %s", err)
+		coinbaseDataWithoutOracleData := coinbaseData.Clone()
+		coinbaseDataWithoutOracleData.OracleData = nil
+		return coinbaseDataWithoutOracleData, nil
+	}
+	return coinbaseData, nil
+}
+
 func (bb *blockBuilder) newBlockCoinbaseTransaction(stagingArea *model.StagingArea,
 	coinbaseData *externalapi.DomainCoinbaseData) (expectedTransaction *externalapi.DomainTransaction, hasRedReward bool, err error) {
 
@@ -187,7 +229,7 @@ func (bb *blockBuilder) newBlockCoinbaseTransaction(stagingArea *model.StagingAr
 }
 
 func (bb *blockBuilder) buildHeader(stagingArea *model.StagingArea, transactions []*externalapi.DomainTransaction,
-	newBlockPruningPoint *externalapi.DomainHash) (externalapi.BlockHeader, error) {
+	newBlockPruningPoint *externalapi.DomainHash, timeInMilliseconds int64) (externalapi.BlockHeader, error) {
 
 	daaScore, err := bb.newBlockDAAScore(stagingArea)
 	if err != nil {
@@ -199,10 +241,6 @@ func (bb *blockBuilder) buildHeader(stagingArea *model.StagingArea, transactions
 		return nil, err
 	}
 
-	timeInMilliseconds, err := bb.newBlockTime(stagingArea)
-	if err != nil {
-		return nil, err
-	}
 	bits, err := bb.newBlockDifficulty(stagingArea)
 	if err != nil {
 		return nil, err
@@ -212,7 +250,8 @@ func (bb *blockBuilder) buildHeader(stagingArea *model.StagingArea, transactions
 	if err != nil {
 		return nil, err
 	}
-	utxoCommitment, err := bb.newBlockUTXOCommitment(stagingArea)
+	utxoCommitment, err := bb.newBlockUTXOCommitment(stagingArea,
+		transactions[transactionhelper.CoinbaseTransactionIndex].Payload, timeInMilliseconds)
 	if err != nil {
 		return nil, err
 	}
@@ -312,12 +351,23 @@ func (bb *blockBuilder) calculateAcceptedIDMerkleRoot(acceptanceData externalapi
 	return merkle.CalculateIDMerkleRoot(acceptedTransactions), nil
 }
 
-func (bb *blockBuilder) newBlockUTXOCommitment(stagingArea *model.StagingArea) (*externalapi.DomainHash, error) {
+func (bb *blockBuilder) newBlockUTXOCommitment(stagingArea *model.StagingArea, coinbasePayload []byte,
+	timeInMilliseconds int64) (*externalapi.DomainHash, error) {
+
 	newBlockMultiset, err := bb.multisetStore.Get(bb.databaseContext, stagingArea, model.VirtualBlockHash)
 	if err != nil {
 		return nil, err
 	}
-	newBlockUTXOCommitment := newBlockMultiset.Hash()
+	newBlockReserveState, err := bb.reserveStateStore.Get(bb.databaseContext, stagingArea, model.VirtualBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	newBlockPriceRecord, err := bb.djedManager.CalculatePriceRecord(
+		stagingArea, model.VirtualBlockHash, coinbasePayload, timeInMilliseconds)
+	if err != nil {
+		return nil, err
+	}
+	newBlockUTXOCommitment := djed.UTXOCommitment(newBlockMultiset.Hash(), newBlockReserveState, newBlockPriceRecord)
 	return newBlockUTXOCommitment, nil
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/testapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/blockheader"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionhelper"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
 	"github.com/Kash-Protocol/kashd/infrastructure/logger"
@@ -125,7 +126,16 @@ func (bb *testBlockBuilder) buildHeaderWithParents(stagingArea *model.StagingAre
 	if err != nil {
 		return nil, err
 	}
-	utxoCommitment := multiset.Hash()
+	reserveState, err := bb.djedManager.CalculateReserveState(stagingArea, tempBlockHash, acceptanceData)
+	if err != nil {
+		return nil, err
+	}
+	priceRecord, err := bb.djedManager.CalculatePriceRecord(stagingArea, tempBlockHash,
+		transactions[transactionhelper.CoinbaseTransactionIndex].Payload, header.TimeInMilliseconds())
+	if err != nil {
+		return nil, err
+	}
+	utxoCommitment := djed.UTXOCommitment(multiset.Hash(), reserveState, priceRecord)
 
 	return blockheader.NewImmutableBlockHeader(
 		header.Version(),
@@ -29,6 +29,7 @@ type blockProcessor struct {
 	headerTipsManager     model.HeadersSelectedTipManager
 	syncManager           model.SyncManager
 	finalityManager       model.FinalityManager
+	djedManager           model.DjedManager
 
 	acceptanceDataStore                 model.AcceptanceDataStore
 	blockStore                          model.BlockStore
@@ -46,6 +47,7 @@ type blockProcessor struct {
 	headersSelectedChainStore           model.HeadersSelectedChainStore
 	daaBlocksStore                      model.DAABlocksStore
 	blocksWithTrustedDataDAAWindowStore model.BlocksWithTrustedDataDAAWindowStore
+	reserveStateStore                   model.ReserveStateStore
 
 	stores []model.Store
 }
@@ -67,6 +69,7 @@ func New(
 	coinbaseManager model.CoinbaseManager,
 	headerTipsManager model.HeadersSelectedTipManager,
 	syncManager model.SyncManager,
+	djedManager model.DjedManager,
 
 	acceptanceDataStore model.AcceptanceDataStore,
 	blockStore model.BlockStore,
@@ -84,6 +87,7 @@ func New(
 	headersSelectedChainStore model.HeadersSelectedChainStore,
 	daaBlocksStore model.DAABlocksStore,
 	blocksWithTrustedDataDAAWindowStore model.BlocksWithTrustedDataDAAWindowStore,
+	reserveStateStore model.ReserveStateStore,
 ) model.BlockProcessor {
 
 	return &blockProcessor{
@@ -101,6 +105,7 @@ func New(
 		coinbaseManager:       coinbaseManager,
 		headerTipsManager:     headerTipsManager,
 		syncManager:           syncManager,
+		djedManager:           djedManager,
 
 		consensusStateManager:               consensusStateManager,
 		acceptanceDataStore:                 acceptanceDataStore,
@@ -119,6 +124,7 @@ func New(
 		headersSelectedChainStore:           headersSelectedChainStore,
 		daaBlocksStore:                      daaBlocksStore,
 		blocksWithTrustedDataDAAWindowStore: blocksWithTrustedDataDAAWindowStore,
+		reserveStateStore:                   reserveStateStore,
 
 		stores: []model.Store{
 			consensusStateStore,
@@ -138,6 +144,7 @@ func New(
 			headersSelectedChainStore,
 			daaBlocksStore,
 			blocksWithTrustedDataDAAWindowStore,
+			reserveStateStore,
 		},
 	}
 }
@@ -73,6 +73,14 @@ func (bp *blockProcessor) updateVirtualAcceptanceDataAfterImportingPruningPoint(
 
 	log.Debugf("Staging virtual multiset after importing the pruning point")
 	bp.multisetStore.Stage(stagingArea, model.VirtualBlockHash, virtualMultiset)
+
+	log.Debugf("Staging virtual reserve state after importing the pruning point")
+	virtualReserveState, err := bp.djedManager.CalculateReserveState(
+		stagingArea, model.VirtualBlockHash, virtualAcceptanceData)
+	if err != nil {
+		return err
+	}
+	bp.reserveStateStore.Stage(stagingArea, model.VirtualBlockHash, virtualReserveState)
 	return nil
 }
 
@@ -90,6 +98,13 @@ func (bp *blockProcessor) validateAndInsertBlock(stagingArea *model.StagingArea,
 		return nil, externalapi.StatusInvalid, err
 	}
 
+	if !isHeaderOnlyBlock(block) {
+		err = bp.djedManager.StagePriceRecord(stagingArea, blockHash, isBlockWithTrustedData)
+		if err != nil {
+			return nil, externalapi.StatusInvalid, err
+		}
+	}
+
 	var oldHeadersSelectedTip *externalapi.DomainHash
 	hasHeaderSelectedTip, err := bp.headersSelectedTipStore.Has(bp.databaseContext, stagingArea)
 	if err != nil {
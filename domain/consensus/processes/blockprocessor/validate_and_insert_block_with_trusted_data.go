@@ -4,6 +4,7 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
 	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
 )
 
@@ -31,6 +32,12 @@ func (bp *blockProcessor) validateAndInsertBlockWithTrustedData(stagingArea *mod
 	}
 
 	bp.daaBlocksStore.StageDAAScore(stagingArea, blockHash, block.Block.Header.DAAScore())
+	if block.OraclePrices != nil {
+		bp.djedManager.StageTrustedPriceRecord(stagingArea, blockHash, oracle.FromOraclePrices(block.OraclePrices))
+	}
+	if block.ReserveState != nil {
+		bp.reserveStateStore.Stage(stagingArea, blockHash, block.ReserveState)
+	}
 	return bp.validateAndInsertBlock(stagingArea, block.Block, false, validateUTXO, true)
 }
 
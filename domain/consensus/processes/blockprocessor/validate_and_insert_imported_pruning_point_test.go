@@ -1,8 +1,12 @@
 package blockprocessor_test
 
 import (
+	"crypto/ed25519"
+	"encoding/hex"
+
 	"github.com/Kash-Protocol/kashd/domain/consensus/model"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
 	"github.com/Kash-Protocol/kashd/domain/dagconfig"
 	"math"
@@ -44,6 +48,14 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 		consensusConfig.K = 0
 		consensusConfig.PruningProofM = 1
 
+		// The syncer commits to a price record, which a syncee has to receive along with the pruning point
+		oraclePublicKey, oraclePrivateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %s", err)
+		}
+		consensusConfig.OraclePublicKeys = []string{"ed25519:" + hex.EncodeToString(oraclePublicKey)}
+		consensusConfig.OracleMinRecords = 1
+
 		syncConsensuses := func(tcSyncerRef, tcSynceeRef *testapi.TestConsensus, updatePruningPointJustAfterImportingPruningPoint bool) {
 			tcSyncer, tcSyncee := *tcSyncerRef, *tcSynceeRef
 			pruningPointProof, err := tcSyncer.BuildPruningPointProof()
@@ -133,6 +145,23 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 					})
 				}
 
+				// Only the reserve state of the pruning point is committed to
+				if blockHash.Equal(pruningPointAndItsAnticone[0]) {
+					reserveState, err := tcSyncer.TrustedReserveState(blockHash)
+					if err != nil {
+						t.Fatalf("TrustedReserveState: %+v", err)
+					}
+					blockWithTrustedData.ReserveState = reserveState
+				}
+
+				oraclePrices, found, err := tcSyncer.TrustedOraclePrices(blockHash)
+				if err != nil {
+					t.Fatalf("TrustedOraclePrices: %+v", err)
+				}
+				if found {
+					blockWithTrustedData.OraclePrices = oraclePrices
+				}
+
 				err = synceeStaging.ValidateAndInsertBlockWithTrustedData(blockWithTrustedData, false)
 				if err != nil {
 					t.Fatalf("ValidateAndInsertBlockWithTrustedData: %+v", err)
@@ -322,6 +351,18 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 				t.Fatalf("The syncee pruning point has not changed as exepcted")
 			}
 
+			syncerOraclePrices, _, err := tcSyncer.GetVirtualOraclePrices()
+			if err != nil {
+				t.Fatalf("GetVirtualOraclePrices: %+v", err)
+			}
+			synceeOraclePrices, _, err := synceeStaging.GetVirtualOraclePrices()
+			if err != nil {
+				t.Fatalf("GetVirtualOraclePrices: %+v", err)
+			}
+			if syncerOraclePrices == nil || synceeOraclePrices == nil || *synceeOraclePrices != *syncerOraclePrices {
+				t.Fatalf("The syncee oracle prices are %+v while the syncer's are %+v", synceeOraclePrices, syncerOraclePrices)
+			}
+
 			*tcSynceeRef = synceeStaging
 		}
 
@@ -337,10 +378,33 @@ func TestValidateAndInsertImportedPruningPoint(t *testing.T) {
 		}
 		defer teardownSyncee1(false)
 
+		priceRecord := &oracle.PriceRecord{KSH: 2 * constants.SompiPerKash, KRV: constants.SompiPerKash,
+			KUSD: constants.SompiPerKash, Timestamp: consensusConfig.GenesisBlock.Header.TimeInMilliseconds()}
+		oracleData, err := oracle.SerializeSignedPriceRecords(
+			[]*oracle.SignedPriceRecord{oracle.SignPriceRecordEd25519(priceRecord, oraclePrivateKey)})
+		if err != nil {
+			t.Fatalf("SerializeSignedPriceRecords: %s", err)
+		}
+		scriptPublicKeyScript, err := txscript.PayToScriptHashScript([]byte{txscript.OpTrue})
+		if err != nil {
+			t.Fatalf("PayToScriptHashScript: %s", err)
+		}
+		tipHash, _, err := tcSyncer.AddBlock([]*externalapi.DomainHash{consensusConfig.GenesisHash},
+			&externalapi.DomainCoinbaseData{
+				ScriptPublicKey: &externalapi.ScriptPublicKey{Script: scriptPublicKeyScript,
+					Version: constants.MaxScriptPublicKeyVersion},
+				ExtraData:  []byte{},
+				OracleData: oracleData,
+			}, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+
 		const numSharedBlocks = 2
-		tipHash := consensusConfig.GenesisHash
 		for i := 0; i < numSharedBlocks; i++ {
-			tipHash = addBlock(tcSyncer, []*externalapi.DomainHash{tipHash}, t)
+			if i > 0 {
+				tipHash = addBlock(tcSyncer, []*externalapi.DomainHash{tipHash}, t)
+			}
 			block, _, err := tcSyncer.GetBlock(tipHash)
 			if err != nil {
 				t.Fatalf("GetBlock: %+v", err)
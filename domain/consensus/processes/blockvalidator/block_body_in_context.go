@@ -38,10 +38,38 @@ func (v *blockValidator) ValidateBodyInContext(stagingArea *model.StagingArea, b
 		if err != nil {
 			return err
 		}
+
+		err = v.checkOraclePriceDeviation(stagingArea, blockHash)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// checkOraclePriceDeviation checks that the prices the block sets, if any, are within
+// the allowed deviation from the prices in effect at its selected parent
+func (v *blockValidator) checkOraclePriceDeviation(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) error {
+	block, err := v.blockStore.Block(v.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+
+	_, coinbaseData, _, err := v.coinbaseManager.ExtractCoinbaseDataBlueScoreAndSubsidy(block.Transactions[transactionhelper.CoinbaseTransactionIndex])
+	if err != nil {
+		return err
+	}
+	priceRecord, err := v.djedManager.ValidateOracleData(coinbaseData.OracleData, block.Header.TimeInMilliseconds())
+	if err != nil {
+		return err
+	}
+	if priceRecord == nil {
+		return nil
+	}
+
+	return v.djedManager.ValidatePriceDeviation(stagingArea, blockHash, priceRecord)
+}
+
 // checkBlockIsNotPruned Checks we don't add block bodies to pruned blocks
 func (v *blockValidator) checkBlockIsNotPruned(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) error {
 	hasValidatedHeader, err := v.hasValidatedHeader(stagingArea, blockHash)
@@ -53,6 +53,11 @@ func (v *blockValidator) ValidateBodyInIsolation(stagingArea *model.StagingArea,
 		return err
 	}
 
+	err = v.checkCoinbaseOracleData(block)
+	if err != nil {
+		return err
+	}
+
 	err = v.checkBlockTransactionOrder(block)
 	if err != nil {
 		return err
@@ -103,6 +108,17 @@ func (v *blockValidator) checkCoinbaseBlueScore(block *externalapi.DomainBlock)
 	return nil
 }
 
+// checkCoinbaseOracleData checks that the signed price records the coinbase commits to, if any,
+// are signed by trusted price feeds at about the time of the block
+func (v *blockValidator) checkCoinbaseOracleData(block *externalapi.DomainBlock) error {
+	_, coinbaseData, _, err := v.coinbaseManager.ExtractCoinbaseDataBlueScoreAndSubsidy(block.Transactions[transactionhelper.CoinbaseTransactionIndex])
+	if err != nil {
+		return err
+	}
+	_, err = v.djedManager.ValidateOracleData(coinbaseData.OracleData, block.Header.TimeInMilliseconds())
+	return err
+}
+
 func (v *blockValidator) checkBlockContainsAtLeastOneTransaction(block *externalapi.DomainBlock) error {
 	if len(block.Transactions) == 0 {
 		return errors.Wrapf(ruleerrors.ErrNoTransactions, "block does not contain "+
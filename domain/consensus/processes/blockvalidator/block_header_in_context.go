@@ -95,13 +95,6 @@ func (v *blockValidator) ValidateHeaderInContext(stagingArea *model.StagingArea,
 		return err
 	}
 
-	if !isBlockWithTrustedData {
-		err = v.validateHeaderPruningPoint(stagingArea, blockHash)
-		if err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
 
@@ -122,7 +122,7 @@ func TestCheckParentsIncest(t *testing.T) {
 				0,
 				0,
 				big.NewInt(0),
-				&externalapi.DomainHash{},
+				consensusConfig.GenesisHash,
 			),
 			Transactions: nil,
 		}
@@ -145,7 +145,7 @@ func TestCheckParentsIncest(t *testing.T) {
 				0,
 				0,
 				big.NewInt(0),
-				&externalapi.DomainHash{},
+				consensusConfig.GenesisHash,
 			),
 			Transactions: nil,
 		}
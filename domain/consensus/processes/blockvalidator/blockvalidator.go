@@ -41,6 +41,7 @@ type blockValidator struct {
 	blockParentBuilder    model.BlockParentBuilder
 	pruningManager        model.PruningManager
 	parentsManager        model.ParentsManager
+	djedManager           model.DjedManager
 
 	blockStore          model.BlockStore
 	ghostdagDataStores  []model.GHOSTDAGDataStore
@@ -80,6 +81,7 @@ func New(powMax *big.Int,
 	blockParentBuilder model.BlockParentBuilder,
 	pruningManager model.PruningManager,
 	parentsManager model.ParentsManager,
+	djedManager model.DjedManager,
 
 	pruningStore model.PruningStore,
 	blockStore model.BlockStore,
@@ -120,6 +122,7 @@ func New(powMax *big.Int,
 		blockParentBuilder:          blockParentBuilder,
 		pruningManager:              pruningManager,
 		parentsManager:              parentsManager,
+		djedManager:                 djedManager,
 
 		pruningStore:        pruningStore,
 		blockStore:          blockStore,
@@ -32,7 +32,9 @@ func (v *blockValidator) ValidatePruningPointViolationAndProofOfWorkAndDifficult
 		return err
 	}
 
-	err = v.setParents(stagingArea, blockHash, header, isBlockWithTrustedData)
+	// Only the direct parents are set at first, since the parents at higher levels depend on
+	// the block level, which requires calculating the proof of work hash.
+	err = v.setParentsAtLevel(stagingArea, blockHash, header, 0, isBlockWithTrustedData)
 	if err != nil {
 		return err
 	}
@@ -47,6 +49,28 @@ func (v *blockValidator) ValidatePruningPointViolationAndProofOfWorkAndDifficult
 		if err != nil {
 			return err
 		}
+
+		// We need to calculate GHOSTDAG for the block in order to check its pruning point,
+		// as well as its difficulty and blue work
+		err = v.ghostdagManagers[0].GHOSTDAG(stagingArea, blockHash)
+		if err != nil {
+			return err
+		}
+
+		// The pruning point is the RandomX key of the proof of work, so it must be validated
+		// before any hashing, or else arbitrary keys would make us initialize RandomX for them.
+		err = v.validateHeaderPruningPoint(stagingArea, blockHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	blockLevel := header.BlockLevel(v.maxBlockLevel)
+	for level := 1; level <= blockLevel; level++ {
+		err = v.setParentsAtLevel(stagingArea, blockHash, header, level, isBlockWithTrustedData)
+		if err != nil {
+			return err
+		}
 	}
 
 	if !blockHash.Equal(v.genesisHash) {
@@ -64,55 +88,41 @@ func (v *blockValidator) ValidatePruningPointViolationAndProofOfWorkAndDifficult
 	return nil
 }
 
-func (v *blockValidator) setParents(stagingArea *model.StagingArea,
+func (v *blockValidator) setParentsAtLevel(stagingArea *model.StagingArea,
 	blockHash *externalapi.DomainHash,
 	header externalapi.BlockHeader,
+	level int,
 	isBlockWithTrustedData bool) error {
 
-	for level := 0; level <= header.BlockLevel(v.maxBlockLevel); level++ {
-		var parents []*externalapi.DomainHash
-		for _, parent := range v.parentsManager.ParentsAtLevel(header, level) {
-			_, err := v.ghostdagDataStores[level].Get(v.databaseContext, stagingArea, parent, false)
-			isNotFoundError := database.IsNotFoundError(err)
-			if !isNotFoundError && err != nil {
-				return err
-			}
+	var parents []*externalapi.DomainHash
+	for _, parent := range v.parentsManager.ParentsAtLevel(header, level) {
+		_, err := v.ghostdagDataStores[level].Get(v.databaseContext, stagingArea, parent, false)
+		isNotFoundError := database.IsNotFoundError(err)
+		if !isNotFoundError && err != nil {
+			return err
+		}
 
-			if isNotFoundError {
-				if level == 0 && !isBlockWithTrustedData {
-					return errors.Errorf("direct parent %s is missing: only block with prefilled information can have some missing parents", parent)
-				}
-				continue
+		if isNotFoundError {
+			if level == 0 && !isBlockWithTrustedData {
+				return errors.Errorf("direct parent %s is missing: only block with prefilled information can have some missing parents", parent)
 			}
-
-			parents = append(parents, parent)
+			continue
 		}
 
-		if len(parents) == 0 {
-			parents = append(parents, model.VirtualGenesisBlockHash)
-		}
+		parents = append(parents, parent)
+	}
 
-		err := v.dagTopologyManagers[level].SetParents(stagingArea, blockHash, parents)
-		if err != nil {
-			return err
-		}
+	if len(parents) == 0 {
+		parents = append(parents, model.VirtualGenesisBlockHash)
 	}
 
-	return nil
+	return v.dagTopologyManagers[level].SetParents(stagingArea, blockHash, parents)
 }
 
 func (v *blockValidator) validateDifficulty(stagingArea *model.StagingArea,
 	blockHash *externalapi.DomainHash,
 	isBlockWithTrustedData bool) error {
 
-	if !isBlockWithTrustedData {
-		// We need to calculate GHOSTDAG for the block in order to check its difficulty and blue work
-		err := v.ghostdagManagers[0].GHOSTDAG(stagingArea, blockHash)
-		if err != nil {
-			return err
-		}
-	}
-
 	header, err := v.blockHeaderStore.BlockHeader(v.databaseContext, stagingArea, blockHash)
 	if err != nil {
 		return err
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/ruleerrors"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
 	"github.com/pkg/errors"
 )
 
@@ -14,7 +15,7 @@ const lengthOfSubsidy = uint64Len
 const lengthOfScriptPubKeyLength = 1
 const lengthOfVersionScriptPubKey = uint16Len
 
-// serializeCoinbasePayload builds the coinbase payload based on the provided scriptPubKey and extra data.
+// serializeCoinbasePayload builds the coinbase payload based on the provided scriptPubKey, extra data and oracle data.
 func (c *coinbaseManager) serializeCoinbasePayload(blueScore uint64,
 	coinbaseData *externalapi.DomainCoinbaseData, subsidy uint64) ([]byte, error) {
 
@@ -33,11 +34,16 @@ func (c *coinbaseManager) serializeCoinbasePayload(blueScore uint64,
 	copy(payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey+lengthOfScriptPubKeyLength:], coinbaseData.ScriptPublicKey.Script)
 	copy(payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey+lengthOfScriptPubKeyLength+scriptLengthOfScriptPubKey:], coinbaseData.ExtraData)
 
-	return payload, nil
+	return appendOracleData(payload, coinbaseData.OracleData)
 }
 
 // ModifyCoinbasePayload modifies the coinbase payload based on the provided scriptPubKey and extra data.
+// The oracle data the payload already commits to is kept as is.
 func ModifyCoinbasePayload(payload []byte, coinbaseData *externalapi.DomainCoinbaseData, coinbasePayloadScriptPublicKeyMaxLength uint8) ([]byte, error) {
+	payload, oracleData, err := extractOracleData(payload)
+	if err != nil {
+		return nil, err
+	}
 
 	scriptLengthOfScriptPubKey := len(coinbaseData.ScriptPublicKey.Script)
 	if scriptLengthOfScriptPubKey > int(coinbasePayloadScriptPublicKeyMaxLength) {
@@ -57,39 +63,64 @@ func ModifyCoinbasePayload(payload []byte, coinbaseData *externalapi.DomainCoinb
 	copy(payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey+lengthOfScriptPubKeyLength:], coinbaseData.ScriptPublicKey.Script)
 	copy(payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey+lengthOfScriptPubKeyLength+scriptLengthOfScriptPubKey:], coinbaseData.ExtraData)
 
-	return payload, nil
+	return appendOracleData(payload, oracleData)
 }
 
-// ExtractCoinbaseDataBlueScoreAndSubsidy deserializes the coinbase payload to its component (scriptPubKey, extra data, and subsidy).
+// ExtractCoinbaseDataBlueScoreAndSubsidy deserializes the coinbase payload to its component (scriptPubKey, extra data,
+// oracle data, and subsidy).
 func (c *coinbaseManager) ExtractCoinbaseDataBlueScoreAndSubsidy(coinbaseTx *externalapi.DomainTransaction) (
 	blueScore uint64, coinbaseData *externalapi.DomainCoinbaseData, subsidy uint64, err error) {
 
+	payload, oracleData, err := extractOracleData(coinbaseTx.Payload)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+
 	minLength := uint64Len + lengthOfSubsidy + lengthOfVersionScriptPubKey + lengthOfScriptPubKeyLength
-	if len(coinbaseTx.Payload) < minLength {
+	if len(payload) < minLength {
 		return 0, nil, 0, errors.Wrapf(ruleerrors.ErrBadCoinbasePayloadLen,
 			"coinbase payload is less than the minimum length of %d", minLength)
 	}
 
-	blueScore = binary.LittleEndian.Uint64(coinbaseTx.Payload[:uint64Len])
-	subsidy = binary.LittleEndian.Uint64(coinbaseTx.Payload[uint64Len:])
+	blueScore = binary.LittleEndian.Uint64(payload[:uint64Len])
+	subsidy = binary.LittleEndian.Uint64(payload[uint64Len:])
 
-	scriptPubKeyVersion := binary.LittleEndian.Uint16(coinbaseTx.Payload[uint64Len+lengthOfSubsidy : uint64Len+lengthOfSubsidy+uint16Len])
+	scriptPubKeyVersion := binary.LittleEndian.Uint16(payload[uint64Len+lengthOfSubsidy : uint64Len+lengthOfSubsidy+uint16Len])
 
-	scriptPubKeyScriptLength := coinbaseTx.Payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey]
+	scriptPubKeyScriptLength := payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey]
 
 	if scriptPubKeyScriptLength > c.coinbasePayloadScriptPublicKeyMaxLength {
 		return 0, nil, 0, errors.Wrapf(ruleerrors.ErrBadCoinbasePayloadLen, "coinbase's payload script public key is "+
 			"longer than the max allowed length of %d", c.coinbasePayloadScriptPublicKeyMaxLength)
 	}
 
-	if len(coinbaseTx.Payload) < minLength+int(scriptPubKeyScriptLength) {
+	if len(payload) < minLength+int(scriptPubKeyScriptLength) {
 		return 0, nil, 0, errors.Wrapf(ruleerrors.ErrBadCoinbasePayloadLen,
 			"coinbase payload doesn't have enough bytes to contain a script public key of %d bytes", scriptPubKeyScriptLength)
 	}
-	scriptPubKeyScript := coinbaseTx.Payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey+lengthOfScriptPubKeyLength : uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey+lengthOfScriptPubKeyLength+scriptPubKeyScriptLength]
+	scriptPubKeyScript := payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey+lengthOfScriptPubKeyLength : uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey+lengthOfScriptPubKeyLength+scriptPubKeyScriptLength]
 
 	return blueScore, &externalapi.DomainCoinbaseData{
 		ScriptPublicKey: &externalapi.ScriptPublicKey{Script: scriptPubKeyScript, Version: scriptPubKeyVersion},
-		ExtraData:       coinbaseTx.Payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey+lengthOfScriptPubKeyLength+scriptPubKeyScriptLength:],
+		ExtraData:       payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey+lengthOfScriptPubKeyLength+scriptPubKeyScriptLength:],
+		OracleData:      oracleData,
 	}, subsidy, nil
 }
+
+func appendOracleData(payload []byte, oracleData []byte) ([]byte, error) {
+	payloadWithOracleData, err := oracle.AppendToCoinbasePayload(payload, oracleData)
+	if err != nil {
+		return nil, errors.Wrapf(ruleerrors.ErrBadCoinbasePayloadLen, "cannot append oracle data to the "+
+			"coinbase payload: %s", err)
+	}
+	return payloadWithOracleData, nil
+}
+
+func extractOracleData(payload []byte) (payloadWithoutOracleData []byte, oracleData []byte, err error) {
+	payloadWithoutOracleData, oracleData, err = oracle.ExtractFromCoinbasePayload(payload)
+	if err != nil {
+		return nil, nil, errors.Wrapf(ruleerrors.ErrBadCoinbasePayloadLen, "cannot extract oracle data from "+
+			"the coinbase payload: %s", err)
+	}
+	return payloadWithoutOracleData, oracleData, nil
+}
@@ -164,6 +164,11 @@ func (csm *consensusStateManager) applyMergeSetBlocks(stagingArea *model.Staging
 	}
 	log.Tracef("The past median time for block %s is: %d", blockHash, selectedParentMedianTime)
 
+	accumulatedReserveState, err := csm.djedManager.ReserveState(stagingArea, blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	multiblockAcceptanceData := make(externalapi.AcceptanceData, len(mergeSetBlocks))
 	accumulatedUTXODiff := selectedParentPastUTXODiff.CloneMutable()
 	accumulatedMass := uint64(0)
@@ -185,7 +190,7 @@ func (csm *consensusStateManager) applyMergeSetBlocks(stagingArea *model.Staging
 				transactionID, mergeSetBlockHash)
 
 			isAccepted, accumulatedMass, err = csm.maybeAcceptTransaction(stagingArea, transaction, blockHash,
-				isSelectedParent, accumulatedUTXODiff, accumulatedMass, selectedParentMedianTime, daaScore)
+				isSelectedParent, accumulatedUTXODiff, accumulatedReserveState, accumulatedMass, selectedParentMedianTime, daaScore)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -215,8 +220,8 @@ func (csm *consensusStateManager) applyMergeSetBlocks(stagingArea *model.Staging
 
 func (csm *consensusStateManager) maybeAcceptTransaction(stagingArea *model.StagingArea,
 	transaction *externalapi.DomainTransaction, blockHash *externalapi.DomainHash, isSelectedParent bool,
-	accumulatedUTXODiff externalapi.MutableUTXODiff, accumulatedMassBefore uint64, selectedParentPastMedianTime int64,
-	blockDAAScore uint64) (isAccepted bool, accumulatedMassAfter uint64, err error) {
+	accumulatedUTXODiff externalapi.MutableUTXODiff, accumulatedReserveState *externalapi.ReserveState,
+	accumulatedMassBefore uint64, selectedParentPastMedianTime int64, blockDAAScore uint64) (isAccepted bool, accumulatedMassAfter uint64, err error) {
 
 	transactionID := consensushashing.TransactionID(transaction)
 	log.Tracef("maybeAcceptTransaction start for transaction %s in block %s", transactionID, blockHash)
@@ -255,6 +260,16 @@ func (csm *consensusStateManager) maybeAcceptTransaction(stagingArea *model.Stag
 			return false, accumulatedMassBefore, nil
 		}
 		log.Tracef("Validation passed for transaction %s in block %s", transactionID, blockHash)
+
+		if transaction.Type.IsConversion() {
+			accepted, err := csm.maybeApplyConversion(stagingArea, transaction, blockHash, accumulatedReserveState)
+			if err != nil {
+				return false, 0, err
+			}
+			if !accepted {
+				return false, accumulatedMassBefore, nil
+			}
+		}
 	}
 
 	log.Tracef("Adding transaction %s in block %s to the accumulated diff", transactionID, blockHash)
@@ -266,6 +281,38 @@ func (csm *consensusStateManager) maybeAcceptTransaction(stagingArea *model.Stag
 	return true, accumulatedMassAfter, nil
 }
 
+// maybeApplyConversion applies the given conversion transaction to accumulatedReserveState in place.
+// The transaction was validated against the reserve state of the selected parent, so it is
+// validated here again against the reserve state accumulated by the conversions accepted before it.
+func (csm *consensusStateManager) maybeApplyConversion(stagingArea *model.StagingArea,
+	transaction *externalapi.DomainTransaction, blockHash *externalapi.DomainHash,
+	accumulatedReserveState *externalapi.ReserveState) (isAccepted bool, err error) {
+
+	transactionID := consensushashing.TransactionID(transaction)
+
+	totalSompiIn := uint64(0)
+	for _, input := range transaction.Inputs {
+		totalSompiIn += input.UTXOEntry.Amount()
+	}
+
+	newReserveState, fee, err := csm.djedManager.ApplyConversion(
+		stagingArea, blockHash, accumulatedReserveState, transaction, totalSompiIn)
+	if err != nil {
+		if !errors.As(err, &(ruleerrors.RuleError{})) {
+			return false, err
+		}
+
+		log.Tracef("Conversion failed for transaction %s "+
+			"in block %s: %s", transactionID, blockHash, err)
+		return false, nil
+	}
+
+	*accumulatedReserveState = *newReserveState
+	// The fee of a conversion depends on the reserve state it was applied to
+	transaction.Fee = fee
+	return true, nil
+}
+
 // RestorePastUTXOSetIterator restores the given block's UTXOSet iterator, and returns it as a externalapi.ReadOnlyUTXOSetIterator
 func (csm *consensusStateManager) RestorePastUTXOSetIterator(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (
 	externalapi.ReadOnlyUTXOSetIterator, error) {
@@ -7,6 +7,7 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/testapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/multiset"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/testutils"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionhelper"
@@ -99,8 +100,21 @@ func checkBlockUTXOCommitment(t *testing.T, consensus testapi.TestConsensus, blo
 		}
 	}
 
-	// Turn the multiset into a UTXO commitment
-	utxoCommitment := ms.Hash()
+	// Turn the multiset, along with the reserve state and the price record, into a UTXO commitment
+	stagingArea := model.NewStagingArea()
+	_, acceptanceData, _, err := csm.CalculatePastUTXOAndAcceptanceData(stagingArea, blockHash)
+	if err != nil {
+		t.Fatalf("Error calculating the acceptance data of block %s: %+v", blockName, err)
+	}
+	reserveState, err := consensus.DjedManager().CalculateReserveState(stagingArea, blockHash, acceptanceData)
+	if err != nil {
+		t.Fatalf("Error calculating the reserve state of block %s: %+v", blockName, err)
+	}
+	priceRecord, _, err := consensus.DjedManager().PriceRecord(stagingArea, blockHash)
+	if err != nil {
+		t.Fatalf("Error getting the price record of block %s: %+v", blockName, err)
+	}
+	utxoCommitment := djed.UTXOCommitment(ms.Hash(), reserveState, priceRecord)
 
 	// Make sure that the two commitments are equal
 	if !utxoCommitment.Equal(block.Header.UTXOCommitment()) {
@@ -21,6 +21,7 @@ type consensusStateManager struct {
 	mergeDepthManager     model.MergeDepthManager
 	finalityManager       model.FinalityManager
 	difficultyManager     model.DifficultyManager
+	djedManager           model.DjedManager
 
 	headersSelectedTipStore model.HeaderSelectedTipStore
 	blockStatusStore        model.BlockStatusStore
@@ -34,6 +35,7 @@ type consensusStateManager struct {
 	blockHeaderStore        model.BlockHeaderStore
 	pruningStore            model.PruningStore
 	daaBlocksStore          model.DAABlocksStore
+	reserveStateStore       model.ReserveStateStore
 
 	stores []model.Store
 }
@@ -54,6 +56,7 @@ func New(
 	mergeDepthManager model.MergeDepthManager,
 	finalityManager model.FinalityManager,
 	difficultyManager model.DifficultyManager,
+	djedManager model.DjedManager,
 
 	blockStatusStore model.BlockStatusStore,
 	ghostdagDataStore model.GHOSTDAGDataStore,
@@ -66,7 +69,8 @@ func New(
 	blockHeaderStore model.BlockHeaderStore,
 	headersSelectedTipStore model.HeaderSelectedTipStore,
 	pruningStore model.PruningStore,
-	daaBlocksStore model.DAABlocksStore) (model.ConsensusStateManager, error) {
+	daaBlocksStore model.DAABlocksStore,
+	reserveStateStore model.ReserveStateStore) (model.ConsensusStateManager, error) {
 
 	csm := &consensusStateManager{
 		maxBlockParents:   maxBlockParents,
@@ -84,6 +88,7 @@ func New(
 		mergeDepthManager:     mergeDepthManager,
 		finalityManager:       finalityManager,
 		difficultyManager:     difficultyManager,
+		djedManager:           djedManager,
 
 		multisetStore:           multisetStore,
 		blockStore:              blockStore,
@@ -97,6 +102,7 @@ func New(
 		headersSelectedTipStore: headersSelectedTipStore,
 		pruningStore:            pruningStore,
 		daaBlocksStore:          daaBlocksStore,
+		reserveStateStore:       reserveStateStore,
 
 		stores: []model.Store{
 			consensusStateStore,
@@ -111,6 +117,7 @@ func New(
 			blockHeaderStore,
 			headersSelectedTipStore,
 			pruningStore,
+			reserveStateStore,
 		},
 	}
 
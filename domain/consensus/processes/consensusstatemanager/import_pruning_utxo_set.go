@@ -5,6 +5,7 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/ruleerrors"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionhelper"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/utxo"
 	"github.com/Kash-Protocol/kashd/infrastructure/logger"
@@ -47,9 +48,31 @@ func (csm *consensusStateManager) importPruningPointUTXOSet(stagingArea *model.S
 	log.Debugf("The UTXO commitment of the pruning point: %s",
 		newPruningPointHeader.UTXOCommitment())
 
-	if !newPruningPointHeader.UTXOCommitment().Equal(importedPruningPointMultiset.Hash()) {
-		return errors.Wrapf(ruleerrors.ErrBadPruningPointUTXOSet, "the expected multiset hash of the pruning "+
-			"point UTXO set is %s but got %s", newPruningPointHeader.UTXOCommitment(), *importedPruningPointMultiset.Hash())
+	// The reserve state and the price record of the pruning point were received along with it as trusted
+	// data, and are verified here together with the UTXO set.
+	hasReserveState, err := csm.reserveStateStore.Has(csm.databaseContext, stagingArea, newPruningPoint)
+	if err != nil {
+		return err
+	}
+	if !hasReserveState {
+		return errors.Wrapf(ruleerrors.ErrBadPruningPointUTXOSet, "the reserve state of the pruning "+
+			"point %s is missing", newPruningPoint)
+	}
+	newPruningPointReserveState, err := csm.reserveStateStore.Get(csm.databaseContext, stagingArea, newPruningPoint)
+	if err != nil {
+		return err
+	}
+	newPruningPointPriceRecord, _, err := csm.djedManager.PriceRecord(stagingArea, newPruningPoint)
+	if err != nil {
+		return err
+	}
+
+	utxoCommitment := djed.UTXOCommitment(
+		importedPruningPointMultiset.Hash(), newPruningPointReserveState, newPruningPointPriceRecord)
+	if !newPruningPointHeader.UTXOCommitment().Equal(utxoCommitment) {
+		return errors.Wrapf(ruleerrors.ErrBadPruningPointUTXOSet, "the expected UTXO commitment of the pruning "+
+			"point UTXO set, reserve state and price record is %s but got %s",
+			newPruningPointHeader.UTXOCommitment(), utxoCommitment)
 	}
 	log.Debugf("The new pruning point UTXO commitment validation passed")
 
@@ -213,8 +213,14 @@ func (csm *consensusStateManager) resolveSingleBlockStatus(stagingArea *model.St
 		return 0, nil, err
 	}
 
+	log.Tracef("Calculating the reserve state of block %s", blockHash)
+	reserveState, err := csm.djedManager.CalculateReserveState(stagingArea, blockHash, acceptanceData)
+	if err != nil {
+		return 0, nil, err
+	}
+
 	log.Tracef("verifying the UTXO of block %s", blockHash)
-	err = csm.verifyUTXO(stagingArea, block, blockHash, pastUTXOSet, acceptanceData, multiset)
+	err = csm.verifyUTXO(stagingArea, block, blockHash, pastUTXOSet, acceptanceData, multiset, reserveState)
 	if err != nil {
 		if errors.As(err, &ruleerrors.RuleError{}) {
 			log.Debugf("UTXO verification for block %s failed: %s", blockHash, err)
@@ -227,6 +233,9 @@ func (csm *consensusStateManager) resolveSingleBlockStatus(stagingArea *model.St
 	log.Tracef("Staging the multiset of block %s", blockHash)
 	csm.multisetStore.Stage(stagingArea, blockHash, multiset)
 
+	log.Tracef("Staging the reserve state of block %s", blockHash)
+	csm.reserveStateStore.Stage(stagingArea, blockHash, reserveState)
+
 	if csm.genesisHash.Equal(blockHash) {
 		log.Tracef("Staging the utxoDiff of genesis")
 		csm.stageDiff(stagingArea, blockHash, pastUTXOSet, nil)
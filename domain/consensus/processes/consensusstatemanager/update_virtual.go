@@ -92,6 +92,14 @@ func (csm *consensusStateManager) updateVirtualWithParents(
 	log.Debugf("Staging new multiset for the virtual block")
 	csm.multisetStore.Stage(stagingArea, model.VirtualBlockHash, virtualMultiset)
 
+	log.Debugf("Staging new reserve state for the virtual block")
+	virtualReserveState, err := csm.djedManager.CalculateReserveState(
+		stagingArea, model.VirtualBlockHash, virtualAcceptanceData)
+	if err != nil {
+		return nil, err
+	}
+	csm.reserveStateStore.Stage(stagingArea, model.VirtualBlockHash, virtualReserveState)
+
 	log.Debugf("Staging new UTXO diff for the virtual block")
 	csm.consensusStateStore.StageVirtualUTXODiff(stagingArea, virtualUTXODiff)
 
@@ -6,6 +6,7 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionhelper"
 
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
 
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/merkle"
 
@@ -17,13 +18,13 @@ import (
 
 func (csm *consensusStateManager) verifyUTXO(stagingArea *model.StagingArea, block *externalapi.DomainBlock,
 	blockHash *externalapi.DomainHash, pastUTXODiff externalapi.UTXODiff, acceptanceData externalapi.AcceptanceData,
-	multiset model.Multiset) error {
+	multiset model.Multiset, reserveState *externalapi.ReserveState) error {
 
 	log.Tracef("verifyUTXO start for block %s", blockHash)
 	defer log.Tracef("verifyUTXO end for block %s", blockHash)
 
 	log.Debugf("Validating UTXO commitment for block %s", blockHash)
-	err := csm.validateUTXOCommitment(block, blockHash, multiset)
+	err := csm.validateUTXOCommitment(stagingArea, block, blockHash, multiset, reserveState)
 	if err != nil {
 		return err
 	}
@@ -111,8 +112,8 @@ func (csm *consensusStateManager) validateAcceptedIDMerkleRoot(block *externalap
 	return nil
 }
 
-func (csm *consensusStateManager) validateUTXOCommitment(
-	block *externalapi.DomainBlock, blockHash *externalapi.DomainHash, multiset model.Multiset) error {
+func (csm *consensusStateManager) validateUTXOCommitment(stagingArea *model.StagingArea, block *externalapi.DomainBlock,
+	blockHash *externalapi.DomainHash, multiset model.Multiset, reserveState *externalapi.ReserveState) error {
 
 	log.Tracef("validateUTXOCommitment start for block %s", blockHash)
 	defer log.Tracef("validateUTXOCommitment end for block %s", blockHash)
@@ -121,10 +122,15 @@ func (csm *consensusStateManager) validateUTXOCommitment(
 		return nil
 	}
 
-	multisetHash := multiset.Hash()
-	if !block.Header.UTXOCommitment().Equal(multisetHash) {
+	priceRecord, _, err := csm.djedManager.PriceRecord(stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+
+	utxoCommitment := djed.UTXOCommitment(multiset.Hash(), reserveState, priceRecord)
+	if !block.Header.UTXOCommitment().Equal(utxoCommitment) {
 		return errors.Wrapf(ruleerrors.ErrBadUTXOCommitment, "block %s UTXO commitment is invalid - block "+
-			"header indicates %s, but calculated value is %s", blockHash, block.Header.UTXOCommitment(), multisetHash)
+			"header indicates %s, but calculated value is %s", blockHash, block.Header.UTXOCommitment(), utxoCommitment)
 	}
 
 	return nil
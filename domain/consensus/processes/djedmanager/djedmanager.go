@@ -0,0 +1,91 @@
+package djedmanager
+
+import (
+	"time"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/ruleerrors"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+	"github.com/pkg/errors"
+)
+
+// djedManager provides the exchange rate and the reserve state that
+// Djed conversion transactions are validated against
+type djedManager struct {
+	databaseContext model.DBReader
+
+	oraclePublicKeys        oracle.PublicKeySet
+	oracleMinRecords        int
+	oracleMaxRecordAge      time.Duration
+	oracleMaxPriceDeviation uint64
+	djedCalculator          *djed.Calculator
+
+	blockStore        model.BlockStore
+	ghostdagDataStore model.GHOSTDAGDataStore
+	priceRecordStore  model.PriceRecordStore
+	reserveStateStore model.ReserveStateStore
+}
+
+// New instantiates a new DjedManager
+func New(
+	databaseContext model.DBReader,
+
+	oraclePublicKeys oracle.PublicKeySet,
+	oracleMinRecords int,
+	oracleMaxRecordAge time.Duration,
+	oracleMaxPriceDeviation uint64,
+	djedCalculator *djed.Calculator,
+
+	blockStore model.BlockStore,
+	ghostdagDataStore model.GHOSTDAGDataStore,
+	priceRecordStore model.PriceRecordStore,
+	reserveStateStore model.ReserveStateStore,
+) model.DjedManager {
+
+	return &djedManager{
+		databaseContext: databaseContext,
+
+		oraclePublicKeys:        oraclePublicKeys,
+		oracleMinRecords:        oracleMinRecords,
+		oracleMaxRecordAge:      oracleMaxRecordAge,
+		oracleMaxPriceDeviation: oracleMaxPriceDeviation,
+		djedCalculator:          djedCalculator,
+
+		blockStore:        blockStore,
+		ghostdagDataStore: ghostdagDataStore,
+		priceRecordStore:  priceRecordStore,
+		reserveStateStore: reserveStateStore,
+	}
+}
+
+// ExchangeRate returns the value of one KSH in KUSD sompi in effect at the given block.
+// That is the KSH price in effect at the selected parent of the block, so that the
+// transactions accepted by a chain block are validated against the same price they
+// were validated against in the mempool, when that block was the virtual.
+func (dm *djedManager) ExchangeRate(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (uint64, error) {
+	selectedParent, err := dm.selectedParent(stagingArea, blockHash)
+	if err != nil {
+		return 0, err
+	}
+
+	priceRecord, found, err := dm.PriceRecord(stagingArea, selectedParent)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, errors.Wrapf(ruleerrors.ErrMissingExchangeRate, "no exchange rate is in effect at block %s", blockHash)
+	}
+	return priceRecord.KSH, nil
+}
+
+func (dm *djedManager) selectedParent(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (
+	*externalapi.DomainHash, error) {
+
+	ghostdagData, err := dm.ghostdagDataStore.Get(dm.databaseContext, stagingArea, blockHash, false)
+	if err != nil {
+		return nil, err
+	}
+	return ghostdagData.SelectedParent(), nil
+}
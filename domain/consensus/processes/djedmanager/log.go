@@ -0,0 +1,7 @@
+package djedmanager
+
+import (
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("DJED")
@@ -0,0 +1,230 @@
+package djedmanager
+
+import (
+	"math/bits"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/ruleerrors"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionhelper"
+	"github.com/pkg/errors"
+)
+
+// PriceRecord returns the price record in effect at the given block, if there is one
+func (dm *djedManager) PriceRecord(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (
+	priceRecord *oracle.PriceRecord, found bool, err error) {
+
+	found, err = dm.priceRecordStore.Has(dm.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return nil, false, err
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	priceRecord, err = dm.priceRecordStore.Get(dm.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return nil, false, err
+	}
+	return priceRecord, true, nil
+}
+
+// ValidateOracleData validates the signed price records that a block with the given
+// timestamp commits to, and returns the price record they aggregate to.
+// A block is not required to commit to any price record, in which case nil is returned.
+func (dm *djedManager) ValidateOracleData(oracleData []byte, blockTimeInMilliseconds int64) (*oracle.PriceRecord, error) {
+	if len(oracleData) == 0 {
+		return nil, nil
+	}
+
+	signedRecords, err := oracle.DeserializeSignedPriceRecords(oracleData)
+	if err != nil {
+		return nil, errors.Wrapf(ruleerrors.ErrBadOraclePriceRecords, "%s", err)
+	}
+
+	if len(signedRecords) > len(dm.oraclePublicKeys) {
+		return nil, errors.Wrapf(ruleerrors.ErrBadOraclePriceRecords, "%d price records are committed to, "+
+			"but there are only %d trusted price feeds", len(signedRecords), len(dm.oraclePublicKeys))
+	}
+
+	signers := make(map[string]struct{}, len(signedRecords))
+	for i, signedRecord := range signedRecords {
+		if _, ok := signers[string(signedRecord.PublicKey)]; ok {
+			return nil, errors.Wrapf(ruleerrors.ErrBadOraclePriceRecords, "price record %d is signed by "+
+				"%x, which already signed a previous price record", i, signedRecord.PublicKey)
+		}
+		signers[string(signedRecord.PublicKey)] = struct{}{}
+
+		err := signedRecord.Verify(dm.oraclePublicKeys)
+		if err != nil {
+			return nil, errors.Wrapf(ruleerrors.ErrBadOraclePriceRecords, "price record %d: %s", i, err)
+		}
+
+		err = oracle.CheckFreshness(signedRecord.Record, blockTimeInMilliseconds, dm.oracleMaxRecordAge)
+		if err != nil {
+			return nil, errors.Wrapf(ruleerrors.ErrBadOraclePriceRecords, "price record %d: %s", i, err)
+		}
+	}
+
+	priceRecord, err := oracle.Aggregate(signedRecords, dm.oracleMinRecords)
+	if err != nil {
+		return nil, errors.Wrapf(ruleerrors.ErrBadOraclePriceRecords, "%s", err)
+	}
+	return priceRecord, nil
+}
+
+// ValidatePriceDeviation validates that none of the prices of the given price record deviates
+// by more than the allowed percentage from the price in effect at the selected parent of the
+// given block. Any price is valid if no price is in effect at the selected parent.
+func (dm *djedManager) ValidatePriceDeviation(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	priceRecord *oracle.PriceRecord) error {
+
+	selectedParent, err := dm.selectedParent(stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+	selectedParentPriceRecord, found, err := dm.PriceRecord(stagingArea, selectedParent)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	prices := []struct {
+		name      string
+		reference uint64
+		price     uint64
+	}{
+		{name: "KSH", reference: selectedParentPriceRecord.KSH, price: priceRecord.KSH},
+		{name: "KRV", reference: selectedParentPriceRecord.KRV, price: priceRecord.KRV},
+		{name: "KUSD", reference: selectedParentPriceRecord.KUSD, price: priceRecord.KUSD},
+	}
+	for _, price := range prices {
+		if exceedsDeviation(price.reference, price.price, dm.oracleMaxPriceDeviation) {
+			return errors.Wrapf(ruleerrors.ErrOraclePriceDeviation, "%s price %d deviates by more than %d%% "+
+				"from the price %d in effect at the selected parent %s", price.name, price.price,
+				dm.oracleMaxPriceDeviation, price.reference, selectedParent)
+		}
+	}
+	return nil
+}
+
+// exceedsDeviation returns whether price deviates from reference by more than
+// maxDeviation percent of reference
+func exceedsDeviation(reference uint64, price uint64, maxDeviation uint64) bool {
+	if reference == 0 {
+		return false
+	}
+
+	deviation := price - reference
+	if price < reference {
+		deviation = reference - price
+	}
+
+	// deviation * 100 > reference * maxDeviation, computed over 128 bits
+	deviationHigh, deviationLow := bits.Mul64(deviation, 100)
+	allowedHigh, allowedLow := bits.Mul64(reference, maxDeviation)
+	return deviationHigh > allowedHigh || (deviationHigh == allowedHigh && deviationLow > allowedLow)
+}
+
+// StagePriceRecord stages the price record in effect at the given block, which must already be
+// validated, as calculated by CalculatePriceRecord.
+// The selected parent of a block with trusted data might be unknown, so unless such a block commits
+// to a price record itself, it keeps the one staged by StageTrustedPriceRecord, if any.
+func (dm *djedManager) StagePriceRecord(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	isBlockWithTrustedData bool) error {
+
+	block, err := dm.blockStore.Block(dm.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+	coinbasePayload := block.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload
+
+	priceRecord, err := dm.aggregatedPriceRecord(coinbasePayload)
+	if err != nil {
+		return err
+	}
+	if priceRecord != nil {
+		log.Debugf("Block %s sets the KSH price to %d", blockHash, priceRecord.KSH)
+		dm.priceRecordStore.Stage(stagingArea, blockHash, priceRecord)
+		return nil
+	}
+
+	if isBlockWithTrustedData {
+		return nil
+	}
+
+	priceRecord, err = dm.inheritedPriceRecord(stagingArea, blockHash, block.Header.TimeInMilliseconds())
+	if err != nil {
+		return err
+	}
+	if priceRecord != nil {
+		dm.priceRecordStore.Stage(stagingArea, blockHash, priceRecord)
+	}
+	return nil
+}
+
+// CalculatePriceRecord returns the price record in effect at a block with the given coinbase payload
+// and timestamp, whose selected parent is the selected parent of the given block. That is the price
+// record aggregated from the signed price records the coinbase payload commits to, which must already
+// be validated, or otherwise the one in effect at the selected parent for as long as it's not stale.
+// It returns nil if no price record is in effect.
+func (dm *djedManager) CalculatePriceRecord(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	coinbasePayload []byte, blockTimeInMilliseconds int64) (*oracle.PriceRecord, error) {
+
+	priceRecord, err := dm.aggregatedPriceRecord(coinbasePayload)
+	if err != nil || priceRecord != nil {
+		return priceRecord, err
+	}
+	return dm.inheritedPriceRecord(stagingArea, blockHash, blockTimeInMilliseconds)
+}
+
+// aggregatedPriceRecord returns the price record aggregated from the signed price records
+// the given coinbase payload commits to, or nil if it commits to none
+func (dm *djedManager) aggregatedPriceRecord(coinbasePayload []byte) (*oracle.PriceRecord, error) {
+	_, oracleData, err := oracle.ExtractFromCoinbasePayload(coinbasePayload)
+	if err != nil {
+		return nil, err
+	}
+	if len(oracleData) == 0 {
+		return nil, nil
+	}
+
+	signedRecords, err := oracle.DeserializeSignedPriceRecords(oracleData)
+	if err != nil {
+		return nil, err
+	}
+	return oracle.Aggregate(signedRecords, dm.oracleMinRecords)
+}
+
+// inheritedPriceRecord returns the price record in effect at the selected parent of the given
+// block, unless it's stale at the given timestamp, in which case nil is returned
+func (dm *djedManager) inheritedPriceRecord(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	blockTimeInMilliseconds int64) (*oracle.PriceRecord, error) {
+
+	selectedParent, err := dm.selectedParent(stagingArea, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	selectedParentPriceRecord, found, err := dm.PriceRecord(stagingArea, selectedParent)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	err = oracle.CheckFreshness(selectedParentPriceRecord, blockTimeInMilliseconds, dm.oracleMaxRecordAge)
+	if err != nil {
+		log.Debugf("The price record in effect at %s is not inherited by %s: %s", selectedParent, blockHash, err)
+		return nil, nil
+	}
+	return selectedParentPriceRecord, nil
+}
+
+// StageTrustedPriceRecord stages the given price record, which was received along with the
+// trusted data of the given block, as the one in effect at that block
+func (dm *djedManager) StageTrustedPriceRecord(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	priceRecord *oracle.PriceRecord) {
+
+	dm.priceRecordStore.Stage(stagingArea, blockHash, priceRecord)
+}
@@ -0,0 +1,111 @@
+package djedmanager
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/ruleerrors"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
+	"github.com/pkg/errors"
+)
+
+// ReserveState returns the reserve state in effect at the given block.
+// That is the reserve state after the transactions accepted by the selected
+// parent of the block, for the same reason ExchangeRate uses the selected parent.
+func (dm *djedManager) ReserveState(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (
+	*externalapi.ReserveState, error) {
+
+	selectedParent, err := dm.selectedParent(stagingArea, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if selectedParent.Equal(model.VirtualGenesisBlockHash) {
+		return &externalapi.ReserveState{}, nil
+	}
+
+	return dm.reserveStateStore.Get(dm.databaseContext, stagingArea, selectedParent)
+}
+
+// ApplyConversion applies the conversion transaction tx, whose inputs amount to totalSompiIn,
+// to reserveState at the exchange rate in effect at povBlockHash. It returns the reserve state
+// after the conversion and the fee the conversion pays, in KSH sompi.
+func (dm *djedManager) ApplyConversion(stagingArea *model.StagingArea, povBlockHash *externalapi.DomainHash,
+	reserveState *externalapi.ReserveState, tx *externalapi.DomainTransaction, totalSompiIn uint64) (
+	*externalapi.ReserveState, uint64, error) {
+
+	// It is safe to ignore overflow here because it would have
+	// already been caught by the transaction validator.
+	totalSompiOut := uint64(0)
+	for _, output := range tx.Outputs {
+		totalSompiOut += output.Value
+	}
+
+	exchangeRate, err := dm.ExchangeRate(stagingArea, povBlockHash)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	newReserveState, fee, err := dm.djedCalculator.ApplyConversion(reserveState, exchangeRate, tx.Type, totalSompiIn, totalSompiOut)
+	if err != nil {
+		if errors.Is(err, djed.ErrReserveRatioTooLow) || errors.Is(err, djed.ErrReserveRatioTooHigh) {
+			return nil, 0, errors.Wrapf(ruleerrors.ErrReserveRatioOutOfBounds, "%s transaction %s at "+
+				"exchange rate %d: %s", tx.Type, consensushashing.TransactionID(tx), exchangeRate, err)
+		}
+		return nil, 0, errors.Wrapf(ruleerrors.ErrBadConversionAmount, "%s transaction %s at "+
+			"exchange rate %d: %s", tx.Type, consensushashing.TransactionID(tx), exchangeRate, err)
+	}
+	return newReserveState, fee, nil
+}
+
+// CalculateReserveState returns the reserve state after the conversions accepted by the given
+// block, as given by acceptanceData, are applied to the reserve state in effect at it
+func (dm *djedManager) CalculateReserveState(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
+	acceptanceData externalapi.AcceptanceData) (*externalapi.ReserveState, error) {
+
+	reserveState, err := dm.ReserveState(stagingArea, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			transaction := transactionAcceptanceData.Transaction
+			if !transactionAcceptanceData.IsAccepted || !transaction.Type.IsConversion() {
+				continue
+			}
+
+			totalSompiIn := uint64(0)
+			for _, utxoEntry := range transactionAcceptanceData.TransactionInputUTXOEntries {
+				totalSompiIn += utxoEntry.Amount()
+			}
+
+			reserveState, _, err = dm.ApplyConversion(stagingArea, blockHash, reserveState, transaction, totalSompiIn)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return reserveState, nil
+}
+
+// ReserveInfo returns the reserve state after the transactions accepted by the given block,
+// valued at the exchange rate in effect at it
+func (dm *djedManager) ReserveInfo(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) (
+	*externalapi.ReserveInfo, error) {
+
+	reserveState, err := dm.reserveStateStore.Get(dm.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	exchangeRate, err := dm.ExchangeRate(stagingArea, blockHash)
+	if err != nil {
+		if !errors.Is(err, ruleerrors.ErrMissingExchangeRate) {
+			return nil, err
+		}
+		exchangeRate = 0
+	}
+
+	return djed.ReserveInfo(reserveState, exchangeRate)
+}
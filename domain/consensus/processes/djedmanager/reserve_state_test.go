@@ -0,0 +1,106 @@
+package djedmanager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/database"
+	"github.com/Kash-Protocol/kashd/domain/consensus/datastructures/ghostdagdatastore"
+	"github.com/Kash-Protocol/kashd/domain/consensus/datastructures/pricerecordstore"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/ruleerrors"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+	"github.com/pkg/errors"
+)
+
+func TestApplyConversion(t *testing.T) {
+	reserveState := &externalapi.ReserveState{
+		Reserve:    100 * constants.SompiPerKash,
+		KUSDSupply: 0,
+		KRVSupply:  100 * constants.SompiPerKash,
+	}
+
+	tests := []struct {
+		name         string
+		exchangeRate uint64
+		kshIn        uint64
+		kusdOut      uint64
+		expectedFee  uint64
+		expectedErr  error
+	}{
+		{
+			name:         "mint within the rules",
+			exchangeRate: constants.SompiPerKash,
+			kshIn:        10 * constants.SompiPerKash,
+			kusdOut:      9 * constants.SompiPerKash,
+			expectedFee:  constants.SompiPerKash,
+		},
+		{
+			name:         "mint more than paid for",
+			exchangeRate: constants.SompiPerKash,
+			kshIn:        1,
+			kusdOut:      10 * constants.SompiPerKash,
+			expectedErr:  ruleerrors.ErrBadConversionAmount,
+		},
+		{
+			name:         "mint below the minimal reserve ratio",
+			exchangeRate: constants.SompiPerKash,
+			kshIn:        50 * constants.SompiPerKash,
+			kusdOut:      50 * constants.SompiPerKash,
+			expectedErr:  ruleerrors.ErrReserveRatioOutOfBounds,
+		},
+		{
+			name:        "mint without an exchange rate",
+			kshIn:       10 * constants.SompiPerKash,
+			kusdOut:     9 * constants.SompiPerKash,
+			expectedErr: ruleerrors.ErrMissingExchangeRate,
+		},
+	}
+
+	povBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	selectedParent := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2})
+	for _, test := range tests {
+		// The stores are only staged, so that no database is needed
+		stagingArea := model.NewStagingArea()
+		ghostdagDataStore := ghostdagdatastore.New(database.MakeBucket(nil), 10, false)
+		ghostdagDataStore.Stage(stagingArea, povBlockHash, externalapi.NewBlockGHOSTDAGData(
+			1, big.NewInt(1), selectedParent, nil, nil, nil), false)
+		priceRecordStore := pricerecordstore.New(database.MakeBucket(nil), 10, false)
+		if test.exchangeRate != 0 {
+			priceRecordStore.Stage(stagingArea, selectedParent, &oracle.PriceRecord{KSH: test.exchangeRate})
+		} else {
+			priceRecordStore.Delete(stagingArea, selectedParent)
+		}
+
+		manager := &djedManager{
+			djedCalculator:    djed.NewCalculator(400, 800, 0, constants.SompiPerKash),
+			ghostdagDataStore: ghostdagDataStore,
+			priceRecordStore:  priceRecordStore,
+		}
+		tx := &externalapi.DomainTransaction{
+			Outputs: []*externalapi.DomainTransactionOutput{{
+				Value:           test.kusdOut,
+				ScriptPublicKey: &externalapi.ScriptPublicKey{Script: []byte{}, Version: 0},
+			}},
+			Type: externalapi.MintKUSD,
+		}
+
+		_, fee, err := manager.ApplyConversion(stagingArea, povBlockHash, reserveState, tx, test.kshIn)
+		if test.expectedErr != nil {
+			if !errors.Is(err, test.expectedErr) {
+				t.Errorf("%s: expected error %v but got %v", test.name, test.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %+v", test.name, err)
+			continue
+		}
+		if fee != test.expectedFee {
+			t.Errorf("%s: expected fee %d but got %d", test.name, test.expectedFee, fee)
+		}
+	}
+}
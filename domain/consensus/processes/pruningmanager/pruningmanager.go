@@ -4,7 +4,9 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/multiset"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/utxo"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/virtual"
 	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
@@ -36,6 +38,8 @@ type pruningManager struct {
 	utxoDiffStore                       model.UTXODiffStore
 	daaBlocksStore                      model.DAABlocksStore
 	reachabilityDataStore               model.ReachabilityDataStore
+	priceRecordStore                    model.PriceRecordStore
+	reserveStateStore                   model.ReserveStateStore
 
 	isArchivalNode                  bool
 	genesisHash                     *externalapi.DomainHash
@@ -71,6 +75,8 @@ func New(
 	daaBlocksStore model.DAABlocksStore,
 	reachabilityDataStore model.ReachabilityDataStore,
 	blocksWithTrustedDataDAAWindowStore model.BlocksWithTrustedDataDAAWindowStore,
+	priceRecordStore model.PriceRecordStore,
+	reserveStateStore model.ReserveStateStore,
 
 	isArchivalNode bool,
 	genesisHash *externalapi.DomainHash,
@@ -101,6 +107,8 @@ func New(
 		daaBlocksStore:                      daaBlocksStore,
 		reachabilityDataStore:               reachabilityDataStore,
 		blocksWithTrustedDataDAAWindowStore: blocksWithTrustedDataDAAWindowStore,
+		priceRecordStore:                    priceRecordStore,
+		reserveStateStore:                   reserveStateStore,
 
 		isArchivalNode:                  isArchivalNode,
 		genesisHash:                     genesisHash,
@@ -523,6 +531,8 @@ func (pm *pruningManager) deleteBlock(stagingArea *model.StagingArea, blockHash
 	pm.blocksStore.Delete(stagingArea, blockHash)
 	pm.utxoDiffStore.Delete(stagingArea, blockHash)
 	pm.daaBlocksStore.Delete(stagingArea, blockHash)
+	pm.priceRecordStore.Delete(stagingArea, blockHash)
+	pm.reserveStateStore.Delete(stagingArea, blockHash)
 
 	return false, nil
 }
@@ -718,19 +728,36 @@ func (pm *pruningManager) validateUTXOSetFitsCommitment(stagingArea *model.Stagi
 	}
 	utxoSetHash := utxoSetMultiset.Hash()
 
+	reserveState, err := pm.reserveStateStore.Get(pm.databaseContext, stagingArea, pruningPointHash)
+	if err != nil {
+		return err
+	}
+	var priceRecord *oracle.PriceRecord
+	hasPriceRecord, err := pm.priceRecordStore.Has(pm.databaseContext, stagingArea, pruningPointHash)
+	if err != nil {
+		return err
+	}
+	if hasPriceRecord {
+		priceRecord, err = pm.priceRecordStore.Get(pm.databaseContext, stagingArea, pruningPointHash)
+		if err != nil {
+			return err
+		}
+	}
+	utxoCommitment := djed.UTXOCommitment(utxoSetHash, reserveState, priceRecord)
+
 	header, err := pm.blockHeaderStore.BlockHeader(pm.databaseContext, stagingArea, pruningPointHash)
 	if err != nil {
 		return err
 	}
 	expectedUTXOCommitment := header.UTXOCommitment()
 
-	if !expectedUTXOCommitment.Equal(utxoSetHash) {
+	if !expectedUTXOCommitment.Equal(utxoCommitment) {
 		return errors.Errorf("Calculated UTXOSet for next pruning point %s doesn't match it's UTXO commitment\n"+
-			"Calculated UTXOSet hash: %s. Commitment: %s",
-			pruningPointHash, utxoSetHash, expectedUTXOCommitment)
+			"Calculated UTXO commitment: %s. Commitment: %s",
+			pruningPointHash, utxoCommitment, expectedUTXOCommitment)
 	}
 
-	log.Debugf("Validated the pruning point %s UTXO commitment: %s", pruningPointHash, utxoSetHash)
+	log.Debugf("Validated the pruning point %s UTXO commitment: %s", pruningPointHash, utxoCommitment)
 
 	return nil
 }
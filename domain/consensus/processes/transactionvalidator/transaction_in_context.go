@@ -78,12 +78,19 @@ func (v *transactionValidator) ValidateTransactionInContextAndPopulateFee(stagin
 		return err
 	}
 
-	totalSompiOut, err := v.checkTransactionOutputAmounts(tx, totalSompiIn)
-	if err != nil {
-		return err
-	}
+	if tx.Type.IsConversion() {
+		tx.Fee, err = v.checkConversionAmounts(stagingArea, povBlockHash, tx, totalSompiIn)
+		if err != nil {
+			return err
+		}
+	} else {
+		totalSompiOut, err := v.checkTransactionOutputAmounts(tx, totalSompiIn)
+		if err != nil {
+			return err
+		}
 
-	tx.Fee = totalSompiIn - totalSompiOut
+		tx.Fee = totalSompiIn - totalSompiOut
+	}
 
 	err = v.checkTransactionSequenceLock(stagingArea, povBlockHash, tx)
 	if err != nil {
@@ -208,6 +215,23 @@ func (v *transactionValidator) checkTransactionOutputAmounts(tx *externalapi.Dom
 	return totalSompiOut, nil
 }
 
+// checkConversionAmounts validates the amounts of a conversion transaction against the
+// Djed rules in effect at povBlockHash, and returns the fee it pays in KSH sompi.
+func (v *transactionValidator) checkConversionAmounts(stagingArea *model.StagingArea,
+	povBlockHash *externalapi.DomainHash, tx *externalapi.DomainTransaction, totalSompiIn uint64) (uint64, error) {
+
+	reserveState, err := v.djedManager.ReserveState(stagingArea, povBlockHash)
+	if err != nil {
+		return 0, err
+	}
+
+	_, fee, err := v.djedManager.ApplyConversion(stagingArea, povBlockHash, reserveState, tx, totalSompiIn)
+	if err != nil {
+		return 0, err
+	}
+	return fee, nil
+}
+
 func (v *transactionValidator) checkTransactionSequenceLock(stagingArea *model.StagingArea,
 	povBlockHash *externalapi.DomainHash, tx *externalapi.DomainTransaction) error {
 
@@ -2,6 +2,13 @@ package transactionvalidator
 
 import (
 	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/ruleerrors"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+	"github.com/pkg/errors"
 )
 
 // TestSequenceLocksActive tests the SequenceLockActive function to ensure it
@@ -31,3 +38,111 @@ func TestSequenceLocksActive(t *testing.T) {
 		}
 	}
 }
+
+type fakeDjedManager struct {
+	exchangeRate  uint64
+	reserveState  *externalapi.ReserveState
+	conversionFee uint64
+	conversionErr error
+}
+
+func (dm *fakeDjedManager) ExchangeRate(_ *model.StagingArea, blockHash *externalapi.DomainHash) (uint64, error) {
+	if dm.exchangeRate == 0 {
+		return 0, errors.Wrapf(ruleerrors.ErrMissingExchangeRate, "no exchange rate at %s", blockHash)
+	}
+	return dm.exchangeRate, nil
+}
+
+func (dm *fakeDjedManager) ReserveState(_ *model.StagingArea, _ *externalapi.DomainHash) (*externalapi.ReserveState, error) {
+	return dm.reserveState, nil
+}
+
+func (dm *fakeDjedManager) ApplyConversion(_ *model.StagingArea, _ *externalapi.DomainHash,
+	reserveState *externalapi.ReserveState, _ *externalapi.DomainTransaction, _ uint64) (
+	*externalapi.ReserveState, uint64, error) {
+
+	if dm.conversionErr != nil {
+		return nil, 0, dm.conversionErr
+	}
+	return reserveState, dm.conversionFee, nil
+}
+
+func (dm *fakeDjedManager) CalculateReserveState(_ *model.StagingArea, _ *externalapi.DomainHash,
+	_ externalapi.AcceptanceData) (*externalapi.ReserveState, error) {
+
+	return dm.reserveState, nil
+}
+
+func (dm *fakeDjedManager) ReserveInfo(_ *model.StagingArea, _ *externalapi.DomainHash) (*externalapi.ReserveInfo, error) {
+	return &externalapi.ReserveInfo{ReserveState: dm.reserveState}, nil
+}
+
+func (dm *fakeDjedManager) PriceRecord(_ *model.StagingArea, _ *externalapi.DomainHash) (*oracle.PriceRecord, bool, error) {
+	return nil, false, nil
+}
+
+func (dm *fakeDjedManager) ValidateOracleData(_ []byte, _ int64) (*oracle.PriceRecord, error) {
+	return nil, nil
+}
+
+func (dm *fakeDjedManager) ValidatePriceDeviation(_ *model.StagingArea, _ *externalapi.DomainHash, _ *oracle.PriceRecord) error {
+	return nil
+}
+
+func (dm *fakeDjedManager) StagePriceRecord(_ *model.StagingArea, _ *externalapi.DomainHash, _ bool) error {
+	return nil
+}
+
+func (dm *fakeDjedManager) CalculatePriceRecord(_ *model.StagingArea, _ *externalapi.DomainHash, _ []byte, _ int64) (
+	*oracle.PriceRecord, error) {
+
+	return nil, nil
+}
+
+func (dm *fakeDjedManager) StageTrustedPriceRecord(_ *model.StagingArea, _ *externalapi.DomainHash, _ *oracle.PriceRecord) {
+}
+
+func TestCheckConversionAmounts(t *testing.T) {
+	// The Djed rules themselves are applied by the djed manager, so only the fee it
+	// calculates and its errors are expected to be passed on
+	tests := []struct {
+		name          string
+		conversionFee uint64
+		conversionErr error
+	}{
+		{
+			name:          "valid conversion",
+			conversionFee: constants.SompiPerKash,
+		},
+		{
+			name:          "invalid conversion",
+			conversionErr: errors.Wrapf(ruleerrors.ErrBadConversionAmount, "bad amount"),
+		},
+	}
+
+	for _, test := range tests {
+		validator := transactionValidator{
+			djedManager: &fakeDjedManager{
+				reserveState:  &externalapi.ReserveState{},
+				conversionFee: test.conversionFee,
+				conversionErr: test.conversionErr,
+			},
+		}
+		tx := &externalapi.DomainTransaction{Type: externalapi.MintKUSD}
+
+		fee, err := validator.checkConversionAmounts(nil, model.VirtualBlockHash, tx, constants.SompiPerKash)
+		if test.conversionErr != nil {
+			if !errors.Is(err, test.conversionErr) {
+				t.Errorf("%s: expected error %v but got %v", test.name, test.conversionErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %+v", test.name, err)
+			continue
+		}
+		if fee != test.conversionFee {
+			t.Errorf("%s: expected fee %d but got %d", test.name, test.conversionFee, fee)
+		}
+	}
+}
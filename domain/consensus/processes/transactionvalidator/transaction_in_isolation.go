@@ -4,6 +4,7 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/ruleerrors"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/subnetworks"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionhelper"
 	"github.com/pkg/errors"
@@ -119,8 +120,14 @@ func (v *transactionValidator) checkCoinbaseInIsolation(tx *externalapi.DomainTr
 		return nil
 	}
 
-	// Coinbase payload length must not exceed the max length.
-	payloadLen := len(tx.Payload)
+	// Coinbase payload length, not counting the oracle data it commits to, must not exceed the max length.
+	// The length of the oracle data is bounded by the number of trusted price feeds.
+	payloadWithoutOracleData, _, err := oracle.ExtractFromCoinbasePayload(tx.Payload)
+	if err != nil {
+		return errors.Wrapf(ruleerrors.ErrBadCoinbasePayloadLen, "coinbase transaction payload has "+
+			"malformed oracle data: %s", err)
+	}
+	payloadLen := len(payloadWithoutOracleData)
 	if uint64(payloadLen) > v.maxCoinbasePayloadLength {
 		return errors.Wrapf(ruleerrors.ErrBadCoinbasePayloadLen, "coinbase transaction payload length "+
 			"of %d is out of range (max: %d)",
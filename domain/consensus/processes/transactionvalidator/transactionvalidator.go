@@ -17,6 +17,7 @@ type transactionValidator struct {
 	pastMedianTimeManager                   model.PastMedianTimeManager
 	ghostdagDataStore                       model.GHOSTDAGDataStore
 	daaBlocksStore                          model.DAABlocksStore
+	djedManager                             model.DjedManager
 	enableNonNativeSubnetworks              bool
 	maxCoinbasePayloadLength                uint64
 	ghostdagK                               externalapi.KType
@@ -36,6 +37,7 @@ func New(blockCoinbaseMaturity uint64,
 	pastMedianTimeManager model.PastMedianTimeManager,
 	ghostdagDataStore model.GHOSTDAGDataStore,
 	daaBlocksStore model.DAABlocksStore,
+	djedManager model.DjedManager,
 	txMassCalculator *txmass.Calculator) model.TransactionValidator {
 
 	return &transactionValidator{
@@ -48,6 +50,7 @@ func New(blockCoinbaseMaturity uint64,
 		pastMedianTimeManager:                   pastMedianTimeManager,
 		ghostdagDataStore:                       ghostdagDataStore,
 		daaBlocksStore:                          daaBlocksStore,
+		djedManager:                             djedManager,
 		sigCache:                                txscript.NewSigCache(sigCacheSize),
 		sigCacheECDSA:                           txscript.NewSigCacheECDSA(sigCacheSize),
 		txMassCalculator:                        txMassCalculator,
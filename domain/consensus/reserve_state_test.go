@@ -0,0 +1,139 @@
+package consensus_test
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/djed"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/testutils"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionhelper"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/txscript"
+)
+
+// TestReserveState verifies that accepted conversion transactions update the reserve state
+// of the virtual, and that the reserve state reverts once they are reorged out
+func TestReserveState(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %s", err)
+		}
+		consensusConfig.BlockCoinbaseMaturity = 0
+		consensusConfig.OraclePublicKeys = []string{"ed25519:" + hex.EncodeToString(publicKey)}
+		consensusConfig.OracleMinRecords = 1
+
+		factory := consensus.NewFactory()
+		testConsensus, teardown, err := factory.NewTestConsensus(consensusConfig, "TestReserveState")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		checkVirtualReserveState := func(expected *externalapi.ReserveState) {
+			reserveInfo, err := testConsensus.GetVirtualReserveInfo()
+			if err != nil {
+				t.Fatalf("GetVirtualReserveInfo: %+v", err)
+			}
+			if !reserveInfo.ReserveState.Equal(expected) {
+				t.Fatalf("expected the virtual reserve state to be %+v but got %+v",
+					expected, reserveInfo.ReserveState)
+			}
+		}
+
+		// One KSH is worth two USD
+		const exchangeRate = 2 * constants.SompiPerKash
+		priceRecord := &oracle.PriceRecord{KSH: exchangeRate, KRV: constants.SompiPerKash, KUSD: constants.SompiPerKash,
+			Timestamp: consensusConfig.GenesisBlock.Header.TimeInMilliseconds()}
+		oracleData, err := oracle.SerializeSignedPriceRecords(
+			[]*oracle.SignedPriceRecord{oracle.SignPriceRecordEd25519(priceRecord, privateKey)})
+		if err != nil {
+			t.Fatalf("SerializeSignedPriceRecords: %s", err)
+		}
+		scriptPublicKeyScript, err := txscript.PayToScriptHashScript([]byte{txscript.OpTrue})
+		if err != nil {
+			t.Fatalf("PayToScriptHashScript: %s", err)
+		}
+		coinbaseDataWithPriceRecord := &externalapi.DomainCoinbaseData{
+			ScriptPublicKey: &externalapi.ScriptPublicKey{Script: scriptPublicKeyScript,
+				Version: constants.MaxScriptPublicKeyVersion},
+			ExtraData:  []byte{},
+			OracleData: oracleData,
+		}
+
+		blockAHash, _, err := testConsensus.AddBlock(
+			[]*externalapi.DomainHash{consensusConfig.GenesisHash}, coinbaseDataWithPriceRecord, nil)
+		if err != nil {
+			t.Fatalf("Error creating blockA: %+v", err)
+		}
+		blockBHash, _, err := testConsensus.AddBlock([]*externalapi.DomainHash{blockAHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error creating blockB: %+v", err)
+		}
+		blockCHash, _, err := testConsensus.AddBlock([]*externalapi.DomainHash{blockBHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error creating blockC: %+v", err)
+		}
+		checkVirtualReserveState(&externalapi.ReserveState{})
+
+		blockC, _, err := testConsensus.GetBlock(blockCHash)
+		if err != nil {
+			t.Fatalf("Error getting blockC: %+v", err)
+		}
+		stakeTransaction, err := testutils.CreateTransaction(
+			blockC.Transactions[transactionhelper.CoinbaseTransactionIndex], 0)
+		if err != nil {
+			t.Fatalf("Error creating stakeTransaction: %+v", err)
+		}
+		kshIn := stakeTransaction.Outputs[0].Value
+		krvOut := kshIn / 2
+		cost, err := djed.NewCalculator(consensusConfig.MinReserveRatio, consensusConfig.MaxReserveRatio,
+			consensusConfig.ConversionFeeBasisPoints, consensusConfig.KRVMinimalPrice).
+			StakeKSHCost(&externalapi.ReserveState{}, exchangeRate, krvOut)
+		if err != nil {
+			t.Fatalf("StakeKSHCost: %s", err)
+		}
+		stakeTransaction.Type = externalapi.StakeKSH
+		stakeTransaction.Outputs[0].Value = krvOut
+
+		blockDHash, _, err := testConsensus.AddBlock([]*externalapi.DomainHash{blockCHash}, nil,
+			[]*externalapi.DomainTransaction{stakeTransaction})
+		if err != nil {
+			t.Fatalf("Error creating blockD: %+v", err)
+		}
+		checkVirtualReserveState(&externalapi.ReserveState{Reserve: cost, KRVSupply: krvOut})
+
+		// blockD itself does not accept stakeTransaction, its merging blocks do
+		blockDReserveState, err := testConsensus.ReserveStateStore().Get(
+			testConsensus.DatabaseContext(), model.NewStagingArea(), blockDHash)
+		if err != nil {
+			t.Fatalf("Error getting the reserve state of blockD: %+v", err)
+		}
+		if !blockDReserveState.Equal(&externalapi.ReserveState{}) {
+			t.Fatalf("expected the reserve state of blockD to be empty but got %+v", blockDReserveState)
+		}
+
+		// Reorg blockD out by a longer chain that double spends the input of stakeTransaction,
+		// so that stakeTransaction is no longer accepted even though blockD is still merged
+		doubleSpendTransaction, err := testutils.CreateTransaction(
+			blockC.Transactions[transactionhelper.CoinbaseTransactionIndex], 1)
+		if err != nil {
+			t.Fatalf("Error creating doubleSpendTransaction: %+v", err)
+		}
+		blockEHash, _, err := testConsensus.AddBlock([]*externalapi.DomainHash{blockCHash}, nil,
+			[]*externalapi.DomainTransaction{doubleSpendTransaction})
+		if err != nil {
+			t.Fatalf("Error creating blockE: %+v", err)
+		}
+		_, _, err = testConsensus.AddBlock([]*externalapi.DomainHash{blockEHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("Error creating blockF: %+v", err)
+		}
+		checkVirtualReserveState(&externalapi.ReserveState{})
+	})
+}
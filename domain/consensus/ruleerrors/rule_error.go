@@ -91,6 +91,27 @@ var (
 	// the expected asset type for the transaction.
 	ErrUTXOAssetTypeMismatch = newRuleError("ErrUTXOAssetTypeMismatch")
 
+	// ErrBadConversionAmount indicates that the output amount of a conversion
+	// transaction is not covered by its input at the exchange rate in effect.
+	ErrBadConversionAmount = newRuleError("ErrBadConversionAmount")
+
+	// ErrReserveRatioOutOfBounds indicates that a conversion transaction would
+	// move the reserve ratio out of its allowed bounds.
+	ErrReserveRatioOutOfBounds = newRuleError("ErrReserveRatioOutOfBounds")
+
+	// ErrMissingExchangeRate indicates that a conversion transaction was
+	// validated against a block with no exchange rate in effect.
+	ErrMissingExchangeRate = newRuleError("ErrMissingExchangeRate")
+
+	// ErrBadOraclePriceRecords indicates that the signed price records a block
+	// commits to are malformed, not signed by trusted price feeds, or too far
+	// from the block timestamp.
+	ErrBadOraclePriceRecords = newRuleError("ErrBadOraclePriceRecords")
+
+	// ErrOraclePriceDeviation indicates that a block sets a price that deviates
+	// too much from the price in effect at its selected parent.
+	ErrOraclePriceDeviation = newRuleError("ErrOraclePriceDeviation")
+
 	// ErrBadTxOutValue indicates an output value for a transaction is
 	// invalid in some way such as being out of range.
 	ErrBadTxOutValue  = newRuleError("ErrBadTxOutValue")
@@ -131,6 +131,10 @@ func (tc *testConsensus) TransactionValidator() testapi.TestTransactionValidator
 	return tc.testTransactionValidator
 }
 
+func (tc *testConsensus) DjedManager() model.DjedManager {
+	return tc.djedManager
+}
+
 func (tc *testConsensus) FinalityManager() model.FinalityManager {
 	return tc.finalityManager
 }
@@ -147,6 +151,10 @@ func (tc *testConsensus) DAABlocksStore() model.DAABlocksStore {
 	return tc.daaBlocksStore
 }
 
+func (tc *testConsensus) ReserveStateStore() model.ReserveStateStore {
+	return tc.reserveStateStore
+}
+
 func (tc *testConsensus) Consensus() externalapi.Consensus {
 	return tc
 }
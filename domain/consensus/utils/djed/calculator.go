@@ -0,0 +1,279 @@
+package djed
+
+import (
+	"math/big"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/pkg/errors"
+)
+
+const (
+	percentDenominator    = 100
+	basisPointDenominator = 10_000
+)
+
+// Calculator implements the Djed stablecoin rules: the prices at which KSH is
+// converted into KUSD and KRV and back, and the reserve ratio bounds those
+// conversions must keep.
+//
+// All exchange rates are expressed as the value of one KSH in KUSD sompi, that
+// is, the USD price of KSH multiplied by constants.SompiPerKash.
+type Calculator struct {
+	minReserveRatio          uint64
+	maxReserveRatio          uint64
+	conversionFeeBasisPoints uint64
+	krvMinimalPrice          uint64
+}
+
+// NewCalculator creates a new instance of Calculator.
+// minReserveRatio and maxReserveRatio are given in percent, the conversion fee in
+// basis points, and krvMinimalPrice in KSH sompi per one KRV.
+func NewCalculator(minReserveRatio, maxReserveRatio, conversionFeeBasisPoints, krvMinimalPrice uint64) *Calculator {
+	return &Calculator{
+		minReserveRatio:          minReserveRatio,
+		maxReserveRatio:          maxReserveRatio,
+		conversionFeeBasisPoints: conversionFeeBasisPoints,
+		krvMinimalPrice:          krvMinimalPrice,
+	}
+}
+
+// MinReserveRatio returns the minimal reserve ratio, in percent, configured for this Calculator
+func (c *Calculator) MinReserveRatio() uint64 { return c.minReserveRatio }
+
+// MaxReserveRatio returns the maximal reserve ratio, in percent, configured for this Calculator
+func (c *Calculator) MaxReserveRatio() uint64 { return c.maxReserveRatio }
+
+// ConversionFeeBasisPoints returns the conversion fee, in basis points, configured for this Calculator
+func (c *Calculator) ConversionFeeBasisPoints() uint64 { return c.conversionFeeBasisPoints }
+
+// KRVMinimalPrice returns the minimal KRV price, in KSH sompi per KRV, configured for this Calculator
+func (c *Calculator) KRVMinimalPrice() uint64 { return c.krvMinimalPrice }
+
+// price is the KSH price, in sompi, of one sompi of KUSD or KRV.
+// It is kept as a fraction so that conversions are rounded only once.
+type price struct {
+	numerator   *big.Int
+	denominator *big.Int
+}
+
+func (p *price) less(other *price) bool {
+	left := new(big.Int).Mul(p.numerator, other.denominator)
+	right := new(big.Int).Mul(other.numerator, p.denominator)
+	return left.Cmp(right) < 0
+}
+
+// kusdTargetPrice is the price of KUSD if it were exactly pegged to the USD
+func (c *Calculator) kusdTargetPrice(exchangeRate uint64) *price {
+	return &price{
+		numerator:   new(big.Int).SetUint64(constants.SompiPerKash),
+		denominator: new(big.Int).SetUint64(exchangeRate),
+	}
+}
+
+// kusdRedeemPrice is the price at which the reserve buys back KUSD: the target
+// price, or the reserve divided by the KUSD supply if the reserve cannot cover it
+func (c *Calculator) kusdRedeemPrice(state *externalapi.ReserveState, exchangeRate uint64) *price {
+	targetPrice := c.kusdTargetPrice(exchangeRate)
+	if state.KUSDSupply == 0 {
+		return targetPrice
+	}
+
+	reservePerKUSD := &price{
+		numerator:   new(big.Int).SetUint64(state.Reserve),
+		denominator: new(big.Int).SetUint64(state.KUSDSupply),
+	}
+	if reservePerKUSD.less(targetPrice) {
+		return reservePerKUSD
+	}
+	return targetPrice
+}
+
+// krvMinimalPriceFraction is the price below which KRV is never sold by the reserve
+func (c *Calculator) krvMinimalPriceFraction() *price {
+	return &price{
+		numerator:   new(big.Int).SetUint64(c.krvMinimalPrice),
+		denominator: new(big.Int).SetUint64(constants.SompiPerKash),
+	}
+}
+
+// krvPrice is the equity of the reserve divided by the KRV supply
+func (c *Calculator) krvPrice(state *externalapi.ReserveState, exchangeRate uint64) *price {
+	equityNumerator, equityDenominator := equity(state, exchangeRate)
+	return &price{
+		numerator:   equityNumerator,
+		denominator: new(big.Int).Mul(equityDenominator, new(big.Int).SetUint64(state.KRVSupply)),
+	}
+}
+
+// krvBuyPrice is the price at which the reserve sells KRV
+func (c *Calculator) krvBuyPrice(state *externalapi.ReserveState, exchangeRate uint64) *price {
+	minimalPrice := c.krvMinimalPriceFraction()
+	if state.KRVSupply == 0 {
+		return minimalPrice
+	}
+
+	krvPrice := c.krvPrice(state, exchangeRate)
+	if krvPrice.less(minimalPrice) {
+		return minimalPrice
+	}
+	return krvPrice
+}
+
+// equity returns the part of the reserve that is not owed to KUSD holders,
+// as a fraction of KSH sompi.
+func equity(state *externalapi.ReserveState, exchangeRate uint64) (numerator, denominator *big.Int) {
+	denominator = new(big.Int).SetUint64(exchangeRate)
+	reserve := new(big.Int).Mul(new(big.Int).SetUint64(state.Reserve), denominator)
+	liabilities := new(big.Int).Mul(new(big.Int).SetUint64(state.KUSDSupply), big.NewInt(constants.SompiPerKash))
+	if liabilities.Cmp(reserve) > 0 {
+		liabilities = reserve
+	}
+	return new(big.Int).Sub(reserve, liabilities), denominator
+}
+
+// cost returns the KSH, in sompi, that must be paid to buy amount at the given price,
+// including the conversion fee. The result is rounded up in favor of the reserve.
+func (c *Calculator) cost(amount uint64, price *price) (uint64, error) {
+	numerator := new(big.Int).Mul(new(big.Int).SetUint64(amount), price.numerator)
+	numerator.Mul(numerator, new(big.Int).SetUint64(basisPointDenominator+c.conversionFeeBasisPoints))
+	denominator := new(big.Int).Mul(price.denominator, big.NewInt(basisPointDenominator))
+
+	result, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() != 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	return toSompi(result)
+}
+
+// value returns the KSH, in sompi, that is paid for selling amount at the given price,
+// minus the conversion fee. The result is rounded down in favor of the reserve.
+func (c *Calculator) value(amount uint64, price *price) (uint64, error) {
+	feeBasisPoints := c.conversionFeeBasisPoints
+	if feeBasisPoints > basisPointDenominator {
+		feeBasisPoints = basisPointDenominator
+	}
+	numerator := new(big.Int).Mul(new(big.Int).SetUint64(amount), price.numerator)
+	numerator.Mul(numerator, new(big.Int).SetUint64(basisPointDenominator-feeBasisPoints))
+	denominator := new(big.Int).Mul(price.denominator, big.NewInt(basisPointDenominator))
+
+	return toSompi(new(big.Int).Quo(numerator, denominator))
+}
+
+func toSompi(amount *big.Int) (uint64, error) {
+	if !amount.IsUint64() || amount.Uint64() > constants.MaxSompi {
+		return 0, errors.Wrapf(ErrAmountOutOfRange, "converted amount %s is higher than max "+
+			"allowed value of %d", amount, constants.MaxSompi)
+	}
+	return amount.Uint64(), nil
+}
+
+// MintKUSDCost returns the KSH, in sompi, that must be paid into the reserve
+// in order to mint kusdAmount sompi of KUSD, including the conversion fee.
+func (c *Calculator) MintKUSDCost(exchangeRate uint64, kusdAmount uint64) (uint64, error) {
+	if exchangeRate == 0 {
+		return 0, errors.WithStack(ErrZeroExchangeRate)
+	}
+	return c.cost(kusdAmount, c.kusdTargetPrice(exchangeRate))
+}
+
+// StakeKSHCost returns the KSH, in sompi, that must be paid into the reserve
+// in order to receive krvAmount sompi of KRV, including the conversion fee.
+func (c *Calculator) StakeKSHCost(state *externalapi.ReserveState, exchangeRate uint64, krvAmount uint64) (uint64, error) {
+	if exchangeRate == 0 {
+		return 0, errors.WithStack(ErrZeroExchangeRate)
+	}
+	return c.cost(krvAmount, c.krvBuyPrice(state, exchangeRate))
+}
+
+// RedeemKSHValue returns the KSH, in sompi, that the reserve pays out for
+// krvAmount sompi of KRV, minus the conversion fee.
+func (c *Calculator) RedeemKSHValue(state *externalapi.ReserveState, exchangeRate uint64, krvAmount uint64) (uint64, error) {
+	if exchangeRate == 0 {
+		return 0, errors.WithStack(ErrZeroExchangeRate)
+	}
+	if state.KRVSupply == 0 {
+		return 0, nil
+	}
+	return c.value(krvAmount, c.krvPrice(state, exchangeRate))
+}
+
+// RedeemKUSDValue returns the KSH, in sompi, that the reserve pays out for
+// kusdAmount sompi of KUSD, minus the conversion fee.
+func (c *Calculator) RedeemKUSDValue(state *externalapi.ReserveState, exchangeRate uint64, kusdAmount uint64) (uint64, error) {
+	if exchangeRate == 0 {
+		return 0, errors.WithStack(ErrZeroExchangeRate)
+	}
+	return c.value(kusdAmount, c.kusdRedeemPrice(state, exchangeRate))
+}
+
+// IsReserveRatioAtLeast returns whether the reserve ratio of the given state is at least
+// ratio percent. A state without any KUSD has an unbounded reserve ratio.
+func IsReserveRatioAtLeast(state *externalapi.ReserveState, exchangeRate uint64, ratio uint64) bool {
+	if state.KUSDSupply == 0 {
+		return true
+	}
+	reserve, liabilities := reserveRatioOperands(state, exchangeRate, ratio)
+	return reserve.Cmp(liabilities) >= 0
+}
+
+// IsReserveRatioAtMost returns whether the reserve ratio of the given state is at most
+// ratio percent. A state without any KUSD has an unbounded reserve ratio, which is
+// not considered to exceed the maximum.
+func IsReserveRatioAtMost(state *externalapi.ReserveState, exchangeRate uint64, ratio uint64) bool {
+	if state.KUSDSupply == 0 {
+		return true
+	}
+	reserve, liabilities := reserveRatioOperands(state, exchangeRate, ratio)
+	return reserve.Cmp(liabilities) <= 0
+}
+
+// reserveRatioOperands returns both sides of reserve / liabilities <= ratio / 100,
+// multiplied by their denominators
+func reserveRatioOperands(state *externalapi.ReserveState, exchangeRate uint64, ratio uint64) (reserve, liabilities *big.Int) {
+	reserve = new(big.Int).SetUint64(state.Reserve)
+	reserve.Mul(reserve, new(big.Int).SetUint64(exchangeRate))
+	reserve.Mul(reserve, big.NewInt(percentDenominator))
+
+	liabilities = new(big.Int).SetUint64(state.KUSDSupply)
+	liabilities.Mul(liabilities, big.NewInt(constants.SompiPerKash))
+	liabilities.Mul(liabilities, new(big.Int).SetUint64(ratio))
+
+	return reserve, liabilities
+}
+
+// ReserveInfo values the given reserve state at the given exchange rate.
+// An exchange rate of zero means that no exchange rate is in effect, in which
+// case only the reserve state is filled.
+func ReserveInfo(state *externalapi.ReserveState, exchangeRate uint64) (*externalapi.ReserveInfo, error) {
+	reserveInfo := &externalapi.ReserveInfo{
+		ReserveState: state.Clone(),
+		ExchangeRate: exchangeRate,
+	}
+	if exchangeRate == 0 {
+		return reserveInfo, nil
+	}
+
+	liabilities := new(big.Int).Mul(new(big.Int).SetUint64(state.KUSDSupply), big.NewInt(constants.SompiPerKash))
+	liabilities.Div(liabilities, new(big.Int).SetUint64(exchangeRate))
+	if !liabilities.IsUint64() {
+		return nil, errors.Wrapf(ErrAmountOutOfRange, "KUSD liabilities of %s KSH sompi are out of range", liabilities)
+	}
+	reserveInfo.KUSDLiabilities = liabilities.Uint64()
+
+	equityNumerator, equityDenominator := equity(state, exchangeRate)
+	reserveInfo.KRVEquity = new(big.Int).Quo(equityNumerator, equityDenominator).Uint64()
+
+	if state.KUSDSupply != 0 {
+		ratio := new(big.Int).SetUint64(state.Reserve)
+		ratio.Mul(ratio, new(big.Int).SetUint64(exchangeRate))
+		ratio.Mul(ratio, big.NewInt(basisPointDenominator))
+		ratio.Quo(ratio, new(big.Int).Mul(new(big.Int).SetUint64(state.KUSDSupply), big.NewInt(constants.SompiPerKash)))
+		if !ratio.IsUint64() {
+			return nil, errors.Wrapf(ErrAmountOutOfRange, "reserve ratio of %s basis points is out of range", ratio)
+		}
+		reserveInfo.ReserveRatioBasisPoints = ratio.Uint64()
+	}
+
+	return reserveInfo, nil
+}
@@ -0,0 +1,89 @@
+package djed
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+)
+
+func TestReserveInfo(t *testing.T) {
+	// One KSH is worth two USD
+	const exchangeRate = 2 * constants.SompiPerKash
+
+	tests := []struct {
+		name         string
+		state        *externalapi.ReserveState
+		exchangeRate uint64
+		expected     *externalapi.ReserveInfo
+	}{
+		{
+			name:         "no exchange rate",
+			state:        &externalapi.ReserveState{Reserve: 1000, KUSDSupply: 100, KRVSupply: 10},
+			exchangeRate: 0,
+			expected: &externalapi.ReserveInfo{
+				ReserveState: &externalapi.ReserveState{Reserve: 1000, KUSDSupply: 100, KRVSupply: 10},
+			},
+		},
+		{
+			name:         "no KUSD liabilities",
+			state:        &externalapi.ReserveState{Reserve: 1000, KRVSupply: 10},
+			exchangeRate: exchangeRate,
+			expected: &externalapi.ReserveInfo{
+				ReserveState: &externalapi.ReserveState{Reserve: 1000, KRVSupply: 10},
+				ExchangeRate: exchangeRate,
+				KRVEquity:    1000,
+			},
+		},
+		{
+			name: "over-collateralized",
+			state: &externalapi.ReserveState{
+				Reserve:    300 * constants.SompiPerKash,
+				KUSDSupply: 100 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			expected: &externalapi.ReserveInfo{
+				ReserveState: &externalapi.ReserveState{
+					Reserve:    300 * constants.SompiPerKash,
+					KUSDSupply: 100 * constants.SompiPerKash,
+				},
+				ExchangeRate:            exchangeRate,
+				KUSDLiabilities:         50 * constants.SompiPerKash,
+				KRVEquity:               250 * constants.SompiPerKash,
+				ReserveRatioBasisPoints: 60_000,
+			},
+		},
+		{
+			name: "under-collateralized",
+			state: &externalapi.ReserveState{
+				Reserve:    25 * constants.SompiPerKash,
+				KUSDSupply: 100 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			expected: &externalapi.ReserveInfo{
+				ReserveState: &externalapi.ReserveState{
+					Reserve:    25 * constants.SompiPerKash,
+					KUSDSupply: 100 * constants.SompiPerKash,
+				},
+				ExchangeRate:            exchangeRate,
+				KUSDLiabilities:         50 * constants.SompiPerKash,
+				KRVEquity:               0,
+				ReserveRatioBasisPoints: 5_000,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		reserveInfo, err := ReserveInfo(test.state, test.exchangeRate)
+		if err != nil {
+			t.Fatalf("%s: ReserveInfo: %s", test.name, err)
+		}
+		if !reserveInfo.ReserveState.Equal(test.expected.ReserveState) ||
+			reserveInfo.ExchangeRate != test.expected.ExchangeRate ||
+			reserveInfo.KUSDLiabilities != test.expected.KUSDLiabilities ||
+			reserveInfo.KRVEquity != test.expected.KRVEquity ||
+			reserveInfo.ReserveRatioBasisPoints != test.expected.ReserveRatioBasisPoints {
+			t.Fatalf("%s: expected %+v but got %+v", test.name, test.expected, reserveInfo)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package djed
+
+import (
+	"encoding/binary"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/hashes"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+)
+
+// UTXOCommitment returns the UTXO commitment of a block whose UTXO set hashes to utxoSetHash,
+// whose reserve state after the conversions it accepts is reserveState, and at which priceRecord
+// is in effect, or nil if no price record is in effect at it.
+//
+// Neither the reserve state nor the price record can be derived from the UTXO set, so a node that
+// syncs from the pruning point receives them along with it and verifies them against this commitment.
+func UTXOCommitment(utxoSetHash *externalapi.DomainHash, reserveState *externalapi.ReserveState,
+	priceRecord *oracle.PriceRecord) *externalapi.DomainHash {
+
+	writer := hashes.NewUTXOCommitmentWriter()
+	writer.InfallibleWrite(utxoSetHash.ByteSlice())
+
+	var serializedReserveState [3 * 8]byte
+	binary.LittleEndian.PutUint64(serializedReserveState[0:], reserveState.Reserve)
+	binary.LittleEndian.PutUint64(serializedReserveState[8:], reserveState.KUSDSupply)
+	binary.LittleEndian.PutUint64(serializedReserveState[16:], reserveState.KRVSupply)
+	writer.InfallibleWrite(serializedReserveState[:])
+
+	if priceRecord == nil {
+		writer.InfallibleWrite([]byte{0})
+	} else {
+		writer.InfallibleWrite([]byte{1})
+		writer.InfallibleWrite(priceRecord.Hash().ByteSlice())
+	}
+	return writer.Finalize()
+}
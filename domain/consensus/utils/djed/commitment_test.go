@@ -0,0 +1,65 @@
+package djed
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+)
+
+func TestUTXOCommitment(t *testing.T) {
+	utxoSetHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	reserveState := &externalapi.ReserveState{Reserve: 100, KUSDSupply: 50, KRVSupply: 10}
+	priceRecord := &oracle.PriceRecord{KSH: 2, KRV: 1, KUSD: 1, Timestamp: 1000}
+
+	commitment := UTXOCommitment(utxoSetHash, reserveState, priceRecord)
+
+	tests := []struct {
+		name         string
+		utxoSetHash  *externalapi.DomainHash
+		reserveState *externalapi.ReserveState
+		priceRecord  *oracle.PriceRecord
+	}{
+		{
+			name:         "different UTXO set",
+			utxoSetHash:  externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+			reserveState: reserveState,
+			priceRecord:  priceRecord,
+		},
+		{
+			name:         "different reserve",
+			utxoSetHash:  utxoSetHash,
+			reserveState: &externalapi.ReserveState{Reserve: 0, KUSDSupply: 50, KRVSupply: 10},
+			priceRecord:  priceRecord,
+		},
+		{
+			name:         "different supplies",
+			utxoSetHash:  utxoSetHash,
+			reserveState: &externalapi.ReserveState{Reserve: 100, KUSDSupply: 10, KRVSupply: 50},
+			priceRecord:  priceRecord,
+		},
+		{
+			name:         "different price record",
+			utxoSetHash:  utxoSetHash,
+			reserveState: reserveState,
+			priceRecord:  &oracle.PriceRecord{KSH: 3, KRV: 1, KUSD: 1, Timestamp: 1000},
+		},
+		{
+			name:         "no price record",
+			utxoSetHash:  utxoSetHash,
+			reserveState: reserveState,
+			priceRecord:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		otherCommitment := UTXOCommitment(test.utxoSetHash, test.reserveState, test.priceRecord)
+		if otherCommitment.Equal(commitment) {
+			t.Errorf("%s: expected the UTXO commitment to change", test.name)
+		}
+	}
+
+	if !UTXOCommitment(utxoSetHash, reserveState.Clone(), priceRecord.Clone()).Equal(commitment) {
+		t.Errorf("expected the UTXO commitment of equal data to be equal")
+	}
+}
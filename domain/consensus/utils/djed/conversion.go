@@ -0,0 +1,195 @@
+package djed
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// ApplyConversion validates a conversion of amountIn sompi of the input asset of txType
+// into amountOut sompi of its output asset, against the given reserve state and exchange rate.
+//
+// It returns the reserve state after the conversion, and the fee, in KSH sompi, that the
+// conversion leaves for the miner. The fee is whatever KSH the transaction pays beyond the
+// conversion cost, or whatever KSH it takes less than the conversion value.
+func (c *Calculator) ApplyConversion(state *externalapi.ReserveState, exchangeRate uint64,
+	txType externalapi.DomainTransactionType, amountIn uint64, amountOut uint64) (
+	newState *externalapi.ReserveState, fee uint64, err error) {
+
+	switch txType {
+	case externalapi.MintKUSD:
+		return c.applyMintKUSD(state, exchangeRate, amountIn, amountOut)
+	case externalapi.StakeKSH:
+		return c.applyStakeKSH(state, exchangeRate, amountIn, amountOut)
+	case externalapi.RedeemKSH:
+		return c.applyRedeemKSH(state, exchangeRate, amountIn, amountOut)
+	case externalapi.RedeemKUSD:
+		return c.applyRedeemKUSD(state, exchangeRate, amountIn, amountOut)
+	default:
+		return nil, 0, errors.Wrapf(ErrNotConversion, "transaction type %s", txType)
+	}
+}
+
+// Quote returns the KSH, in sompi, that a conversion of txType pays in for (MintKUSD, StakeKSH)
+// or is paid out for (RedeemKSH, RedeemKUSD) amount sompi of KUSD or KRV, along with the part of
+// it that is the conversion fee.
+func (c *Calculator) Quote(state *externalapi.ReserveState, exchangeRate uint64,
+	txType externalapi.DomainTransactionType, amount uint64) (kshAmount uint64, fee uint64, err error) {
+
+	kshAmount, err = c.quote(state, exchangeRate, txType, amount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	feeless := *c
+	feeless.conversionFeeBasisPoints = 0
+	feelessKSHAmount, err := feeless.quote(state, exchangeRate, txType, amount)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if kshAmount > feelessKSHAmount {
+		return kshAmount, kshAmount - feelessKSHAmount, nil
+	}
+	return kshAmount, feelessKSHAmount - kshAmount, nil
+}
+
+func (c *Calculator) quote(state *externalapi.ReserveState, exchangeRate uint64,
+	txType externalapi.DomainTransactionType, amount uint64) (uint64, error) {
+
+	switch txType {
+	case externalapi.MintKUSD:
+		return c.MintKUSDCost(exchangeRate, amount)
+	case externalapi.StakeKSH:
+		return c.StakeKSHCost(state, exchangeRate, amount)
+	case externalapi.RedeemKSH:
+		return c.RedeemKSHValue(state, exchangeRate, amount)
+	case externalapi.RedeemKUSD:
+		return c.RedeemKUSDValue(state, exchangeRate, amount)
+	default:
+		return 0, errors.Wrapf(ErrNotConversion, "transaction type %s", txType)
+	}
+}
+
+func (c *Calculator) applyMintKUSD(state *externalapi.ReserveState, exchangeRate uint64,
+	kshIn uint64, kusdOut uint64) (*externalapi.ReserveState, uint64, error) {
+
+	cost, err := c.MintKUSDCost(exchangeRate, kusdOut)
+	if err != nil {
+		return nil, 0, err
+	}
+	if kshIn < cost {
+		return nil, 0, errors.Wrapf(ErrInsufficientConversionInput, "minting %d KUSD sompi "+
+			"costs %d KSH sompi but only %d were paid", kusdOut, cost, kshIn)
+	}
+
+	newState := state.Clone()
+	newState.Reserve, err = add(newState.Reserve, cost)
+	if err != nil {
+		return nil, 0, err
+	}
+	newState.KUSDSupply, err = add(newState.KUSDSupply, kusdOut)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !IsReserveRatioAtLeast(newState, exchangeRate, c.minReserveRatio) {
+		return nil, 0, errors.Wrapf(ErrReserveRatioTooLow, "minting %d KUSD sompi would leave "+
+			"the reserve ratio below %d%%", kusdOut, c.minReserveRatio)
+	}
+
+	return newState, kshIn - cost, nil
+}
+
+func (c *Calculator) applyStakeKSH(state *externalapi.ReserveState, exchangeRate uint64,
+	kshIn uint64, krvOut uint64) (*externalapi.ReserveState, uint64, error) {
+
+	cost, err := c.StakeKSHCost(state, exchangeRate, krvOut)
+	if err != nil {
+		return nil, 0, err
+	}
+	if kshIn < cost {
+		return nil, 0, errors.Wrapf(ErrInsufficientConversionInput, "staking for %d KRV sompi "+
+			"costs %d KSH sompi but only %d were paid", krvOut, cost, kshIn)
+	}
+
+	newState := state.Clone()
+	newState.Reserve, err = add(newState.Reserve, cost)
+	if err != nil {
+		return nil, 0, err
+	}
+	newState.KRVSupply, err = add(newState.KRVSupply, krvOut)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !IsReserveRatioAtMost(newState, exchangeRate, c.maxReserveRatio) {
+		return nil, 0, errors.Wrapf(ErrReserveRatioTooHigh, "staking for %d KRV sompi would leave "+
+			"the reserve ratio above %d%%", krvOut, c.maxReserveRatio)
+	}
+
+	return newState, kshIn - cost, nil
+}
+
+func (c *Calculator) applyRedeemKSH(state *externalapi.ReserveState, exchangeRate uint64,
+	krvIn uint64, kshOut uint64) (*externalapi.ReserveState, uint64, error) {
+
+	if krvIn > state.KRVSupply {
+		return nil, 0, errors.Wrapf(ErrInsufficientSupply, "redeeming %d KRV sompi while only "+
+			"%d are in circulation", krvIn, state.KRVSupply)
+	}
+
+	value, err := c.RedeemKSHValue(state, exchangeRate, krvIn)
+	if err != nil {
+		return nil, 0, err
+	}
+	if kshOut > value {
+		return nil, 0, errors.Wrapf(ErrInsufficientConversionInput, "redeeming %d KRV sompi "+
+			"is worth %d KSH sompi but %d were requested", krvIn, value, kshOut)
+	}
+
+	newState := state.Clone()
+	newState.Reserve -= value
+	newState.KRVSupply -= krvIn
+
+	if !IsReserveRatioAtLeast(newState, exchangeRate, c.minReserveRatio) {
+		return nil, 0, errors.Wrapf(ErrReserveRatioTooLow, "redeeming %d KRV sompi would leave "+
+			"the reserve ratio below %d%%", krvIn, c.minReserveRatio)
+	}
+
+	return newState, value - kshOut, nil
+}
+
+// applyRedeemKUSD does not check the reserve ratio: buying back KUSD at no more
+// than the reserve per KUSD never lowers it, and stablecoin holders must always
+// be able to redeem.
+func (c *Calculator) applyRedeemKUSD(state *externalapi.ReserveState, exchangeRate uint64,
+	kusdIn uint64, kshOut uint64) (*externalapi.ReserveState, uint64, error) {
+
+	if kusdIn > state.KUSDSupply {
+		return nil, 0, errors.Wrapf(ErrInsufficientSupply, "redeeming %d KUSD sompi while only "+
+			"%d are in circulation", kusdIn, state.KUSDSupply)
+	}
+
+	value, err := c.RedeemKUSDValue(state, exchangeRate, kusdIn)
+	if err != nil {
+		return nil, 0, err
+	}
+	if kshOut > value {
+		return nil, 0, errors.Wrapf(ErrInsufficientConversionInput, "redeeming %d KUSD sompi "+
+			"is worth %d KSH sompi but %d were requested", kusdIn, value, kshOut)
+	}
+
+	newState := state.Clone()
+	newState.Reserve -= value
+	newState.KUSDSupply -= kusdIn
+
+	return newState, value - kshOut, nil
+}
+
+func add(a, b uint64) (uint64, error) {
+	sum := a + b
+	if sum < a {
+		return 0, errors.Wrapf(ErrAmountOutOfRange, "%d + %d overflows", a, b)
+	}
+	return sum, nil
+}
@@ -0,0 +1,297 @@
+package djed
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
+	"github.com/pkg/errors"
+)
+
+func TestApplyConversion(t *testing.T) {
+	calculator := NewCalculator(400, 800, 100, constants.SompiPerKash)
+
+	// One KSH is worth two USD
+	const exchangeRate = 2 * constants.SompiPerKash
+
+	tests := []struct {
+		name          string
+		state         *externalapi.ReserveState
+		exchangeRate  uint64
+		txType        externalapi.DomainTransactionType
+		amountIn      uint64
+		amountOut     uint64
+		expectedState *externalapi.ReserveState
+		expectedFee   uint64
+		expectedErr   error
+	}{
+		{
+			name:         "mint KUSD",
+			state:        &externalapi.ReserveState{Reserve: 1000 * constants.SompiPerKash, KRVSupply: 1000 * constants.SompiPerKash},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.MintKUSD,
+			amountIn:     5_060_000_000,
+			amountOut:    100 * constants.SompiPerKash,
+			expectedState: &externalapi.ReserveState{
+				Reserve:    1000*constants.SompiPerKash + 5_050_000_000,
+				KUSDSupply: 100 * constants.SompiPerKash,
+				KRVSupply:  1000 * constants.SompiPerKash,
+			},
+			expectedFee: 10_000_000,
+		},
+		{
+			name:         "mint KUSD with insufficient input",
+			state:        &externalapi.ReserveState{Reserve: 1000 * constants.SompiPerKash, KRVSupply: 1000 * constants.SompiPerKash},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.MintKUSD,
+			amountIn:     5_049_999_999,
+			amountOut:    100 * constants.SompiPerKash,
+			expectedErr:  ErrInsufficientConversionInput,
+		},
+		{
+			name:         "mint KUSD below the minimal reserve ratio",
+			state:        &externalapi.ReserveState{Reserve: 100 * constants.SompiPerKash, KRVSupply: 100 * constants.SompiPerKash},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.MintKUSD,
+			amountIn:     5_050_000_000,
+			amountOut:    100 * constants.SompiPerKash,
+			expectedErr:  ErrReserveRatioTooLow,
+		},
+		{
+			name:         "stake KSH with no KRV in circulation",
+			state:        &externalapi.ReserveState{},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.StakeKSH,
+			amountIn:     1_010_000_000,
+			amountOut:    10 * constants.SompiPerKash,
+			expectedState: &externalapi.ReserveState{
+				Reserve:   1_010_000_000,
+				KRVSupply: 10 * constants.SompiPerKash,
+			},
+			expectedFee: 0,
+		},
+		{
+			name: "stake KSH above the maximal reserve ratio",
+			state: &externalapi.ReserveState{
+				Reserve:    1000 * constants.SompiPerKash,
+				KUSDSupply: 100 * constants.SompiPerKash,
+				KRVSupply:  1000 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.StakeKSH,
+			amountIn:     10 * constants.SompiPerKash,
+			amountOut:    constants.SompiPerKash,
+			expectedErr:  ErrReserveRatioTooHigh,
+		},
+		{
+			name: "redeem KSH",
+			state: &externalapi.ReserveState{
+				Reserve:    1000 * constants.SompiPerKash,
+				KUSDSupply: 100 * constants.SompiPerKash,
+				KRVSupply:  1000 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.RedeemKSH,
+			amountIn:     100 * constants.SompiPerKash,
+			amountOut:    9_400_000_000,
+			expectedState: &externalapi.ReserveState{
+				Reserve:    1000*constants.SompiPerKash - 9_405_000_000,
+				KUSDSupply: 100 * constants.SompiPerKash,
+				KRVSupply:  900 * constants.SompiPerKash,
+			},
+			expectedFee: 5_000_000,
+		},
+		{
+			name: "redeem KSH asking for too much",
+			state: &externalapi.ReserveState{
+				Reserve:    1000 * constants.SompiPerKash,
+				KUSDSupply: 100 * constants.SompiPerKash,
+				KRVSupply:  1000 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.RedeemKSH,
+			amountIn:     100 * constants.SompiPerKash,
+			amountOut:    9_405_000_001,
+			expectedErr:  ErrInsufficientConversionInput,
+		},
+		{
+			name: "redeem more KRV than in circulation",
+			state: &externalapi.ReserveState{
+				Reserve:   1000 * constants.SompiPerKash,
+				KRVSupply: 10 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.RedeemKSH,
+			amountIn:     11 * constants.SompiPerKash,
+			amountOut:    1,
+			expectedErr:  ErrInsufficientSupply,
+		},
+		{
+			name: "redeem KSH below the minimal reserve ratio",
+			state: &externalapi.ReserveState{
+				Reserve:    300 * constants.SompiPerKash,
+				KUSDSupply: 100 * constants.SompiPerKash,
+				KRVSupply:  100 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.RedeemKSH,
+			amountIn:     50 * constants.SompiPerKash,
+			amountOut:    100 * constants.SompiPerKash,
+			expectedErr:  ErrReserveRatioTooLow,
+		},
+		{
+			name: "redeem KUSD",
+			state: &externalapi.ReserveState{
+				Reserve:    1000 * constants.SompiPerKash,
+				KUSDSupply: 100 * constants.SompiPerKash,
+				KRVSupply:  1000 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.RedeemKUSD,
+			amountIn:     100 * constants.SompiPerKash,
+			amountOut:    4_900_000_000,
+			expectedState: &externalapi.ReserveState{
+				Reserve:   1000*constants.SompiPerKash - 4_950_000_000,
+				KRVSupply: 1000 * constants.SompiPerKash,
+			},
+			expectedFee: 50_000_000,
+		},
+		{
+			name: "redeem KUSD from an undercollateralized reserve",
+			state: &externalapi.ReserveState{
+				Reserve:    40 * constants.SompiPerKash,
+				KUSDSupply: 100 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.RedeemKUSD,
+			amountIn:     50 * constants.SompiPerKash,
+			amountOut:    1_980_000_000,
+			expectedState: &externalapi.ReserveState{
+				Reserve:    40*constants.SompiPerKash - 1_980_000_000,
+				KUSDSupply: 50 * constants.SompiPerKash,
+			},
+			expectedFee: 0,
+		},
+		{
+			name: "redeem KUSD asking for too much",
+			state: &externalapi.ReserveState{
+				Reserve:    1000 * constants.SompiPerKash,
+				KUSDSupply: 100 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.RedeemKUSD,
+			amountIn:     100 * constants.SompiPerKash,
+			amountOut:    4_950_000_001,
+			expectedErr:  ErrInsufficientConversionInput,
+		},
+		{
+			name: "redeem more KUSD than in circulation",
+			state: &externalapi.ReserveState{
+				Reserve:    1000 * constants.SompiPerKash,
+				KUSDSupply: 10 * constants.SompiPerKash,
+			},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.RedeemKUSD,
+			amountIn:     11 * constants.SompiPerKash,
+			amountOut:    1,
+			expectedErr:  ErrInsufficientSupply,
+		},
+		{
+			name:         "zero exchange rate",
+			state:        &externalapi.ReserveState{},
+			exchangeRate: 0,
+			txType:       externalapi.MintKUSD,
+			amountIn:     constants.SompiPerKash,
+			amountOut:    1,
+			expectedErr:  ErrZeroExchangeRate,
+		},
+		{
+			name:         "not a conversion",
+			state:        &externalapi.ReserveState{},
+			exchangeRate: exchangeRate,
+			txType:       externalapi.TransferKSH,
+			amountIn:     constants.SompiPerKash,
+			amountOut:    constants.SompiPerKash,
+			expectedErr:  ErrNotConversion,
+		},
+	}
+
+	for _, test := range tests {
+		newState, fee, err := calculator.ApplyConversion(test.state, test.exchangeRate, test.txType,
+			test.amountIn, test.amountOut)
+		if test.expectedErr != nil {
+			if !errors.Is(err, test.expectedErr) {
+				t.Errorf("%s: expected error %v but got %v", test.name, test.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %+v", test.name, err)
+			continue
+		}
+		if !newState.Equal(test.expectedState) {
+			t.Errorf("%s: expected state %+v but got %+v", test.name, test.expectedState, newState)
+		}
+		if fee != test.expectedFee {
+			t.Errorf("%s: expected fee %d but got %d", test.name, test.expectedFee, fee)
+		}
+	}
+}
+
+func TestIsReserveRatioWithinBounds(t *testing.T) {
+	const exchangeRate = constants.SompiPerKash
+
+	state := &externalapi.ReserveState{
+		Reserve:    400 * constants.SompiPerKash,
+		KUSDSupply: 100 * constants.SompiPerKash,
+	}
+	if !IsReserveRatioAtLeast(state, exchangeRate, 400) {
+		t.Errorf("a reserve ratio of exactly 400%% is expected to be at least 400%%")
+	}
+	if IsReserveRatioAtLeast(state, exchangeRate, 401) {
+		t.Errorf("a reserve ratio of exactly 400%% is not expected to be at least 401%%")
+	}
+	if !IsReserveRatioAtMost(state, exchangeRate, 400) {
+		t.Errorf("a reserve ratio of exactly 400%% is expected to be at most 400%%")
+	}
+	if IsReserveRatioAtMost(state, exchangeRate, 399) {
+		t.Errorf("a reserve ratio of exactly 400%% is not expected to be at most 399%%")
+	}
+
+	emptyState := &externalapi.ReserveState{Reserve: constants.SompiPerKash}
+	if !IsReserveRatioAtLeast(emptyState, exchangeRate, 400) || !IsReserveRatioAtMost(emptyState, exchangeRate, 800) {
+		t.Errorf("a reserve with no KUSD liabilities is expected to be within any bounds")
+	}
+}
+
+func TestQuote(t *testing.T) {
+	calculator := NewCalculator(400, 800, 100, constants.SompiPerKash)
+
+	// One KSH is worth two USD
+	const exchangeRate = 2 * constants.SompiPerKash
+
+	kshAmount, fee, err := calculator.Quote(&externalapi.ReserveState{}, exchangeRate,
+		externalapi.MintKUSD, 100*constants.SompiPerKash)
+	if err != nil {
+		t.Fatalf("Quote: %s", err)
+	}
+	if kshAmount != 5_050_000_000 || fee != 50_000_000 {
+		t.Errorf("expected minting 100 KUSD to cost 5050000000 KSH sompi with a fee of 50000000, "+
+			"but got %d with a fee of %d", kshAmount, fee)
+	}
+
+	state := &externalapi.ReserveState{Reserve: 400 * constants.SompiPerKash, KUSDSupply: 100 * constants.SompiPerKash}
+	kshAmount, fee, err = calculator.Quote(state, exchangeRate, externalapi.RedeemKUSD, 100*constants.SompiPerKash)
+	if err != nil {
+		t.Fatalf("Quote: %s", err)
+	}
+	if kshAmount != 4_950_000_000 || fee != 50_000_000 {
+		t.Errorf("expected redeeming 100 KUSD to pay 4950000000 KSH sompi with a fee of 50000000, "+
+			"but got %d with a fee of %d", kshAmount, fee)
+	}
+
+	_, _, err = calculator.Quote(state, exchangeRate, externalapi.TransferKSH, constants.SompiPerKash)
+	if !errors.Is(err, ErrNotConversion) {
+		t.Errorf("expected ErrNotConversion but got %v", err)
+	}
+}
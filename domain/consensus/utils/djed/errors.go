@@ -0,0 +1,26 @@
+package djed
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrZeroExchangeRate indicates that a conversion was attempted with an exchange rate of zero
+	ErrZeroExchangeRate = errors.New("exchange rate is zero")
+
+	// ErrNotConversion indicates that the transaction type does not convert between assets
+	ErrNotConversion = errors.New("transaction type is not a conversion")
+
+	// ErrAmountOutOfRange indicates that a converted amount exceeds the maximum sompi amount
+	ErrAmountOutOfRange = errors.New("converted amount is out of range")
+
+	// ErrInsufficientConversionInput indicates that the input of a conversion does not cover its output
+	ErrInsufficientConversionInput = errors.New("conversion input does not cover its output")
+
+	// ErrInsufficientSupply indicates that a conversion redeems more than the circulating supply
+	ErrInsufficientSupply = errors.New("conversion redeems more than the circulating supply")
+
+	// ErrReserveRatioTooLow indicates that a conversion would leave the reserve ratio below its minimum
+	ErrReserveRatioTooLow = errors.New("reserve ratio is below the minimum")
+
+	// ErrReserveRatioTooHigh indicates that a conversion would leave the reserve ratio above its maximum
+	ErrReserveRatioTooHigh = errors.New("reserve ratio is above the maximum")
+)
@@ -16,6 +16,8 @@ const (
 	proofOfWorkDomain             = "ProofOfWorkHash"
 	heavyHashDomain               = "HeavyHash"
 	merkleBranchDomain            = "MerkleBranchHash"
+	priceRecordSigningDomain      = "PriceRecordSigningHash"
+	utxoCommitmentDomain          = "UTXOCommitment"
 )
 
 // transactionSigningECDSADomainHash is a hashed version of transcationSigningECDSADomain that is used
@@ -86,3 +88,21 @@ func NewMerkleBranchHashWriter() HashWriter {
 	}
 	return HashWriter{blake}
 }
+
+// NewPriceRecordSigningHashWriter Returns a new HashWriter used for signing on an oracle price record
+func NewPriceRecordSigningHashWriter() HashWriter {
+	blake, err := blake2b.New256([]byte(priceRecordSigningDomain))
+	if err != nil {
+		panic(errors.Wrapf(err, "this should never happen. %s is less than 64 bytes", priceRecordSigningDomain))
+	}
+	return HashWriter{blake}
+}
+
+// NewUTXOCommitmentWriter Returns a new HashWriter used for the UTXO commitment of a block
+func NewUTXOCommitmentWriter() HashWriter {
+	blake, err := blake2b.New256([]byte(utxoCommitmentDomain))
+	if err != nil {
+		panic(errors.Wrapf(err, "this should never happen. %s is less than 64 bytes", utxoCommitmentDomain))
+	}
+	return HashWriter{blake}
+}
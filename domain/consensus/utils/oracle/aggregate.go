@@ -0,0 +1,100 @@
+package oracle
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CheckFreshness returns ErrStalePriceRecord if the record was signed more than maxAge
+// before now, or is timestamped more than maxAge after now.
+// now is in milliseconds since the epoch.
+func CheckFreshness(record *PriceRecord, now int64, maxAge time.Duration) error {
+	maxAgeMilliseconds := maxAge.Milliseconds()
+	if record.Timestamp < now-maxAgeMilliseconds {
+		return errors.Wrapf(ErrStalePriceRecord, "price record timestamp %d is more than %s "+
+			"before %d", record.Timestamp, maxAge, now)
+	}
+	if record.Timestamp > now+maxAgeMilliseconds {
+		return errors.Wrapf(ErrStalePriceRecord, "price record timestamp %d is more than %s "+
+			"after %d", record.Timestamp, maxAge, now)
+	}
+	return nil
+}
+
+// ValidSignedPriceRecords filters out the records that are not signed by one of
+// publicKeys, or that are stale at the given time. When a feed signed more than one
+// of the records, only its latest record is kept.
+func ValidSignedPriceRecords(signedRecords []*SignedPriceRecord, publicKeys PublicKeySet,
+	now int64, maxAge time.Duration) []*SignedPriceRecord {
+
+	latestBySigner := make(map[string]*SignedPriceRecord)
+	for _, signedRecord := range signedRecords {
+		err := signedRecord.Verify(publicKeys)
+		if err != nil {
+			log.Debugf("Ignoring price record: %s", err)
+			continue
+		}
+		err = CheckFreshness(signedRecord.Record, now, maxAge)
+		if err != nil {
+			log.Debugf("Ignoring price record: %s", err)
+			continue
+		}
+
+		signer := string(signedRecord.PublicKey)
+		latest, ok := latestBySigner[signer]
+		if !ok || signedRecord.Record.Timestamp > latest.Record.Timestamp {
+			latestBySigner[signer] = signedRecord
+		}
+	}
+
+	validRecords := make([]*SignedPriceRecord, 0, len(latestBySigner))
+	for _, signedRecord := range signedRecords {
+		if latestBySigner[string(signedRecord.PublicKey)] == signedRecord {
+			validRecords = append(validRecords, signedRecord)
+		}
+	}
+	return validRecords
+}
+
+// Aggregate returns the median of the prices and timestamps of the given records.
+// The moving average fields of the result are left empty. It returns
+// ErrNotEnoughPriceRecords if fewer than minRecords records are given.
+func Aggregate(signedRecords []*SignedPriceRecord, minRecords int) (*PriceRecord, error) {
+	if len(signedRecords) == 0 || len(signedRecords) < minRecords {
+		return nil, errors.Wrapf(ErrNotEnoughPriceRecords, "%d price records are required, "+
+			"but only %d are available", minRecords, len(signedRecords))
+	}
+
+	ksh := make([]uint64, len(signedRecords))
+	krv := make([]uint64, len(signedRecords))
+	kusd := make([]uint64, len(signedRecords))
+	timestamps := make([]uint64, len(signedRecords))
+	for i, signedRecord := range signedRecords {
+		ksh[i] = signedRecord.Record.KSH
+		krv[i] = signedRecord.Record.KRV
+		kusd[i] = signedRecord.Record.KUSD
+		timestamps[i] = uint64(signedRecord.Record.Timestamp)
+	}
+
+	return &PriceRecord{
+		KSH:       median(ksh),
+		KRV:       median(krv),
+		KUSD:      median(kusd),
+		Timestamp: int64(median(timestamps)),
+	}, nil
+}
+
+// median returns the median of values, rounding down between the two middle
+// values of an even-length slice. values is sorted in place.
+func median(values []uint64) uint64 {
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	middle := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[middle]
+	}
+	low, high := values[middle-1], values[middle]
+	return low + (high-low)/2
+}
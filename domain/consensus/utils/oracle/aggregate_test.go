@@ -0,0 +1,131 @@
+package oracle
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/pkg/errors"
+)
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values   []uint64
+		expected uint64
+	}{
+		{values: []uint64{5}, expected: 5},
+		{values: []uint64{3, 1, 2}, expected: 2},
+		{values: []uint64{4, 1, 3, 2}, expected: 2},
+		{values: []uint64{^uint64(0), ^uint64(0) - 2}, expected: ^uint64(0) - 1},
+	}
+	for _, test := range tests {
+		result := median(test.values)
+		if result != test.expected {
+			t.Errorf("median(%v): expected %d but got %d", test.values, test.expected, result)
+		}
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	record := &PriceRecord{KSH: 200_000_000, KRV: 50_000_000, KUSD: 100_000_000, Timestamp: 1_000_000}
+
+	ed25519PublicKey, ed25519PrivateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	ed25519Record := SignPriceRecordEd25519(record, ed25519PrivateKey)
+
+	schnorrKeyPair, err := secp256k1.GenerateSchnorrKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateSchnorrKeyPair: %s", err)
+	}
+	schnorrRecord, err := SignPriceRecordSchnorr(record, schnorrKeyPair)
+	if err != nil {
+		t.Fatalf("SignPriceRecordSchnorr: %s", err)
+	}
+
+	publicKeys, err := ParsePublicKeySet([]string{
+		"ed25519:" + hex.EncodeToString(ed25519PublicKey),
+		"schnorr:" + hex.EncodeToString(schnorrRecord.PublicKey),
+	})
+	if err != nil {
+		t.Fatalf("ParsePublicKeySet: %s", err)
+	}
+
+	for _, signedRecord := range []*SignedPriceRecord{ed25519Record, schnorrRecord} {
+		err := signedRecord.Verify(publicKeys)
+		if err != nil {
+			t.Fatalf("Verify: %s", err)
+		}
+
+		tampered := signedRecord.Clone()
+		tampered.Record.KSH++
+		err = tampered.Verify(publicKeys)
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("expected ErrInvalidSignature for a tampered record but got %v", err)
+		}
+	}
+
+	_, otherPrivateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	err = SignPriceRecordEd25519(record, otherPrivateKey).Verify(publicKeys)
+	if !errors.Is(err, ErrUnknownSigner) {
+		t.Fatalf("expected ErrUnknownSigner but got %v", err)
+	}
+}
+
+func TestValidSignedPriceRecordsAndAggregate(t *testing.T) {
+	const now = 10_000_000
+	const maxAge = time.Minute
+
+	var publicKeyStrings []string
+	var signedRecords []*SignedPriceRecord
+	for i, price := range []uint64{100, 300, 200, 10_000} {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %s", err)
+		}
+		publicKeyStrings = append(publicKeyStrings, "ed25519:"+hex.EncodeToString(publicKey))
+
+		timestamp := int64(now - i*1000)
+		if price == 10_000 {
+			// The outlier is too old to be counted
+			timestamp = now - maxAge.Milliseconds() - 1
+		}
+		signedRecords = append(signedRecords,
+			SignPriceRecordEd25519(&PriceRecord{KSH: price, KRV: price, KUSD: price, Timestamp: timestamp}, privateKey))
+
+		if i == 0 {
+			// An older record of the same feed is superseded by the latest one
+			signedRecords = append(signedRecords,
+				SignPriceRecordEd25519(&PriceRecord{KSH: 1, KRV: 1, KUSD: 1, Timestamp: timestamp - 1}, privateKey))
+		}
+	}
+	publicKeys, err := ParsePublicKeySet(publicKeyStrings)
+	if err != nil {
+		t.Fatalf("ParsePublicKeySet: %s", err)
+	}
+
+	validRecords := ValidSignedPriceRecords(signedRecords, publicKeys, now, maxAge)
+	if len(validRecords) != 3 {
+		t.Fatalf("expected 3 valid records but got %d", len(validRecords))
+	}
+
+	aggregated, err := Aggregate(validRecords, 3)
+	if err != nil {
+		t.Fatalf("Aggregate: %s", err)
+	}
+	expected := &PriceRecord{KSH: 200, KRV: 200, KUSD: 200, Timestamp: now - 1000}
+	if !aggregated.Equal(expected) {
+		t.Fatalf("expected %+v but got %+v", expected, aggregated)
+	}
+
+	_, err = Aggregate(validRecords, 4)
+	if !errors.Is(err, ErrNotEnoughPriceRecords) {
+		t.Fatalf("expected ErrNotEnoughPriceRecords but got %v", err)
+	}
+}
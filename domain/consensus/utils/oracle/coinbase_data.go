@@ -0,0 +1,155 @@
+package oracle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// coinbasePayloadMarker terminates a coinbase payload that carries oracle data.
+//
+// Oracle data is appended to the coinbase payload as a trailer:
+// [oracle data][uint16 length of oracle data][coinbasePayloadMarker]
+// so that payloads without oracle data, such as the genesis payloads, keep their format.
+var coinbasePayloadMarker = []byte("ORCL")
+
+const oracleDataLengthLength = 2
+
+// SerializeSignedPriceRecords serializes the given records into the oracle data
+// committed to by a coinbase payload. The format is:
+// [uint8 record count] and for each record
+// [PriceRecord fields as little-endian uint64s][uint8 public key length][public key][uint8 signature length][signature]
+func SerializeSignedPriceRecords(signedRecords []*SignedPriceRecord) ([]byte, error) {
+	if len(signedRecords) > math.MaxUint8 {
+		return nil, errors.Wrapf(ErrMalformedOracleData, "cannot serialize more than %d price records", math.MaxUint8)
+	}
+
+	serialized := []byte{byte(len(signedRecords))}
+	for _, signedRecord := range signedRecords {
+		if len(signedRecord.PublicKey) > math.MaxUint8 || len(signedRecord.Signature) > math.MaxUint8 {
+			return nil, errors.Wrapf(ErrMalformedOracleData, "public key of length %d or signature of "+
+				"length %d is too long", len(signedRecord.PublicKey), len(signedRecord.Signature))
+		}
+		record := signedRecord.Record.serialize()
+		serialized = append(serialized, record[:]...)
+		serialized = append(serialized, byte(len(signedRecord.PublicKey)))
+		serialized = append(serialized, signedRecord.PublicKey...)
+		serialized = append(serialized, byte(len(signedRecord.Signature)))
+		serialized = append(serialized, signedRecord.Signature...)
+	}
+	return serialized, nil
+}
+
+// DeserializeSignedPriceRecords is the inverse of SerializeSignedPriceRecords.
+// It returns ErrMalformedOracleData if data is not exactly a serialized list of records.
+func DeserializeSignedPriceRecords(data []byte) ([]*SignedPriceRecord, error) {
+	if len(data) == 0 {
+		return nil, errors.Wrapf(ErrMalformedOracleData, "oracle data is empty")
+	}
+
+	count := int(data[0])
+	reader := &byteReader{data: data[1:]}
+	signedRecords := make([]*SignedPriceRecord, 0, count)
+	for i := 0; i < count; i++ {
+		record, ok := reader.next(priceRecordLength)
+		if !ok {
+			return nil, errors.Wrapf(ErrMalformedOracleData, "price record %d is truncated", i)
+		}
+		publicKey, ok := reader.nextWithLength()
+		if !ok {
+			return nil, errors.Wrapf(ErrMalformedOracleData, "public key of price record %d is truncated", i)
+		}
+		signature, ok := reader.nextWithLength()
+		if !ok {
+			return nil, errors.Wrapf(ErrMalformedOracleData, "signature of price record %d is truncated", i)
+		}
+		signedRecords = append(signedRecords, &SignedPriceRecord{
+			Record:    deserializePriceRecord(record),
+			PublicKey: publicKey,
+			Signature: signature,
+		})
+	}
+	if len(reader.data) != 0 {
+		return nil, errors.Wrapf(ErrMalformedOracleData, "%d unexpected bytes after %d price records",
+			len(reader.data), count)
+	}
+	return signedRecords, nil
+}
+
+// AppendToCoinbasePayload appends oracleData to a coinbase payload.
+// A payload that happens to end with the oracle marker gets an empty trailer even
+// if there is no oracle data, so that ExtractFromCoinbasePayload never misreads it.
+func AppendToCoinbasePayload(payload []byte, oracleData []byte) ([]byte, error) {
+	if len(oracleData) == 0 && !bytes.HasSuffix(payload, coinbasePayloadMarker) {
+		return payload, nil
+	}
+	if len(oracleData) > math.MaxUint16 {
+		return nil, errors.Wrapf(ErrMalformedOracleData, "oracle data of length %d is longer than "+
+			"the max allowed length of %d", len(oracleData), math.MaxUint16)
+	}
+
+	payloadWithOracleData := make([]byte, 0, len(payload)+len(oracleData)+oracleDataLengthLength+len(coinbasePayloadMarker))
+	payloadWithOracleData = append(payloadWithOracleData, payload...)
+	payloadWithOracleData = append(payloadWithOracleData, oracleData...)
+	var oracleDataLength [oracleDataLengthLength]byte
+	binary.LittleEndian.PutUint16(oracleDataLength[:], uint16(len(oracleData)))
+	payloadWithOracleData = append(payloadWithOracleData, oracleDataLength[:]...)
+	payloadWithOracleData = append(payloadWithOracleData, coinbasePayloadMarker...)
+	return payloadWithOracleData, nil
+}
+
+// ExtractFromCoinbasePayload splits a coinbase payload into the payload the oracle
+// data was appended to, and the oracle data itself. Payloads that carry no oracle
+// data are returned as is.
+func ExtractFromCoinbasePayload(payload []byte) (payloadWithoutOracleData []byte, oracleData []byte, err error) {
+	if !bytes.HasSuffix(payload, coinbasePayloadMarker) {
+		return payload, nil, nil
+	}
+
+	trailerLength := oracleDataLengthLength + len(coinbasePayloadMarker)
+	if len(payload) < trailerLength {
+		return nil, nil, errors.Wrapf(ErrMalformedOracleData, "coinbase payload is too short to contain "+
+			"the oracle data length")
+	}
+	lengthStart := len(payload) - trailerLength
+	oracleDataLength := int(binary.LittleEndian.Uint16(payload[lengthStart:]))
+	if lengthStart < oracleDataLength {
+		return nil, nil, errors.Wrapf(ErrMalformedOracleData, "coinbase payload is too short to contain "+
+			"oracle data of length %d", oracleDataLength)
+	}
+
+	oracleDataStart := lengthStart - oracleDataLength
+	if oracleDataLength == 0 {
+		return payload[:oracleDataStart], nil, nil
+	}
+	return payload[:oracleDataStart], payload[oracleDataStart:lengthStart], nil
+}
+
+type byteReader struct {
+	data []byte
+}
+
+func (r *byteReader) next(length int) ([]byte, bool) {
+	if len(r.data) < length {
+		return nil, false
+	}
+	next := r.data[:length]
+	r.data = r.data[length:]
+	return next, true
+}
+
+func (r *byteReader) nextWithLength() ([]byte, bool) {
+	length, ok := r.next(1)
+	if !ok {
+		return nil, false
+	}
+	next, ok := r.next(int(length[0]))
+	if !ok {
+		return nil, false
+	}
+	cloned := make([]byte, len(next))
+	copy(cloned, next)
+	return cloned, true
+}
@@ -0,0 +1,95 @@
+package oracle
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestSerializeSignedPriceRecords(t *testing.T) {
+	var signedRecords []*SignedPriceRecord
+	for i := 0; i < 3; i++ {
+		_, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %s", err)
+		}
+		price := uint64(100 + i)
+		record := &PriceRecord{KSH: price, KRV: price + 1, KUSD: price + 2, KSHMA: price + 3, KRVMA: price + 4,
+			KUSDMA: price + 5, Timestamp: int64(1_000_000 + i)}
+		signedRecords = append(signedRecords, SignPriceRecordEd25519(record, privateKey))
+	}
+
+	serialized, err := SerializeSignedPriceRecords(signedRecords)
+	if err != nil {
+		t.Fatalf("SerializeSignedPriceRecords: %s", err)
+	}
+	deserialized, err := DeserializeSignedPriceRecords(serialized)
+	if err != nil {
+		t.Fatalf("DeserializeSignedPriceRecords: %s", err)
+	}
+	if len(deserialized) != len(signedRecords) {
+		t.Fatalf("expected %d records but got %d", len(signedRecords), len(deserialized))
+	}
+	for i, signedRecord := range signedRecords {
+		if !deserialized[i].Record.Equal(signedRecord.Record) ||
+			!bytes.Equal(deserialized[i].PublicKey, signedRecord.PublicKey) ||
+			!bytes.Equal(deserialized[i].Signature, signedRecord.Signature) {
+			t.Fatalf("record %d: expected %+v but got %+v", i, signedRecord, deserialized[i])
+		}
+	}
+
+	malformed := [][]byte{
+		{},
+		serialized[:len(serialized)-1],
+		append(append([]byte{}, serialized...), 0),
+	}
+	for _, data := range malformed {
+		_, err := DeserializeSignedPriceRecords(data)
+		if !errors.Is(err, ErrMalformedOracleData) {
+			t.Fatalf("expected ErrMalformedOracleData for %x but got %v", data, err)
+		}
+	}
+}
+
+func TestCoinbasePayloadOracleData(t *testing.T) {
+	tests := []struct {
+		name       string
+		payload    []byte
+		oracleData []byte
+	}{
+		{name: "no oracle data", payload: []byte{1, 2, 3}},
+		{name: "with oracle data", payload: []byte{1, 2, 3}, oracleData: []byte{4, 5, 6}},
+		{name: "empty payload", payload: []byte{}, oracleData: []byte{4, 5, 6}},
+		{name: "payload ending with the marker", payload: append([]byte{1}, coinbasePayloadMarker...)},
+		{name: "payload that is the marker", payload: coinbasePayloadMarker, oracleData: []byte{4}},
+	}
+	for _, test := range tests {
+		payloadWithOracleData, err := AppendToCoinbasePayload(test.payload, test.oracleData)
+		if err != nil {
+			t.Fatalf("%s: AppendToCoinbasePayload: %s", test.name, err)
+		}
+		payload, oracleData, err := ExtractFromCoinbasePayload(payloadWithOracleData)
+		if err != nil {
+			t.Fatalf("%s: ExtractFromCoinbasePayload: %s", test.name, err)
+		}
+		if !bytes.Equal(payload, test.payload) {
+			t.Fatalf("%s: expected payload %x but got %x", test.name, test.payload, payload)
+		}
+		if !bytes.Equal(oracleData, test.oracleData) {
+			t.Fatalf("%s: expected oracle data %x but got %x", test.name, test.oracleData, oracleData)
+		}
+	}
+
+	malformed := [][]byte{
+		coinbasePayloadMarker,
+		append([]byte{5, 0}, coinbasePayloadMarker...),
+	}
+	for _, payload := range malformed {
+		_, _, err := ExtractFromCoinbasePayload(payload)
+		if !errors.Is(err, ErrMalformedOracleData) {
+			t.Fatalf("expected ErrMalformedOracleData for %x but got %v", payload, err)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package oracle
+
+import "github.com/pkg/errors"
+
+var (
+	// ErrInvalidPublicKey indicates that a configured oracle public key could not be parsed
+	ErrInvalidPublicKey = errors.New("invalid oracle public key")
+
+	// ErrUnknownSigner indicates that a price record is signed by a key that is not trusted
+	ErrUnknownSigner = errors.New("price record is signed by an unknown key")
+
+	// ErrInvalidSignature indicates that the signature of a price record does not verify
+	ErrInvalidSignature = errors.New("invalid price record signature")
+
+	// ErrStalePriceRecord indicates that a price record is too old, or too far in the future
+	ErrStalePriceRecord = errors.New("price record is stale")
+
+	// ErrNotEnoughPriceRecords indicates that too few valid price records are available to aggregate
+	ErrNotEnoughPriceRecords = errors.New("not enough valid price records")
+
+	// ErrMalformedOracleData indicates that serialized price records, or the coinbase payload carrying them, cannot be parsed
+	ErrMalformedOracleData = errors.New("malformed oracle data")
+)
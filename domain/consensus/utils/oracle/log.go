@@ -0,0 +1,9 @@
+package oracle
+
+import (
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+	"github.com/Kash-Protocol/kashd/util/panics"
+)
+
+var log = logger.RegisterSubSystem("ORCL")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -1,24 +1,232 @@
 package oracle
 
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Kash-Protocol/kashd/util/mstime"
+	"github.com/pkg/errors"
+)
+
+// Config holds the parameters of an Oracle
+type Config struct {
+	// URLPool is the list of price feeds to fetch signed price records from
+	URLPool []string
+
+	// PublicKeys are the keys of the trusted price feeds, as accepted by ParsePublicKey
+	PublicKeys []string
+
+	// Transport is used to fetch from the price feeds. Defaults to HTTP over direct connections
+	Transport Transport
+
+	// FetchInterval is the time between two fetches of a started Oracle
+	FetchInterval time.Duration
+
+	// FetchTimeout bounds the time a single fetch from all price feeds may take
+	FetchTimeout time.Duration
+
+	// MaxRecordAge is the age beyond which a price record is considered stale
+	MaxRecordAge time.Duration
+
+	// MinRecords is the number of valid signed price records required to aggregate a price
+	MinRecords int
+
+	// MovingAverageWindow is the number of aggregated price records the moving averages are taken over
+	MovingAverageWindow int
+}
+
 // Oracle represents a collection of price records and associated metadata.
+// It periodically fetches signed price records from a pool of price feeds,
+// and aggregates the valid ones into a single price record.
 type Oracle struct {
-	PriceRecords []*PriceRecord
-	URLPool      []string
-	PublicKeys   []string
+	config     *Config
+	publicKeys PublicKeySet
+	now        func() int64
+
+	lock               sync.RWMutex
+	priceRecords       []*PriceRecord
+	signedPriceRecords []*SignedPriceRecord
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
 }
 
 // NewOracle creates a new instance of Oracle.
-func NewOracle(urlPool []string, publicKeys []string) *Oracle {
-	return &Oracle{
-		URLPool:    urlPool,
-		PublicKeys: publicKeys,
+func NewOracle(config *Config) (*Oracle, error) {
+	publicKeys, err := ParsePublicKeySet(config.PublicKeys)
+	if err != nil {
+		return nil, err
 	}
+	if config.MinRecords < 1 {
+		return nil, errors.Errorf("at least one price record must be required, but MinRecords is %d",
+			config.MinRecords)
+	}
+	if config.MovingAverageWindow < 1 {
+		return nil, errors.Errorf("the moving average window must be positive, but it is %d",
+			config.MovingAverageWindow)
+	}
+
+	configCopy := *config
+	if configCopy.Transport == nil {
+		configCopy.Transport = NewHTTPTransport(configCopy.FetchTimeout, net.DialTimeout)
+	}
+
+	return &Oracle{
+		config:     &configCopy,
+		publicKeys: publicKeys,
+		now:        func() int64 { return mstime.Now().UnixMilliseconds() },
+		stop:       make(chan struct{}),
+	}, nil
+}
+
+// PublicKeys returns the set of trusted price feed public keys
+func (o *Oracle) PublicKeys() PublicKeySet {
+	return o.publicKeys
+}
+
+// Start fetches prices every FetchInterval until Stop is called
+func (o *Oracle) Start() {
+	o.wg.Add(1)
+	spawn("Oracle.Start", func() {
+		defer o.wg.Done()
+
+		ticker := time.NewTicker(o.config.FetchInterval)
+		defer ticker.Stop()
+		for {
+			err := o.FetchPrices()
+			if err != nil {
+				log.Warnf("Failed to fetch prices: %s", err)
+			}
+
+			select {
+			case <-o.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// Stop stops fetching prices, and waits for an ongoing fetch to finish
+func (o *Oracle) Stop() {
+	o.stopOnce.Do(func() {
+		close(o.stop)
+	})
+	o.wg.Wait()
 }
 
 // FetchPrices updates the PriceRecords by fetching new data.
-// This method should contain the logic to periodically fetch prices.
+// Every price feed in URLPool is queried, and the records that are signed by one of
+// PublicKeys and are not stale are aggregated into a new price record.
 func (o *Oracle) FetchPrices() error {
-	// Implement the logic to fetch prices from the URLs in URLPool
-	// and update the PriceRecords slice.
+	ctx, cancel := context.WithTimeout(context.Background(), o.config.FetchTimeout)
+	defer cancel()
+
+	fetchedRecords := make([]*SignedPriceRecord, len(o.config.URLPool))
+	var wg sync.WaitGroup
+	for i, url := range o.config.URLPool {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+
+			data, err := o.config.Transport.Fetch(ctx, url)
+			if err != nil {
+				log.Debugf("Failed to fetch a price record from %s: %s", url, err)
+				return
+			}
+			signedRecord, err := SignedPriceRecordFromBytes(data)
+			if err != nil {
+				log.Debugf("Failed to parse the price record from %s: %s", url, err)
+				return
+			}
+			fetchedRecords[i] = signedRecord
+		}(i, url)
+	}
+	wg.Wait()
+
+	signedRecords := make([]*SignedPriceRecord, 0, len(fetchedRecords))
+	for _, signedRecord := range fetchedRecords {
+		if signedRecord != nil {
+			signedRecords = append(signedRecords, signedRecord)
+		}
+	}
+
+	validRecords := ValidSignedPriceRecords(signedRecords, o.publicKeys, o.now(), o.config.MaxRecordAge)
+	priceRecord, err := Aggregate(validRecords, o.config.MinRecords)
+	if err != nil {
+		return err
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.priceRecords = append(o.priceRecords, priceRecord)
+	if len(o.priceRecords) > o.config.MovingAverageWindow {
+		o.priceRecords = o.priceRecords[len(o.priceRecords)-o.config.MovingAverageWindow:]
+	}
+	o.updateMovingAverages(priceRecord)
+	o.signedPriceRecords = validRecords
+
+	log.Debugf("Aggregated a price record out of %d signed records: KSH %d, KRV %d, KUSD %d",
+		len(validRecords), priceRecord.KSH, priceRecord.KRV, priceRecord.KUSD)
 	return nil
 }
+
+// updateMovingAverages sets the moving averages of priceRecord, the latest of
+// o.priceRecords, to the simple moving averages over o.priceRecords.
+// This function assumes that o.lock is held for writing.
+func (o *Oracle) updateMovingAverages(priceRecord *PriceRecord) {
+	var kshSum, krvSum, kusdSum uint64
+	count := uint64(len(o.priceRecords))
+	for _, record := range o.priceRecords {
+		// The sum of MovingAverageWindow prices may overflow, so each price is divided first
+		// and the remainders are accumulated separately
+		kshSum += record.KSH / count
+		krvSum += record.KRV / count
+		kusdSum += record.KUSD / count
+	}
+	var kshRemainders, krvRemainders, kusdRemainders uint64
+	for _, record := range o.priceRecords {
+		kshRemainders += record.KSH % count
+		krvRemainders += record.KRV % count
+		kusdRemainders += record.KUSD % count
+	}
+
+	priceRecord.KSHMA = kshSum + kshRemainders/count
+	priceRecord.KRVMA = krvSum + krvRemainders/count
+	priceRecord.KUSDMA = kusdSum + kusdRemainders/count
+}
+
+// LatestPriceRecord returns the latest aggregated price record, including its
+// moving averages. It returns ErrStalePriceRecord if that record is older than MaxRecordAge.
+func (o *Oracle) LatestPriceRecord() (*PriceRecord, error) {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	if len(o.priceRecords) == 0 {
+		return nil, errors.Wrapf(ErrNotEnoughPriceRecords, "no price record was fetched yet")
+	}
+
+	latest := o.priceRecords[len(o.priceRecords)-1]
+	err := CheckFreshness(latest, o.now(), o.config.MaxRecordAge)
+	if err != nil {
+		return nil, err
+	}
+	return latest.Clone(), nil
+}
+
+// LatestSignedPriceRecords returns the valid signed price records that the
+// latest price record was aggregated from
+func (o *Oracle) LatestSignedPriceRecords() []*SignedPriceRecord {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	signedRecords := make([]*SignedPriceRecord, len(o.signedPriceRecords))
+	for i, signedRecord := range o.signedPriceRecords {
+		signedRecords[i] = signedRecord.Clone()
+	}
+	return signedRecords
+}
@@ -0,0 +1,131 @@
+package oracle
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type testFeed struct {
+	privateKey ed25519.PrivateKey
+	lock       sync.Mutex
+	record     *PriceRecord
+}
+
+func (f *testFeed) ServeHTTP(writer http.ResponseWriter, _ *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	data, err := SignPriceRecordEd25519(f.record, f.privateKey).ToBytes()
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = writer.Write(data)
+}
+
+func (f *testFeed) setRecord(record *PriceRecord) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.record = record
+}
+
+func TestFetchPrices(t *testing.T) {
+	const maxAge = time.Minute
+	now := int64(10_000_000)
+
+	var feeds []*testFeed
+	var urls []string
+	var publicKeys []string
+	for i := 0; i < 3; i++ {
+		publicKey, privateKey, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatalf("GenerateKey: %s", err)
+		}
+		feed := &testFeed{privateKey: privateKey}
+		server := httptest.NewServer(feed)
+		defer server.Close()
+
+		feeds = append(feeds, feed)
+		urls = append(urls, server.URL)
+		publicKeys = append(publicKeys, "ed25519:"+hex.EncodeToString(publicKey))
+	}
+
+	// An unreachable feed is skipped
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	urls = append(urls, unreachable.URL)
+	unreachable.Close()
+
+	oracle, err := NewOracle(&Config{
+		URLPool:             urls,
+		PublicKeys:          publicKeys,
+		FetchTimeout:        5 * time.Second,
+		MaxRecordAge:        maxAge,
+		MinRecords:          2,
+		MovingAverageWindow: 2,
+	})
+	if err != nil {
+		t.Fatalf("NewOracle: %s", err)
+	}
+	oracle.now = func() int64 { return now }
+
+	_, err = oracle.LatestPriceRecord()
+	if !errors.Is(err, ErrNotEnoughPriceRecords) {
+		t.Fatalf("expected ErrNotEnoughPriceRecords before the first fetch but got %v", err)
+	}
+
+	for i, feed := range feeds {
+		price := uint64(100 + i*100)
+		feed.setRecord(&PriceRecord{KSH: price, KRV: price, KUSD: price, Timestamp: now})
+	}
+	err = oracle.FetchPrices()
+	if err != nil {
+		t.Fatalf("FetchPrices: %s", err)
+	}
+	expected := &PriceRecord{KSH: 200, KRV: 200, KUSD: 200, KSHMA: 200, KRVMA: 200, KUSDMA: 200, Timestamp: now}
+	latest, err := oracle.LatestPriceRecord()
+	if err != nil {
+		t.Fatalf("LatestPriceRecord: %s", err)
+	}
+	if !latest.Equal(expected) {
+		t.Fatalf("expected %+v but got %+v", expected, latest)
+	}
+	if len(oracle.LatestSignedPriceRecords()) != len(feeds) {
+		t.Fatalf("expected %d signed records but got %d", len(feeds), len(oracle.LatestSignedPriceRecords()))
+	}
+
+	now += 1000
+	for i, feed := range feeds {
+		price := uint64(200 + i*100)
+		feed.setRecord(&PriceRecord{KSH: price, KRV: price, KUSD: price, Timestamp: now})
+	}
+	err = oracle.FetchPrices()
+	if err != nil {
+		t.Fatalf("FetchPrices: %s", err)
+	}
+	expected = &PriceRecord{KSH: 300, KRV: 300, KUSD: 300, KSHMA: 250, KRVMA: 250, KUSDMA: 250, Timestamp: now}
+	latest, err = oracle.LatestPriceRecord()
+	if err != nil {
+		t.Fatalf("LatestPriceRecord: %s", err)
+	}
+	if !latest.Equal(expected) {
+		t.Fatalf("expected %+v but got %+v", expected, latest)
+	}
+
+	// Once all feeds stop updating, their records go stale
+	now += maxAge.Milliseconds() + 1
+	_, err = oracle.LatestPriceRecord()
+	if !errors.Is(err, ErrStalePriceRecord) {
+		t.Fatalf("expected ErrStalePriceRecord but got %v", err)
+	}
+	err = oracle.FetchPrices()
+	if !errors.Is(err, ErrNotEnoughPriceRecords) {
+		t.Fatalf("expected ErrNotEnoughPriceRecords but got %v", err)
+	}
+}
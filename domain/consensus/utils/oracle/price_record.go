@@ -1,21 +1,107 @@
 package oracle
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/hashes"
 )
 
 // PriceRecord stores the prices and moving averages of KSH, KRV, and KUSD against USDT.
+//
+// Prices are fixed-point numbers: the USDT price of one unit multiplied by
+// constants.SompiPerKash, so that KSH is directly usable as the Djed exchange rate.
+// Timestamp is in milliseconds since the epoch.
 type PriceRecord struct {
-	KSH       float64
-	KRV       float64
-	KUSD      float64
-	KSHMA     float64
-	KRVMA     float64
-	KUSDMA    float64
+	KSH       uint64
+	KRV       uint64
+	KUSD      uint64
+	KSHMA     uint64
+	KRVMA     uint64
+	KUSDMA    uint64
 	Timestamp int64
 }
 
+// Clone returns a clone of PriceRecord
+func (pr *PriceRecord) Clone() *PriceRecord {
+	clone := *pr
+	return &clone
+}
+
+// Equal returns whether pr equals to other
+func (pr *PriceRecord) Equal(other *PriceRecord) bool {
+	if pr == nil || other == nil {
+		return pr == other
+	}
+	return *pr == *other
+}
+
+// OraclePrices returns the prices of pr as externalapi.OraclePrices
+func (pr *PriceRecord) OraclePrices() *externalapi.OraclePrices {
+	return &externalapi.OraclePrices{
+		KSH:       pr.KSH,
+		KRV:       pr.KRV,
+		KUSD:      pr.KUSD,
+		KSHMA:     pr.KSHMA,
+		KRVMA:     pr.KRVMA,
+		KUSDMA:    pr.KUSDMA,
+		Timestamp: pr.Timestamp,
+	}
+}
+
+// FromOraclePrices is the inverse of PriceRecord.OraclePrices
+func FromOraclePrices(prices *externalapi.OraclePrices) *PriceRecord {
+	return &PriceRecord{
+		KSH:       prices.KSH,
+		KRV:       prices.KRV,
+		KUSD:      prices.KUSD,
+		KSHMA:     prices.KSHMA,
+		KRVMA:     prices.KRVMA,
+		KUSDMA:    prices.KUSDMA,
+		Timestamp: prices.Timestamp,
+	}
+}
+
+// priceRecordLength is the length of a binary serialized PriceRecord
+const priceRecordLength = 7 * 8
+
+// Hash returns the hash that price feeds sign on for this PriceRecord
+func (pr *PriceRecord) Hash() *externalapi.DomainHash {
+	serialized := pr.serialize()
+
+	writer := hashes.NewPriceRecordSigningHashWriter()
+	writer.InfallibleWrite(serialized[:])
+	return writer.Finalize()
+}
+
+// serialize returns the fields of pr as little-endian 64 bit integers, in declaration order
+func (pr *PriceRecord) serialize() [priceRecordLength]byte {
+	var serialized [priceRecordLength]byte
+	binary.LittleEndian.PutUint64(serialized[0:], pr.KSH)
+	binary.LittleEndian.PutUint64(serialized[8:], pr.KRV)
+	binary.LittleEndian.PutUint64(serialized[16:], pr.KUSD)
+	binary.LittleEndian.PutUint64(serialized[24:], pr.KSHMA)
+	binary.LittleEndian.PutUint64(serialized[32:], pr.KRVMA)
+	binary.LittleEndian.PutUint64(serialized[40:], pr.KUSDMA)
+	binary.LittleEndian.PutUint64(serialized[48:], uint64(pr.Timestamp))
+	return serialized
+}
+
+// deserializePriceRecord is the inverse of PriceRecord.serialize
+func deserializePriceRecord(serialized []byte) *PriceRecord {
+	return &PriceRecord{
+		KSH:       binary.LittleEndian.Uint64(serialized[0:]),
+		KRV:       binary.LittleEndian.Uint64(serialized[8:]),
+		KUSD:      binary.LittleEndian.Uint64(serialized[16:]),
+		KSHMA:     binary.LittleEndian.Uint64(serialized[24:]),
+		KRVMA:     binary.LittleEndian.Uint64(serialized[32:]),
+		KUSDMA:    binary.LittleEndian.Uint64(serialized[40:]),
+		Timestamp: int64(binary.LittleEndian.Uint64(serialized[48:])),
+	}
+}
+
 // ToBytes serializes the PriceRecord into a byte slice.
 func (pr *PriceRecord) ToBytes() ([]byte, error) {
 	data, err := json.Marshal(pr)
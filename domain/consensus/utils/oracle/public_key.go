@@ -0,0 +1,126 @@
+package oracle
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/pkg/errors"
+)
+
+// SignatureScheme is the signature algorithm a price feed signs its records with
+type SignatureScheme uint8
+
+const (
+	// SignatureSchemeEd25519 is an Ed25519 signature over the price record hash
+	SignatureSchemeEd25519 SignatureScheme = iota
+
+	// SignatureSchemeSchnorr is a BIP-340 Schnorr signature over the price record hash
+	SignatureSchemeSchnorr
+)
+
+func (s SignatureScheme) String() string {
+	switch s {
+	case SignatureSchemeEd25519:
+		return "ed25519"
+	case SignatureSchemeSchnorr:
+		return "schnorr"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// PublicKey is the public key of a trusted price feed
+type PublicKey struct {
+	scheme SignatureScheme
+	key    []byte
+}
+
+// ParsePublicKey parses a public key in the form <scheme>:<hex>, for example
+// "ed25519:3b6a27bc...", where scheme is either ed25519 or schnorr.
+func ParsePublicKey(publicKeyString string) (*PublicKey, error) {
+	parts := strings.SplitN(publicKeyString, ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.Wrapf(ErrInvalidPublicKey, "%s is not in the form <scheme>:<hex>", publicKeyString)
+	}
+
+	key, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidPublicKey, "%s: %s", publicKeyString, err)
+	}
+
+	switch strings.ToLower(parts[0]) {
+	case SignatureSchemeEd25519.String():
+		if len(key) != ed25519.PublicKeySize {
+			return nil, errors.Wrapf(ErrInvalidPublicKey, "ed25519 public keys are %d bytes long, "+
+				"but %s is %d bytes long", ed25519.PublicKeySize, publicKeyString, len(key))
+		}
+		return &PublicKey{scheme: SignatureSchemeEd25519, key: key}, nil
+	case SignatureSchemeSchnorr.String():
+		_, err := secp256k1.DeserializeSchnorrPubKey(key)
+		if err != nil {
+			return nil, errors.Wrapf(ErrInvalidPublicKey, "%s: %s", publicKeyString, err)
+		}
+		return &PublicKey{scheme: SignatureSchemeSchnorr, key: key}, nil
+	default:
+		return nil, errors.Wrapf(ErrInvalidPublicKey, "unknown signature scheme %s", parts[0])
+	}
+}
+
+// Scheme returns the signature scheme of this public key
+func (pk *PublicKey) Scheme() SignatureScheme {
+	return pk.scheme
+}
+
+// Bytes returns the serialized public key
+func (pk *PublicKey) Bytes() []byte {
+	return pk.key
+}
+
+func (pk *PublicKey) String() string {
+	return fmt.Sprintf("%s:%x", pk.scheme, pk.key)
+}
+
+func (pk *PublicKey) verify(hash *externalapi.DomainHash, signature []byte) bool {
+	switch pk.scheme {
+	case SignatureSchemeEd25519:
+		return len(signature) == ed25519.SignatureSize && ed25519.Verify(pk.key, hash.ByteSlice(), signature)
+	case SignatureSchemeSchnorr:
+		publicKey, err := secp256k1.DeserializeSchnorrPubKey(pk.key)
+		if err != nil {
+			return false
+		}
+		schnorrSignature, err := secp256k1.DeserializeSchnorrSignatureFromSlice(signature)
+		if err != nil {
+			return false
+		}
+		secpHash := secp256k1.Hash(*hash.ByteArray())
+		return publicKey.SchnorrVerify(&secpHash, schnorrSignature)
+	default:
+		return false
+	}
+}
+
+// PublicKeySet is a set of trusted price feed public keys
+type PublicKeySet map[string]*PublicKey
+
+// ParsePublicKeySet parses a set of public keys as accepted by ParsePublicKey
+func ParsePublicKeySet(publicKeyStrings []string) (PublicKeySet, error) {
+	publicKeySet := make(PublicKeySet, len(publicKeyStrings))
+	for _, publicKeyString := range publicKeyStrings {
+		publicKey, err := ParsePublicKey(publicKeyString)
+		if err != nil {
+			return nil, err
+		}
+		publicKeySet[string(publicKey.key)] = publicKey
+	}
+	return publicKeySet, nil
+}
+
+func (pks PublicKeySet) get(key []byte) (*PublicKey, bool) {
+	publicKey, ok := pks[string(key)]
+	return publicKey, ok
+}
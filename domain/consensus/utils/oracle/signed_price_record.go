@@ -0,0 +1,99 @@
+package oracle
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/pkg/errors"
+)
+
+// SignedPriceRecord is a PriceRecord as published by a single price feed,
+// together with the feed's public key and its signature over PriceRecord.Hash.
+type SignedPriceRecord struct {
+	Record    *PriceRecord
+	PublicKey []byte
+	Signature []byte
+}
+
+// Clone returns a clone of SignedPriceRecord
+func (spr *SignedPriceRecord) Clone() *SignedPriceRecord {
+	publicKeyClone := make([]byte, len(spr.PublicKey))
+	copy(publicKeyClone, spr.PublicKey)
+	signatureClone := make([]byte, len(spr.Signature))
+	copy(signatureClone, spr.Signature)
+
+	return &SignedPriceRecord{
+		Record:    spr.Record.Clone(),
+		PublicKey: publicKeyClone,
+		Signature: signatureClone,
+	}
+}
+
+// SignPriceRecordEd25519 signs the given record with an Ed25519 private key
+func SignPriceRecordEd25519(record *PriceRecord, privateKey ed25519.PrivateKey) *SignedPriceRecord {
+	return &SignedPriceRecord{
+		Record:    record,
+		PublicKey: privateKey.Public().(ed25519.PublicKey),
+		Signature: ed25519.Sign(privateKey, record.Hash().ByteSlice()),
+	}
+}
+
+// SignPriceRecordSchnorr signs the given record with a Schnorr key pair
+func SignPriceRecordSchnorr(record *PriceRecord, keyPair *secp256k1.SchnorrKeyPair) (*SignedPriceRecord, error) {
+	publicKey, err := keyPair.SchnorrPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	serializedPublicKey, err := publicKey.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := secp256k1.Hash(*record.Hash().ByteArray())
+	signature, err := keyPair.SchnorrSign(&hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedPriceRecord{
+		Record:    record,
+		PublicKey: serializedPublicKey[:],
+		Signature: signature.Serialize()[:],
+	}, nil
+}
+
+// Verify checks that the record is signed by one of the given trusted public keys
+func (spr *SignedPriceRecord) Verify(publicKeys PublicKeySet) error {
+	if spr.Record == nil {
+		return errors.Wrapf(ErrInvalidSignature, "signed price record has no record")
+	}
+
+	publicKey, ok := publicKeys.get(spr.PublicKey)
+	if !ok {
+		return errors.Wrapf(ErrUnknownSigner, "price record signed by %x", spr.PublicKey)
+	}
+
+	if !publicKey.verify(spr.Record.Hash(), spr.Signature) {
+		return errors.Wrapf(ErrInvalidSignature, "price record signed by %s", publicKey)
+	}
+	return nil
+}
+
+// ToBytes serializes the SignedPriceRecord into a byte slice, as served by price feeds.
+func (spr *SignedPriceRecord) ToBytes() ([]byte, error) {
+	data, err := json.Marshal(spr)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize SignedPriceRecord")
+	}
+	return data, nil
+}
+
+// SignedPriceRecordFromBytes deserializes a byte slice into a SignedPriceRecord.
+func SignedPriceRecordFromBytes(data []byte) (*SignedPriceRecord, error) {
+	var spr SignedPriceRecord
+	if err := json.Unmarshal(data, &spr); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize byte slice to SignedPriceRecord")
+	}
+	return &spr, nil
+}
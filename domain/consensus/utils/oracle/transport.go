@@ -0,0 +1,69 @@
+package oracle
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxResponseSize bounds the size of a single price feed response
+const maxResponseSize = 1 << 16
+
+// Transport fetches the serialized SignedPriceRecord published at a price feed URL
+type Transport interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// DialFunc opens a connection to address on the given network, giving up after timeout
+type DialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
+
+type httpTransport struct {
+	client *http.Client
+}
+
+// NewHTTPTransport returns a Transport that fetches price records over HTTP(S), connecting
+// to the price feeds through dial
+func NewHTTPTransport(timeout time.Duration, dial DialFunc) Transport {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialTimeout := timeout
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < dialTimeout {
+				dialTimeout = time.Until(deadline)
+			}
+			return dial(network, address, dialTimeout)
+		},
+		ForceAttemptHTTP2:   true,
+		TLSHandshakeTimeout: timeout,
+	}
+
+	return &httpTransport{
+		client: &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+func (t *httpTransport) Fetch(ctx context.Context, url string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := t.client.Do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("price feed %s responded with status %s", url, response.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return body, nil
+}
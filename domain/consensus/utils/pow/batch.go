@@ -0,0 +1,123 @@
+package pow
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+)
+
+// proofOfWorkValueCacheSize is comfortably more than the number of headers the IBD flow
+// calculates proof of work values for ahead of validating them.
+const proofOfWorkValueCacheSize = 4096
+
+// maxSeedsPerBatch is the number of distinct seeds CalculateProofOfWorkValues calculates values
+// for. It matches the number of seeds the global RxVMPools keep, so that a batch doesn't evict
+// pools that are about to be used again.
+const maxSeedsPerBatch = maxSeededRxVMPools
+
+// proofOfWorkValueCache holds proof of work values calculated ahead of time by
+// CalculateProofOfWorkValues, evicting the oldest ones once full.
+type proofOfWorkValueCache struct {
+	sync.Mutex
+	values map[string]*big.Int
+	keys   []string
+}
+
+var globalProofOfWorkValueCache = newProofOfWorkValueCache()
+
+func newProofOfWorkValueCache() *proofOfWorkValueCache {
+	return &proofOfWorkValueCache{
+		values: make(map[string]*big.Int, proofOfWorkValueCacheSize),
+		keys:   make([]string, 0, proofOfWorkValueCacheSize),
+	}
+}
+
+func proofOfWorkValueKey(seed []byte, powHash *externalapi.DomainHash) string {
+	return string(seed) + string(powHash.ByteSlice())
+}
+
+func (c *proofOfWorkValueCache) get(key string) (*big.Int, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	value, ok := c.values[key]
+	if !ok {
+		return nil, false
+	}
+	return new(big.Int).Set(value), true
+}
+
+func (c *proofOfWorkValueCache) add(key string, value *big.Int) {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, ok := c.values[key]; ok {
+		return
+	}
+	if len(c.keys) == proofOfWorkValueCacheSize {
+		delete(c.values, c.keys[0])
+		c.keys = c.keys[1:]
+	}
+	c.values[key] = value
+	c.keys = append(c.keys, key)
+}
+
+// CalculateProofOfWorkValues calculates the proof of work values of the given headers in
+// parallel over the global RxVMPools. The values are cached, so that validating the headers
+// right afterwards, which checks their proof of work and calculates their block levels one
+// header at a time, doesn't have to calculate them again.
+//
+// Only the headers of the first maxSeedsPerBatch seeds are calculated, and the values of the
+// rest are left to be calculated when they're validated.
+func CalculateProofOfWorkValues(headers []externalapi.BlockHeader) {
+	statesChan := make(chan *State, len(headers))
+	var seeds [][]byte
+	for _, header := range headers {
+		// Genesis has no proof of work to calculate
+		if len(header.DirectParents()) == 0 {
+			continue
+		}
+		state := NewState(header.ToMutable())
+		if !containsSeed(seeds, state.seed) {
+			if len(seeds) == maxSeedsPerBatch {
+				continue
+			}
+			seeds = append(seeds, state.seed)
+		}
+		statesChan <- state
+	}
+	close(statesChan)
+
+	workerCount, _ := globalRxVMPools.settings()
+	if workerCount > len(statesChan) {
+		workerCount = len(statesChan)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workerCount)
+	for i := 0; i < workerCount; i++ {
+		go func() {
+			defer wg.Done()
+			for state := range statesChan {
+				powHash := state.calculatePowHash()
+				key := proofOfWorkValueKey(state.seed, powHash)
+				if _, ok := globalProofOfWorkValueCache.get(key); ok {
+					continue
+				}
+				globalProofOfWorkValueCache.add(key, calculateRandomXValue(state.seed, powHash))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func containsSeed(seeds [][]byte, seed []byte) bool {
+	for _, otherSeed := range seeds {
+		if bytes.Equal(otherSeed, seed) {
+			return true
+		}
+	}
+	return false
+}
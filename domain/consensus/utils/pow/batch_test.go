@@ -0,0 +1,34 @@
+package pow_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/blockheader"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/pow"
+)
+
+func TestCalculateProofOfWorkValues(t *testing.T) {
+	parents := []externalapi.BlockLevelParents{{&externalapi.DomainHash{}}}
+	pruningPoint := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+
+	const headerCount = 8
+	headers := make([]externalapi.BlockHeader, headerCount)
+	expectedValues := make([]*big.Int, headerCount)
+	for i := range headers {
+		headers[i] = blockheader.NewImmutableBlockHeader(0, parents, &externalapi.DomainHash{},
+			&externalapi.DomainHash{}, &externalapi.DomainHash{}, 0, 0x207fffff, uint64(i), 0, 0,
+			big.NewInt(0), pruningPoint)
+		expectedValues[i] = pow.NewState(headers[i].ToMutable()).CalculateProofOfWorkValue()
+	}
+
+	pow.CalculateProofOfWorkValues(headers)
+
+	for i, header := range headers {
+		value := pow.NewState(header.ToMutable()).CalculateProofOfWorkValue()
+		if value.Cmp(expectedValues[i]) != 0 {
+			t.Fatalf("header %d: expected proof of work value %x but got %x", i, expectedValues[i], value)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package pow
 import (
 	"fmt"
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/util/randomx"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
@@ -15,6 +17,7 @@ func TestCalcRandomxHashRate(t *testing.T) {
 		testDuration  = 5 * time.Second
 		numGoroutines = 2 // Number of goroutines to use
 	)
+	seed := []byte("test seed")
 	data := []byte("test data for hashing")
 
 	var (
@@ -32,7 +35,7 @@ func TestCalcRandomxHashRate(t *testing.T) {
 				if time.Since(startTime) >= testDuration {
 					return
 				}
-				_ = CalcGlobalVMHash(data)
+				_ = CalcGlobalVMHash(seed, data)
 				hashCount++
 			}
 		}()
@@ -43,6 +46,38 @@ func TestCalcRandomxHashRate(t *testing.T) {
 	fmt.Printf("Randomx - Total Hashes per second: %f\n", hashRate)
 }
 
+// BenchmarkCalcHashLightMode and BenchmarkCalcHashFullMode compare the throughput and the memory
+// usage of the RandomX modes. Note that initializing the dataset of ModeFull takes a while.
+func BenchmarkCalcHashLightMode(b *testing.B) {
+	benchmarkCalcHash(b, ModeLight)
+}
+
+func BenchmarkCalcHashFullMode(b *testing.B) {
+	benchmarkCalcHash(b, ModeFull)
+}
+
+func benchmarkCalcHash(b *testing.B, mode Mode) {
+	pool, err := NewRxVMPool([]byte("test seed"), runtime.GOMAXPROCS(0), mode)
+	if err != nil {
+		b.Fatalf("NewRxVMPool: %s", err)
+	}
+	defer pool.Cleanup()
+
+	data := []byte("test data for hashing")
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = pool.CalcHash(data)
+		}
+	})
+
+	memory := uint64(randomx.CacheSize)
+	if mode == ModeFull {
+		memory += randomx.DatasetSize()
+	}
+	b.ReportMetric(float64(memory)/(1<<20), "MiB/seed")
+}
+
 // TestCalcKheavyHashRate tests the efficiency of HeavyHash function using multiple goroutines.
 // It calculates the total number of hashes computed per second by all goroutines.
 func TestCalcKheavyHashRate(t *testing.T) {
@@ -0,0 +1,11 @@
+package pow
+
+import (
+	"github.com/Kash-Protocol/kashd/infrastructure/metrics"
+)
+
+// randomXHashDuration includes the time to create the RandomX VM pool of a new seed, since
+// verification waits for it as well
+var randomXHashDuration = metrics.NewHistogram("kashd_randomx_hash_duration_seconds",
+	"The time it took to calculate the RandomX hash of a block header to verify its proof of work",
+	metrics.DurationBuckets)
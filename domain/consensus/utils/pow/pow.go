@@ -8,6 +8,7 @@ import (
 	"github.com/Kash-Protocol/kashd/util/difficulty"
 	"github.com/pkg/errors"
 	"math/big"
+	"time"
 )
 
 // State is an intermediate data structure with pre-computed values to speed up mining.
@@ -16,6 +17,7 @@ type State struct {
 	Nonce      uint64
 	Target     big.Int
 	prePowHash externalapi.DomainHash
+	seed       []byte
 }
 
 // NewState creates a new state with pre-computed values to speed up mining
@@ -35,11 +37,36 @@ func NewState(header externalapi.MutableBlockHeader) *State {
 		prePowHash: *prePowHash,
 		Timestamp:  timestamp,
 		Nonce:      nonce,
+		seed:       Seed(header),
 	}
 }
 
+// Seed returns the RandomX key the proof of work of the given header is calculated with: the
+// hash of its pruning point. The pruning point is the selected chain block pruning depth
+// back, and it only moves forward once per finality interval, so the key rotates on a
+// schedule set by the DAG while still being derivable from the header alone, as block
+// levels require.
+//
+// Note that the pruning point of a header can only be verified in context, so consensus
+// validates it before calculating the proof of work, and anyone else calculating the proof
+// of work of untrusted headers must make sure their seeds are known blocks beforehand.
+func Seed(header externalapi.BaseBlockHeader) []byte {
+	return header.PruningPoint().ByteSlice()
+}
+
 // CalculateProofOfWorkValue hashes the internal header and returns its big.Int value
 func (state *State) CalculateProofOfWorkValue() *big.Int {
+	powHash := state.calculatePowHash()
+	value, ok := globalProofOfWorkValueCache.get(proofOfWorkValueKey(state.seed, powHash))
+	if ok {
+		return value
+	}
+
+	return calculateRandomXValue(state.seed, powHash)
+}
+
+// calculatePowHash returns the hash of the internal header that RandomX is applied to
+func (state *State) calculatePowHash() *externalapi.DomainHash {
 	// PRE_POW_HASH || TIME || 32 zero byte padding || NONCE
 	writer := hashes.NewPoWHashWriter()
 	writer.InfallibleWrite(state.prePowHash.ByteSlice())
@@ -53,10 +80,14 @@ func (state *State) CalculateProofOfWorkValue() *big.Int {
 	if err != nil {
 		panic(errors.Wrap(err, "this should never happen. Hash digest should never return an error"))
 	}
-	powHash := writer.Finalize()
+	return writer.Finalize()
+}
 
-	// Use RandomX to calculate the hash
-	randomxHash := CalcGlobalVMHash(powHash.ByteSlice())
+// calculateRandomXValue returns the big.Int value of the RandomX hash of powHash under the given seed
+func calculateRandomXValue(seed []byte, powHash *externalapi.DomainHash) *big.Int {
+	start := time.Now()
+	randomxHash := CalcGlobalVMHash(seed, powHash.ByteSlice())
+	randomXHashDuration.ObserveSince(start)
 
 	domainHash, err := externalapi.NewDomainHashFromByteSlice(randomxHash)
 	if err != nil {
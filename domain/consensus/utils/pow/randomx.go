@@ -1,61 +1,352 @@
 package pow
 
 import (
+	"bytes"
+	"runtime"
+	"sync"
+
 	"github.com/Kash-Protocol/kashd/util/randomx"
 	"github.com/pkg/errors"
-	"sync"
 )
 
-// RxVMPool represents a pool of RandomX VMs.
+// Mode is the way RandomX hashes are calculated in, trading memory for speed. The resulting
+// hashes are the same in all modes.
+type Mode int
+
+const (
+	// ModeLight calculates hashes using only the RandomX cache, which takes about 256 MiB per
+	// seed. It's several times slower than ModeFull, and is meant for nodes that only verify
+	// proof of work.
+	ModeLight Mode = iota
+
+	// ModeFull calculates hashes using the full RandomX dataset, which takes over 2 GiB per
+	// seed and a while to initialize. It's meant for miners.
+	ModeFull
+)
+
+var modeNames = map[Mode]string{
+	ModeLight: "light",
+	ModeFull:  "full",
+}
+
+func (mode Mode) String() string {
+	name, ok := modeNames[mode]
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+// ParseMode returns the Mode of the given name.
+func ParseMode(name string) (Mode, error) {
+	for mode, modeName := range modeNames {
+		if modeName == name {
+			return mode, nil
+		}
+	}
+	return 0, errors.Errorf("unknown RandomX mode %s", name)
+}
+
+// RxVMPool represents a pool of RandomX VMs that share a dataset initialized with a single seed.
 type RxVMPool struct {
-	vmChan chan *randomx.RxVM
-	size   int
+	seed    []byte
+	mode    Mode
+	dataset *randomx.RxDataset
+
+	lock        sync.Mutex
+	vmAvailable *sync.Cond
+	idleVMs     []*randomx.RxVM
+	vmCount     int
+	size        int
+	isClosed    bool
 }
 
-var (
-	// globalRxVMPool is the global instance of RxVMPool.
-	globalRxVMPool *RxVMPool
-	once           sync.Once
-)
+// maxSeededRxVMPools is the number of seeds the global pools keep VMs for. Keeping the VMs of
+// the previous seed warm lets blocks on both sides of a seed switch be validated without
+// re-initializing RandomX back and forth.
+const maxSeededRxVMPools = 2
+
+// maxLiveSeededRxVMPools bounds the number of pools that exist at once, including evicted pools
+// that are still in use. Each pool holds RandomX memory for its seed, so hashing with a new seed
+// waits for an evicted pool to be destroyed rather than exceed it.
+const maxLiveSeededRxVMPools = maxSeededRxVMPools + 1
+
+// defaultRxVMPoolSize lets every CPU hash at once, which is what verifying batches of headers needs.
+var defaultRxVMPoolSize = runtime.NumCPU()
+
+// seededRxVMPool is a global RxVMPool along with the bookkeeping needed to create it lazily
+// and destroy it once it's evicted and no longer in use.
+type seededRxVMPool struct {
+	seed  []byte
+	pool  *RxVMPool
+	err   error
+	ready chan struct{}
+
+	users   int
+	evicted bool
+}
+
+// seededRxVMPools holds the global RxVMPools, ordered from the least to the most recently used.
+type seededRxVMPools struct {
+	sync.Mutex
+	pools    []*seededRxVMPool
+	poolSize int
+	mode     Mode
+
+	livePools     int
+	poolDestroyed *sync.Cond
+}
+
+func newSeededRxVMPools(poolSize int, mode Mode) *seededRxVMPools {
+	p := &seededRxVMPools{poolSize: poolSize, mode: mode}
+	p.poolDestroyed = sync.NewCond(p)
+	return p
+}
+
+// globalRxVMPools are created lazily, so that merely importing this package doesn't allocate RandomX memory.
+var globalRxVMPools = newSeededRxVMPools(defaultRxVMPoolSize, ModeLight)
+
+// CalcGlobalVMHash calculates the hash using one of the global RandomX VMs initialized with the given seed.
+func CalcGlobalVMHash(seed []byte, data []byte) []byte {
+	return globalRxVMPools.calcHash(seed, data)
+}
+
+// ResizeGlobalPool adjusts the size of the global RxVMPools to the specified size.
+func ResizeGlobalPool(newSize int) error {
+	return globalRxVMPools.resize(newSize)
+}
+
+// SetGlobalMode sets the Mode of the global RxVMPools. Pools that were already created in
+// a different mode are discarded.
+func SetGlobalMode(mode Mode) {
+	globalRxVMPools.setMode(mode)
+}
+
+func (p *seededRxVMPools) calcHash(seed []byte, data []byte) []byte {
+	seededPool, isNew := p.acquire(seed)
+	defer p.release(seededPool)
+
+	if isNew {
+		pool, err := p.create(seed)
+		p.finishCreation(seededPool, pool, err)
+	}
+	<-seededPool.ready
+	if seededPool.err != nil {
+		panic(errors.Wrapf(seededPool.err, "failed to initialize RandomX VM pool for seed %x", seed))
+	}
+
+	return seededPool.pool.CalcHash(data)
+}
+
+// acquire returns the pool of the given seed, marking it as in use. If there's no such pool
+// yet, a new one is registered and isNew is returned, in which case the caller must create it.
+// Registering a new pool waits while there are maxLiveSeededRxVMPools pools.
+func (p *seededRxVMPools) acquire(seed []byte) (seededPool *seededRxVMPool, isNew bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	for {
+		for i, seededPool := range p.pools {
+			if bytes.Equal(seededPool.seed, seed) {
+				p.pools = append(append(p.pools[:i:i], p.pools[i+1:]...), seededPool)
+				seededPool.users++
+				return seededPool, false
+			}
+		}
+
+		if len(p.pools) == maxSeededRxVMPools && p.pools[0].users == 0 {
+			p.evict(p.pools[0])
+			p.pools = p.pools[1:]
+		}
+		if p.livePools < maxLiveSeededRxVMPools {
+			break
+		}
+		// Another user may have registered the seed while this one waited
+		p.poolDestroyed.Wait()
+	}
+
+	if len(p.pools) == maxSeededRxVMPools {
+		p.evict(p.pools[0])
+		p.pools = p.pools[1:]
+	}
+
+	seededPool = &seededRxVMPool{
+		seed:  seed,
+		ready: make(chan struct{}),
+		users: 1,
+	}
+	p.pools = append(p.pools, seededPool)
+	p.livePools++
+	return seededPool, true
+}
+
+// create creates the RxVMPool of the given seed. The hashes are the same in all modes, so if
+// there isn't enough memory for the full dataset, the pool is created in ModeLight instead.
+func (p *seededRxVMPools) create(seed []byte) (*RxVMPool, error) {
+	poolSize, mode := p.settings()
+	pool, err := NewRxVMPool(seed, poolSize, mode)
+	if err != nil && mode == ModeFull {
+		log.Warnf("Failed to initialize a full RandomX VM pool for seed %x, falling back to %s mode: %s",
+			seed, ModeLight, err)
+		pool, err = NewRxVMPool(seed, poolSize, ModeLight)
+	}
+	return pool, err
+}
+
+// finishCreation makes a newly created pool available to its other users, resizing it first
+// in case the global pool size was changed while it was being created. A pool that failed to
+// be created is removed, so that the next user of its seed tries to create it again.
+func (p *seededRxVMPools) finishCreation(seededPool *seededRxVMPool, pool *RxVMPool, err error) {
+	p.Lock()
+	defer p.Unlock()
 
-func init() {
-	once.Do(func() {
-		var err error
-		globalRxVMPool, err = NewRxVMPool(2)
+	if err == nil && pool.Size() != p.poolSize {
+		err = pool.ResizePool(p.poolSize)
 		if err != nil {
-			panic(errors.Wrap(err, "failed to initialize global RandomX VM pool"))
+			pool.Cleanup()
+			pool = nil
+		}
+	}
+	seededPool.pool, seededPool.err = pool, err
+	close(seededPool.ready)
+
+	// An evicted pool is already removed, and is destroyed by its last user
+	if err != nil && !seededPool.evicted {
+		for i, otherPool := range p.pools {
+			if otherPool == seededPool {
+				p.pools = append(p.pools[:i:i], p.pools[i+1:]...)
+				break
+			}
 		}
-	})
+		p.livePools--
+		p.poolDestroyed.Broadcast()
+	}
 }
 
-// CalcGlobalVMHash calculates the hash using one of the RandomX VMs from the global pool.
-func CalcGlobalVMHash(data []byte) []byte {
-	return globalRxVMPool.CalcHash(data)
+func (p *seededRxVMPools) release(seededPool *seededRxVMPool) {
+	p.Lock()
+	defer p.Unlock()
+
+	seededPool.users--
+	if seededPool.evicted && seededPool.users == 0 {
+		p.destroy(seededPool)
+	}
 }
 
-// NewRxVMPool initializes a new pool of RandomX VMs with the given size.
-func NewRxVMPool(poolSize int) (*RxVMPool, error) {
-	vmChan := make(chan *randomx.RxVM, poolSize)
+func (p *seededRxVMPools) settings() (poolSize int, mode Mode) {
+	p.Lock()
+	defer p.Unlock()
 
-	for i := 0; i < poolSize; i++ {
-		vm, err := createRxVM()
+	return p.poolSize, p.mode
+}
+
+func (p *seededRxVMPools) setMode(mode Mode) {
+	p.Lock()
+	defer p.Unlock()
+
+	if mode == p.mode {
+		return
+	}
+	for _, seededPool := range p.pools {
+		p.evict(seededPool)
+	}
+	p.pools = nil
+	p.mode = mode
+}
+
+func (p *seededRxVMPools) resize(newSize int) error {
+	p.Lock()
+	defer p.Unlock()
+
+	for _, seededPool := range p.pools {
+		select {
+		case <-seededPool.ready:
+		default:
+			// The pool is still being created, and will be resized by finishCreation
+			continue
+		}
+		if seededPool.err != nil {
+			continue
+		}
+		err := seededPool.pool.ResizePool(newSize)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to create RandomX VM")
+			return err
 		}
-		vmChan <- vm
 	}
+	p.poolSize = newSize
+	return nil
+}
+
+// evict marks the pool as no longer available to new users, and destroys it once it isn't in
+// use. It must be called with the lock held.
+func (p *seededRxVMPools) evict(seededPool *seededRxVMPool) {
+	seededPool.evicted = true
+	if seededPool.users == 0 {
+		p.destroy(seededPool)
+	}
+}
 
-	return &RxVMPool{vmChan: vmChan, size: poolSize}, nil
+// destroy cleans up an evicted pool, making room for a new one. It must be called with the lock held.
+func (p *seededRxVMPools) destroy(seededPool *seededRxVMPool) {
+	if seededPool.pool != nil {
+		seededPool.pool.Cleanup()
+	}
+	p.livePools--
+	p.poolDestroyed.Broadcast()
 }
 
-// createRxVM creates a new RandomX VM instance.
-func createRxVM() (*randomx.RxVM, error) {
-	rxDataset, err := randomx.NewRxDataset(randomx.FlagDefault)
+// NewRxVMPool initializes a new pool of RandomX VMs with the given size and mode, keyed by the given seed.
+func NewRxVMPool(seed []byte, poolSize int, mode Mode) (*RxVMPool, error) {
+	dataset, err := newRxDataset(seed, mode)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create RandomX dataset")
+		return nil, err
 	}
 
-	vm, err := randomx.NewRxVM(rxDataset, randomx.FlagDefault)
+	pool := &RxVMPool{seed: seed, mode: mode, dataset: dataset}
+	pool.vmAvailable = sync.NewCond(&pool.lock)
+	err = pool.ResizePool(poolSize)
+	if err != nil {
+		pool.Cleanup()
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// newRxDataset creates a RandomX dataset initialized with the given seed. In ModeLight
+// only its cache is allocated.
+func newRxDataset(seed []byte, mode Mode) (*randomx.RxDataset, error) {
+	if mode == ModeFull {
+		dataset, err := randomx.NewRxDataset(randomx.GetFlags())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create RandomX dataset")
+		}
+		if !dataset.CInit(seed, uint32(runtime.NumCPU())) {
+			dataset.Close()
+			return nil, errors.New("failed to initialize RandomX dataset")
+		}
+		return dataset, nil
+	}
+
+	dataset, err := randomx.NewLightRxDataset(randomx.GetFlags())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create RandomX cache")
+	}
+	if !dataset.InitCache(seed) {
+		dataset.Close()
+		return nil, errors.New("failed to initialize RandomX cache")
+	}
+	return dataset, nil
+}
+
+// createRxVM creates a new RandomX VM instance over the given dataset.
+func createRxVM(dataset *randomx.RxDataset, mode Mode) (*randomx.RxVM, error) {
+	flags := randomx.GetFlags()
+	if mode == ModeFull {
+		flags |= randomx.FlagFullMEM
+	}
+
+	vm, err := randomx.NewRxVM(dataset, flags)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create RandomX VM")
 	}
@@ -63,80 +354,123 @@ func createRxVM() (*randomx.RxVM, error) {
 	return vm, nil
 }
 
-// CalcHash calculates the hash using one of the available RandomX VMs.
+// Seed returns the seed the VMs of this pool were initialized with.
+func (p *RxVMPool) Seed() []byte {
+	return p.seed
+}
+
+// CalcHash calculates the hash using one of the available RandomX VMs, waiting for one
+// to become available if they're all in use.
 func (p *RxVMPool) CalcHash(data []byte) []byte {
-	vm := <-p.vmChan // Get a VM from the pool (non-blocking)
-	hash := vm.CalcHash(data)
-	p.vmChan <- vm // Return the VM back to the pool
-	return hash
-}
-
-// ResizePool adjusts the size of the RxVMPool to the specified size.
-// This function increases the pool size by creating new RandomX VM instances
-// and transferring existing ones to a new channel with the desired size.
-//
-// Note:
-//   - This function is not thread-safe and should not be called concurrently.
-//   - It does not support shrinking the pool size; attempting to do so will return an error.
-//   - The pool can only be resized when it's not in use (i.e., when the current pool size
-//     equals the number of VMs in the channel). Attempting to resize while the pool is in use
-//     will result in an error.
-//
-// Parameters:
-// - newSize: The desired size of the pool after resizing.
-//
-// Returns an error if shrinking is attempted, the pool is in use, or if there is an issue
-// creating new RandomX VM instances.
-func (p *RxVMPool) ResizePool(newSize int) error {
-	currentSize := p.size
-	if newSize < currentSize {
-		return errors.New("shrinking the pool size is not supported")
+	vm := p.takeVM()
+	defer p.returnVM(vm)
+
+	return vm.CalcHash(data)
+}
+
+func (p *RxVMPool) takeVM() *randomx.RxVM {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for len(p.idleVMs) == 0 {
+		if p.isClosed {
+			panic(errors.New("cannot calculate a hash with a RandomX VM pool that was cleaned up"))
+		}
+		p.vmAvailable.Wait()
 	}
 
-	if currentSize != len(p.vmChan) {
-		return errors.New("cannot resize the pool while it is in use")
+	vm := p.idleVMs[len(p.idleVMs)-1]
+	p.idleVMs = p.idleVMs[:len(p.idleVMs)-1]
+	return vm
+}
+
+// returnVM returns a VM taken by takeVM to the pool, or destroys it if the pool has
+// shrunk or was cleaned up in the meantime.
+func (p *RxVMPool) returnVM(vm *randomx.RxVM) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.isClosed || p.vmCount > p.size {
+		p.destroyVM(vm)
+		return
 	}
 
-	// Create a new channel with the desired size
-	newVmChan := make(chan *randomx.RxVM, newSize)
+	p.idleVMs = append(p.idleVMs, vm)
+	p.vmAvailable.Signal()
+}
 
-	// Transfer existing VMs to the new channel
-	for i := 0; i < currentSize; i++ {
-		if vm, ok := <-p.vmChan; ok {
-			newVmChan <- vm
-		}
+// destroyVM destroys a VM of the pool, along with the dataset once the pool was cleaned
+// up and no VMs remain. It must be called with the pool lock held.
+func (p *RxVMPool) destroyVM(vm *randomx.RxVM) {
+	vm.Close()
+	p.vmCount--
+	if p.isClosed && p.vmCount == 0 {
+		p.dataset.Close()
 	}
+}
 
-	// Calculate the number of VMs to add
-	diff := newSize - currentSize
+// ResizePool adjusts the size of the RxVMPool to the specified size. It may be called
+// while the pool is in use: when growing, new RandomX VM instances are created right away,
+// and when shrinking, idle VMs are destroyed right away and VMs that are in use are
+// destroyed once they're returned to the pool.
+func (p *RxVMPool) ResizePool(newSize int) error {
+	if newSize < 1 {
+		return errors.Errorf("the pool size must be at least 1, got %d", newSize)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.isClosed {
+		return errors.New("cannot resize a RandomX VM pool that was cleaned up")
+	}
 
-	// Add new VMs to the pool
-	for i := 0; i < diff; i++ {
-		vm, err := createRxVM()
+	p.size = newSize
+	for p.vmCount < p.size {
+		vm, err := createRxVM(p.dataset, p.mode)
 		if err != nil {
 			return errors.Wrap(err, "failed to create RandomX VM during pool resize")
 		}
-		newVmChan <- vm
+		p.idleVMs = append(p.idleVMs, vm)
+		p.vmCount++
+		p.vmAvailable.Signal()
+	}
+	for p.vmCount > p.size && len(p.idleVMs) > 0 {
+		vm := p.idleVMs[len(p.idleVMs)-1]
+		p.idleVMs = p.idleVMs[:len(p.idleVMs)-1]
+		p.destroyVM(vm)
 	}
-
-	// Replace the old channel with the new one
-	p.vmChan = newVmChan
-	p.size = newSize
 
 	return nil
 }
 
-// ResizeGlobalPool adjusts the size of the global RxVMPool to the specified size.
-func ResizeGlobalPool(newSize int) error {
-	return globalRxVMPool.ResizePool(newSize)
+// Size returns the number of VMs the pool is sized to.
+func (p *RxVMPool) Size() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.size
 }
 
-// Cleanup safely destroys all RandomX VMs in the pool.
+// Cleanup safely destroys all RandomX VMs in the pool, along with their dataset. VMs that
+// are in use are destroyed once they're returned to the pool.
 func (p *RxVMPool) Cleanup() {
-	close(p.vmChan) // Close the channel before cleanup
-	for vm := range p.vmChan {
-		if vm != nil {
-			vm.Close()
-		}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.isClosed {
+		return
+	}
+	p.isClosed = true
+	p.vmAvailable.Broadcast()
+	if p.vmCount == 0 {
+		p.dataset.Close()
+		return
+	}
+
+	idleVMs := p.idleVMs
+	p.idleVMs = nil
+	for _, vm := range idleVMs {
+		p.destroyVM(vm)
 	}
 }
@@ -0,0 +1,188 @@
+package pow
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestCalcGlobalVMHashSeeds(t *testing.T) {
+	// A test vector of the RandomX reference implementation
+	seed := []byte("test key 000")
+	data := []byte("This is a test")
+	expectedHash, err := hex.DecodeString("639183aae1bf4c9a35884cb46b09cad9175f04efd7684e7262a0ac1c2f0b4e3f")
+	if err != nil {
+		t.Fatalf("DecodeString: %s", err)
+	}
+
+	hash := CalcGlobalVMHash(seed, data)
+	if !bytes.Equal(hash, expectedHash) {
+		t.Fatalf("expected hash %x but got %x", expectedHash, hash)
+	}
+
+	otherSeed := []byte("test key 001")
+	if bytes.Equal(CalcGlobalVMHash(otherSeed, data), expectedHash) {
+		t.Fatalf("expected a different seed to result in a different hash")
+	}
+
+	// Using a third seed evicts the pool of the least recently used one
+	CalcGlobalVMHash([]byte("test key 002"), data)
+	if len(globalRxVMPools.pools) != maxSeededRxVMPools {
+		t.Fatalf("expected %d pools to be kept but got %d", maxSeededRxVMPools, len(globalRxVMPools.pools))
+	}
+	for _, seededPool := range globalRxVMPools.pools {
+		if bytes.Equal(seededPool.seed, seed) {
+			t.Fatalf("expected the pool of the least recently used seed to be evicted")
+		}
+	}
+
+	hash = CalcGlobalVMHash(seed, data)
+	if !bytes.Equal(hash, expectedHash) {
+		t.Fatalf("expected hash %x after re-initializing the seed but got %x", expectedHash, hash)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, mode := range []Mode{ModeLight, ModeFull} {
+		parsedMode, err := ParseMode(mode.String())
+		if err != nil {
+			t.Fatalf("ParseMode: %s", err)
+		}
+		if parsedMode != mode {
+			t.Fatalf("expected mode %s but got %s", mode, parsedMode)
+		}
+	}
+
+	_, err := ParseMode("heavy")
+	if err == nil {
+		t.Fatalf("expected an error for an unknown mode")
+	}
+}
+
+func TestSetGlobalMode(t *testing.T) {
+	CalcGlobalVMHash([]byte("test key 000"), []byte("This is a test"))
+	if len(globalRxVMPools.pools) == 0 {
+		t.Fatalf("expected the global pools to hold the pool of the used seed")
+	}
+
+	SetGlobalMode(ModeLight)
+	if len(globalRxVMPools.pools) == 0 {
+		t.Fatalf("expected setting the current mode to keep the global pools")
+	}
+
+	SetGlobalMode(ModeFull)
+	defer SetGlobalMode(ModeLight)
+	if len(globalRxVMPools.pools) != 0 {
+		t.Fatalf("expected changing the mode to discard the global pools")
+	}
+}
+
+func TestRxVMPoolResizeWhileInUse(t *testing.T) {
+	pool, err := NewRxVMPool([]byte("test key 000"), 2, ModeLight)
+	if err != nil {
+		t.Fatalf("NewRxVMPool: %s", err)
+	}
+	defer pool.Cleanup()
+
+	vm := pool.takeVM()
+
+	err = pool.ResizePool(1)
+	if err != nil {
+		t.Fatalf("ResizePool: %s", err)
+	}
+	if pool.vmCount != 1 || len(pool.idleVMs) != 0 {
+		t.Fatalf("expected shrinking to destroy the idle VM but got %d VMs, %d of them idle",
+			pool.vmCount, len(pool.idleVMs))
+	}
+
+	err = pool.ResizePool(3)
+	if err != nil {
+		t.Fatalf("ResizePool: %s", err)
+	}
+	if pool.vmCount != 3 || len(pool.idleVMs) != 2 {
+		t.Fatalf("expected growing to create 2 idle VMs but got %d VMs, %d of them idle",
+			pool.vmCount, len(pool.idleVMs))
+	}
+
+	err = pool.ResizePool(1)
+	if err != nil {
+		t.Fatalf("ResizePool: %s", err)
+	}
+	pool.returnVM(vm)
+	if pool.vmCount != 1 || len(pool.idleVMs) != 1 {
+		t.Fatalf("expected the VM in use to be destroyed once returned but got %d VMs, %d of them idle",
+			pool.vmCount, len(pool.idleVMs))
+	}
+
+	err = pool.ResizePool(0)
+	if err == nil {
+		t.Fatalf("expected an error when resizing the pool to 0")
+	}
+}
+
+func TestSeededRxVMPoolsLimit(t *testing.T) {
+	pools := newSeededRxVMPools(1, ModeLight)
+
+	// The pools are only registered, and never created, so that no RandomX memory is allocated
+	var acquiredPools []*seededRxVMPool
+	for i := 0; i < maxLiveSeededRxVMPools; i++ {
+		seededPool, isNew := pools.acquire([]byte{byte(i)})
+		if !isNew {
+			t.Fatalf("expected seed %d to register a new pool", i)
+		}
+		acquiredPools = append(acquiredPools, seededPool)
+	}
+	if pools.livePools != maxLiveSeededRxVMPools {
+		t.Fatalf("expected %d live pools but got %d", maxLiveSeededRxVMPools, pools.livePools)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		pools.acquire([]byte{maxLiveSeededRxVMPools})
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatalf("expected registering a pool above the limit to wait")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Releasing the evicted pool destroys it, which makes room for the new one
+	pools.release(acquiredPools[0])
+	select {
+	case <-acquired:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("expected the new pool to be registered once an evicted pool was destroyed")
+	}
+	if pools.livePools != maxLiveSeededRxVMPools {
+		t.Fatalf("expected %d live pools but got %d", maxLiveSeededRxVMPools, pools.livePools)
+	}
+}
+
+func TestSeededRxVMPoolsCreationFailure(t *testing.T) {
+	pools := newSeededRxVMPools(1, ModeLight)
+
+	seed := []byte{1}
+	seededPool, isNew := pools.acquire(seed)
+	if !isNew {
+		t.Fatalf("expected the seed to register a new pool")
+	}
+	pools.finishCreation(seededPool, nil, errors.New("out of memory"))
+	pools.release(seededPool)
+	if len(pools.pools) != 0 || pools.livePools != 0 {
+		t.Fatalf("expected the pool that failed to be created to be removed, but got %d pools, %d of them live",
+			len(pools.pools), pools.livePools)
+	}
+
+	// The next user of the seed tries to create its pool again
+	seededPool, isNew = pools.acquire(seed)
+	if !isNew {
+		t.Fatalf("expected the seed to register a new pool after the previous one failed to be created")
+	}
+	if pools.livePools != 1 {
+		t.Fatalf("expected 1 live pool but got %d", pools.livePools)
+	}
+}
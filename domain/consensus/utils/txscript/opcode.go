@@ -216,7 +216,7 @@ const (
 	OpAssetKSH            = 0xb2 // 178
 	OpAssetKUSD           = 0xb3 // 179
 	OpAssetKRV            = 0xb4 // 180
-	OpUnknown181          = 0xb5 // 181
+	OpRedeemKUSD          = 0xb5 // 181
 	OpUnknown182          = 0xb6 // 182
 	OpUnknown183          = 0xb7 // 183
 	OpUnknown184          = 0xb8 // 184
@@ -495,8 +495,9 @@ var opcodeArray = [256]opcode{
 	OpCheckMultiSigVerify: {OpCheckMultiSigVerify, "OP_CHECKMULTISIGVERIFY", 1, opcodeCheckMultiSigVerify},
 
 	// KASH opcodes.
-	OpMintKUSD: {OpMintKUSD, "OP_MINTKUSD", 1, opcodeMintKUSD},
-	OpStakeKSH: {OpStakeKSH, "OP_STAKEKSH", 1, opcodeStakeKSH},
+	OpMintKUSD:   {OpMintKUSD, "OP_MINTKUSD", 1, opcodeMintKUSD},
+	OpStakeKSH:   {OpStakeKSH, "OP_STAKEKSH", 1, opcodeStakeKSH},
+	OpRedeemKUSD: {OpRedeemKUSD, "OP_REDEEMKUSD", 1, opcodeRedeemKUSD},
 
 	// UTXO asset type opcodes.(KSH, KUSD, KRV)
 	OpAssetKSH:  {OpAssetKSH, "OP_ASSETKSH", 1, opcodeNop},
@@ -504,7 +505,6 @@ var opcodeArray = [256]opcode{
 	OpAssetKRV:  {OpAssetKRV, "OP_ASSETKRV", 1, opcodeNop},
 
 	// Undefined opcodes.
-	OpUnknown181: {OpUnknown191, "OP_UNKNOWN181", 1, opcodeInvalid},
 	OpUnknown182: {OpUnknown192, "OP_UNKNOWN182", 1, opcodeInvalid},
 	OpUnknown183: {OpUnknown193, "OP_UNKNOWN183", 1, opcodeInvalid},
 	OpUnknown184: {OpUnknown194, "OP_UNKNOWN184", 1, opcodeInvalid},
@@ -2483,14 +2483,26 @@ func opcodeCheckMultiSigVerify(op *parsedOpcode, vm *Engine) error {
 	return err
 }
 
+// opcodeMintKUSD is a no-op marker for scripts that take part in minting KUSD.
+// The amounts of a MintKUSD transaction depend on the exchange rate and the reserve
+// state, which the script engine has no access to, so they are enforced during
+// transaction validation rather than here.
 func opcodeMintKUSD(op *parsedOpcode, vm *Engine) error {
 	return nil
 }
 
+// opcodeStakeKSH is a no-op marker for scripts that take part in staking KSH for KRV.
+// See opcodeMintKUSD for why the conversion amounts are not checked here.
 func opcodeStakeKSH(op *parsedOpcode, vm *Engine) error {
 	return nil
 }
 
+// opcodeRedeemKUSD is a no-op marker for scripts that take part in redeeming KUSD for KSH.
+// See opcodeMintKUSD for why the conversion amounts are not checked here.
+func opcodeRedeemKUSD(op *parsedOpcode, vm *Engine) error {
+	return nil
+}
+
 // OpcodeByName is a map that can be used to lookup an opcode by its
 // human-readable name (OP_CHECKMULTISIG, OP_CHECKSIG, etc).
 var OpcodeByName = make(map[string]byte)
@@ -80,7 +80,7 @@ func TestOpcodeDisasm(t *testing.T) {
 		0xfd: "OP_PUBKEYHASH", 0xfe: "OP_PUBKEY",
 		0xff: "OP_INVALIDOPCODE", 0xb2: "OP_ASSETKSH",
 		0xb3: "OP_ASSETKUSD", 0xb4: "OP_ASSETKRV",
-		0xa6: "OP_MINTKUSD", 0xa7: "OP_STAKEKSH",
+		0xa6: "OP_MINTKUSD", 0xa7: "OP_STAKEKSH", 0xb5: "OP_REDEEMKUSD",
 	}
 	for opcodeVal, expectedStr := range expectedStrings {
 		var data []byte
@@ -180,5 +180,5 @@ func TestOpcodeDisasm(t *testing.T) {
 }
 
 func isOpUnknown(opcodeVal int) bool {
-	return opcodeVal >= 0xb5 && opcodeVal <= 0xf9 || opcodeVal == 0xfc
+	return opcodeVal >= 0xb6 && opcodeVal <= 0xf9 || opcodeVal == 0xfc
 }
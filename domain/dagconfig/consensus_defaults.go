@@ -84,4 +84,22 @@ const (
 	defaultDeflationaryPhaseDaaScore = 15778800 - 259200
 
 	defaultMergeDepth = 3600
+
+	// defaultMinReserveRatio and defaultMaxReserveRatio bound the ratio, in percent, between the KSH reserve
+	// and the KUSD liabilities. Minting KUSD and redeeming KRV is only allowed while the ratio stays above
+	// the minimum, and staking KSH for KRV is only allowed while it stays below the maximum.
+	// For more information see the Djed paper: https://eprint.iacr.org/2021/1069.pdf
+	defaultMinReserveRatio = 400
+	defaultMaxReserveRatio = 800
+	// defaultConversionFeeBasisPoints is the fee, in basis points, kept in the reserve on every conversion
+	defaultConversionFeeBasisPoints = 100
+	// defaultKRVMinimalPrice is the price, in KSH sompi, below which the reserve never sells KRV
+	defaultKRVMinimalPrice = 1 * constants.SompiPerKash
+
+	// defaultOracleMinRecords is the number of signed price records a block has to commit to in order to set a price
+	defaultOracleMinRecords = 1
+	// defaultOracleMaxRecordAge is the max distance between the timestamps of a block and of the price records it commits to
+	defaultOracleMaxRecordAge = 5 * time.Minute
+	// defaultOracleMaxPriceDeviation is the max change, in percent, of a price relative to the one in effect at the selected parent
+	defaultOracleMaxPriceDeviation = 10
 )
@@ -188,6 +188,38 @@ type Params struct {
 	MaxBlockLevel int
 
 	MergeDepth uint64
+
+	// MinReserveRatio is the minimal ratio, in percent, between the KSH reserve and
+	// the KUSD liabilities that minting KUSD or redeeming KRV may leave behind
+	MinReserveRatio uint64
+
+	// MaxReserveRatio is the maximal ratio, in percent, between the KSH reserve and
+	// the KUSD liabilities that staking KSH for KRV may leave behind
+	MaxReserveRatio uint64
+
+	// ConversionFeeBasisPoints is the fee, in basis points, that the reserve keeps
+	// from every conversion between KSH and KUSD or KRV
+	ConversionFeeBasisPoints uint64
+
+	// KRVMinimalPrice is the minimal price, in KSH sompi, at which the reserve sells one KRV
+	KRVMinimalPrice uint64
+
+	// OraclePublicKeys are the public keys, in the format accepted by oracle.ParsePublicKey, of
+	// the price feeds whose signed price records a block may commit to. A network without
+	// oracle public keys never has an exchange rate in effect.
+	OraclePublicKeys []string
+
+	// OracleMinRecords is the minimal number of signed price records a block has to commit to
+	OracleMinRecords int
+
+	// OracleMaxRecordAge is the max distance between the timestamp of a block and the timestamps
+	// of the price records it commits to. A price stays in effect for the descendants of a block
+	// for as long as it is not older than that.
+	OracleMaxRecordAge time.Duration
+
+	// OracleMaxPriceDeviation is the max change, in percent, of any price set by a block relative
+	// to the price in effect at its selected parent
+	OracleMaxPriceDeviation uint64
 }
 
 // NormalizeRPCServerAddress returns addr with the current network default
@@ -269,6 +301,16 @@ var MainnetParams = Params{
 	// This means that any block that has a level lower or equal to genesis will be level 0.
 	MaxBlockLevel: 225,
 	MergeDepth:    defaultMergeDepth,
+
+	MinReserveRatio:          defaultMinReserveRatio,
+	MaxReserveRatio:          defaultMaxReserveRatio,
+	ConversionFeeBasisPoints: defaultConversionFeeBasisPoints,
+	KRVMinimalPrice:          defaultKRVMinimalPrice,
+
+	OraclePublicKeys:        []string{},
+	OracleMinRecords:        defaultOracleMinRecords,
+	OracleMaxRecordAge:      defaultOracleMaxRecordAge,
+	OracleMaxPriceDeviation: defaultOracleMaxPriceDeviation,
 }
 
 // TestnetParams defines the network parameters for the test Kash network.
@@ -331,6 +373,16 @@ var TestnetParams = Params{
 
 	MaxBlockLevel: 250,
 	MergeDepth:    defaultMergeDepth,
+
+	MinReserveRatio:          defaultMinReserveRatio,
+	MaxReserveRatio:          defaultMaxReserveRatio,
+	ConversionFeeBasisPoints: defaultConversionFeeBasisPoints,
+	KRVMinimalPrice:          defaultKRVMinimalPrice,
+
+	OraclePublicKeys:        []string{},
+	OracleMinRecords:        defaultOracleMinRecords,
+	OracleMaxRecordAge:      defaultOracleMaxRecordAge,
+	OracleMaxPriceDeviation: defaultOracleMaxPriceDeviation,
 }
 
 // SimnetParams defines the network parameters for the simulation test Kash
@@ -397,6 +449,16 @@ var SimnetParams = Params{
 
 	MaxBlockLevel: 250,
 	MergeDepth:    defaultMergeDepth,
+
+	MinReserveRatio:          defaultMinReserveRatio,
+	MaxReserveRatio:          defaultMaxReserveRatio,
+	ConversionFeeBasisPoints: defaultConversionFeeBasisPoints,
+	KRVMinimalPrice:          defaultKRVMinimalPrice,
+
+	OraclePublicKeys:        []string{},
+	OracleMinRecords:        defaultOracleMinRecords,
+	OracleMaxRecordAge:      defaultOracleMaxRecordAge,
+	OracleMaxPriceDeviation: defaultOracleMaxPriceDeviation,
 }
 
 // DevnetParams defines the network parameters for the development Kash network.
@@ -459,6 +521,16 @@ var DevnetParams = Params{
 
 	MaxBlockLevel: 250,
 	MergeDepth:    defaultMergeDepth,
+
+	MinReserveRatio:          defaultMinReserveRatio,
+	MaxReserveRatio:          defaultMaxReserveRatio,
+	ConversionFeeBasisPoints: defaultConversionFeeBasisPoints,
+	KRVMinimalPrice:          defaultKRVMinimalPrice,
+
+	OraclePublicKeys:        []string{},
+	OracleMinRecords:        defaultOracleMinRecords,
+	OracleMaxRecordAge:      defaultOracleMaxRecordAge,
+	OracleMaxPriceDeviation: defaultOracleMaxPriceDeviation,
 }
 
 // ErrDuplicateNet describes an error where the parameters for a Kash
@@ -11,6 +11,7 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/miningmanager"
 	"github.com/Kash-Protocol/kashd/domain/miningmanager/mempool"
+	miningmanagermodel "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
 	"github.com/Kash-Protocol/kashd/domain/prefixmanager"
 	"github.com/Kash-Protocol/kashd/domain/prefixmanager/prefix"
 	infrastructuredatabase "github.com/Kash-Protocol/kashd/infrastructure/db/database"
@@ -178,7 +179,8 @@ func (d *domain) DeleteStagingConsensus() error {
 }
 
 // New instantiates a new instance of a Domain object
-func New(consensusConfig *consensus.Config, mempoolConfig *mempool.Config, db infrastructuredatabase.Database) (Domain, error) {
+func New(consensusConfig *consensus.Config, mempoolConfig *mempool.Config, priceSource miningmanagermodel.PriceSource,
+	db infrastructuredatabase.Database) (Domain, error) {
 	err := prefixmanager.DeleteInactivePrefix(db)
 	if err != nil {
 		return nil, err
@@ -222,6 +224,7 @@ func New(consensusConfig *consensus.Config, mempoolConfig *mempool.Config, db in
 
 	// We create a consensus wrapper because the actual consensus might change
 	consensusReference := consensusreference.NewConsensusReference(&domainInstance.consensus)
-	domainInstance.miningManager = miningManagerFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+	domainInstance.miningManager = miningManagerFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig,
+		priceSource)
 	return domainInstance, nil
 }
@@ -31,7 +31,7 @@ func TestCreateStagingConsensus(t *testing.T) {
 			t.Fatalf("NewLevelDB: %+v", err)
 		}
 
-		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		domainInstance, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), nil, db)
 		if err != nil {
 			t.Fatalf("New: %+v", err)
 		}
@@ -125,7 +125,7 @@ func TestCreateStagingConsensus(t *testing.T) {
 			t.Fatalf("ValidateAndInsertBlock: %+v", err)
 		}
 
-		domainInstance2, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), db)
+		domainInstance2, err := domain.New(consensusConfig, mempool.DefaultConfig(&consensusConfig.Params), nil, db)
 		if err != nil {
 			t.Fatalf("New: %+v", err)
 		}
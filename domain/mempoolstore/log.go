@@ -0,0 +1,9 @@
+package mempoolstore
+
+import (
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+	"github.com/Kash-Protocol/kashd/util/panics"
+)
+
+var log = logger.RegisterSubSystem("MPST")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,165 @@
+package mempoolstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Kash-Protocol/kashd/domain"
+	miningmanagermodel "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+)
+
+var mempoolTransactionsBucket = database.MakeBucket([]byte("mempool-transactions"))
+
+// MempoolStore saves the transaction pool of the mempool to the database periodically and on
+// shutdown, and restores it on startup, so that mempool transactions survive node restarts
+type MempoolStore struct {
+	domain       domain.Domain
+	database     database.Database
+	saveInterval time.Duration
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// New creates a new MempoolStore that saves the mempool of the given domain every saveInterval
+func New(domain domain.Domain, database database.Database, saveInterval time.Duration) *MempoolStore {
+	return &MempoolStore{
+		domain:       domain,
+		database:     database,
+		saveInterval: saveInterval,
+		stop:         make(chan struct{}),
+	}
+}
+
+// Restore adds the saved transactions back to the mempool. Restored transactions are revalidated, and the
+// ones that expired while the node was down or are no longer valid are dropped.
+func (ms *MempoolStore) Restore() error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "MempoolStore.Restore")
+	defer onEnd()
+
+	records, err := ms.savedTransactionRecords()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	restoredCount, expiredCount, invalidCount, err := ms.domain.MiningManager().RestoreTransactions(records)
+	if err != nil {
+		return err
+	}
+	log.Infof("Restored %d mempool transactions, dropped %d expired and %d invalid ones",
+		restoredCount, expiredCount, invalidCount)
+
+	return nil
+}
+
+func (ms *MempoolStore) savedTransactionRecords() ([]*miningmanagermodel.TransactionRecord, error) {
+	cursor, err := ms.database.Cursor(mempoolTransactionsBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	records := []*miningmanagermodel.TransactionRecord{}
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		serializedRecord, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		record, err := deserializeTransactionRecord(serializedRecord)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Save replaces the saved transactions with the ones currently in the transaction pool
+func (ms *MempoolStore) Save() error {
+	records := ms.domain.MiningManager().TransactionRecords()
+
+	dbTransaction, err := ms.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	cursor, err := dbTransaction.Cursor(mempoolTransactionsBucket)
+	if err != nil {
+		return err
+	}
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			cursor.Close()
+			return err
+		}
+		err = dbTransaction.Delete(key)
+		if err != nil {
+			cursor.Close()
+			return err
+		}
+	}
+	err = cursor.Close()
+	if err != nil {
+		return err
+	}
+
+	// Records are ordered parents first, and are saved in the same order so that they can be restored in it
+	for i, record := range records {
+		serializedRecord, err := serializeTransactionRecord(record)
+		if err != nil {
+			return err
+		}
+		err = dbTransaction.Put(mempoolTransactionsBucket.Key(serializeRecordIndex(uint64(i))), serializedRecord)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = dbTransaction.Commit()
+	if err != nil {
+		return err
+	}
+	log.Debugf("Saved %d mempool transactions", len(records))
+	return nil
+}
+
+// Start starts saving the mempool every saveInterval
+func (ms *MempoolStore) Start() {
+	ms.wg.Add(1)
+	spawn("MempoolStore.Start", func() {
+		defer ms.wg.Done()
+
+		ticker := time.NewTicker(ms.saveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ms.stop:
+				return
+			case <-ticker.C:
+			}
+
+			err := ms.Save()
+			if err != nil {
+				log.Warnf("Failed to save the mempool: %s", err)
+			}
+		}
+	})
+}
+
+// Stop stops saving the mempool periodically, and saves it one last time
+func (ms *MempoolStore) Stop() error {
+	ms.stopOnce.Do(func() {
+		close(ms.stop)
+	})
+	ms.wg.Wait()
+
+	return ms.Save()
+}
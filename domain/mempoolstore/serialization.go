@@ -0,0 +1,52 @@
+package mempoolstore
+
+import (
+	"encoding/binary"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/database/serialization"
+	miningmanagermodel "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+func serializeTransactionRecord(record *miningmanagermodel.TransactionRecord) ([]byte, error) {
+	dbMempoolTransaction := &serialization.DbMempoolTransaction{
+		Transaction:     serialization.DomainTransactionToDbTransaction(record.Transaction),
+		Fee:             record.Transaction.Fee,
+		Mass:            record.Transaction.Mass,
+		IsHighPriority:  record.IsHighPriority,
+		AddedAtDAAScore: record.AddedAtDAAScore,
+	}
+	return proto.Marshal(dbMempoolTransaction)
+}
+
+func deserializeTransactionRecord(serializedRecord []byte) (*miningmanagermodel.TransactionRecord, error) {
+	var dbMempoolTransaction serialization.DbMempoolTransaction
+	err := proto.Unmarshal(serializedRecord, &dbMempoolTransaction)
+	if err != nil {
+		return nil, err
+	}
+	if dbMempoolTransaction.Transaction == nil {
+		return nil, errors.Errorf("mempool transaction record is missing its transaction")
+	}
+	transaction, err := serialization.DbTransactionToDomainTransaction(dbMempoolTransaction.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	transaction.Fee = dbMempoolTransaction.Fee
+	transaction.Mass = dbMempoolTransaction.Mass
+
+	return &miningmanagermodel.TransactionRecord{
+		Transaction:     transaction,
+		IsHighPriority:  dbMempoolTransaction.IsHighPriority,
+		AddedAtDAAScore: dbMempoolTransaction.AddedAtDAAScore,
+	}, nil
+}
+
+// serializeRecordIndex serializes the index of a record as its key, in big endian so that
+// the database iterates the records in the order they were saved in
+func serializeRecordIndex(index uint64) []byte {
+	serializedIndex := make([]byte, 8)
+	binary.BigEndian.PutUint64(serializedIndex, index)
+	return serializedIndex
+}
@@ -0,0 +1,57 @@
+package mempoolstore
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/subnetworks"
+	miningmanagermodel "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
+)
+
+func Test_serializeTransactionRecord(t *testing.T) {
+	record := &miningmanagermodel.TransactionRecord{
+		Transaction: &externalapi.DomainTransaction{
+			Version: 0,
+			Inputs: []*externalapi.DomainTransactionInput{{
+				PreviousOutpoint: externalapi.DomainOutpoint{
+					TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+					Index:         2,
+				},
+				SignatureScript: []byte{3, 4},
+				Sequence:        5,
+			}},
+			Outputs: []*externalapi.DomainTransactionOutput{{
+				Value:           6,
+				ScriptPublicKey: &externalapi.ScriptPublicKey{Script: []byte{7, 8}, Version: 0},
+			}},
+			SubnetworkID: subnetworks.SubnetworkIDNative,
+			Payload:      []byte{},
+			Fee:          9,
+			Mass:         10,
+			Type:         externalapi.TransferKSH,
+		},
+		IsHighPriority:  true,
+		AddedAtDAAScore: 11,
+	}
+
+	serializedRecord, err := serializeTransactionRecord(record)
+	if err != nil {
+		t.Fatalf("serializeTransactionRecord: %+v", err)
+	}
+	result, err := deserializeTransactionRecord(serializedRecord)
+	if err != nil {
+		t.Fatalf("deserializeTransactionRecord: %+v", err)
+	}
+
+	if !result.Transaction.Equal(record.Transaction) {
+		t.Fatalf("Expected transaction %v but got %v", record.Transaction, result.Transaction)
+	}
+	if result.Transaction.Fee != record.Transaction.Fee || result.Transaction.Mass != record.Transaction.Mass {
+		t.Fatalf("Expected a fee of %d and a mass of %d but got %d and %d", record.Transaction.Fee,
+			record.Transaction.Mass, result.Transaction.Fee, result.Transaction.Mass)
+	}
+	if result.IsHighPriority != record.IsHighPriority || result.AddedAtDAAScore != record.AddedAtDAAScore {
+		t.Fatalf("Expected %t and %d but got %t and %d", record.IsHighPriority, record.AddedAtDAAScore,
+			result.IsHighPriority, result.AddedAtDAAScore)
+	}
+}
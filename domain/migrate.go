@@ -111,6 +111,23 @@ func syncConsensuses(syncer, syncee externalapi.Consensus) error {
 			})
 		}
 
+		// Only the reserve state of the pruning point is committed to
+		if blockHash.Equal(pruningPointAndItsAnticone[0]) {
+			reserveState, err := syncer.TrustedReserveState(blockHash)
+			if err != nil {
+				return err
+			}
+			blockWithTrustedData.ReserveState = reserveState
+		}
+
+		oraclePrices, found, err := syncer.TrustedOraclePrices(blockHash)
+		if err != nil {
+			return err
+		}
+		if found {
+			blockWithTrustedData.OraclePrices = oraclePrices
+		}
+
 		err = syncee.ValidateAndInsertBlockWithTrustedData(blockWithTrustedData, false)
 		if err != nil {
 			return err
@@ -2,12 +2,15 @@ package blocktemplatebuilder
 
 import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/processes/coinbasemanager"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/merkle"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/transactionhelper"
 	"github.com/Kash-Protocol/kashd/domain/consensusreference"
 	"github.com/Kash-Protocol/kashd/util/mstime"
 	"math"
 	"sort"
+	"sync"
 
 	"github.com/Kash-Protocol/kashd/util/difficulty"
 
@@ -34,17 +37,24 @@ type candidateTx struct {
 type blockTemplateBuilder struct {
 	consensusReference consensusreference.ConsensusReference
 	mempool            miningmanagerapi.Mempool
+	priceSource        miningmanagerapi.PriceSource
 	policy             policy
 
 	coinbasePayloadScriptPublicKeyMaxLength uint8
+
+	lastBlockTemplateFillLock sync.Mutex
+	lastBlockTemplateFill     *miningmanagerapi.BlockTemplateFill
 }
 
-// New creates a new blockTemplateBuilder
+// New creates a new blockTemplateBuilder.
+// priceSource may be nil, in which case the built blocks commit to no price record.
 func New(consensusReference consensusreference.ConsensusReference, mempool miningmanagerapi.Mempool,
-	blockMaxMass uint64, coinbasePayloadScriptPublicKeyMaxLength uint8) miningmanagerapi.BlockTemplateBuilder {
+	priceSource miningmanagerapi.PriceSource, blockMaxMass uint64,
+	coinbasePayloadScriptPublicKeyMaxLength uint8) miningmanagerapi.BlockTemplateBuilder {
 	return &blockTemplateBuilder{
 		consensusReference: consensusReference,
 		mempool:            mempool,
+		priceSource:        priceSource,
 		policy:             policy{BlockMaxMass: blockMaxMass},
 
 		coinbasePayloadScriptPublicKeyMaxLength: coinbasePayloadScriptPublicKeyMaxLength,
@@ -133,6 +143,8 @@ func (btb *blockTemplateBuilder) BuildBlockTemplate(
 		})
 	}
 
+	btb.applyPackageValues(candidateTxs)
+
 	// Sort the candidate txs by subnetworkID.
 	sort.Slice(candidateTxs, func(i, j int) bool {
 		return subnetworks.Less(candidateTxs[i].SubnetworkID, candidateTxs[j].SubnetworkID)
@@ -142,7 +154,12 @@ func (btb *blockTemplateBuilder) BuildBlockTemplate(
 		len(candidateTxs))
 
 	blockTxs := btb.selectTransactions(candidateTxs)
-	blockTemplate, err := btb.consensusReference.Consensus().BuildBlockTemplate(coinbaseData, blockTxs.selectedTxs)
+	btb.setLastBlockTemplateFill(blockTxs)
+	coinbaseDataWithOracleData, err := btb.coinbaseDataWithOracleData(coinbaseData)
+	if err != nil {
+		return nil, err
+	}
+	blockTemplate, err := btb.consensusReference.Consensus().BuildBlockTemplate(coinbaseDataWithOracleData, blockTxs.selectedTxs)
 
 	invalidTxsErr := ruleerrors.ErrInvalidTransactionsInNewBlock{}
 	if errors.As(err, &invalidTxsErr) {
@@ -165,9 +182,66 @@ func (btb *blockTemplateBuilder) BuildBlockTemplate(
 	log.Debugf("Created new block template (%d transactions, %d in fees, %d mass, target difficulty %064x)",
 		len(blockTemplate.Block.Transactions), blockTxs.totalFees, blockTxs.totalMass, difficulty.CompactToBig(blockTemplate.Block.Header.Bits()))
 
+	// The oracle data is not part of what the miner requested, and is kept as is by ModifyBlockTemplate
+	blockTemplate.CoinbaseData = coinbaseData
 	return blockTemplate, nil
 }
 
+// setLastBlockTemplateFill records how much of the block mass the selected transactions take
+func (btb *blockTemplateBuilder) setLastBlockTemplateFill(blockTxs selectedTransactions) {
+	minimumFeeRate := 0.0
+	for i := range blockTxs.selectedTxs {
+		feeRate := float64(blockTxs.txFees[i]) / float64(blockTxs.txMasses[i])
+		if i == 0 || feeRate < minimumFeeRate {
+			minimumFeeRate = feeRate
+		}
+	}
+
+	btb.lastBlockTemplateFillLock.Lock()
+	defer btb.lastBlockTemplateFillLock.Unlock()
+
+	btb.lastBlockTemplateFill = &miningmanagerapi.BlockTemplateFill{
+		Mass:           blockTxs.totalMass,
+		MaxMass:        btb.policy.BlockMaxMass,
+		MinimumFeeRate: minimumFeeRate,
+	}
+}
+
+// LastBlockTemplateFill returns how much of the block mass the transactions of the
+// last built block template take. ok is false if no block template was built yet.
+func (btb *blockTemplateBuilder) LastBlockTemplateFill() (fill *miningmanagerapi.BlockTemplateFill, ok bool) {
+	btb.lastBlockTemplateFillLock.Lock()
+	defer btb.lastBlockTemplateFillLock.Unlock()
+
+	if btb.lastBlockTemplateFill == nil {
+		return nil, false
+	}
+	fillCopy := *btb.lastBlockTemplateFill
+	return &fillCopy, true
+}
+
+// coinbaseDataWithOracleData returns a copy of coinbaseData that commits to the
+// latest signed price records of the price source
+func (btb *blockTemplateBuilder) coinbaseDataWithOracleData(
+	coinbaseData *consensusexternalapi.DomainCoinbaseData) (*consensusexternalapi.DomainCoinbaseData, error) {
+
+	if btb.priceSource == nil {
+		return coinbaseData, nil
+	}
+	signedPriceRecords := btb.priceSource.LatestSignedPriceRecords()
+	if len(signedPriceRecords) == 0 {
+		return coinbaseData, nil
+	}
+
+	oracleData, err := oracle.SerializeSignedPriceRecords(signedPriceRecords)
+	if err != nil {
+		return nil, err
+	}
+	coinbaseDataWithOracleData := coinbaseData.Clone()
+	coinbaseDataWithOracleData.OracleData = oracleData
+	return coinbaseDataWithOracleData, nil
+}
+
 // ModifyBlockTemplate modifies an existing block template to the requested coinbase data and updates the timestamp
 func (btb *blockTemplateBuilder) ModifyBlockTemplate(newCoinbaseData *consensusexternalapi.DomainCoinbaseData,
 	blockTemplateToModify *consensusexternalapi.DomainBlockTemplate) (*consensusexternalapi.DomainBlockTemplate, error) {
@@ -201,18 +275,45 @@ func (btb *blockTemplateBuilder) ModifyBlockTemplate(newCoinbaseData *consensuse
 	return blockTemplateToModify, nil
 }
 
+// applyPackageValues raises the value of every candidate transaction to the value of the best
+// mempool transaction package it is a ready transaction of.
+// Transactions can't be mined in the same block as the transactions whose outputs they spend,
+// so a package is mined by first mining its ready transactions, and valuing them by the aggregate
+// fee rate of the package lets a child that pays a high fee pull in its low-fee parent.
+func (btb *blockTemplateBuilder) applyPackageValues(candidateTxs []*candidateTx) {
+	candidateTxsByID := make(map[consensusexternalapi.DomainTransactionID]*candidateTx, len(candidateTxs))
+	for _, candidate := range candidateTxs {
+		candidateTxsByID[*consensushashing.TransactionID(candidate.DomainTransaction)] = candidate
+	}
+
+	for _, transactionPackage := range btb.mempool.TransactionPackages() {
+		packageValue := btb.calcValue(transactionPackage.Fee, transactionPackage.Mass)
+		for _, readyTransactionID := range transactionPackage.ReadyTransactionIDs {
+			candidate, ok := candidateTxsByID[readyTransactionID]
+			if ok && packageValue > candidate.txValue {
+				candidate.txValue = packageValue
+			}
+		}
+	}
+}
+
 // calcTxValue calculates a value to be used in transaction selection.
 // The higher the number the more likely it is that the transaction will be
 // included in the block.
 func (btb *blockTemplateBuilder) calcTxValue(tx *consensusexternalapi.DomainTransaction) float64 {
-	massLimit := btb.policy.BlockMaxMass
+	if subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
+		return btb.calcValue(tx.Fee, tx.Mass)
+	}
 
+	massLimit := btb.policy.BlockMaxMass
 	mass := tx.Mass
 	fee := tx.Fee
-	if subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
-		return float64(fee) / (float64(mass) / float64(massLimit))
-	}
 	// TODO: Replace with real gas once implemented
 	gasLimit := uint64(math.MaxUint64)
 	return float64(fee) / (float64(mass)/float64(massLimit) + float64(tx.Gas)/float64(gasLimit))
 }
+
+// calcValue calculates the selection value of native transactions, or packages of them, with the given fee and mass
+func (btb *blockTemplateBuilder) calcValue(fee uint64, mass uint64) float64 {
+	return float64(fee) / (float64(mass) / float64(btb.policy.BlockMaxMass))
+}
@@ -0,0 +1,68 @@
+package blocktemplatebuilder
+
+import (
+	"testing"
+
+	consensusexternalapi "github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/subnetworks"
+	miningmanagerapi "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
+)
+
+type fakeMempool struct {
+	miningmanagerapi.Mempool
+	transactionPackages []*miningmanagerapi.TransactionPackage
+}
+
+func (fm *fakeMempool) TransactionPackages() []*miningmanagerapi.TransactionPackage {
+	return fm.transactionPackages
+}
+
+// TestSelectTransactionsWithPackages verifies that a low-fee parent whose child pays a high fee
+// is selected over a transaction that pays more than the parent alone.
+func TestSelectTransactionsWithPackages(t *testing.T) {
+	const transactionMass = 1000
+	newTransaction := func(fee uint64, lockTime uint64) *consensusexternalapi.DomainTransaction {
+		return &consensusexternalapi.DomainTransaction{
+			SubnetworkID: subnetworks.SubnetworkIDNative,
+			LockTime:     lockTime,
+			Fee:          fee,
+			Mass:         transactionMass,
+		}
+	}
+	parent := newTransaction(100, 1)
+	child := newTransaction(100_000_000, 2)
+	competitor := newTransaction(10_000, 3)
+
+	mempool := &fakeMempool{}
+	// The block fits a single transaction, so only the most valuable candidate is selected
+	btb := &blockTemplateBuilder{
+		mempool: mempool,
+		policy:  policy{BlockMaxMass: transactionMass},
+	}
+	selectTransactions := func() []*consensusexternalapi.DomainTransaction {
+		candidateTxs := []*candidateTx{
+			{DomainTransaction: parent, txValue: btb.calcTxValue(parent)},
+			{DomainTransaction: competitor, txValue: btb.calcTxValue(competitor)},
+		}
+		btb.applyPackageValues(candidateTxs)
+		return btb.selectTransactions(candidateTxs).selectedTxs
+	}
+
+	selectedTxs := selectTransactions()
+	if len(selectedTxs) != 1 || selectedTxs[0] != competitor {
+		t.Fatalf("Expected only the competitor to be selected without packages")
+	}
+
+	mempool.transactionPackages = []*miningmanagerapi.TransactionPackage{
+		{
+			ReadyTransactionIDs: []consensusexternalapi.DomainTransactionID{*consensushashing.TransactionID(parent)},
+			Fee:                 parent.Fee + child.Fee,
+			Mass:                parent.Mass + child.Mass,
+		},
+	}
+	selectedTxs = selectTransactions()
+	if len(selectedTxs) != 1 || selectedTxs[0] != parent {
+		t.Fatalf("Expected only the parent to be selected along with its package")
+	}
+}
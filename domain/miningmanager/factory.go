@@ -5,23 +5,27 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/dagconfig"
 	"github.com/Kash-Protocol/kashd/domain/miningmanager/blocktemplatebuilder"
 	mempoolpkg "github.com/Kash-Protocol/kashd/domain/miningmanager/mempool"
+	miningmanagermodel "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
 	"sync"
 	"time"
 )
 
 // Factory instantiates new mining managers
 type Factory interface {
-	NewMiningManager(consensus consensusreference.ConsensusReference, params *dagconfig.Params, mempoolConfig *mempoolpkg.Config) MiningManager
+	NewMiningManager(consensus consensusreference.ConsensusReference, params *dagconfig.Params, mempoolConfig *mempoolpkg.Config,
+		priceSource miningmanagermodel.PriceSource) MiningManager
 }
 
 type factory struct{}
 
-// NewMiningManager instantiate a new mining manager
+// NewMiningManager instantiate a new mining manager.
+// priceSource may be nil, in which case the block templates commit to no price record.
 func (f *factory) NewMiningManager(consensusReference consensusreference.ConsensusReference, params *dagconfig.Params,
-	mempoolConfig *mempoolpkg.Config) MiningManager {
+	mempoolConfig *mempoolpkg.Config, priceSource miningmanagermodel.PriceSource) MiningManager {
 
 	mempool := mempoolpkg.New(mempoolConfig, consensusReference)
-	blockTemplateBuilder := blocktemplatebuilder.New(consensusReference, mempool, params.MaxBlockMass, params.CoinbasePayloadScriptPublicKeyMaxLength)
+	blockTemplateBuilder := blocktemplatebuilder.New(consensusReference, mempool, priceSource, params.MaxBlockMass,
+		params.CoinbasePayloadScriptPublicKeyMaxLength)
 
 	return &miningManager{
 		consensusReference:   consensusReference,
@@ -29,6 +33,7 @@ func (f *factory) NewMiningManager(consensusReference consensusreference.Consens
 		blockTemplateBuilder: blockTemplateBuilder,
 		cachingTime:          time.Time{},
 		cacheLock:            &sync.Mutex{},
+		blockMaxMass:         params.MaxBlockMass,
 	}
 }
 
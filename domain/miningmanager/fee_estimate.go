@@ -0,0 +1,78 @@
+package miningmanager
+
+import (
+	miningmanagermodel "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
+)
+
+const (
+	// priorityFeeRateBlocks, normalFeeRateBlocks and lowFeeRateBlocks are the number of blocks
+	// a transaction paying the respective fee rate is expected to be mined within
+	priorityFeeRateBlocks = 1
+	normalFeeRateBlocks   = 10
+	lowFeeRateBlocks      = 60
+
+	// fullBlockTemplateMassRatio is the ratio of the block mass above which a block template
+	// is considered full, meaning that transactions compete over getting into it
+	fullBlockTemplateMassRatio = 0.9
+)
+
+// FeeEstimate holds the fee rates, in sompi per gram of mass, that transactions are
+// expected to need in order to be mined with low, normal and high priority
+type FeeEstimate struct {
+	LowFeeRate      float64
+	NormalFeeRate   float64
+	PriorityFeeRate float64
+}
+
+// GetFeeEstimate estimates the fee rates transactions need from the transactions in
+// the mempool, from how full the last built block template was and from the minimum
+// fee rate the mempool relays
+func (mm *miningManager) GetFeeEstimate() *FeeEstimate {
+	// lastBlockTemplateFill is nil if no block template was built yet
+	lastBlockTemplateFill, _ := mm.blockTemplateBuilder.LastBlockTemplateFill()
+	return estimateFees(mm.mempool.TransactionFeeRates(), mm.blockMaxMass, mm.mempool.MinimumRelayFeeRate(),
+		lastBlockTemplateFill)
+}
+
+// estimateFees estimates the fee rates transactions need in order to be mined within
+// priorityFeeRateBlocks, normalFeeRateBlocks and lowFeeRateBlocks blocks, assuming
+// blocks take the mempool transactions, which are ordered from the highest fee rate,
+// in order. lastBlockTemplateFill may be nil.
+func estimateFees(transactionFeeRates []miningmanagermodel.TransactionFeeRate, blockMaxMass uint64,
+	minimumFeeRate float64, lastBlockTemplateFill *miningmanagermodel.BlockTemplateFill) *FeeEstimate {
+
+	feeRateWithin := func(blocks uint64) float64 {
+		massAhead := uint64(0)
+		for _, transactionFeeRate := range transactionFeeRates {
+			massAhead += transactionFeeRate.Mass
+			if massAhead > blocks*blockMaxMass {
+				if transactionFeeRate.FeeRate > minimumFeeRate {
+					return transactionFeeRate.FeeRate
+				}
+				break
+			}
+		}
+		return minimumFeeRate
+	}
+
+	feeEstimate := &FeeEstimate{
+		LowFeeRate:      feeRateWithin(lowFeeRateBlocks),
+		NormalFeeRate:   feeRateWithin(normalFeeRateBlocks),
+		PriorityFeeRate: feeRateWithin(priorityFeeRateBlocks),
+	}
+
+	// Block templates don't select transactions strictly by fee rate, so if the last one was
+	// full, a transaction paying less than any transaction it took might not get into the next
+	// block even if the mempool doesn't fill a block by itself
+	if lastBlockTemplateFill != nil && lastBlockTemplateFill.MaxMass > 0 &&
+		float64(lastBlockTemplateFill.Mass) >= fullBlockTemplateMassRatio*float64(lastBlockTemplateFill.MaxMass) &&
+		lastBlockTemplateFill.MinimumFeeRate > feeEstimate.PriorityFeeRate {
+
+		feeEstimate.PriorityFeeRate = lastBlockTemplateFill.MinimumFeeRate
+	}
+	if feeEstimate.NormalFeeRate > feeEstimate.PriorityFeeRate {
+		feeEstimate.NormalFeeRate = feeEstimate.PriorityFeeRate
+	}
+
+	return feeEstimate
+}
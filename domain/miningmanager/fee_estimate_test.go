@@ -0,0 +1,79 @@
+package miningmanager
+
+import (
+	"testing"
+
+	miningmanagermodel "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
+)
+
+func TestEstimateFees(t *testing.T) {
+	const blockMaxMass = 1000
+	const minimumFeeRate = 1.0
+
+	// transactionFeeRates returns count transactions of 100 grams each, with fee
+	// rates descending from count+minimumFeeRate to minimumFeeRate+1, so that the
+	// first transaction left out of n blocks has a fee rate of count-n*10+minimumFeeRate
+	transactionFeeRates := func(count int) []miningmanagermodel.TransactionFeeRate {
+		feeRates := make([]miningmanagermodel.TransactionFeeRate, count)
+		for i := range feeRates {
+			feeRates[i] = miningmanagermodel.TransactionFeeRate{
+				FeeRate: float64(count-i) + minimumFeeRate,
+				Mass:    100,
+			}
+		}
+		return feeRates
+	}
+
+	tests := []struct {
+		name                  string
+		transactionFeeRates   []miningmanagermodel.TransactionFeeRate
+		lastBlockTemplateFill *miningmanagermodel.BlockTemplateFill
+		expected              FeeEstimate
+	}{
+		{
+			name:     "empty mempool",
+			expected: FeeEstimate{LowFeeRate: 1, NormalFeeRate: 1, PriorityFeeRate: 1},
+		},
+		{
+			name:                "less than a block",
+			transactionFeeRates: transactionFeeRates(10),
+			expected:            FeeEstimate{LowFeeRate: 1, NormalFeeRate: 1, PriorityFeeRate: 1},
+		},
+		{
+			// The 11th transaction is the first one that doesn't fit in the next block
+			name:                "more than a block",
+			transactionFeeRates: transactionFeeRates(20),
+			expected:            FeeEstimate{LowFeeRate: 1, NormalFeeRate: 1, PriorityFeeRate: 11},
+		},
+		{
+			name:                "more than normal blocks",
+			transactionFeeRates: transactionFeeRates(normalFeeRateBlocks*10 + 5),
+			expected:            FeeEstimate{LowFeeRate: 1, NormalFeeRate: 6, PriorityFeeRate: normalFeeRateBlocks*10 - 4},
+		},
+		{
+			name:                "more than low blocks",
+			transactionFeeRates: transactionFeeRates(lowFeeRateBlocks*10 + 5),
+			expected: FeeEstimate{LowFeeRate: 6, NormalFeeRate: lowFeeRateBlocks*10 - normalFeeRateBlocks*10 + 6,
+				PriorityFeeRate: lowFeeRateBlocks*10 - 4},
+		},
+		{
+			name:                  "full block template",
+			transactionFeeRates:   transactionFeeRates(5),
+			lastBlockTemplateFill: &miningmanagermodel.BlockTemplateFill{Mass: 950, MaxMass: blockMaxMass, MinimumFeeRate: 3},
+			expected:              FeeEstimate{LowFeeRate: 1, NormalFeeRate: 1, PriorityFeeRate: 3},
+		},
+		{
+			name:                  "partially full block template",
+			transactionFeeRates:   transactionFeeRates(5),
+			lastBlockTemplateFill: &miningmanagermodel.BlockTemplateFill{Mass: 500, MaxMass: blockMaxMass, MinimumFeeRate: 3},
+			expected:              FeeEstimate{LowFeeRate: 1, NormalFeeRate: 1, PriorityFeeRate: 1},
+		},
+	}
+
+	for _, test := range tests {
+		feeEstimate := estimateFees(test.transactionFeeRates, blockMaxMass, minimumFeeRate, test.lastBlockTemplateFill)
+		if *feeEstimate != test.expected {
+			t.Errorf("%s: expected fee estimate %+v but got %+v", test.name, test.expected, *feeEstimate)
+		}
+	}
+}
@@ -227,3 +227,52 @@ func (mp *mempool) RemoveTransaction(transactionID *externalapi.DomainTransactio
 
 	return mp.removeTransaction(transactionID, removeRedeemers)
 }
+
+// TransactionFeeRates returns the fee rates and masses of the transactions in the
+// transaction pool, ordered from the highest fee rate to the lowest
+func (mp *mempool) TransactionFeeRates() []miningmanagermodel.TransactionFeeRate {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	transactionsOrderedByFeeRate := &mp.transactionsPool.transactionsOrderedByFeeRate
+	transactionFeeRates := make([]miningmanagermodel.TransactionFeeRate, transactionsOrderedByFeeRate.Len())
+	for i := range transactionFeeRates {
+		// transactionsOrderedByFeeRate is ordered from the lowest fee rate to the highest
+		transaction := transactionsOrderedByFeeRate.GetByIndex(len(transactionFeeRates) - 1 - i).Transaction()
+		transactionFeeRates[i] = miningmanagermodel.TransactionFeeRate{
+			FeeRate: float64(transaction.Fee) / float64(transaction.Mass),
+			Mass:    transaction.Mass,
+		}
+	}
+	return transactionFeeRates
+}
+
+// MinimumRelayFeeRate returns the fee rate, in sompi per gram, required for transactions
+// to be accepted into the mempool and relayed
+func (mp *mempool) MinimumRelayFeeRate() float64 {
+	// MinimumRelayTransactionFee is in sompi/kg
+	return float64(mp.config.MinimumRelayTransactionFee) / 1000
+}
+
+func (mp *mempool) TransactionPackages() []*miningmanagermodel.TransactionPackage {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	return mp.transactionsPool.transactionPackages()
+}
+
+func (mp *mempool) TransactionRecords() []*miningmanagermodel.TransactionRecord {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	return mp.transactionRecords()
+}
+
+func (mp *mempool) RestoreTransactions(records []*miningmanagermodel.TransactionRecord) (
+	restoredCount int, expiredCount int, invalidCount int, err error) {
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return mp.restoreTransactions(records)
+}
@@ -1,12 +1,8 @@
 package mempool
 
 import (
-	"fmt"
-
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/constants"
 
-	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
-
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/utxo"
 	"github.com/Kash-Protocol/kashd/domain/miningmanager/mempool/model"
@@ -65,18 +61,21 @@ func (mpus *mempoolUTXOSet) removeTransaction(transaction *model.MempoolTransact
 	}
 }
 
-func (mpus *mempoolUTXOSet) checkDoubleSpends(transaction *externalapi.DomainTransaction) error {
-	outpoint := externalapi.DomainOutpoint{TransactionID: *consensushashing.TransactionID(transaction)}
-
-	for i, input := range transaction.Inputs {
-		outpoint.Index = uint32(i)
-
-		if existingTransaction, exists := mpus.transactionByPreviousOutpoint[input.PreviousOutpoint]; exists {
-			str := fmt.Sprintf("output %s already spent by transaction %s in the memory pool",
-				input.PreviousOutpoint, existingTransaction.TransactionID())
-			return transactionRuleError(RejectDuplicate, str)
+// conflictingTransactions returns the transactions in the mempool that spend any of the outpoints transaction spends
+func (mpus *mempoolUTXOSet) conflictingTransactions(transaction *externalapi.DomainTransaction) []*model.MempoolTransaction {
+	var conflictingTransactions []*model.MempoolTransaction
+	conflictingTransactionIDs := make(map[externalapi.DomainTransactionID]struct{})
+	for _, input := range transaction.Inputs {
+		existingTransaction, exists := mpus.transactionByPreviousOutpoint[input.PreviousOutpoint]
+		if !exists {
+			continue
+		}
+		if _, ok := conflictingTransactionIDs[*existingTransaction.TransactionID()]; ok {
+			continue
 		}
+		conflictingTransactionIDs[*existingTransaction.TransactionID()] = struct{}{}
+		conflictingTransactions = append(conflictingTransactions, existingTransaction)
 	}
 
-	return nil
+	return conflictingTransactions
 }
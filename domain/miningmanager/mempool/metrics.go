@@ -0,0 +1,14 @@
+package mempool
+
+import (
+	"github.com/Kash-Protocol/kashd/infrastructure/metrics"
+)
+
+var (
+	transactionCount = metrics.NewGauge("kashd_mempool_transactions",
+		"The number of transactions in the mempool, not including orphans")
+	transactionsMass = metrics.NewGauge("kashd_mempool_mass",
+		"The total mass of the transactions in the mempool, not including orphans")
+	orphanCount = metrics.NewGauge("kashd_mempool_orphans",
+		"The number of transactions in the orphan pool")
+)
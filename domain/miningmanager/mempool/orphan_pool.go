@@ -127,6 +127,7 @@ func (op *orphansPool) addOrphan(transaction *externalapi.DomainTransaction, isH
 	orphanTransaction := model.NewOrphanTransaction(transaction, isHighPriority, virtualDAAScore)
 
 	op.allOrphans[*orphanTransaction.TransactionID()] = orphanTransaction
+	orphanCount.Set(float64(len(op.allOrphans)))
 	for _, input := range transaction.Inputs {
 		op.orphansByPreviousOutpoint[input.PreviousOutpoint] = orphanTransaction
 	}
@@ -234,6 +235,7 @@ func (op *orphansPool) removeOrphan(orphanTransactionID *externalapi.DomainTrans
 	}
 
 	delete(op.allOrphans, *orphanTransactionID)
+	orphanCount.Set(float64(len(op.allOrphans)))
 
 	for i, input := range orphanTransaction.Transaction().Inputs {
 		if _, ok := op.orphansByPreviousOutpoint[input.PreviousOutpoint]; !ok {
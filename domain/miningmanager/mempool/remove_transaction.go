@@ -37,6 +37,11 @@ func (mp *mempool) removeTransaction(transactionID *externalapi.DomainTransactio
 		if err != nil {
 			return err
 		}
+	} else {
+		// The removed transaction no longer belongs to the packages of the redeemers that remain in the pool
+		for _, redeemer := range redeemers {
+			mp.transactionsPool.updatePackage(redeemer)
+		}
 	}
 
 	return nil
@@ -0,0 +1,89 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/miningmanager/mempool/model"
+)
+
+// checkReplaceByFee checks whether transaction may replace the mempool transactions it double spends,
+// and returns all the transactions the replacement evicts: the double spent transactions and their redeemers.
+//
+// A replacement must:
+// 1. Not evict more than MaximumReplacedTransactionCount transactions
+// 2. Not spend the outputs of any transaction it evicts
+// 3. Pay a higher fee rate than every transaction it double spends
+// 4. Pay at least the fees of all the transactions it evicts, plus the minimum relay fee for its own mass
+func (mp *mempool) checkReplaceByFee(transaction *externalapi.DomainTransaction,
+	parentsInPool model.IDToTransactionMap, conflictingTransactions []*model.MempoolTransaction) (
+	evictedTransactions model.IDToTransactionMap, err error) {
+
+	transactionID := consensushashing.TransactionID(transaction)
+	rejectReplacement := func(rejectCode RejectCode, reason string) error {
+		str := fmt.Sprintf("transaction %s spends outputs already spent by transaction %s in the memory pool, "+
+			"and cannot replace it: %s", transactionID, conflictingTransactions[0].TransactionID(), reason)
+		return transactionRuleError(rejectCode, str)
+	}
+
+	evictedTransactions = model.IDToTransactionMap{}
+	for _, conflictingTransaction := range conflictingTransactions {
+		evictedTransactions[*conflictingTransaction.TransactionID()] = conflictingTransaction
+		for _, redeemer := range mp.transactionsPool.getRedeemers(conflictingTransaction) {
+			evictedTransactions[*redeemer.TransactionID()] = redeemer
+		}
+	}
+	if uint64(len(evictedTransactions)) > mp.config.MaximumReplacedTransactionCount {
+		return nil, rejectReplacement(RejectDuplicate, fmt.Sprintf(
+			"it would evict %d transactions, while at most %d may be replaced",
+			len(evictedTransactions), mp.config.MaximumReplacedTransactionCount))
+	}
+
+	for parentID := range parentsInPool {
+		if _, ok := evictedTransactions[parentID]; ok {
+			return nil, rejectReplacement(RejectInvalid,
+				fmt.Sprintf("it spends outputs of transaction %s which it evicts", parentID))
+		}
+	}
+
+	feeRate := float64(transaction.Fee) / float64(transaction.Mass)
+	for _, conflictingTransaction := range conflictingTransactions {
+		conflictingFeeRate := float64(conflictingTransaction.Transaction().Fee) /
+			float64(conflictingTransaction.Transaction().Mass)
+		if feeRate <= conflictingFeeRate {
+			return nil, rejectReplacement(RejectInsufficientFee, fmt.Sprintf(
+				"its fee rate %f is not higher than the fee rate %f of transaction %s",
+				feeRate, conflictingFeeRate, conflictingTransaction.TransactionID()))
+		}
+	}
+
+	evictedFees := uint64(0)
+	for _, evictedTransaction := range evictedTransactions {
+		evictedFees += evictedTransaction.Transaction().Fee
+	}
+	minimumFee := evictedFees + mp.minimumRequiredTransactionRelayFee(transaction.Mass)
+	if transaction.Fee < minimumFee {
+		return nil, rejectReplacement(RejectInsufficientFee, fmt.Sprintf(
+			"its fee %d is lower than %d, the fees of the %d transactions it evicts plus its minimum relay fee",
+			transaction.Fee, minimumFee, len(evictedTransactions)))
+	}
+
+	return evictedTransactions, nil
+}
+
+// replaceTransactions removes the given double spent transactions from the mempool, along with their redeemers,
+// to make room for the transaction replacing them
+func (mp *mempool) replaceTransactions(replacingTransactionID *externalapi.DomainTransactionID,
+	conflictingTransactions []*model.MempoolTransaction, evictedTransactionCount int) error {
+
+	log.Debugf("Transaction %s replaces %d transactions in the mempool",
+		replacingTransactionID, evictedTransactionCount)
+	for _, conflictingTransaction := range conflictingTransactions {
+		err := mp.removeTransaction(conflictingTransaction.TransactionID(), true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
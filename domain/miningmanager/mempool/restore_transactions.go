@@ -0,0 +1,78 @@
+package mempool
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
+)
+
+// transactionRecords returns records of all the transactions in the transaction pool, parents before their children
+func (mp *mempool) transactionRecords() []*miningmanagermodel.TransactionRecord {
+	sortedTransactions := topologicallySortedTransactions(mp.transactionsPool.allTransactions)
+	records := make([]*miningmanagermodel.TransactionRecord, len(sortedTransactions))
+	for i, mempoolTransaction := range sortedTransactions {
+		records[i] = &miningmanagermodel.TransactionRecord{
+			Transaction:     mempoolTransaction.Transaction().Clone(), //this pointer leaves the mempool, hence we clone.
+			IsHighPriority:  mempoolTransaction.IsHighPriority(),
+			AddedAtDAAScore: mempoolTransaction.AddedAtDAAScore(),
+		}
+	}
+	return records
+}
+
+// restoreTransactions adds the recorded transactions back to the transaction pool, and then revalidates them
+// the same way high priority transactions are revalidated. Records must be ordered parents first.
+// Non high priority transactions that expired since they were recorded are dropped without being added,
+// as are transactions that are already in the mempool or that double spend it.
+func (mp *mempool) restoreTransactions(records []*miningmanagermodel.TransactionRecord) (
+	restoredCount int, expiredCount int, invalidCount int, err error) {
+
+	virtualDAAScore, err := mp.consensusReference.Consensus().GetVirtualDAAScore()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	addedTransactions := model.IDToTransactionMap{}
+	for _, record := range records {
+		transaction := record.Transaction
+		transactionID := consensushashing.TransactionID(transaction)
+
+		if !record.IsHighPriority && virtualDAAScore > record.AddedAtDAAScore &&
+			virtualDAAScore-record.AddedAtDAAScore > mp.config.TransactionExpireIntervalDAAScore {
+
+			log.Debugf("Not restoring transaction %s, because it expired", transactionID)
+			expiredCount++
+			continue
+		}
+		if _, ok := mp.transactionsPool.allTransactions[*transactionID]; ok ||
+			len(mp.mempoolUTXOSet.conflictingTransactions(transaction)) > 0 ||
+			transaction.Fee == 0 || transaction.Mass == 0 {
+
+			log.Debugf("Not restoring transaction %s, because it is already in the mempool or conflicts with it", transactionID)
+			invalidCount++
+			continue
+		}
+
+		parentsInPool := mp.transactionsPool.getParentTransactionsInPool(transaction)
+		mempoolTransaction := model.NewMempoolTransaction(
+			transaction, parentsInPool, record.IsHighPriority, record.AddedAtDAAScore)
+		err := mp.transactionsPool.addMempoolTransaction(mempoolTransaction)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		addedTransactions[*transactionID] = mempoolTransaction
+	}
+
+	validTransactions, err := mp.revalidateTransactions(addedTransactions)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	invalidCount += len(addedTransactions) - len(validTransactions)
+
+	err = mp.transactionsPool.limitTransactionCount()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return len(validTransactions), expiredCount, invalidCount, nil
+}
@@ -4,9 +4,36 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/miningmanager/mempool/model"
 	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+	"github.com/pkg/errors"
 )
 
 func (mp *mempool) revalidateHighPriorityTransactions() ([]*externalapi.DomainTransaction, error) {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "revalidateHighPriorityTransactions")
+	defer onEnd()
+
+	return mp.revalidateTransactions(mp.transactionsPool.highPriorityTransactions)
+}
+
+// revalidateTransactions revalidates the given mempool transactions, removing the ones that are no longer valid,
+// and returns clones of the valid ones
+func (mp *mempool) revalidateTransactions(transactions model.IDToTransactionMap) ([]*externalapi.DomainTransaction, error) {
+	validTransactions := []*externalapi.DomainTransaction{}
+	for _, transaction := range topologicallySortedTransactions(transactions) {
+		isValid, err := mp.revalidateTransaction(transaction)
+		if err != nil {
+			return nil, err
+		}
+		if isValid {
+			validTransactions = append(validTransactions, transaction.Transaction().Clone())
+		}
+	}
+
+	return validTransactions, nil
+}
+
+// topologicallySortedTransactions returns the given transactions ordered so that every transaction
+// comes after all of its parents within the given set
+func topologicallySortedTransactions(transactions model.IDToTransactionMap) []*model.MempoolTransaction {
 	type txNode struct {
 		children          map[externalapi.DomainTransactionID]struct{}
 		nonVisitedParents int
@@ -14,11 +41,6 @@ func (mp *mempool) revalidateHighPriorityTransactions() ([]*externalapi.DomainTr
 		visited           bool
 	}
 
-	onEnd := logger.LogAndMeasureExecutionTime(log, "revalidateHighPriorityTransactions")
-	defer onEnd()
-
-	// We revalidate transactions in topological order in case there are dependencies between them
-
 	// Naturally transactions point to their dependencies, but since we want to start processing the dependencies
 	// first, we build the opposite DAG. We initially fill `queue` with transactions with no dependencies.
 	txDAG := make(map[externalapi.DomainTransactionID]*txNode)
@@ -31,19 +53,19 @@ func (mp *mempool) revalidateHighPriorityTransactions() ([]*externalapi.DomainTr
 		node := &txNode{
 			children:          make(map[externalapi.DomainTransactionID]struct{}),
 			nonVisitedParents: 0,
-			tx:                mp.transactionsPool.highPriorityTransactions[txID],
+			tx:                transactions[txID],
 		}
 		txDAG[txID] = node
 		return node
 	}
 
-	queue := make([]*txNode, 0, len(mp.transactionsPool.highPriorityTransactions))
-	for id, transaction := range mp.transactionsPool.highPriorityTransactions {
+	queue := make([]*txNode, 0, len(transactions))
+	for id, transaction := range transactions {
 		node := maybeAddNode(id)
 
 		parents := make(map[externalapi.DomainTransactionID]struct{})
 		for _, input := range transaction.Transaction().Inputs {
-			if _, ok := mp.transactionsPool.highPriorityTransactions[input.PreviousOutpoint.TransactionID]; !ok {
+			if _, ok := transactions[input.PreviousOutpoint.TransactionID]; !ok {
 				continue
 			}
 
@@ -57,7 +79,7 @@ func (mp *mempool) revalidateHighPriorityTransactions() ([]*externalapi.DomainTr
 		}
 	}
 
-	validTransactions := []*externalapi.DomainTransaction{}
+	sortedTransactions := make([]*model.MempoolTransaction, 0, len(transactions))
 
 	// Now we iterate the DAG in topological order using BFS
 	for len(queue) > 0 {
@@ -69,12 +91,6 @@ func (mp *mempool) revalidateHighPriorityTransactions() ([]*externalapi.DomainTr
 		}
 		node.visited = true
 
-		transaction := node.tx
-		isValid, err := mp.revalidateTransaction(transaction)
-		if err != nil {
-			return nil, err
-		}
-
 		for child := range node.children {
 			childNode := txDAG[child]
 			childNode.nonVisitedParents--
@@ -83,12 +99,10 @@ func (mp *mempool) revalidateHighPriorityTransactions() ([]*externalapi.DomainTr
 			}
 		}
 
-		if isValid {
-			validTransactions = append(validTransactions, transaction.Transaction().Clone())
-		}
+		sortedTransactions = append(sortedTransactions, node.tx)
 	}
 
-	return validTransactions, nil
+	return sortedTransactions
 }
 
 func (mp *mempool) revalidateTransaction(transaction *model.MempoolTransaction) (isValid bool, err error) {
@@ -96,7 +110,11 @@ func (mp *mempool) revalidateTransaction(transaction *model.MempoolTransaction)
 
 	_, missingParents, err := mp.fillInputsAndGetMissingParents(transaction.Transaction())
 	if err != nil {
-		return false, err
+		if !errors.As(err, &RuleError{}) {
+			return false, err
+		}
+		log.Debugf("Removing transaction %s, it is no longer valid: %s", transaction.TransactionID(), err)
+		return false, mp.removeTransaction(transaction.TransactionID(), false)
 	}
 	if len(missingParents) > 0 {
 		log.Debugf("Removing transaction %s, it failed revalidation", transaction.TransactionID())
@@ -8,14 +8,21 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
 	"github.com/Kash-Protocol/kashd/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/Kash-Protocol/kashd/domain/miningmanager/model"
 )
 
+// maximumPackageAncestorCount is the maximum number of ancestors a transaction may have in the pool
+// for its ancestor package to be considered in block templates. It bounds the work of computing packages.
+const maximumPackageAncestorCount = 25
+
 type transactionsPool struct {
 	mempool                       *mempool
 	allTransactions               model.IDToTransactionMap
 	highPriorityTransactions      model.IDToTransactionMap
 	chainedTransactionsByParentID model.IDToTransactionsSliceMap
 	transactionsOrderedByFeeRate  model.TransactionsOrderedByFeeRate
+	packagesByTransactionID       map[externalapi.DomainTransactionID]*miningmanagermodel.TransactionPackage
+	totalMass                     uint64
 	lastExpireScanDAAScore        uint64
 	lastExpireScanTime            time.Time
 }
@@ -27,6 +34,7 @@ func newTransactionsPool(mp *mempool) *transactionsPool {
 		highPriorityTransactions:      model.IDToTransactionMap{},
 		chainedTransactionsByParentID: model.IDToTransactionsSliceMap{},
 		transactionsOrderedByFeeRate:  model.TransactionsOrderedByFeeRate{},
+		packagesByTransactionID:       map[externalapi.DomainTransactionID]*miningmanagermodel.TransactionPackage{},
 		lastExpireScanDAAScore:        0,
 		lastExpireScanTime:            time.Now(),
 	}
@@ -53,6 +61,8 @@ func (tp *transactionsPool) addTransaction(transaction *externalapi.DomainTransa
 
 func (tp *transactionsPool) addMempoolTransaction(transaction *model.MempoolTransaction) error {
 	tp.allTransactions[*transaction.TransactionID()] = transaction
+	tp.totalMass += transaction.Transaction().Mass
+	tp.updateMetrics()
 
 	for _, parentTransactionInPool := range transaction.ParentTransactionsInPool() {
 		parentTransactionID := *parentTransactionInPool.TransactionID()
@@ -74,11 +84,15 @@ func (tp *transactionsPool) addMempoolTransaction(transaction *model.MempoolTran
 		tp.highPriorityTransactions[*transaction.TransactionID()] = transaction
 	}
 
+	tp.updatePackage(transaction)
+
 	return nil
 }
 
 func (tp *transactionsPool) removeTransaction(transaction *model.MempoolTransaction) error {
 	delete(tp.allTransactions, *transaction.TransactionID())
+	tp.totalMass -= transaction.Transaction().Mass
+	tp.updateMetrics()
 
 	err := tp.transactionsOrderedByFeeRate.Remove(transaction)
 	if err != nil {
@@ -92,11 +106,18 @@ func (tp *transactionsPool) removeTransaction(transaction *model.MempoolTransact
 
 	delete(tp.highPriorityTransactions, *transaction.TransactionID())
 
+	delete(tp.packagesByTransactionID, *transaction.TransactionID())
+
 	delete(tp.chainedTransactionsByParentID, *transaction.TransactionID())
 
 	return nil
 }
 
+func (tp *transactionsPool) updateMetrics() {
+	transactionCount.Set(float64(len(tp.allTransactions)))
+	transactionsMass.Set(float64(tp.totalMass))
+}
+
 func (tp *transactionsPool) expireOldTransactions() error {
 	virtualDAAScore, err := tp.mempool.consensusReference.Consensus().GetVirtualDAAScore()
 	if err != nil {
@@ -173,6 +194,72 @@ func (tp *transactionsPool) getRedeemers(transaction *model.MempoolTransaction)
 	return redeemers
 }
 
+// getAncestors returns all the transactions in the pool whose outputs transaction spends, directly or through
+// other transactions in the pool. ok is false if transaction has more than maximumPackageAncestorCount ancestors.
+func (tp *transactionsPool) getAncestors(transaction *model.MempoolTransaction) (
+	ancestors []*model.MempoolTransaction, ok bool) {
+
+	stack := []*model.MempoolTransaction{transaction}
+	visited := map[externalapi.DomainTransactionID]struct{}{}
+	for len(stack) > 0 {
+		var current *model.MempoolTransaction
+		last := len(stack) - 1
+		current, stack = stack[last], stack[:last]
+
+		for parentID, parent := range current.ParentTransactionsInPool() {
+			if _, ok := visited[parentID]; ok {
+				continue
+			}
+			visited[parentID] = struct{}{}
+			if len(visited) > maximumPackageAncestorCount {
+				return nil, false
+			}
+			stack = append(stack, parent)
+			ancestors = append(ancestors, parent)
+		}
+	}
+	return ancestors, true
+}
+
+// updatePackage recalculates the ancestor package of transaction. It must be called whenever the
+// ancestors of transaction in the pool change. Transactions without ancestors in the pool, or with more
+// than maximumPackageAncestorCount of them, have no package.
+func (tp *transactionsPool) updatePackage(transaction *model.MempoolTransaction) {
+	delete(tp.packagesByTransactionID, *transaction.TransactionID())
+	if len(transaction.ParentTransactionsInPool()) == 0 {
+		return
+	}
+	ancestors, ok := tp.getAncestors(transaction)
+	if !ok {
+		return
+	}
+
+	transactionPackage := &miningmanagermodel.TransactionPackage{
+		Fee:  transaction.Transaction().Fee,
+		Mass: transaction.Transaction().Mass,
+	}
+	for _, ancestor := range ancestors {
+		transactionPackage.Fee += ancestor.Transaction().Fee
+		transactionPackage.Mass += ancestor.Transaction().Mass
+		if len(ancestor.ParentTransactionsInPool()) == 0 {
+			transactionPackage.ReadyTransactionIDs = append(transactionPackage.ReadyTransactionIDs,
+				*ancestor.TransactionID())
+		}
+	}
+	tp.packagesByTransactionID[*transaction.TransactionID()] = transactionPackage
+}
+
+// transactionPackages returns the ancestor packages of all the transactions in the pool that spend outputs of
+// other transactions in the pool. Transactions with more than maximumPackageAncestorCount ancestors are skipped.
+// The packages are replaced rather than modified when the pool changes, so they may be shared with the caller.
+func (tp *transactionsPool) transactionPackages() []*miningmanagermodel.TransactionPackage {
+	packages := make([]*miningmanagermodel.TransactionPackage, 0, len(tp.packagesByTransactionID))
+	for _, transactionPackage := range tp.packagesByTransactionID {
+		packages = append(packages, transactionPackage)
+	}
+	return packages
+}
+
 func (tp *transactionsPool) limitTransactionCount() error {
 	currentIndex := 0
 
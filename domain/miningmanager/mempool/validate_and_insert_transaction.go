@@ -18,17 +18,25 @@ func (mp *mempool) validateAndInsertTransaction(transaction *externalapi.DomainT
 	// Populate mass in the beginning, it will be used in multiple places throughout the validation and insertion.
 	mp.consensusReference.Consensus().PopulateMass(transaction)
 
-	err = mp.validateTransactionPreUTXOEntry(transaction)
+	err = mp.validateTransactionInIsolation(transaction)
 	if err != nil {
 		return nil, err
 	}
 
+	conflictingTransactions := mp.mempoolUTXOSet.conflictingTransactions(transaction)
+
 	parentsInPool, missingOutpoints, err := mp.fillInputsAndGetMissingParents(transaction)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(missingOutpoints) > 0 {
+		// The fee of an orphan is unknown, so it can't replace anything
+		if len(conflictingTransactions) > 0 {
+			str := fmt.Sprintf("orphan transaction %s spends outputs already spent by transaction %s in the memory pool",
+				consensushashing.TransactionID(transaction), conflictingTransactions[0].TransactionID())
+			return nil, transactionRuleError(RejectDuplicate, str)
+		}
 		if !allowOrphan {
 			str := fmt.Sprintf("Transaction %s is an orphan, where allowOrphan = false",
 				consensushashing.TransactionID(transaction))
@@ -43,6 +51,18 @@ func (mp *mempool) validateAndInsertTransaction(transaction *externalapi.DomainT
 		return nil, err
 	}
 
+	if len(conflictingTransactions) > 0 {
+		evictedTransactions, err := mp.checkReplaceByFee(transaction, parentsInPool, conflictingTransactions)
+		if err != nil {
+			return nil, err
+		}
+		err = mp.replaceTransactions(consensushashing.TransactionID(transaction), conflictingTransactions,
+			len(evictedTransactions))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	mempoolTransaction, err := mp.transactionsPool.addTransaction(transaction, parentsInPool, isHighPriority)
 	if err != nil {
 		return nil, err
@@ -8,18 +8,6 @@ import (
 	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
 )
 
-func (mp *mempool) validateTransactionPreUTXOEntry(transaction *externalapi.DomainTransaction) error {
-	err := mp.validateTransactionInIsolation(transaction)
-	if err != nil {
-		return err
-	}
-
-	if err := mp.mempoolUTXOSet.checkDoubleSpends(transaction); err != nil {
-		return err
-	}
-	return nil
-}
-
 func (mp *mempool) validateTransactionInIsolation(transaction *externalapi.DomainTransaction) error {
 	transactionID := consensushashing.TransactionID(transaction)
 	if _, ok := mp.transactionsPool.allTransactions[*transactionID]; ok {
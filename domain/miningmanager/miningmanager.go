@@ -33,6 +33,10 @@ type MiningManager interface {
 	ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
 		acceptedTransactions []*externalapi.DomainTransaction, err error)
 	RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error)
+	GetFeeEstimate() *FeeEstimate
+	TransactionRecords() []*miningmanagermodel.TransactionRecord
+	RestoreTransactions(records []*miningmanagermodel.TransactionRecord) (
+		restoredCount int, expiredCount int, invalidCount int, err error)
 }
 
 type miningManager struct {
@@ -42,6 +46,7 @@ type miningManager struct {
 	cachedBlockTemplate  *externalapi.DomainBlockTemplate
 	cachingTime          time.Time
 	cacheLock            *sync.Mutex
+	blockMaxMass         uint64
 }
 
 // GetBlockTemplate obtains a block template for a miner to consume
@@ -154,3 +159,17 @@ func (mm *miningManager) RevalidateHighPriorityTransactions() (
 
 	return mm.mempool.RevalidateHighPriorityTransactions()
 }
+
+// TransactionRecords returns records of all the transactions in the transaction pool, from which
+// they can be restored with RestoreTransactions. Parents are recorded before their children.
+func (mm *miningManager) TransactionRecords() []*miningmanagermodel.TransactionRecord {
+	return mm.mempool.TransactionRecords()
+}
+
+// RestoreTransactions adds previously recorded transactions back to the mempool and revalidates them,
+// dropping the ones that expired or are no longer valid
+func (mm *miningManager) RestoreTransactions(records []*miningmanagermodel.TransactionRecord) (
+	restoredCount int, expiredCount int, invalidCount int, err error) {
+
+	return mm.mempool.RestoreTransactions(records)
+}
@@ -41,7 +41,7 @@ func TestValidateAndInsertTransaction(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		transactionsToInsert := make([]*externalapi.DomainTransaction, 10)
 		for i := range transactionsToInsert {
 			transactionsToInsert[i] = createTransactionWithUTXOEntry(t, i, 0)
@@ -92,7 +92,7 @@ func TestImmatureSpend(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		tx := createTransactionWithUTXOEntry(t, 0, consensusConfig.GenesisBlock.Header.DAAScore())
 		_, err = miningManager.ValidateAndInsertTransaction(tx, false, false)
 		txRuleError := &mempool.TxRuleError{}
@@ -122,7 +122,7 @@ func TestInsertDoubleTransactionsToMempool(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		transaction := createTransactionWithUTXOEntry(t, 0, 0)
 		_, err = miningManager.ValidateAndInsertTransaction(transaction, false, true)
 		if err != nil {
@@ -151,7 +151,7 @@ func TestDoubleSpendInMempool(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		transaction, err := createChildAndParentTxsAndAddParentToConsensus(tc)
 		if err != nil {
 			t.Fatalf("Error creating transaction: %+v", err)
@@ -172,6 +172,251 @@ func TestDoubleSpendInMempool(t *testing.T) {
 	})
 }
 
+// TestReplaceByFee verifies that a transaction double-spending transactions in the mempool replaces them,
+// along with their redeemers, only if it pays enough fees and doesn't evict too many transactions.
+func TestReplaceByFee(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestReplaceByFee")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
+
+		const chainSize = 3
+		chain, err := createTxChain(tc, chainSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, transaction := range chain {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, false)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+		}
+
+		// A replacement that pays less than the transactions it evicts is rejected
+		underpayingReplacement := chain[0].Clone()
+		underpayingReplacement.ID = nil
+		underpayingReplacement.Outputs[0].Value -= 1000
+		_, err = miningManager.ValidateAndInsertTransaction(underpayingReplacement, false, false)
+		if err == nil || !strings.Contains(err.Error(), "cannot replace it") {
+			t.Fatalf("Expected the underpaying replacement to be rejected, but got: %v", err)
+		}
+
+		// A replacement that would evict too many transactions is rejected
+		mempoolConfig.MaximumReplacedTransactionCount = chainSize - 1
+		replacement := chain[0].Clone()
+		replacement.ID = nil
+		replacement.Outputs[0].Value -= 100_000
+		_, err = miningManager.ValidateAndInsertTransaction(replacement.Clone(), false, false)
+		if err == nil || !strings.Contains(err.Error(), "at most") {
+			t.Fatalf("Expected the replacement evicting too many transactions to be rejected, but got: %v", err)
+		}
+
+		mempoolConfig.MaximumReplacedTransactionCount = chainSize
+		_, err = miningManager.ValidateAndInsertTransaction(replacement, false, false)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+
+		for _, transaction := range chain {
+			_, _, found := miningManager.GetTransaction(consensushashing.TransactionID(transaction), true, true)
+			if found {
+				t.Fatalf("Expected transaction %s to be evicted by its replacement",
+					consensushashing.TransactionID(transaction))
+			}
+		}
+		_, _, found := miningManager.GetTransaction(consensushashing.TransactionID(replacement), true, false)
+		if !found {
+			t.Fatalf("Expected the replacement to be in the mempool")
+		}
+	})
+}
+
+// TestTransactionPackages verifies that the mempool reports the ancestor package of every transaction
+// that spends outputs of other mempool transactions, with its aggregated fee and mass.
+func TestTransactionPackages(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestTransactionPackages")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		transactionsMempool := mempool.New(mempool.DefaultConfig(&consensusConfig.Params), consensusReference)
+
+		const chainSize = 3
+		chain, err := createTxChain(tc, chainSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, transaction := range chain {
+			_, err = transactionsMempool.ValidateAndInsertTransaction(transaction, false, false)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+		}
+
+		transactionPackages := transactionsMempool.TransactionPackages()
+		if len(transactionPackages) != chainSize-1 {
+			t.Fatalf("Expected %d transaction packages but got %d", chainSize-1, len(transactionPackages))
+		}
+		readyTransactionID := *consensushashing.TransactionID(chain[0])
+		for _, transactionPackage := range transactionPackages {
+			if len(transactionPackage.ReadyTransactionIDs) != 1 ||
+				!transactionPackage.ReadyTransactionIDs[0].Equal(&readyTransactionID) {
+				t.Fatalf("Expected %s to be the only ready transaction of the package, but got %v",
+					readyTransactionID, transactionPackage.ReadyTransactionIDs)
+			}
+		}
+
+		// The package of the last transaction holds the whole chain
+		expectedFee, expectedMass := uint64(0), uint64(0)
+		for _, transaction := range chain {
+			expectedFee += transaction.Fee
+			expectedMass += transaction.Mass
+		}
+		foundWholeChain := false
+		for _, transactionPackage := range transactionPackages {
+			if transactionPackage.Fee == expectedFee && transactionPackage.Mass == expectedMass {
+				foundWholeChain = true
+			}
+		}
+		if !foundWholeChain {
+			t.Fatalf("Expected a package with a fee of %d and a mass of %d", expectedFee, expectedMass)
+		}
+
+		// Once the first transaction is mined, it leaves the packages of its redeemers
+		_, err = transactionsMempool.HandleNewBlockTransactions([]*externalapi.DomainTransaction{nil, chain[0]})
+		if err != nil {
+			t.Fatalf("HandleNewBlockTransactions: %v", err)
+		}
+		transactionPackages = transactionsMempool.TransactionPackages()
+		if len(transactionPackages) != chainSize-2 {
+			t.Fatalf("Expected %d transaction packages but got %d", chainSize-2, len(transactionPackages))
+		}
+		readyTransactionID = *consensushashing.TransactionID(chain[1])
+		transactionPackage := transactionPackages[0]
+		if len(transactionPackage.ReadyTransactionIDs) != 1 ||
+			!transactionPackage.ReadyTransactionIDs[0].Equal(&readyTransactionID) {
+			t.Fatalf("Expected %s to be the only ready transaction of the package, but got %v",
+				readyTransactionID, transactionPackage.ReadyTransactionIDs)
+		}
+		expectedFee -= chain[0].Fee
+		expectedMass -= chain[0].Mass
+		if transactionPackage.Fee != expectedFee || transactionPackage.Mass != expectedMass {
+			t.Fatalf("Expected a package with a fee of %d and a mass of %d but got a fee of %d and a mass of %d",
+				expectedFee, expectedMass, transactionPackage.Fee, transactionPackage.Mass)
+		}
+	})
+}
+
+// TestRestoreTransactions verifies that recorded transactions are restored to a new mempool,
+// and that expired and invalid records are dropped.
+func TestRestoreTransactions(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestRestoreTransactions")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params,
+			mempool.DefaultConfig(&consensusConfig.Params), nil)
+
+		const chainSize = 3
+		chain, err := createTxChain(tc, chainSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, transaction := range chain {
+			_, err = miningManager.ValidateAndInsertTransaction(transaction, false, false)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+		}
+
+		records := miningManager.TransactionRecords()
+		if len(records) != chainSize {
+			t.Fatalf("Expected %d records but got %d", chainSize, len(records))
+		}
+		for i, record := range records {
+			if !record.Transaction.Equal(chain[i]) {
+				t.Fatalf("Expected record #%d to be of transaction %s but got %s", i,
+					consensushashing.TransactionID(chain[i]), consensushashing.TransactionID(record.Transaction))
+			}
+		}
+
+		// Only the last transaction of the chain may expire
+		records[0].IsHighPriority = true
+		records[1].IsHighPriority = true
+
+		// A double spend of the second transaction, and a transaction spending a missing outpoint, are invalid
+		doubleSpend := chain[1].Clone()
+		doubleSpend.ID = nil
+		doubleSpend.Outputs[0].Value--
+		missingOutpointSpend := chain[1].Clone()
+		missingOutpointSpend.ID = nil
+		missingOutpointSpend.Inputs[0].PreviousOutpoint.TransactionID =
+			*externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1})
+		records = append(records,
+			&model.TransactionRecord{Transaction: doubleSpend, IsHighPriority: true},
+			&model.TransactionRecord{Transaction: missingOutpointSpend, IsHighPriority: true})
+
+		tips, err := tc.Tips()
+		if err != nil {
+			t.Fatalf("Error getting tips: %+v", err)
+		}
+		_, _, err = tc.AddBlock(tips, nil, nil)
+		if err != nil {
+			t.Fatalf("Error adding block: %+v", err)
+		}
+
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		mempoolConfig.TransactionExpireIntervalDAAScore = 0
+		restoringMiningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params,
+			mempoolConfig, nil)
+		restoredCount, expiredCount, invalidCount, err := restoringMiningManager.RestoreTransactions(records)
+		if err != nil {
+			t.Fatalf("RestoreTransactions: %+v", err)
+		}
+		if restoredCount != 2 || expiredCount != 1 || invalidCount != 2 {
+			t.Fatalf("Expected 2 restored, 1 expired and 2 invalid transactions, but got %d, %d and %d",
+				restoredCount, expiredCount, invalidCount)
+		}
+		for _, transaction := range chain[:2] {
+			_, _, found := restoringMiningManager.GetTransaction(consensushashing.TransactionID(transaction), true, false)
+			if !found {
+				t.Fatalf("Expected transaction %s to be restored", consensushashing.TransactionID(transaction))
+			}
+		}
+		if restoringMiningManager.TransactionCount(true, true) != 2 {
+			t.Fatalf("Expected only the 2 restored transactions in the mempool, but got %d",
+				restoringMiningManager.TransactionCount(true, true))
+		}
+	})
+}
+
 // TestHandleNewBlockTransactions verifies that all the transactions in the block were successfully removed from the mempool.
 func TestHandleNewBlockTransactions(t *testing.T) {
 	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
@@ -187,7 +432,7 @@ func TestHandleNewBlockTransactions(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		transactionsToInsert := make([]*externalapi.DomainTransaction, 10)
 		for i := range transactionsToInsert {
 			transaction := createTransactionWithUTXOEntry(t, i, 0)
@@ -257,7 +502,7 @@ func TestDoubleSpendWithBlock(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		transactionInTheMempool := createTransactionWithUTXOEntry(t, 0, 0)
 		_, err = miningManager.ValidateAndInsertTransaction(transactionInTheMempool, false, true)
 		if err != nil {
@@ -293,7 +538,7 @@ func TestOrphanTransactions(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 		// Before each parent transaction, We will add two blocks by consensus in order to fund the parent transactions.
 		parentTransactions, childTransactions, err := createArraysOfParentAndChildrenTransactions(tc)
 		if err != nil {
@@ -396,7 +641,7 @@ func TestHighPriorityTransactions(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
 
 		// Create 3 pairs of transaction parent-and-child pairs: 1 low priority and 2 high priority
 		lowPriorityParentTransaction, lowPriorityChildTransaction, err := createParentAndChildrenTransactions(tc)
@@ -489,7 +734,7 @@ func TestRevalidateHighPriorityTransactions(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
 
 		// Create two valid transactions that double-spend each other (childTransaction1, childTransaction2)
 		parentTransaction, childTransaction1, err := createParentAndChildrenTransactions(tc)
@@ -592,7 +837,7 @@ func TestRevalidateHighPriorityTransactionsWithChain(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig, nil)
 
 		const chainSize = 10
 		chain, err := createTxChain(tc, chainSize)
@@ -658,7 +903,7 @@ func TestModifyBlockTemplate(t *testing.T) {
 		tcAsConsensus := tc.(externalapi.Consensus)
 		tcAsConsensusPointer := &tcAsConsensus
 		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
-		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params), nil)
 
 		// Create some complex transactions. Logic taken from TestOrphanTransactions
 
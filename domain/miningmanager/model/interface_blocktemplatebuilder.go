@@ -9,4 +9,13 @@ type BlockTemplateBuilder interface {
 	BuildBlockTemplate(coinbaseData *consensusexternalapi.DomainCoinbaseData) (*consensusexternalapi.DomainBlockTemplate, error)
 	ModifyBlockTemplate(newCoinbaseData *consensusexternalapi.DomainCoinbaseData,
 		blockTemplateToModify *consensusexternalapi.DomainBlockTemplate) (*consensusexternalapi.DomainBlockTemplate, error)
+	LastBlockTemplateFill() (fill *BlockTemplateFill, ok bool)
+}
+
+// BlockTemplateFill describes how much of the block mass the transactions of a block template take
+type BlockTemplateFill struct {
+	Mass    uint64
+	MaxMass uint64
+	// MinimumFeeRate is the lowest fee rate, in sompi per gram, of the transactions in the template
+	MinimumFeeRate float64
 }
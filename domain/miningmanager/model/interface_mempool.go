@@ -40,4 +40,33 @@ type Mempool interface {
 		includeOrphanPool bool) int
 	RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error)
 	IsTransactionOutputDust(output *externalapi.DomainTransactionOutput) bool
+	TransactionFeeRates() []TransactionFeeRate
+	MinimumRelayFeeRate() float64
+	TransactionPackages() []*TransactionPackage
+	TransactionRecords() []*TransactionRecord
+	RestoreTransactions(records []*TransactionRecord) (restoredCount int, expiredCount int, invalidCount int, err error)
+}
+
+// TransactionFeeRate is the fee rate, in sompi per gram, and the mass of a mempool transaction
+type TransactionFeeRate struct {
+	FeeRate float64
+	Mass    uint64
+}
+
+// TransactionPackage is a mempool transaction that spends outputs of other mempool transactions,
+// along with all its ancestors in the mempool. Fee and Mass are the totals of the whole package.
+// ReadyTransactionIDs are the package transactions that spend no outputs of other mempool
+// transactions, and so are the ones that can be mined in the next block.
+type TransactionPackage struct {
+	ReadyTransactionIDs []externalapi.DomainTransactionID
+	Fee                 uint64
+	Mass                uint64
+}
+
+// TransactionRecord is a transaction pool transaction along with the mempool data needed to restore it
+// after a restart. The transaction's Fee and Mass are those it was accepted to the mempool with.
+type TransactionRecord struct {
+	Transaction     *externalapi.DomainTransaction
+	IsHighPriority  bool
+	AddedAtDAAScore uint64
 }
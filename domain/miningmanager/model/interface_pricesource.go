@@ -0,0 +1,10 @@
+package model
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/oracle"
+)
+
+// PriceSource provides the signed price records that new blocks commit to
+type PriceSource interface {
+	LatestSignedPriceRecords() []*oracle.SignedPriceRecord
+}
@@ -0,0 +1,7 @@
+package txindex
+
+import (
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("TXIN")
@@ -0,0 +1,16 @@
+package txindex
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+)
+
+// TXAcceptance is where a transaction was accepted into the DAG
+type TXAcceptance struct {
+	// AcceptingBlockHash is the hash of the selected parent chain block that accepted the transaction
+	AcceptingBlockHash      *externalapi.DomainHash
+	AcceptingBlockBlueScore uint64
+
+	// ContainingBlockHashes are the hashes of the blocks, in the merge set of the accepting block,
+	// that contain the transaction. The first is the block the transaction was accepted from.
+	ContainingBlockHashes []*externalapi.DomainHash
+}
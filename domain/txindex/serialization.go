@@ -0,0 +1,63 @@
+package txindex
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/Kash-Protocol/kashd/domain/chainindex"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+const blueScoreSize = 8
+
+func serializeTXAcceptance(txAcceptance *TXAcceptance) []byte {
+	serializedContainingBlockHashes := chainindex.SerializeHashes(txAcceptance.ContainingBlockHashes)
+	serializedTXAcceptance := make([]byte, externalapi.DomainHashSize+blueScoreSize+len(serializedContainingBlockHashes))
+	copy(serializedTXAcceptance[:externalapi.DomainHashSize], txAcceptance.AcceptingBlockHash.ByteSlice())
+	binary.LittleEndian.PutUint64(serializedTXAcceptance[externalapi.DomainHashSize:], txAcceptance.AcceptingBlockBlueScore)
+	copy(serializedTXAcceptance[externalapi.DomainHashSize+blueScoreSize:], serializedContainingBlockHashes)
+	return serializedTXAcceptance
+}
+
+func deserializeTXAcceptance(serializedTXAcceptance []byte) (*TXAcceptance, error) {
+	if len(serializedTXAcceptance) < externalapi.DomainHashSize+blueScoreSize {
+		return nil, errors.Wrapf(io.ErrUnexpectedEOF, "unexpected EOF while deserializing a transaction acceptance")
+	}
+
+	acceptingBlockHash, err := externalapi.NewDomainHashFromByteSlice(serializedTXAcceptance[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+	acceptingBlockBlueScore := binary.LittleEndian.Uint64(serializedTXAcceptance[externalapi.DomainHashSize:])
+	containingBlockHashes, err := chainindex.DeserializeHashes(serializedTXAcceptance[externalapi.DomainHashSize+blueScoreSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &TXAcceptance{
+		AcceptingBlockHash:      acceptingBlockHash,
+		AcceptingBlockBlueScore: acceptingBlockBlueScore,
+		ContainingBlockHashes:   containingBlockHashes,
+	}, nil
+}
+
+func serializeTransactionIDs(transactionIDs []*externalapi.DomainTransactionID) []byte {
+	hashes := make([]*externalapi.DomainHash, len(transactionIDs))
+	for i, transactionID := range transactionIDs {
+		hashes[i] = (*externalapi.DomainHash)(transactionID)
+	}
+	return chainindex.SerializeHashes(hashes)
+}
+
+func deserializeTransactionIDs(serializedTransactionIDs []byte) ([]*externalapi.DomainTransactionID, error) {
+	hashes, err := chainindex.DeserializeHashes(serializedTransactionIDs)
+	if err != nil {
+		return nil, err
+	}
+	transactionIDs := make([]*externalapi.DomainTransactionID, len(hashes))
+	for i, hash := range hashes {
+		transactionIDs[i] = (*externalapi.DomainTransactionID)(hash)
+	}
+	return transactionIDs, nil
+}
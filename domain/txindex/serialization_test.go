@@ -0,0 +1,54 @@
+package txindex
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+func TestTXAcceptanceSerialization(t *testing.T) {
+	txAcceptance := &TXAcceptance{
+		AcceptingBlockHash:      externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		AcceptingBlockBlueScore: 1234,
+		ContainingBlockHashes: []*externalapi.DomainHash{
+			externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+			externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{3}),
+		},
+	}
+
+	result, err := deserializeTXAcceptance(serializeTXAcceptance(txAcceptance))
+	if err != nil {
+		t.Fatalf("Failed deserializing the transaction acceptance: %+v", err)
+	}
+	if !reflect.DeepEqual(txAcceptance, result) {
+		t.Fatalf("Expected %+v, but got %+v", txAcceptance, result)
+	}
+
+	_, err = deserializeTXAcceptance(serializeTXAcceptance(txAcceptance)[:externalapi.DomainHashSize])
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected error to be EOF, instead got: %v", err)
+	}
+}
+
+func TestDeserializeTransactionIDsFailure(t *testing.T) {
+	transactionIDs := []*externalapi.DomainTransactionID{
+		externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{2}),
+	}
+	serialized := serializeTransactionIDs(transactionIDs)
+	result, err := deserializeTransactionIDs(serialized)
+	if err != nil {
+		t.Fatalf("Failed deserializing transaction IDs: %+v", err)
+	}
+	if !reflect.DeepEqual(transactionIDs, result) {
+		t.Fatalf("Expected %s, but got %s", transactionIDs, result)
+	}
+
+	_, err = deserializeTransactionIDs(serialized[:len(serialized)-1])
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Expected error to be EOF, instead got: %v", err)
+	}
+}
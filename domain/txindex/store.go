@@ -0,0 +1,65 @@
+package txindex
+
+import (
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
+)
+
+// txIndexBucket maps transaction IDs to their TXAcceptance
+var txIndexBucket = database.MakeBucket([]byte("tx-index"))
+
+type txIndexStore struct {
+	database database.Database
+}
+
+func newTXIndexStore(database database.Database) *txIndexStore {
+	return &txIndexStore{
+		database: database,
+	}
+}
+
+func (tis *txIndexStore) transactionKey(transactionID *externalapi.DomainTransactionID) *database.Key {
+	return txIndexBucket.Key(transactionID.ByteSlice())
+}
+
+// addTXAcceptances indexes the given transactions with their TXAcceptance
+func (tis *txIndexStore) addTXAcceptances(dataAccessor database.DataAccessor,
+	transactionIDs []*externalapi.DomainTransactionID,
+	txAcceptances map[externalapi.DomainTransactionID]*TXAcceptance) error {
+
+	for _, transactionID := range transactionIDs {
+		err := dataAccessor.Put(tis.transactionKey(transactionID), serializeTXAcceptance(txAcceptances[*transactionID]))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tis *txIndexStore) removeTXAcceptances(dataAccessor database.DataAccessor,
+	transactionIDs []*externalapi.DomainTransactionID) error {
+
+	for _, transactionID := range transactionIDs {
+		err := dataAccessor.Delete(tis.transactionKey(transactionID))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tis *txIndexStore) getTXAcceptance(transactionID *externalapi.DomainTransactionID) (*TXAcceptance, bool, error) {
+	serializedTXAcceptance, err := tis.database.Get(tis.transactionKey(transactionID))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	txAcceptance, err := deserializeTXAcceptance(serializedTXAcceptance)
+	if err != nil {
+		return nil, false, err
+	}
+	return txAcceptance, true, nil
+}
@@ -0,0 +1,123 @@
+package txindex
+
+import (
+	"github.com/Kash-Protocol/kashd/domain"
+	"github.com/Kash-Protocol/kashd/domain/chainindex"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+)
+
+// TXIndex maintains an index between transaction IDs and the
+// blocks that accepted and contain them
+type TXIndex struct {
+	store      *txIndexStore
+	chainIndex *chainindex.ChainIndex
+}
+
+// New creates a new transaction index. Transactions accepted by blocks
+// below the pruning point are removed from the index, unless the node is
+// an archival node, which keeps their data.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func New(domain domain.Domain, database database.Database, isArchivalNode bool) (*TXIndex, error) {
+	store := newTXIndexStore(database)
+	chainIndex, err := chainindex.New(domain, database, &txIndexer{store: store}, "transaction index", "tx-index",
+		isArchivalNode, !isArchivalNode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TXIndex{
+		store:      store,
+		chainIndex: chainIndex,
+	}, nil
+}
+
+// Reset catches the transaction index up with consensus after its state was replaced,
+// such as by syncing from a new pruning point. The index is not deleted.
+func (ti *TXIndex) Reset() error {
+	return ti.chainIndex.CatchUp()
+}
+
+// Update updates the transaction index with the given DAG selected parent chain changes
+func (ti *TXIndex) Update(virtualChangeSet *externalapi.VirtualChangeSet) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "TXIndex.Update")
+	defer onEnd()
+
+	return ti.chainIndex.Update(virtualChangeSet)
+}
+
+// txIndexer adds the transactions accepted by selected parent chain blocks to the transaction index
+type txIndexer struct {
+	store *txIndexStore
+}
+
+func (tir *txIndexer) AddAcceptingBlock(dataAccessor database.DataAccessor, blockHash *externalapi.DomainHash,
+	blueScore uint64, acceptanceData externalapi.AcceptanceData) ([]byte, error) {
+
+	transactionIDs, txAcceptances := collectTXAcceptances(blockHash, blueScore, acceptanceData)
+	err := tir.store.addTXAcceptances(dataAccessor, transactionIDs, txAcceptances)
+	if err != nil {
+		return nil, err
+	}
+	return serializeTransactionIDs(transactionIDs), nil
+}
+
+func (tir *txIndexer) RemoveAcceptingBlock(dataAccessor database.DataAccessor, rollbackData []byte) error {
+	transactionIDs, err := deserializeTransactionIDs(rollbackData)
+	if err != nil {
+		return err
+	}
+	return tir.store.removeTXAcceptances(dataAccessor, transactionIDs)
+}
+
+// collectTXAcceptances returns the IDs of the transactions accepted by the given block, in the
+// order of its acceptance data, and their TXAcceptance
+func collectTXAcceptances(acceptingBlockHash *externalapi.DomainHash, acceptingBlockBlueScore uint64,
+	acceptanceData externalapi.AcceptanceData) (
+	[]*externalapi.DomainTransactionID, map[externalapi.DomainTransactionID]*TXAcceptance) {
+
+	var acceptedTransactionIDs []*externalapi.DomainTransactionID
+	txAcceptances := make(map[externalapi.DomainTransactionID]*TXAcceptance)
+	rejectedContainingBlockHashes := make(map[externalapi.DomainTransactionID][]*externalapi.DomainHash)
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			transactionID := consensushashing.TransactionID(transactionAcceptanceData.Transaction)
+			if !transactionAcceptanceData.IsAccepted {
+				rejectedContainingBlockHashes[*transactionID] =
+					append(rejectedContainingBlockHashes[*transactionID], blockAcceptanceData.BlockHash)
+				continue
+			}
+
+			acceptedTransactionIDs = append(acceptedTransactionIDs, transactionID)
+			txAcceptances[*transactionID] = &TXAcceptance{
+				AcceptingBlockHash:      acceptingBlockHash,
+				AcceptingBlockBlueScore: acceptingBlockBlueScore,
+				ContainingBlockHashes:   []*externalapi.DomainHash{blockAcceptanceData.BlockHash},
+			}
+		}
+	}
+
+	// Other blocks in the merge set may contain an accepted transaction as well, in which
+	// case it's rejected there as a double spend
+	for transactionID, containingBlockHashes := range rejectedContainingBlockHashes {
+		txAcceptance, ok := txAcceptances[transactionID]
+		if !ok {
+			continue
+		}
+		txAcceptance.ContainingBlockHashes = append(txAcceptance.ContainingBlockHashes, containingBlockHashes...)
+	}
+
+	return acceptedTransactionIDs, txAcceptances
+}
+
+// TXAcceptance returns where the transaction with the given ID was accepted
+// into the DAG, or false if it's not in the index
+func (ti *TXIndex) TXAcceptance(transactionID *externalapi.DomainTransactionID) (*TXAcceptance, bool, error) {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "TXIndex.TXAcceptance")
+	defer onEnd()
+
+	return ti.store.getTXAcceptance(transactionID)
+}
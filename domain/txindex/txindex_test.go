@@ -0,0 +1,53 @@
+package txindex
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+)
+
+func TestCollectTXAcceptances(t *testing.T) {
+	acceptingBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	mergedBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{2})
+
+	acceptedTransaction := &externalapi.DomainTransaction{Version: 0, LockTime: 1}
+	rejectedTransaction := &externalapi.DomainTransaction{Version: 0, LockTime: 2}
+	acceptanceData := externalapi.AcceptanceData{
+		{
+			BlockHash: acceptingBlockHash,
+			TransactionAcceptanceData: []*externalapi.TransactionAcceptanceData{
+				{Transaction: acceptedTransaction, IsAccepted: true},
+				{Transaction: rejectedTransaction, IsAccepted: false},
+			},
+		},
+		{
+			// The merged block contains the accepted transaction as well, but it's a double spend there
+			BlockHash: mergedBlockHash,
+			TransactionAcceptanceData: []*externalapi.TransactionAcceptanceData{
+				{Transaction: acceptedTransaction, IsAccepted: false},
+			},
+		},
+	}
+
+	transactionIDs, txAcceptances := collectTXAcceptances(acceptingBlockHash, 10, acceptanceData)
+
+	acceptedTransactionID := consensushashing.TransactionID(acceptedTransaction)
+	if len(transactionIDs) != 1 || !transactionIDs[0].Equal(acceptedTransactionID) {
+		t.Fatalf("Expected only transaction %s to be accepted, but got %s", acceptedTransactionID, transactionIDs)
+	}
+	if _, ok := txAcceptances[*consensushashing.TransactionID(rejectedTransaction)]; ok {
+		t.Fatalf("Expected the rejected transaction not to be indexed")
+	}
+
+	txAcceptance := txAcceptances[*acceptedTransactionID]
+	if !txAcceptance.AcceptingBlockHash.Equal(acceptingBlockHash) || txAcceptance.AcceptingBlockBlueScore != 10 {
+		t.Fatalf("Unexpected accepting block %s with blue score %d",
+			txAcceptance.AcceptingBlockHash, txAcceptance.AcceptingBlockBlueScore)
+	}
+	expectedContainingBlockHashes := []*externalapi.DomainHash{acceptingBlockHash, mergedBlockHash}
+	if !externalapi.HashesEqual(txAcceptance.ContainingBlockHashes, expectedContainingBlockHashes) {
+		t.Fatalf("Expected the containing blocks to be %s, but got %s",
+			expectedContainingBlockHashes, txAcceptance.ContainingBlockHashes)
+	}
+}
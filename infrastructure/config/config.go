@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/pow"
 	"github.com/Kash-Protocol/kashd/domain/dagconfig"
 	"github.com/Kash-Protocol/kashd/infrastructure/logger"
 	"github.com/Kash-Protocol/kashd/util"
@@ -49,11 +50,20 @@ const (
 	defaultMinRelayTxFee         = 1e-5 // 1 sompi per byte
 	defaultMaxOrphanTransactions = 100
 	//DefaultMaxOrphanTxSize is the default maximum size for an orphan transaction
-	DefaultMaxOrphanTxSize  = 100_000
-	defaultSigCacheMaxSize  = 100_000
-	sampleConfigFilename    = "sample-kashd.conf"
-	defaultMaxUTXOCacheSize = 5_000_000_000
-	defaultProtocolVersion  = 5
+	DefaultMaxOrphanTxSize     = 100_000
+	defaultSigCacheMaxSize     = 100_000
+	sampleConfigFilename       = "sample-kashd.conf"
+	defaultMaxUTXOCacheSize    = 5_000_000_000
+	defaultProtocolVersion     = 5
+	defaultOracleFetchInterval = time.Second * 10
+	defaultRandomXMode         = "light"
+)
+
+// The networks --onlynet accepts
+const (
+	OnlyNetIPv4  = "ipv4"
+	OnlyNetIPv6  = "ipv6"
+	OnlyNetOnion = "onion"
 )
 
 var (
@@ -94,6 +104,13 @@ type Flags struct {
 	RPCListeners                    []string      `long:"rpclisten" description:"Add an interface/port to listen for RPC connections (default port: 16110, testnet: 16210)"`
 	RPCCert                         string        `long:"rpccert" description:"File containing the certificate file"`
 	RPCKey                          string        `long:"rpckey" description:"File containing the certificate key"`
+	RPCTLS                          bool          `long:"rpctls" description:"Serve RPC connections over TLS -- A self-signed certificate is generated at --rpccert and --rpckey if neither exists"`
+	RPCUser                         string        `long:"rpcuser" description:"Username for RPC connections"`
+	RPCPass                         string        `long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
+	RPCAuthToken                    string        `long:"rpcauthtoken" default-mask:"-" description:"Bearer token for RPC connections"`
+	RPCLimitUser                    string        `long:"rpclimituser" description:"Username for limited RPC connections, which are restricted as if --saferpc were set"`
+	RPCLimitPass                    string        `long:"rpclimitpass" default-mask:"-" description:"Password for limited RPC connections"`
+	RPCLimitAuthToken               string        `long:"rpclimitauthtoken" default-mask:"-" description:"Bearer token for limited RPC connections, which are restricted as if --saferpc were set"`
 	RPCMaxClients                   int           `long:"rpcmaxclients" description:"Max number of RPC clients for standard connections"`
 	RPCMaxWebsockets                int           `long:"rpcmaxwebsockets" description:"Max number of RPC websocket connections"`
 	RPCMaxConcurrentReqs            int           `long:"rpcmaxconcurrentreqs" description:"Max number of concurrent RPC requests that may be processed concurrently"`
@@ -106,8 +123,11 @@ type Flags struct {
 	Proxy                           string        `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
 	ProxyUser                       string        `long:"proxyuser" description:"Username for proxy server"`
 	ProxyPass                       string        `long:"proxypass" default-mask:"-" description:"Password for proxy server"`
+	NoProxyDNS                      bool          `long:"noproxydns" description:"Resolve host names with the system resolver rather than through the proxy -- NOTE: Resolving host names through the proxy requires it to be a Tor proxy"`
+	OnlyNets                        []string      `long:"onlynet" description:"Only make outbound connections to peers of the given network {ipv4, ipv6, onion} -- Can be specified multiple times -- NOTE: onion requires --proxy"`
 	DbType                          string        `long:"dbtype" description:"Database backend to use for the Block DAG"`
 	Profile                         string        `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	MetricsListen                   string        `long:"metricslisten" description:"Serve Prometheus metrics over HTTP at /metrics on the given interface/port (eg. 127.0.0.1:16120) -- NOTE: The metrics server is disabled if this option is not specified"`
 	LogLevel                        string        `short:"d" long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
 	Upnp                            bool          `long:"upnp" description:"Use UPnP to map our listening port outside of NAT"`
 	MinRelayTxFee                   float64       `long:"minrelaytxfee" description:"The minimum transaction fee in KSH/kB to be considered a non-zero fee."`
@@ -122,10 +142,15 @@ type Flags struct {
 	ResetDatabase                   bool          `long:"reset-db" description:"Reset database before starting node. It's needed when switching between subnetworks."`
 	MaxUTXOCacheSize                uint64        `long:"maxutxocachesize" description:"Max size of loaded UTXO into ram from the disk in bytes"`
 	UTXOIndex                       bool          `long:"utxoindex" description:"Enable the UTXO index"`
+	TXIndex                         bool          `long:"txindex" description:"Enable the transaction index, which looks up accepted transactions by ID"`
+	AddressIndex                    bool          `long:"addressindex" description:"Enable the address index, which looks up the transaction history of addresses"`
 	IsArchivalNode                  bool          `long:"archival" description:"Run as an archival node: don't delete old block data when moving the pruning point (Warning: heavy disk usage)'"`
 	AllowSubmitBlockWhenNotSynced   bool          `long:"allow-submit-block-when-not-synced" hidden:"true" description:"Allow the node to accept blocks from RPC while not synced (this flag is mainly used for testing)"`
 	EnableSanityCheckPruningUTXOSet bool          `long:"enable-sanity-check-pruning-utxo" hidden:"true" description:"When moving the pruning point - check that the utxo set matches the utxo commitment"`
 	ProtocolVersion                 uint32        `long:"protocol-version" description:"Use non default p2p protocol version"`
+	OracleURLs                      []string      `long:"oracleurl" description:"Add a price feed to fetch signed price records from, to be committed to mined blocks"`
+	OracleFetchInterval             time.Duration `long:"oraclefetchinterval" description:"How often to fetch price records from the price feeds. Valid time units are {s, m, h}. Minimum 1 second"`
+	RandomXMode                     string        `long:"randomxmode" description:"RandomX mode to verify proof of work in {light, full} -- light needs about 256 MiB of memory per RandomX key, full needs over 2 GiB per key but verifies faster"`
 	NetworkFlags
 	ServiceOptions *ServiceOptions
 }
@@ -193,6 +218,8 @@ func defaultFlags() *Flags {
 		MaxUTXOCacheSize:     defaultMaxUTXOCacheSize,
 		ServiceOptions:       &ServiceOptions{},
 		ProtocolVersion:      defaultProtocolVersion,
+		OracleFetchInterval:  defaultOracleFetchInterval,
+		RandomXMode:          defaultRandomXMode,
 	}
 }
 
@@ -364,6 +391,18 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// Validate the metrics listen address
+	if cfg.MetricsListen != "" {
+		_, _, err := net.SplitHostPort(cfg.MetricsListen)
+		if err != nil {
+			str := "%s: The metricslisten option must be an interface/port, such as 127.0.0.1:16120 -- parsed [%s]: %s"
+			err := errors.Errorf(str, funcName, cfg.MetricsListen, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	// Don't allow ban durations that are too short.
 	if cfg.BanDuration < time.Second {
 		str := "%s: The banduration option may not be less than 1s -- parsed [%s]"
@@ -373,6 +412,33 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// Price feeds are only useful if the network trusts some of them.
+	if len(cfg.OracleURLs) > 0 && len(cfg.ActiveNetParams.OraclePublicKeys) == 0 {
+		str := "%s: The oracleurl option requires the active network to define oracle public keys"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Don't allow oracle fetch intervals that are too short.
+	if cfg.OracleFetchInterval < time.Second {
+		str := "%s: The oraclefetchinterval option may not be less than 1s -- parsed [%s]"
+		err := errors.Errorf(str, funcName, cfg.OracleFetchInterval)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Validate the RandomX mode.
+	if _, err := pow.ParseMode(cfg.RandomXMode); err != nil {
+		str := "%s: The randomxmode option must be one of {light, full} -- parsed [%s]"
+		err := errors.Errorf(str, funcName, cfg.RandomXMode)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
 	// Validate any given whitelisted IP addresses and networks.
 	if len(cfg.Whitelists) > 0 {
 		var ip net.IP
@@ -449,6 +515,42 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// Check that the RPC credentials are complete and that the full and the
+	// limited credentials can be told apart.
+	if (cfg.RPCUser == "") != (cfg.RPCPass == "") {
+		str := "%s: --rpcuser and --rpcpass must be used together"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if (cfg.RPCLimitUser == "") != (cfg.RPCLimitPass == "") {
+		str := "%s: --rpclimituser and --rpclimitpass must be used together"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.RPCUser != "" && cfg.RPCUser == cfg.RPCLimitUser {
+		str := "%s: --rpcuser and --rpclimituser must not specify the same username"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.RPCAuthToken != "" && cfg.RPCAuthToken == cfg.RPCLimitAuthToken {
+		str := "%s: --rpcauthtoken and --rpclimitauthtoken must not specify the same token"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	isRPCAuthenticated := cfg.RPCUser != "" || cfg.RPCAuthToken != "" ||
+		cfg.RPCLimitUser != "" || cfg.RPCLimitAuthToken != ""
+	if isRPCAuthenticated && !cfg.RPCTLS {
+		log.Warnf("RPC authentication is enabled without --rpctls, so RPC credentials are sent unencrypted")
+	}
+
 	if cfg.RPCMaxConcurrentReqs < 0 {
 		str := "%s: The rpcmaxwebsocketconcurrentrequests option may " +
 			"not be less than 0 -- parsed [%d]"
@@ -541,11 +643,32 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	for _, onlyNet := range cfg.OnlyNets {
+		switch onlyNet {
+		case OnlyNetIPv4, OnlyNetIPv6:
+		case OnlyNetOnion:
+			if cfg.Proxy == "" {
+				str := "%s: --onlynet=%s requires --proxy, since onion services are only reachable through Tor"
+				err := errors.Errorf(str, funcName, onlyNet)
+				fmt.Fprintln(os.Stderr, err)
+				fmt.Fprintln(os.Stderr, usageMessage)
+				return nil, err
+			}
+		default:
+			str := "%s: --onlynet must be one of {%s, %s, %s}, but got %s"
+			err := errors.Errorf(str, funcName, OnlyNetIPv4, OnlyNetIPv6, OnlyNetOnion, onlyNet)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	// Setup dial and DNS resolution (lookup) functions depending on the
 	// specified options. The default is to use the standard
 	// net.DialTimeout function as well as the system DNS resolver. When a
 	// proxy is specified, the dial function is set to the proxy specific
-	// dial function.
+	// dial function, and host names are resolved through the proxy, unless
+	// --noproxydns is specified, so that DNS requests do not leak outside of it.
 	cfg.Dial = net.DialTimeout
 	cfg.Lookup = net.LookupIP
 	if cfg.Proxy != "" {
@@ -564,6 +687,12 @@ func LoadConfig() (*Config, error) {
 			Password: cfg.ProxyPass,
 		}
 		cfg.Dial = proxy.DialTimeout
+
+		if !cfg.NoProxyDNS {
+			cfg.Lookup = func(host string) ([]net.IP, error) {
+				return network.TorLookupIP(host, proxy.Addr, proxy.Username, proxy.Password)
+			}
+		}
 	}
 
 	// Warn about missing config file only after all other configuration is
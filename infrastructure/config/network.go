@@ -49,6 +49,10 @@ type overrideDAGParamsConfig struct {
 	DisableDifficultyAdjustment             *bool              `json:"disableDifficultyAdjustment"`
 	SkipProofOfWork                         *bool              `json:"skipProofOfWork"`
 	HardForkOmitGenesisFromParentsDAAScore  *uint64            `json:"hardForkOmitGenesisFromParentsDaaScore"`
+	OraclePublicKeys                        []string           `json:"oraclePublicKeys"`
+	OracleMinRecords                        *int               `json:"oracleMinRecords"`
+	OracleMaxRecordAgeInMilliseconds        *int64             `json:"oracleMaxRecordAgeInMilliseconds"`
+	OracleMaxPriceDeviation                 *uint64            `json:"oracleMaxPriceDeviation"`
 }
 
 // ResolveNetwork parses the network command line argument and sets NetParams accordingly.
@@ -213,5 +217,22 @@ func (networkFlags *NetworkFlags) overrideDAGParams() error {
 		networkFlags.ActiveNetParams.SkipProofOfWork = *config.SkipProofOfWork
 	}
 
+	if config.OraclePublicKeys != nil {
+		networkFlags.ActiveNetParams.OraclePublicKeys = config.OraclePublicKeys
+	}
+
+	if config.OracleMinRecords != nil {
+		networkFlags.ActiveNetParams.OracleMinRecords = *config.OracleMinRecords
+	}
+
+	if config.OracleMaxRecordAgeInMilliseconds != nil {
+		networkFlags.ActiveNetParams.OracleMaxRecordAge = time.Duration(*config.OracleMaxRecordAgeInMilliseconds) *
+			time.Millisecond
+	}
+
+	if config.OracleMaxPriceDeviation != nil {
+		networkFlags.ActiveNetParams.OracleMaxPriceDeviation = *config.OracleMaxPriceDeviation
+	}
+
 	return nil
 }
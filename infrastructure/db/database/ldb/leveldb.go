@@ -11,7 +11,11 @@ import (
 
 // LevelDB defines a thin wrapper around leveldb.
 type LevelDB struct {
-	ldb *leveldb.DB
+	ldb  *leveldb.DB
+	path string
+
+	// levelCount is the number of levels whose metrics were collected
+	levelCount int
 }
 
 // NewLevelDB opens a leveldb instance defined by the given path.
@@ -43,8 +47,10 @@ func NewLevelDB(path string, cacheSizeMiB int) (*LevelDB, error) {
 	}
 
 	db := &LevelDB{
-		ldb: ldb,
+		ldb:  ldb,
+		path: path,
 	}
+	addOpenDatabase(db)
 	return db, nil
 }
 
@@ -56,6 +62,7 @@ func (db *LevelDB) Compact() error {
 
 // Close closes the leveldb instance.
 func (db *LevelDB) Close() error {
+	removeOpenDatabase(db)
 	err := db.ldb.Close()
 	return errors.WithStack(err)
 }
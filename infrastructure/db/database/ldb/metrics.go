@@ -0,0 +1,117 @@
+package ldb
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/Kash-Protocol/kashd/infrastructure/metrics"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// The LevelDB statistics are kept up to date by LevelDB itself, so they are only
+// read when the metrics are collected, for every open database
+var (
+	ioReadBytes = metrics.NewGaugeVec("kashd_leveldb_io_read_bytes",
+		"The number of bytes LevelDB read from disk since the database was opened", "path")
+	ioWriteBytes = metrics.NewGaugeVec("kashd_leveldb_io_write_bytes",
+		"The number of bytes LevelDB wrote to disk since the database was opened", "path")
+	writeDelays = metrics.NewGaugeVec("kashd_leveldb_write_delays",
+		"The number of writes LevelDB delayed due to compaction since the database was opened", "path")
+	writeDelaySeconds = metrics.NewGaugeVec("kashd_leveldb_write_delay_seconds",
+		"The time writes were delayed by LevelDB due to compaction since the database was opened", "path")
+	writePaused = metrics.NewGaugeVec("kashd_leveldb_write_paused",
+		"Whether writes are currently paused by LevelDB due to compaction", "path")
+	aliveSnapshots = metrics.NewGaugeVec("kashd_leveldb_alive_snapshots",
+		"The number of open LevelDB snapshots", "path")
+	aliveIterators = metrics.NewGaugeVec("kashd_leveldb_alive_iterators",
+		"The number of open LevelDB iterators", "path")
+	blockCacheBytes = metrics.NewGaugeVec("kashd_leveldb_block_cache_bytes",
+		"The size of the LevelDB block cache", "path")
+	openedTables = metrics.NewGaugeVec("kashd_leveldb_opened_tables",
+		"The number of LevelDB tables that are currently open", "path")
+	levelSizeBytes = metrics.NewGaugeVec("kashd_leveldb_level_size_bytes",
+		"The size of the tables of each LevelDB level", "path", "level")
+	levelTables = metrics.NewGaugeVec("kashd_leveldb_level_tables",
+		"The number of tables of each LevelDB level", "path", "level")
+	compactions = metrics.NewGaugeVec("kashd_leveldb_compactions",
+		"The number of LevelDB compactions of each type since the database was opened", "path", "type")
+)
+
+var (
+	openDatabases      = map[*LevelDB]struct{}{}
+	openDatabasesMutex sync.Mutex
+)
+
+func init() {
+	metrics.OnCollect(collectMetrics)
+}
+
+func addOpenDatabase(db *LevelDB) {
+	openDatabasesMutex.Lock()
+	defer openDatabasesMutex.Unlock()
+
+	openDatabases[db] = struct{}{}
+}
+
+// removeOpenDatabase stops collecting the metrics of the given database and removes its series
+func removeOpenDatabase(db *LevelDB) {
+	openDatabasesMutex.Lock()
+	defer openDatabasesMutex.Unlock()
+
+	delete(openDatabases, db)
+
+	for _, gaugeVec := range []*metrics.GaugeVec{ioReadBytes, ioWriteBytes, writeDelays, writeDelaySeconds,
+		writePaused, aliveSnapshots, aliveIterators, blockCacheBytes, openedTables} {
+		gaugeVec.Delete(db.path)
+	}
+	for level := 0; level < db.levelCount; level++ {
+		levelSizeBytes.Delete(db.path, strconv.Itoa(level))
+		levelTables.Delete(db.path, strconv.Itoa(level))
+	}
+	for _, compactionType := range compactionTypes {
+		compactions.Delete(db.path, compactionType)
+	}
+}
+
+var compactionTypes = []string{"memory", "level0", "non_level0", "seek"}
+
+func collectMetrics() {
+	openDatabasesMutex.Lock()
+	defer openDatabasesMutex.Unlock()
+
+	for db := range openDatabases {
+		stats := &leveldb.DBStats{}
+		err := db.ldb.Stats(stats)
+		if err != nil {
+			log.Warnf("Could not collect the statistics of the database at %s: %s", db.path, err)
+			continue
+		}
+
+		ioReadBytes.WithLabelValues(db.path).Set(float64(stats.IORead))
+		ioWriteBytes.WithLabelValues(db.path).Set(float64(stats.IOWrite))
+		writeDelays.WithLabelValues(db.path).Set(float64(stats.WriteDelayCount))
+		writeDelaySeconds.WithLabelValues(db.path).Set(stats.WriteDelayDuration.Seconds())
+		if stats.WritePaused {
+			writePaused.WithLabelValues(db.path).Set(1)
+		} else {
+			writePaused.WithLabelValues(db.path).Set(0)
+		}
+		aliveSnapshots.WithLabelValues(db.path).Set(float64(stats.AliveSnapshots))
+		aliveIterators.WithLabelValues(db.path).Set(float64(stats.AliveIterators))
+		blockCacheBytes.WithLabelValues(db.path).Set(float64(stats.BlockCacheSize))
+		openedTables.WithLabelValues(db.path).Set(float64(stats.OpenedTablesCount))
+
+		for level, size := range stats.LevelSizes {
+			levelSizeBytes.WithLabelValues(db.path, strconv.Itoa(level)).Set(float64(size))
+			levelTables.WithLabelValues(db.path, strconv.Itoa(level)).Set(float64(stats.LevelTablesCounts[level]))
+		}
+		if len(stats.LevelSizes) > db.levelCount {
+			db.levelCount = len(stats.LevelSizes)
+		}
+
+		compactionCounts := []uint32{stats.MemComp, stats.Level0Comp, stats.NonLevel0Comp, stats.SeekComp}
+		for i, compactionType := range compactionTypes {
+			compactions.WithLabelValues(db.path, compactionType).Set(float64(compactionCounts[i]))
+		}
+	}
+}
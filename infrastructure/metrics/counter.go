@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+const counterType = "counter"
+
+// Counter is a metric whose value only ever increases, such as the number of processed blocks
+type Counter struct {
+	value atomicFloat64
+}
+
+// Inc increases the counter by 1
+func (c *Counter) Inc() {
+	c.value.add(1)
+}
+
+// Add increases the counter by the given delta, which must not be negative
+func (c *Counter) Add(delta float64) {
+	if delta < 0 {
+		panic(errors.Errorf("counters cannot decrease, but got a delta of %f", delta))
+	}
+	c.value.add(delta)
+}
+
+// Value returns the current value of the counter
+func (c *Counter) Value() float64 {
+	return c.value.load()
+}
+
+type registeredCounter struct {
+	metadata
+	*Counter
+}
+
+func (c *registeredCounter) write(w io.Writer) {
+	c.writeHeader(w)
+	fmt.Fprintf(w, "%s %s\n", c.metricName, formatValue(c.Value()))
+}
+
+// NewCounter registers and returns a new counter with the given name and help text
+func NewCounter(name string, help string) *Counter {
+	counter := &registeredCounter{
+		metadata: metadata{metricName: name, help: help, metricType: counterType},
+		Counter:  &Counter{},
+	}
+	defaultRegistry.register(counter)
+	return counter.Counter
+}
+
+// CounterVec is a counter with labels, which holds a separate counter for every combination
+// of label values
+type CounterVec struct {
+	vector *vector
+}
+
+// NewCounterVec registers and returns a new counter with the given name, help text and label names
+func NewCounterVec(name string, help string, labelNames ...string) *CounterVec {
+	counterVec := &CounterVec{
+		vector: newVector(metadata{metricName: name, help: help, metricType: counterType, labelNames: labelNames},
+			func() interface{} { return &Counter{} }),
+	}
+	defaultRegistry.register(counterVec)
+	return counterVec
+}
+
+// WithLabelValues returns the counter of the given label values, which are given in the order
+// of the label names
+func (cv *CounterVec) WithLabelValues(labelValues ...string) *Counter {
+	return cv.vector.get(labelValues).(*Counter)
+}
+
+func (cv *CounterVec) name() string {
+	return cv.vector.name()
+}
+
+func (cv *CounterVec) write(w io.Writer) {
+	cv.vector.writeHeader(w)
+	cv.vector.forEach(func(labelValues []string, series interface{}) {
+		labels := formatLabels(cv.vector.labelNames, labelValues)
+		fmt.Fprintf(w, "%s%s %s\n", cv.vector.metricName, labels, formatValue(series.(*Counter).Value()))
+	})
+}
@@ -0,0 +1,17 @@
+/*
+Package metrics implements the metrics kashd exports in the Prometheus text
+exposition format.
+
+Metrics are registered by the subsystems that own them, the same way subsystem
+loggers are, by declaring them in a global variable:
+
+	var blocksProcessed = metrics.NewCounter("kashd_blocks_processed_total",
+		"The number of blocks processed")
+
+Metrics that are costly to keep up to date, such as database statistics, may be
+updated right before they are collected with OnCollect.
+
+The metrics are served over HTTP by Start, which kashd calls when --metricslisten
+is specified.
+*/
+package metrics
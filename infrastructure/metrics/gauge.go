@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+)
+
+const gaugeType = "gauge"
+
+// Gauge is a metric whose value may go up and down, such as the number of transactions in the mempool
+type Gauge struct {
+	value atomicFloat64
+}
+
+// Set sets the gauge to the given value
+func (g *Gauge) Set(value float64) {
+	g.value.store(value)
+}
+
+// Add adds the given delta, which may be negative, to the gauge
+func (g *Gauge) Add(delta float64) {
+	g.value.add(delta)
+}
+
+// Inc increases the gauge by 1
+func (g *Gauge) Inc() {
+	g.value.add(1)
+}
+
+// Dec decreases the gauge by 1
+func (g *Gauge) Dec() {
+	g.value.add(-1)
+}
+
+// Value returns the current value of the gauge
+func (g *Gauge) Value() float64 {
+	return g.value.load()
+}
+
+type registeredGauge struct {
+	metadata
+	*Gauge
+}
+
+func (g *registeredGauge) write(w io.Writer) {
+	g.writeHeader(w)
+	fmt.Fprintf(w, "%s %s\n", g.metricName, formatValue(g.Value()))
+}
+
+// NewGauge registers and returns a new gauge with the given name and help text
+func NewGauge(name string, help string) *Gauge {
+	gauge := &registeredGauge{
+		metadata: metadata{metricName: name, help: help, metricType: gaugeType},
+		Gauge:    &Gauge{},
+	}
+	defaultRegistry.register(gauge)
+	return gauge.Gauge
+}
+
+type gaugeFunc struct {
+	metadata
+	value func() float64
+}
+
+func (g *gaugeFunc) write(w io.Writer) {
+	g.writeHeader(w)
+	fmt.Fprintf(w, "%s %s\n", g.metricName, formatValue(g.value()))
+}
+
+// NewGaugeFunc registers a new gauge with the given name and help text, whose value is
+// returned by the given function every time the metrics are collected
+func NewGaugeFunc(name string, help string, value func() float64) {
+	defaultRegistry.register(&gaugeFunc{
+		metadata: metadata{metricName: name, help: help, metricType: gaugeType},
+		value:    value,
+	})
+}
+
+// GaugeVec is a gauge with labels, which holds a separate gauge for every combination
+// of label values
+type GaugeVec struct {
+	vector *vector
+}
+
+// NewGaugeVec registers and returns a new gauge with the given name, help text and label names
+func NewGaugeVec(name string, help string, labelNames ...string) *GaugeVec {
+	gaugeVec := &GaugeVec{
+		vector: newVector(metadata{metricName: name, help: help, metricType: gaugeType, labelNames: labelNames},
+			func() interface{} { return &Gauge{} }),
+	}
+	defaultRegistry.register(gaugeVec)
+	return gaugeVec
+}
+
+// WithLabelValues returns the gauge of the given label values, which are given in the order
+// of the label names
+func (gv *GaugeVec) WithLabelValues(labelValues ...string) *Gauge {
+	return gv.vector.get(labelValues).(*Gauge)
+}
+
+// Delete removes the gauge of the given label values, so that it is no longer collected
+func (gv *GaugeVec) Delete(labelValues ...string) {
+	gv.vector.delete(labelValues)
+}
+
+func (gv *GaugeVec) name() string {
+	return gv.vector.name()
+}
+
+func (gv *GaugeVec) write(w io.Writer) {
+	gv.vector.writeHeader(w)
+	gv.vector.forEach(func(labelValues []string, series interface{}) {
+		labels := formatLabels(gv.vector.labelNames, labelValues)
+		fmt.Fprintf(w, "%s%s %s\n", gv.vector.metricName, labels, formatValue(series.(*Gauge).Value()))
+	})
+}
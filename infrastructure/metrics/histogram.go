@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+const histogramType = "histogram"
+
+// DurationBuckets are histogram buckets, in seconds, that fit durations between a millisecond and
+// ten seconds, such as the processing time of a block or of an RPC request
+var DurationBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Histogram is a metric that counts observed values, such as durations, in buckets
+type Histogram struct {
+	upperBounds  []float64
+	bucketCounts []uint64
+	count        uint64
+	sum          float64
+	mutex        sync.Mutex
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	upperBounds := append([]float64(nil), buckets...)
+	sort.Float64s(upperBounds)
+	return &Histogram{
+		upperBounds:  upperBounds,
+		bucketCounts: make([]uint64, len(upperBounds)),
+	}
+}
+
+// Observe adds the given value to the histogram
+func (h *Histogram) Observe(value float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	bucketIndex := sort.SearchFloat64s(h.upperBounds, value)
+	if bucketIndex < len(h.bucketCounts) {
+		h.bucketCounts[bucketIndex]++
+	}
+	h.count++
+	h.sum += value
+}
+
+// ObserveSince adds the time that passed since the given start time, in seconds, to the histogram
+func (h *Histogram) ObserveSince(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+// Count returns the number of values observed by the histogram
+func (h *Histogram) Count() uint64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return h.count
+}
+
+// writeSeries writes the cumulative buckets, sum and count of the histogram.
+// labelNames and labelValues are the labels of the series, if it is part of a HistogramVec.
+func (h *Histogram) writeSeries(w io.Writer, name string, labelNames []string, labelValues []string) {
+	h.mutex.Lock()
+	bucketCounts := append([]uint64(nil), h.bucketCounts...)
+	count, sum := h.count, h.sum
+	h.mutex.Unlock()
+
+	bucketLabelNames := append(append([]string(nil), labelNames...), "le")
+	cumulativeCount := uint64(0)
+	for i, upperBound := range h.upperBounds {
+		cumulativeCount += bucketCounts[i]
+		bucketLabelValues := append(append([]string(nil), labelValues...), formatValue(upperBound))
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(bucketLabelNames, bucketLabelValues), cumulativeCount)
+	}
+	infLabelValues := append(append([]string(nil), labelValues...), "+Inf")
+	fmt.Fprintf(w, "%s_bucket%s %d\n", name, formatLabels(bucketLabelNames, infLabelValues), count)
+
+	labels := formatLabels(labelNames, labelValues)
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, labels, formatValue(sum))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labels, count)
+}
+
+type registeredHistogram struct {
+	metadata
+	*Histogram
+}
+
+func (h *registeredHistogram) write(w io.Writer) {
+	h.writeHeader(w)
+	h.writeSeries(w, h.metricName, nil, nil)
+}
+
+// NewHistogram registers and returns a new histogram with the given name, help text and bucket
+// upper bounds
+func NewHistogram(name string, help string, buckets []float64) *Histogram {
+	histogram := &registeredHistogram{
+		metadata:  metadata{metricName: name, help: help, metricType: histogramType},
+		Histogram: newHistogram(buckets),
+	}
+	defaultRegistry.register(histogram)
+	return histogram.Histogram
+}
+
+// HistogramVec is a histogram with labels, which holds a separate histogram for every combination
+// of label values
+type HistogramVec struct {
+	vector *vector
+}
+
+// NewHistogramVec registers and returns a new histogram with the given name, help text, bucket
+// upper bounds and label names
+func NewHistogramVec(name string, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	histogramVec := &HistogramVec{
+		vector: newVector(metadata{metricName: name, help: help, metricType: histogramType, labelNames: labelNames},
+			func() interface{} { return newHistogram(buckets) }),
+	}
+	defaultRegistry.register(histogramVec)
+	return histogramVec
+}
+
+// WithLabelValues returns the histogram of the given label values, which are given in the order
+// of the label names
+func (hv *HistogramVec) WithLabelValues(labelValues ...string) *Histogram {
+	return hv.vector.get(labelValues).(*Histogram)
+}
+
+func (hv *HistogramVec) name() string {
+	return hv.vector.name()
+}
+
+func (hv *HistogramVec) write(w io.Writer) {
+	hv.vector.writeHeader(w)
+	hv.vector.forEach(func(labelValues []string, series interface{}) {
+		series.(*Histogram).writeSeries(w, hv.vector.metricName, hv.vector.labelNames, labelValues)
+	})
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	counter := NewCounter("test_counter_total", "A test counter")
+	counter.Add(2)
+	counter.Inc()
+
+	gauge := NewGauge("test_gauge", "A test gauge\nwith a second line")
+	gauge.Set(10)
+	gauge.Dec()
+
+	gaugeVec := NewGaugeVec("test_gauge_vec", "A test gauge with labels", "direction", "version")
+	gaugeVec.WithLabelValues("outbound", "5").Inc()
+	gaugeVec.WithLabelValues("inbound", "5").Add(2)
+	gaugeVec.WithLabelValues("inbound", "4").Inc()
+	gaugeVec.Delete("inbound", "4")
+
+	counterVec := NewCounterVec("test_counter_vec_total", "A test counter with labels", "command")
+	counterVec.WithLabelValues(`a "quoted" command`).Inc()
+
+	histogram := NewHistogram("test_histogram_seconds", "A test histogram", []float64{1, 0.1})
+	histogram.Observe(0.05)
+	histogram.Observe(0.1)
+	histogram.Observe(0.5)
+	histogram.Observe(20)
+
+	NewGaugeFunc("test_gauge_func", "A test gauge function", func() float64 { return 0.25 })
+
+	collectedGauge := NewGauge("test_collected_gauge", "A test gauge that is updated on collection")
+	OnCollect(func() { collectedGauge.Set(42) })
+
+	var buffer bytes.Buffer
+	err := WriteTo(&buffer)
+	if err != nil {
+		t.Fatalf("WriteTo: %+v", err)
+	}
+	output := buffer.String()
+
+	expectedOutputs := []string{
+		"# HELP test_counter_total A test counter\n# TYPE test_counter_total counter\ntest_counter_total 3\n",
+		"# HELP test_gauge A test gauge\\nwith a second line\n# TYPE test_gauge gauge\ntest_gauge 9\n",
+		"# TYPE test_gauge_vec gauge\n" +
+			"test_gauge_vec{direction=\"inbound\",version=\"5\"} 2\n" +
+			"test_gauge_vec{direction=\"outbound\",version=\"5\"} 1\n",
+		"test_counter_vec_total{command=\"a \\\"quoted\\\" command\"} 1\n",
+		"# TYPE test_histogram_seconds histogram\n" +
+			"test_histogram_seconds_bucket{le=\"0.1\"} 2\n" +
+			"test_histogram_seconds_bucket{le=\"1\"} 3\n" +
+			"test_histogram_seconds_bucket{le=\"+Inf\"} 4\n" +
+			"test_histogram_seconds_sum 20.65\n" +
+			"test_histogram_seconds_count 4\n",
+		"test_gauge_func 0.25\n",
+		"test_collected_gauge 42\n",
+	}
+	for _, expectedOutput := range expectedOutputs {
+		if !strings.Contains(output, expectedOutput) {
+			t.Errorf("Expected the output to contain:\n%s\nbut got:\n%s", expectedOutput, output)
+		}
+	}
+	if strings.Contains(output, "version=\"4\"") {
+		t.Errorf("Expected a deleted series not to be written, but got:\n%s", output)
+	}
+	if strings.Index(output, "test_collected_gauge") > strings.Index(output, "test_counter_total") {
+		t.Errorf("Expected the metrics to be sorted by name, but got:\n%s", output)
+	}
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	NewCounter("test_duplicate_total", "A test counter")
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected registering a duplicate metric to panic")
+		}
+	}()
+	NewGauge("test_duplicate_total", "A test gauge with the same name")
+}
@@ -0,0 +1,149 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// collector is a registered metric, which writes all of its series when the metrics are collected
+type collector interface {
+	name() string
+	write(w io.Writer)
+}
+
+type registry struct {
+	collectors   map[string]collector
+	collectHooks []func()
+	mutex        sync.Mutex
+}
+
+var defaultRegistry = newRegistry()
+
+func newRegistry() *registry {
+	return &registry{
+		collectors: map[string]collector{},
+	}
+}
+
+// register registers the given collector. It panics if a metric with the same name is already
+// registered, since metrics are registered in global variables and this is a programming error.
+func (r *registry) register(c collector) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.collectors[c.name()]; exists {
+		panic(errors.Errorf("metric %s is already registered", c.name()))
+	}
+	r.collectors[c.name()] = c
+}
+
+func (r *registry) onCollect(hook func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.collectHooks = append(r.collectHooks, hook)
+}
+
+// writeTo runs the collect hooks and writes all the registered metrics, sorted by name,
+// in the Prometheus text exposition format
+func (r *registry) writeTo(w io.Writer) error {
+	r.mutex.Lock()
+	collectHooks := make([]func(), len(r.collectHooks))
+	copy(collectHooks, r.collectHooks)
+	collectors := make([]collector, 0, len(r.collectors))
+	for _, c := range r.collectors {
+		collectors = append(collectors, c)
+	}
+	r.mutex.Unlock()
+
+	for _, hook := range collectHooks {
+		hook()
+	}
+
+	sort.Slice(collectors, func(i, j int) bool {
+		return collectors[i].name() < collectors[j].name()
+	})
+
+	bufferedWriter := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.write(bufferedWriter)
+	}
+	return bufferedWriter.Flush()
+}
+
+// OnCollect registers a function that is called every time the metrics are collected, right
+// before they are written. It lets subsystems update metrics that are costly to keep up to date.
+func OnCollect(hook func()) {
+	defaultRegistry.onCollect(hook)
+}
+
+// WriteTo writes all the registered metrics in the Prometheus text exposition format
+func WriteTo(w io.Writer) error {
+	return defaultRegistry.writeTo(w)
+}
+
+// metadata is the name, help text, type and label names of a metric
+type metadata struct {
+	metricName string
+	help       string
+	metricType string
+	labelNames []string
+}
+
+func (m *metadata) name() string {
+	return m.metricName
+}
+
+func (m *metadata) writeHeader(w io.Writer) {
+	help := strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(m.help)
+	fmt.Fprintf(w, "# HELP %s %s\n", m.metricName, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", m.metricName, m.metricType)
+}
+
+// checkLabelValues panics if the number of the given label values does not match the label names
+func (m *metadata) checkLabelValues(labelValues []string) {
+	if len(labelValues) != len(m.labelNames) {
+		panic(errors.Errorf("metric %s has %d labels, but got %d label values",
+			m.metricName, len(m.labelNames), len(labelValues)))
+	}
+}
+
+// labelsKey returns a key that uniquely identifies the series with the given label values
+func labelsKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+// formatLabels returns the given labels as they appear after the metric name, such as
+// {direction="inbound",protocol_version="5"}, or an empty string if there are no labels
+func formatLabels(labelNames []string, labelValues []string) string {
+	if len(labelNames) == 0 {
+		return ""
+	}
+	valueReplacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, len(labelNames))
+	for i, labelName := range labelNames {
+		pairs[i] = fmt.Sprintf(`%s="%s"`, labelName, valueReplacer.Replace(labelValues[i]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
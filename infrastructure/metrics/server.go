@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/Kash-Protocol/kashd/infrastructure/logger"
+	"github.com/Kash-Protocol/kashd/util/panics"
+)
+
+// metricsPath is the HTTP path the metrics are served on
+const metricsPath = "/metrics"
+
+// contentType is the content type of the Prometheus text exposition format
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Handler returns an HTTP handler that serves all the registered metrics
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		err := WriteTo(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// Start starts an HTTP server that serves the metrics on the given listen address
+func Start(listenAddr string, log *logger.Logger) {
+	spawn := panics.GoroutineWrapperFunc(log)
+	spawn("metrics.Start", func() {
+		log.Infof("Metrics server listening on %s%s", listenAddr, metricsPath)
+		serveMux := http.NewServeMux()
+		serveMux.Handle(metricsPath, Handler())
+		log.Error(http.ListenAndServe(listenAddr, serveMux))
+	})
+}
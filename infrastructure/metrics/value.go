@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// atomicFloat64 is a float64 that is safe for concurrent use
+type atomicFloat64 struct {
+	bits uint64
+}
+
+func (f *atomicFloat64) load() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&f.bits))
+}
+
+func (f *atomicFloat64) store(value float64) {
+	atomic.StoreUint64(&f.bits, math.Float64bits(value))
+}
+
+func (f *atomicFloat64) add(delta float64) {
+	for {
+		oldBits := atomic.LoadUint64(&f.bits)
+		newBits := math.Float64bits(math.Float64frombits(oldBits) + delta)
+		if atomic.CompareAndSwapUint64(&f.bits, oldBits, newBits) {
+			return
+		}
+	}
+}
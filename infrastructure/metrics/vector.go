@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+// vector holds the series of a metric with labels, one for every combination of label values
+type vector struct {
+	metadata
+	newSeries   func() interface{}
+	series      map[string]interface{}
+	labelValues map[string][]string
+	mutex       sync.RWMutex
+}
+
+func newVector(metadata metadata, newSeries func() interface{}) *vector {
+	return &vector{
+		metadata:    metadata,
+		newSeries:   newSeries,
+		series:      map[string]interface{}{},
+		labelValues: map[string][]string{},
+	}
+}
+
+// get returns the series of the given label values, creating it if it does not exist yet
+func (v *vector) get(labelValues []string) interface{} {
+	v.checkLabelValues(labelValues)
+	key := labelsKey(labelValues)
+
+	v.mutex.RLock()
+	series, ok := v.series[key]
+	v.mutex.RUnlock()
+	if ok {
+		return series
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	series, ok = v.series[key]
+	if !ok {
+		series = v.newSeries()
+		v.series[key] = series
+		v.labelValues[key] = append([]string(nil), labelValues...)
+	}
+	return series
+}
+
+// delete removes the series of the given label values
+func (v *vector) delete(labelValues []string) {
+	v.checkLabelValues(labelValues)
+	key := labelsKey(labelValues)
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	delete(v.series, key)
+	delete(v.labelValues, key)
+}
+
+// forEach calls f with the label values and series of every combination of label values,
+// sorted by label values
+func (v *vector) forEach(f func(labelValues []string, series interface{})) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		f(v.labelValues[key], v.series[key])
+	}
+}
@@ -5,11 +5,12 @@
 package addressmanager
 
 import (
+	"github.com/Kash-Protocol/kashd/infrastructure/config"
 	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
 	"github.com/Kash-Protocol/kashd/util/mstime"
+	"github.com/Kash-Protocol/kashd/util/network"
 	"net"
 	"sync"
-	"time"
 
 	"github.com/Kash-Protocol/kashd/app/appmessage"
 	"github.com/pkg/errors"
@@ -25,10 +26,21 @@ type addressRandomizer interface {
 	RandomAddresses(addresses []*address, count int) []*appmessage.NetAddress
 }
 
-// addressKey represents a pair of IP and port, the IP is always in V6 representation
-type addressKey struct {
-	port    uint16
+// hostKey represents the host of an address, which is either an IP, always in V6 representation,
+// or the public key of a Tor onion service
+type hostKey struct {
 	address ipv6
+	onion   onionPublicKey
+}
+
+func (key hostKey) isOnion() bool {
+	return key.onion != onionPublicKey{}
+}
+
+// addressKey represents a pair of host and port
+type addressKey struct {
+	port uint16
+	hostKey
 }
 
 type address struct {
@@ -38,9 +50,7 @@ type address struct {
 
 type ipv6 [net.IPv6len]byte
 
-func (i ipv6) equal(other ipv6) bool {
-	return i == other
-}
+type onionPublicKey [network.OnionPublicKeyLength]byte
 
 // ErrAddressNotFound is an error returned from some functions when a
 // given address is not found in the address manager
@@ -49,6 +59,10 @@ var ErrAddressNotFound = errors.New("address not found")
 // NetAddressKey returns a key of the ip address to use it in maps.
 func netAddressKey(netAddress *appmessage.NetAddress) addressKey {
 	key := addressKey{port: netAddress.Port}
+	if netAddress.IsOnion() {
+		copy(key.onion[:], netAddress.OnionPublicKey)
+		return key
+	}
 	// all IPv4 can be represented as IPv6.
 	copy(key.address[:], netAddress.IP.To16())
 	return key
@@ -160,7 +174,7 @@ func (am *AddressManager) MarkConnectionFailure(address *appmessage.NetAddress)
 	key := netAddressKey(address)
 	entry, ok := am.store.getNotBanned(key)
 	if !ok {
-		return errors.Errorf("address %s is not registered with the address manager", address.TCPAddress())
+		return errors.Errorf("address %s is not registered with the address manager", address)
 	}
 	entry.connectionFailedCount = entry.connectionFailedCount + 1
 
@@ -181,7 +195,7 @@ func (am *AddressManager) MarkConnectionSuccess(address *appmessage.NetAddress)
 	key := netAddressKey(address)
 	entry, ok := am.store.getNotBanned(key)
 	if !ok {
-		return errors.Errorf("address %s is not registered with the address manager", address.TCPAddress())
+		return errors.Errorf("address %s is not registered with the address manager", address)
 	}
 	entry.connectionFailedCount = 0
 	return am.store.updateNotBanned(key, entry)
@@ -211,12 +225,44 @@ func (am *AddressManager) notBannedAddressesWithException(exceptions []*appmessa
 	return am.store.getAllNotBannedNetAddressesWithout(exceptions)
 }
 
-// RandomAddresses returns count addresses at random that aren't banned and aren't in exceptions
+// RandomAddresses returns count addresses at random that aren't banned, aren't in exceptions
+// and are reachable
 func (am *AddressManager) RandomAddresses(count int, exceptions []*appmessage.NetAddress) []*appmessage.NetAddress {
-	validAddresses := am.notBannedAddressesWithException(exceptions)
+	notBannedAddresses := am.notBannedAddressesWithException(exceptions)
+	validAddresses := make([]*address, 0, len(notBannedAddresses))
+	for _, address := range notBannedAddresses {
+		if am.isReachable(address.netAddress) {
+			validAddresses = append(validAddresses, address)
+		}
+	}
 	return am.random.RandomAddresses(validAddresses, count)
 }
 
+// isReachable returns whether outbound connections may be made to the given address.
+// Onion services are only reachable through a proxy, and if --onlynet is specified,
+// only addresses of the given networks are reachable.
+func (am *AddressManager) isReachable(address *appmessage.NetAddress) bool {
+	if address.IsOnion() && !am.cfg.OnionReachable {
+		return false
+	}
+	if len(am.cfg.OnlyNets) == 0 {
+		return true
+	}
+
+	addressNet := config.OnlyNetIPv6
+	if address.IsOnion() {
+		addressNet = config.OnlyNetOnion
+	} else if IsIPv4(address) {
+		addressNet = config.OnlyNetIPv4
+	}
+	for _, onlyNet := range am.cfg.OnlyNets {
+		if onlyNet == addressNet {
+			return true
+		}
+	}
+	return false
+}
+
 // BestLocalAddress returns the most appropriate local address to use
 // for the given remote address.
 func (am *AddressManager) BestLocalAddress(remoteAddress *appmessage.NetAddress) *appmessage.NetAddress {
@@ -232,7 +278,7 @@ func (am *AddressManager) Ban(addressToBan *appmessage.NetAddress) error {
 	keysToDelete := make([]addressKey, 0)
 	for _, address := range am.store.getAllNotBannedNetAddresses() {
 		key := netAddressKey(address)
-		if key.address.equal(keyToBan.address) {
+		if key.hostKey == keyToBan.hostKey {
 			keysToDelete = append(keysToDelete, key)
 		}
 	}
@@ -255,7 +301,7 @@ func (am *AddressManager) Unban(address *appmessage.NetAddress) error {
 	key := netAddressKey(address)
 	if !am.store.isBanned(key) {
 		return errors.Wrapf(ErrAddressNotFound, "address %s "+
-			"is not registered with the address manager as banned", address.TCPAddress())
+			"is not registered with the address manager as banned", address)
 	}
 
 	return am.store.removeBanned(key)
@@ -274,7 +320,7 @@ func (am *AddressManager) IsBanned(address *appmessage.NetAddress) (bool, error)
 	if !am.store.isBanned(key) {
 		if !am.store.isNotBanned(key) {
 			return false, errors.Wrapf(ErrAddressNotFound, "address %s "+
-				"is not registered with the address manager", address.TCPAddress())
+				"is not registered with the address manager", address)
 		}
 		return false, nil
 	}
@@ -288,8 +334,7 @@ func (am *AddressManager) unbanIfOldEnough(key addressKey) error {
 		return nil
 	}
 
-	const maxBanTime = 24 * time.Hour
-	if mstime.Since(address.netAddress.Timestamp) > maxBanTime {
+	if mstime.Since(address.netAddress.Timestamp) > am.cfg.BanDuration {
 		err := am.store.removeBanned(key)
 		if err != nil {
 			return err
@@ -372,3 +372,73 @@ func TestOverfillAddressManager(t *testing.T) {
 		}
 	}
 }
+
+func TestRandomAddressesReachability(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestRandomAddressesReachability")
+	defer teardown()
+
+	ipv4Address := &appmessage.NetAddress{IP: net.ParseIP("173.194.115.66"), Port: 16111, Timestamp: mstime.Now()}
+	ipv6Address := &appmessage.NetAddress{IP: net.ParseIP("2602:100:abcd::102"), Port: 16111, Timestamp: mstime.Now()}
+	onionAddress := &appmessage.NetAddress{OnionPublicKey: make([]byte, 32), Port: 16111, Timestamp: mstime.Now()}
+	onionAddress.OnionPublicKey[0] = 1
+	err := addressManager.AddAddresses(ipv4Address, ipv6Address, onionAddress)
+	if err != nil {
+		t.Fatalf("AddAddresses: %s", err)
+	}
+
+	tests := []struct {
+		name           string
+		onlyNets       []string
+		onionReachable bool
+		expected       []*appmessage.NetAddress
+	}{
+		{
+			name:     "no proxy",
+			expected: []*appmessage.NetAddress{ipv4Address, ipv6Address},
+		},
+		{
+			name:           "proxy",
+			onionReachable: true,
+			expected:       []*appmessage.NetAddress{ipv4Address, ipv6Address, onionAddress},
+		},
+		{
+			name:           "only onion",
+			onlyNets:       []string{config.OnlyNetOnion},
+			onionReachable: true,
+			expected:       []*appmessage.NetAddress{onionAddress},
+		},
+		{
+			name:           "only ipv4 and ipv6",
+			onlyNets:       []string{config.OnlyNetIPv4, config.OnlyNetIPv6},
+			onionReachable: true,
+			expected:       []*appmessage.NetAddress{ipv4Address, ipv6Address},
+		},
+		{
+			name:     "only ipv6",
+			onlyNets: []string{config.OnlyNetIPv6},
+			expected: []*appmessage.NetAddress{ipv6Address},
+		},
+	}
+
+	for _, test := range tests {
+		addressManager.cfg.OnlyNets = test.onlyNets
+		addressManager.cfg.OnionReachable = test.onionReachable
+
+		randomAddresses := addressManager.RandomAddresses(3, nil)
+		if len(randomAddresses) != len(test.expected) {
+			t.Fatalf("%s: expected %d addresses, but got %d", test.name, len(test.expected), len(randomAddresses))
+		}
+		for _, expectedAddress := range test.expected {
+			found := false
+			for _, randomAddress := range randomAddresses {
+				if randomAddress == expectedAddress {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Fatalf("%s: expected %s to be returned", test.name, expectedAddress)
+			}
+		}
+	}
+}
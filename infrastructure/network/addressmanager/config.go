@@ -2,6 +2,7 @@ package addressmanager
 
 import (
 	"net"
+	"time"
 
 	"github.com/Kash-Protocol/kashd/infrastructure/config"
 )
@@ -13,6 +14,9 @@ type Config struct {
 	ExternalIPs      []string
 	Listeners        []string
 	Lookup           func(string) ([]net.IP, error)
+	BanDuration      time.Duration
+	OnlyNets         []string
+	OnionReachable   bool
 }
 
 // NewConfig returns a new address manager Config.
@@ -23,5 +27,8 @@ func NewConfig(cfg *config.Config) *Config {
 		ExternalIPs:      cfg.ExternalIPs,
 		Listeners:        cfg.Listeners,
 		Lookup:           cfg.Lookup,
+		BanDuration:      cfg.BanDuration,
+		OnlyNets:         cfg.OnlyNets,
+		OnionReachable:   cfg.Proxy != "",
 	}
 }
@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/util/network"
 	"github.com/pkg/errors"
 )
 
@@ -63,7 +64,7 @@ func newLocalAddressManager(cfg *Config) (*localAddressManager, error) {
 // with the given priority.
 func (lam *localAddressManager) addLocalNetAddress(netAddress *appmessage.NetAddress, priority AddressPriority) error {
 	if !IsRoutable(netAddress, lam.cfg.AcceptUnroutable) {
-		return errors.Errorf("address %s is not routable", netAddress.IP)
+		return errors.Errorf("address %s is not routable", netAddress.Host())
 	}
 
 	lam.mutex.Lock()
@@ -230,8 +231,12 @@ func (lam *localAddressManager) initListeners() error {
 }
 
 // hostToNetAddress returns a netaddress given a host address. If
-// the host is not an IP address it will be resolved.
+// the host is neither an IP address nor an onion host it will be resolved.
 func (lam *localAddressManager) hostToNetAddress(host string, port uint16) (*appmessage.NetAddress, error) {
+	if network.IsOnionHost(host) {
+		return appmessage.NewNetAddressHostPort(host, port)
+	}
+
 	ip := net.ParseIP(host)
 	if ip == nil {
 		ips, err := lam.lookupFunc(host)
@@ -326,6 +331,22 @@ func reachabilityFrom(localAddress, remoteAddress *appmessage.NetAddress, accept
 		return Unreachable
 	}
 
+	if remoteAddress.IsOnion() {
+		if localAddress.IsOnion() {
+			return Private
+		}
+		if IsRoutable(localAddress) && IsIPv4(localAddress) {
+			return Ipv4
+		}
+		return Default
+	}
+
+	// Onion services are only advertised to other onion services, so that they
+	// are not linked to the clearnet addresses of the node
+	if localAddress.IsOnion() {
+		return Unreachable
+	}
+
 	if IsRFC4380(remoteAddress) {
 		if !IsRoutable(localAddress) {
 			return Default
@@ -5,6 +5,7 @@
 package addressmanager
 
 import (
+	"fmt"
 	"net"
 
 	"github.com/Kash-Protocol/kashd/app/appmessage"
@@ -197,7 +198,12 @@ func IsRFC6598(na *appmessage.NetAddress) bool {
 // considered invalid under the following circumstances:
 // IPv4: It is either a zero or all bits set address.
 // IPv6: It is either a zero or RFC3849 documentation address.
+// Tor onion service addresses are always valid.
 func IsValid(na *appmessage.NetAddress) bool {
+	if na.IsOnion() {
+		return true
+	}
+
 	// IsUnspecified returns if address is 0, so only all bits set, and
 	// RFC3849 need to be explicitly checked.
 	return na.IP != nil && !(na.IP.IsUnspecified() ||
@@ -206,8 +212,11 @@ func IsValid(na *appmessage.NetAddress) bool {
 
 // IsRoutable returns whether or not the passed address is routable over
 // the public internet. This is true as long as the address is valid and is not
-// in any reserved ranges.
+// in any reserved ranges. Tor onion service addresses are always routable.
 func IsRoutable(na *appmessage.NetAddress, acceptUnroutable bool) bool {
+	if na.IsOnion() {
+		return true
+	}
 	if acceptUnroutable {
 		return !IsLocal(na)
 	}
@@ -219,10 +228,14 @@ func IsRoutable(na *appmessage.NetAddress, acceptUnroutable bool) bool {
 }
 
 // GroupKey returns a string representing the network group an address is part
-// of. This is the /16 for IPv4, the /32 (/36 for he.net) for IPv6, the string
+// of. This is the /16 for IPv4, the /32 (/36 for he.net) for IPv6, "tor:" followed
+// by the first 4 bits of the public key for a Tor onion service, the string
 // "local" for a local address, and the string "unroutable" for an unroutable
 // address.
 func (am *AddressManager) GroupKey(na *appmessage.NetAddress) string {
+	if na.IsOnion() {
+		return fmt.Sprintf("tor:%d", na.OnionPublicKey[0]&((1<<4)-1))
+	}
 	if IsLocal(na) {
 		return "local"
 	}
@@ -5,6 +5,7 @@ import (
 	"github.com/Kash-Protocol/kashd/app/appmessage"
 	"github.com/Kash-Protocol/kashd/infrastructure/db/database"
 	"github.com/Kash-Protocol/kashd/util/mstime"
+	"github.com/Kash-Protocol/kashd/util/network"
 	"github.com/pkg/errors"
 	"net"
 )
@@ -15,14 +16,14 @@ var bannedAddressBucket = database.MakeBucket([]byte("banned-addresses"))
 type addressStore struct {
 	database           database.Database
 	notBannedAddresses map[addressKey]*address
-	bannedAddresses    map[ipv6]*address
+	bannedAddresses    map[hostKey]*address
 }
 
 func newAddressStore(database database.Database) (*addressStore, error) {
 	addressStore := &addressStore{
 		database:           database,
 		notBannedAddresses: map[addressKey]*address{},
-		bannedAddresses:    map[ipv6]*address{},
+		bannedAddresses:    map[hostKey]*address{},
 	}
 	err := addressStore.restoreNotBannedAddresses()
 	if err != nil {
@@ -74,15 +75,14 @@ func (as *addressStore) restoreBannedAddresses() error {
 		if err != nil {
 			return err
 		}
-		var ipv6 ipv6
-		copy(ipv6[:], databaseKey.Suffix())
+		key := as.deserializeHostKey(databaseKey.Suffix())
 
 		serializedNetAddress, err := cursor.Value()
 		if err != nil {
 			return err
 		}
 		netAddress := as.deserializeAddress(serializedNetAddress)
-		as.bannedAddresses[ipv6] = netAddress
+		as.bannedAddresses[key] = netAddress
 	}
 	return nil
 }
@@ -106,7 +106,7 @@ func (as *addressStore) add(key addressKey, address *address) error {
 // updateNotBanned updates the not-banned address collection
 func (as *addressStore) updateNotBanned(key addressKey, address *address) error {
 	if _, ok := as.notBannedAddresses[key]; !ok {
-		return errors.Errorf("address %s is not in the store", address.netAddress.TCPAddress())
+		return errors.Errorf("address %s is not in the store", address.netAddress)
 	}
 
 	as.notBannedAddresses[key] = address
@@ -162,11 +162,11 @@ func (as *addressStore) isNotBanned(key addressKey) bool {
 }
 
 func (as *addressStore) addBanned(key addressKey, address *address) error {
-	if _, ok := as.bannedAddresses[key.address]; ok {
+	if _, ok := as.bannedAddresses[key.hostKey]; ok {
 		return nil
 	}
 
-	as.bannedAddresses[key.address] = address
+	as.bannedAddresses[key.hostKey] = address
 
 	databaseKey := as.bannedDatabaseKey(key)
 	serializedAddress := as.serializeAddress(address)
@@ -174,7 +174,7 @@ func (as *addressStore) addBanned(key addressKey, address *address) error {
 }
 
 func (as *addressStore) removeBanned(key addressKey) error {
-	delete(as.bannedAddresses, key.address)
+	delete(as.bannedAddresses, key.hostKey)
 
 	databaseKey := as.bannedDatabaseKey(key)
 	return as.database.Delete(databaseKey)
@@ -189,12 +189,12 @@ func (as *addressStore) getAllBannedNetAddresses() []*appmessage.NetAddress {
 }
 
 func (as *addressStore) isBanned(key addressKey) bool {
-	_, ok := as.bannedAddresses[key.address]
+	_, ok := as.bannedAddresses[key.hostKey]
 	return ok
 }
 
 func (as *addressStore) getBanned(key addressKey) (*address, bool) {
-	bannedAddress, ok := as.bannedAddresses[key.address]
+	bannedAddress, ok := as.bannedAddresses[key.hostKey]
 	return bannedAddress, ok
 }
 
@@ -214,57 +214,101 @@ func (as *addressStore) notBannedDatabaseKey(key addressKey) *database.Key {
 }
 
 func (as *addressStore) bannedDatabaseKey(key addressKey) *database.Key {
-	return bannedAddressBucket.Key(key.address[:])
+	serializedKey := as.serializeHostKey(key.hostKey)
+	return bannedAddressBucket.Key(serializedKey)
+}
+
+// serializeHostKey serializes the IP of the given host key, followed by its onion
+// public key if it is a Tor onion service. Keys of IP hosts are serialized the same
+// way they were before onion services were supported.
+func (as *addressStore) serializeHostKey(key hostKey) []byte {
+	serializedSize := 16 // ipv6
+	if key.isOnion() {
+		serializedSize += network.OnionPublicKeyLength
+	}
+	serializedKey := make([]byte, serializedSize)
+
+	copy(serializedKey[:], key.address[:])
+	if key.isOnion() {
+		copy(serializedKey[16:], key.onion[:])
+	}
+
+	return serializedKey
+}
+
+func (as *addressStore) deserializeHostKey(serializedKey []byte) hostKey {
+	var key hostKey
+	copy(key.address[:], serializedKey[:])
+	if len(serializedKey) > 16 {
+		copy(key.onion[:], serializedKey[16:])
+	}
+	return key
 }
 
 func (as *addressStore) serializeAddressKey(key addressKey) []byte {
 	serializedSize := 16 + 2 // ipv6 + port
+	if key.isOnion() {
+		serializedSize += network.OnionPublicKeyLength
+	}
 	serializedKey := make([]byte, serializedSize)
 
 	copy(serializedKey[:], key.address[:])
 	binary.LittleEndian.PutUint16(serializedKey[16:], key.port)
+	if key.isOnion() {
+		copy(serializedKey[18:], key.onion[:])
+	}
 
 	return serializedKey
 }
 
 func (as *addressStore) deserializeAddressKey(serializedKey []byte) addressKey {
-	var ip ipv6
-	copy(ip[:], serializedKey[:])
-
-	port := binary.LittleEndian.Uint16(serializedKey[16:])
-
-	return addressKey{
-		port:    port,
-		address: ip,
+	var key addressKey
+	copy(key.address[:], serializedKey[:])
+	key.port = binary.LittleEndian.Uint16(serializedKey[16:])
+	if len(serializedKey) > 18 {
+		copy(key.onion[:], serializedKey[18:])
 	}
+
+	return key
 }
 
 func (as *addressStore) serializeAddress(address *address) []byte {
 	serializedSize := 16 + 2 + 8 + 8 // ipv6 + port + timestamp + connectionFailedCount
+	if address.netAddress.IsOnion() {
+		serializedSize += network.OnionPublicKeyLength
+	}
 	serializedNetAddress := make([]byte, serializedSize)
 
 	copy(serializedNetAddress[:], address.netAddress.IP.To16()[:])
 	binary.LittleEndian.PutUint16(serializedNetAddress[16:], address.netAddress.Port)
 	binary.LittleEndian.PutUint64(serializedNetAddress[18:], uint64(address.netAddress.Timestamp.UnixMilliseconds()))
 	binary.LittleEndian.PutUint64(serializedNetAddress[26:], uint64(address.connectionFailedCount))
+	if address.netAddress.IsOnion() {
+		copy(serializedNetAddress[34:], address.netAddress.OnionPublicKey)
+	}
 
 	return serializedNetAddress
 }
 
 func (as *addressStore) deserializeAddress(serializedAddress []byte) *address {
-	ip := make(net.IP, 16)
-	copy(ip[:], serializedAddress[:])
-
 	port := binary.LittleEndian.Uint16(serializedAddress[16:])
 	timestamp := mstime.UnixMilliseconds(int64(binary.LittleEndian.Uint64(serializedAddress[18:])))
 	connectionFailedCount := binary.LittleEndian.Uint64(serializedAddress[26:])
 
+	netAddress := &appmessage.NetAddress{
+		Port:      port,
+		Timestamp: timestamp,
+	}
+	if len(serializedAddress) > 34 {
+		netAddress.OnionPublicKey = make([]byte, network.OnionPublicKeyLength)
+		copy(netAddress.OnionPublicKey, serializedAddress[34:])
+	} else {
+		netAddress.IP = make(net.IP, 16)
+		copy(netAddress.IP[:], serializedAddress[:])
+	}
+
 	return &address{
-		netAddress: &appmessage.NetAddress{
-			IP:        ip,
-			Port:      port,
-			Timestamp: timestamp,
-		},
+		netAddress:            netAddress,
 		connectionFailedCount: connectionFailedCount,
 	}
 }
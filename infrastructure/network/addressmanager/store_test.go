@@ -3,6 +3,7 @@ package addressmanager
 import (
 	"github.com/Kash-Protocol/kashd/app/appmessage"
 	"github.com/Kash-Protocol/kashd/util/mstime"
+	"github.com/Kash-Protocol/kashd/util/network"
 	"net"
 	"reflect"
 	"testing"
@@ -45,3 +46,44 @@ func TestAddressSerialization(t *testing.T) {
 			"testAddress:%+v\ndeserializedTestAddress:%+v", testAddress, deserializedTestAddress)
 	}
 }
+
+func TestOnionAddressSerialization(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestOnionAddressSerialization")
+	defer teardown()
+	addressStore := addressManager.store
+
+	onionPublicKey := make([]byte, network.OnionPublicKeyLength)
+	for i := range onionPublicKey {
+		onionPublicKey[i] = byte(i + 1)
+	}
+	testAddress := &address{
+		netAddress: &appmessage.NetAddress{
+			OnionPublicKey: onionPublicKey,
+			Port:           12345,
+			Timestamp:      mstime.Now(),
+		},
+		connectionFailedCount: 98465,
+	}
+
+	testAddressKey := netAddressKey(testAddress.netAddress)
+	serializedTestAddressKey := addressStore.serializeAddressKey(testAddressKey)
+	deserializedTestAddressKey := addressStore.deserializeAddressKey(serializedTestAddressKey)
+	if !reflect.DeepEqual(testAddressKey, deserializedTestAddressKey) {
+		t.Fatalf("testAddressKey and deserializedTestAddressKey are not equal\n"+
+			"testAddressKey:%+v\ndeserializedTestAddressKey:%+v", testAddressKey, deserializedTestAddressKey)
+	}
+
+	serializedTestHostKey := addressStore.serializeHostKey(testAddressKey.hostKey)
+	deserializedTestHostKey := addressStore.deserializeHostKey(serializedTestHostKey)
+	if deserializedTestHostKey != testAddressKey.hostKey {
+		t.Fatalf("testHostKey and deserializedTestHostKey are not equal\n"+
+			"testHostKey:%+v\ndeserializedTestHostKey:%+v", testAddressKey.hostKey, deserializedTestHostKey)
+	}
+
+	serializedTestAddress := addressStore.serializeAddress(testAddress)
+	deserializedTestAddress := addressStore.deserializeAddress(serializedTestAddress)
+	if !reflect.DeepEqual(testAddress, deserializedTestAddress) {
+		t.Fatalf("testAddress and deserializedTestAddress are not equal\n"+
+			"testAddress:%+v\ndeserializedTestAddress:%+v", testAddress, deserializedTestAddress)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Kash-Protocol/kashd/app/appmessage"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/dnsseed"
+	"github.com/Kash-Protocol/kashd/util/network"
 	"github.com/pkg/errors"
 
 	"github.com/Kash-Protocol/kashd/infrastructure/network/addressmanager"
@@ -245,6 +246,11 @@ func (c *ConnectionManager) extractAddressIPs(address string) ([]net.IP, error)
 		return nil, err
 	}
 
+	// Onion services have no IP address
+	if network.IsOnionHost(host) {
+		return nil, nil
+	}
+
 	ip := net.ParseIP(host)
 	if ip == nil {
 		return c.cfg.Lookup(host)
@@ -264,7 +270,7 @@ func (c *ConnectionManager) seedFromDNS() {
 				_ = c.addressManager.AddAddresses(addresses...)
 			})
 
-		dnsseed.SeedFromGRPC(cfg.NetParams(), cfg.GRPCSeed, false, nil,
+		dnsseed.SeedFromGRPC(cfg.NetParams(), cfg.GRPCSeed, false, nil, cfg.Dial,
 			func(addresses []*appmessage.NetAddress) {
 				_ = c.addressManager.AddAddresses(addresses...)
 			})
@@ -31,6 +31,8 @@ const (
 
 	// SubnetworkIDPrefixChar is the prefix of subnetworkID, when building a DNS seed request
 	SubnetworkIDPrefixChar byte = 'n'
+
+	grpcSeedDialTimeout = 30 * time.Second
 )
 
 // OnSeed is the signature of the callback function which is invoked when DNS
@@ -40,6 +42,9 @@ type OnSeed func(addrs []*appmessage.NetAddress)
 // LookupFunc is the signature of the DNS lookup function.
 type LookupFunc func(string) ([]net.IP, error)
 
+// DialFunc is the signature of the function gRPC seeds are dialed with.
+type DialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
+
 // SeedFromDNS uses DNS seeding to populate the address manager with peers.
 func SeedFromDNS(dagParams *dagconfig.Params, customSeed string, includeAllSubnetworks bool,
 	subnetworkID *externalapi.DomainSubnetworkID, lookupFn LookupFunc, seedFn OnSeed) {
@@ -94,9 +99,10 @@ func SeedFromDNS(dagParams *dagconfig.Params, customSeed string, includeAllSubne
 	}
 }
 
-// SeedFromGRPC send gRPC request to get list of peers for a given host
+// SeedFromGRPC send gRPC request to get list of peers for a given host. The gRPC seeds
+// are dialed with dialFn.
 func SeedFromGRPC(dagParams *dagconfig.Params, customSeed string, includeAllSubnetworks bool,
-	subnetworkID *externalapi.DomainSubnetworkID, seedFn OnSeed) {
+	subnetworkID *externalapi.DomainSubnetworkID, dialFn DialFunc, seedFn OnSeed) {
 
 	var grpcSeeds []string
 	if customSeed != "" {
@@ -109,11 +115,20 @@ func SeedFromGRPC(dagParams *dagconfig.Params, customSeed string, includeAllSubn
 		spawn("SeedFromGRPC", func() {
 			randSource := rand.New(rand.NewSource(time.Now().UnixNano()))
 
-			conn, err := grpc.Dial(host, grpc.WithInsecure())
-			client := pb2.NewPeerServiceClient(conn)
+			conn, err := grpc.Dial(host, grpc.WithInsecure(),
+				grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+					timeout := grpcSeedDialTimeout
+					if deadline, ok := ctx.Deadline(); ok {
+						timeout = time.Until(deadline)
+					}
+					return dialFn("tcp", address, timeout)
+				}))
 			if err != nil {
 				log.Warnf("Failed to connect to gRPC server: %s", host)
+				return
 			}
+			defer conn.Close()
+			client := pb2.NewPeerServiceClient(conn)
 
 			var subnetID []byte
 			if subnetworkID != nil {
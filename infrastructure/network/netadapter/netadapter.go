@@ -1,6 +1,8 @@
 package netadapter
 
 import (
+	"crypto/tls"
+	"net"
 	"sync"
 	"sync/atomic"
 
@@ -10,6 +12,7 @@ import (
 	routerpkg "github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/server"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/server/grpcserver"
+	"github.com/Kash-Protocol/kashd/util/network"
 	"github.com/pkg/errors"
 )
 
@@ -42,11 +45,26 @@ func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
 	if err != nil {
 		return nil, err
 	}
-	p2pServer, err := grpcserver.NewP2PServer(cfg.Listeners)
+	p2pServer, err := grpcserver.NewP2PServer(cfg.Listeners, cfg.Dial)
 	if err != nil {
 		return nil, err
 	}
-	rpcServer, err := grpcserver.NewRPCServer(cfg.RPCListeners, cfg.RPCMaxClients)
+	var rpcTLSConfig *tls.Config
+	if cfg.RPCTLS {
+		rpcTLSConfig, err = grpcserver.RPCTLSConfig(cfg.RPCCert, cfg.RPCKey, cfg.RPCListeners)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rpcServer, err := grpcserver.NewRPCServer(cfg.RPCListeners, cfg.RPCMaxClients, rpcTLSConfig,
+		&grpcserver.RPCCredentials{
+			User:            cfg.RPCUser,
+			Password:        cfg.RPCPass,
+			Token:           cfg.RPCAuthToken,
+			LimitedUser:     cfg.RPCLimitUser,
+			LimitedPassword: cfg.RPCLimitPass,
+			LimitedToken:    cfg.RPCLimitAuthToken,
+		})
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +119,28 @@ func (na *NetAdapter) Stop() error {
 // P2PConnect tells the NetAdapter's underlying p2p server to initiate a connection
 // to the given address
 func (na *NetAdapter) P2PConnect(address string) error {
-	_, err := na.p2pServer.Connect(address)
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if network.IsOnionHost(host) && na.cfg.Proxy == "" {
+		return errors.Errorf("cannot connect to onion host %s without --proxy", host)
+	}
+
+	// Host names are resolved before connecting through a proxy, so that every connection
+	// is made to either an IP address or an onion host
+	if na.cfg.Proxy != "" && net.ParseIP(host) == nil && !network.IsOnionHost(host) {
+		ips, err := na.cfg.Lookup(host)
+		if err != nil {
+			return errors.Wrapf(err, "could not resolve %s", host)
+		}
+		if len(ips) == 0 {
+			return errors.Errorf("could not resolve %s", host)
+		}
+		address = net.JoinHostPort(ips[0].String(), port)
+	}
+
+	_, err = na.p2pServer.Connect(address)
 	return err
 }
 
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"github.com/Kash-Protocol/kashd/app/appmessage"
 	routerpkg "github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
+	"github.com/Kash-Protocol/kashd/util/network"
 	"github.com/pkg/errors"
+	"net"
 	"sync/atomic"
 
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/id"
@@ -75,9 +77,21 @@ func (c *NetConnection) IsOutbound() bool {
 	return c.connection.IsOutbound()
 }
 
+// IsLimitedRPCUser returns whether this is an RPC connection that authenticated with limited credentials
+func (c *NetConnection) IsLimitedRPCUser() bool {
+	return c.connection.IsLimitedRPCUser()
+}
+
 // NetAddress returns the NetAddress associated with this connection
 func (c *NetConnection) NetAddress() *appmessage.NetAddress {
-	return appmessage.NewNetAddress(c.connection.Address())
+	switch address := c.connection.Address().(type) {
+	case *net.TCPAddr:
+		return appmessage.NewNetAddress(address)
+	case *network.OnionAddr:
+		return appmessage.NewNetAddressOnion(address.PublicKey, uint16(address.Port))
+	default:
+		panic(errors.Errorf("unsupported connection address type %T", address))
+	}
 }
 
 func (c *NetConnection) setOnDisconnectedHandler(onDisconnectedHandler server.OnDisconnectedHandler) {
@@ -17,7 +17,7 @@ import (
 
 type gRPCConnection struct {
 	server                   *gRPCServer
-	address                  *net.TCPAddr
+	address                  net.Addr
 	stream                   grpcStream
 	router                   *router.Router
 	lowLevelClientConnection *grpc.ClientConn
@@ -34,6 +34,9 @@ type gRPCConnection struct {
 	onInvalidMessageHandler server.OnInvalidMessageHandler
 
 	isConnected uint32
+
+	// isLimitedRPCUser is set for RPC connections that authenticated with limited credentials
+	isLimitedRPCUser bool
 }
 
 type grpcStream interface {
@@ -41,7 +44,7 @@ type grpcStream interface {
 	Recv() (*protowire.KashdMessage, error)
 }
 
-func newConnection(server *gRPCServer, address *net.TCPAddr, stream grpcStream,
+func newConnection(server *gRPCServer, address net.Addr, stream grpcStream,
 	lowLevelClientConnection *grpc.ClientConn) *gRPCConnection {
 	connection := &gRPCConnection{
 		server:                   server,
@@ -100,6 +103,10 @@ func (c *gRPCConnection) IsOutbound() bool {
 	return c.lowLevelClientConnection != nil
 }
 
+func (c *gRPCConnection) IsLimitedRPCUser() bool {
+	return c.isLimitedRPCUser
+}
+
 // Disconnect disconnects the connection
 // Calling this function a second time doesn't do anything
 //
@@ -123,7 +130,7 @@ func (c *gRPCConnection) Disconnect() {
 	}
 }
 
-func (c *gRPCConnection) Address() *net.TCPAddr {
+func (c *gRPCConnection) Address() net.Addr {
 	return c.address
 }
 
@@ -25,10 +25,14 @@ type gRPCServer struct {
 }
 
 // newGRPCServer creates a gRPC server
-func newGRPCServer(listeningAddresses []string, maxMessageSize int, maxInboundConnections int, name string) *gRPCServer {
+func newGRPCServer(listeningAddresses []string, maxMessageSize int, maxInboundConnections int, name string,
+	extraServerOptions ...grpc.ServerOption) *gRPCServer {
+
 	log.Debugf("Created new %s GRPC server with maxMessageSize %d and maxInboundConnections %d", name, maxMessageSize, maxInboundConnections)
+	serverOptions := append([]grpc.ServerOption{grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize)},
+		extraServerOptions...)
 	return &gRPCServer{
-		server:                     grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize)),
+		server:                     grpc.NewServer(serverOptions...),
 		listeningAddresses:         listeningAddresses,
 		name:                       name,
 		maxInboundConnections:      maxInboundConnections,
@@ -93,7 +97,7 @@ func (s *gRPCServer) SetOnConnectedHandler(onConnectedHandler server.OnConnected
 	s.onConnectedHandler = onConnectedHandler
 }
 
-func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStream) error {
+func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStream, isLimitedRPCUser bool) error {
 	connectionCount, err := s.incrementInboundConnectionCountAndLimitIfRequired()
 	if err != nil {
 		return err
@@ -110,6 +114,7 @@ func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStr
 	}
 
 	connection := newConnection(s, tcpAddress, stream, nil)
+	connection.isLimitedRPCUser = isLimitedRPCUser
 
 	err = s.onConnectedHandler(connection)
 	if err != nil {
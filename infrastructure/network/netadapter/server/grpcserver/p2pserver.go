@@ -4,20 +4,27 @@ import (
 	"context"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/server"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/Kash-Protocol/kashd/util/network"
 	"github.com/Kash-Protocol/kashd/util/panics"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/peer"
 	"net"
+	"strconv"
 	"time"
 )
 
 type p2pServer struct {
 	protowire.UnimplementedP2PServer
 	gRPCServer
+
+	dial DialFunc
 }
 
+// DialFunc is the signature of the function outbound P2P connections are dialed with
+type DialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
+
 const p2pMaxMessageSize = 1024 * 1024 * 1024 // 1GB
 
 // p2pMaxInboundConnections is the max amount of inbound connections for the P2P server.
@@ -26,10 +33,14 @@ const p2pMaxMessageSize = 1024 * 1024 * 1024 // 1GB
 // is handled in the ConnectionManager instead.
 const p2pMaxInboundConnections = 0
 
-// NewP2PServer creates a new P2PServer
-func NewP2PServer(listeningAddresses []string) (server.P2PServer, error) {
+// NewP2PServer creates a new P2PServer. Outbound connections are dialed with the given
+// dial function, such as one that dials through a proxy. If it is nil, they are dialed directly.
+func NewP2PServer(listeningAddresses []string, dial DialFunc) (server.P2PServer, error) {
+	if dial == nil {
+		dial = net.DialTimeout
+	}
 	gRPCServer := newGRPCServer(listeningAddresses, p2pMaxMessageSize, p2pMaxInboundConnections, "P2P")
-	p2pServer := &p2pServer{gRPCServer: *gRPCServer}
+	p2pServer := &p2pServer{gRPCServer: *gRPCServer, dial: dial}
 	protowire.RegisterP2PServer(gRPCServer.server, p2pServer)
 	return p2pServer, nil
 }
@@ -37,7 +48,7 @@ func NewP2PServer(listeningAddresses []string) (server.P2PServer, error) {
 func (p *p2pServer) MessageStream(stream protowire.P2P_MessageStreamServer) error {
 	defer panics.HandlePanic(log, "p2pServer.MessageStream", nil)
 
-	return p.handleInboundConnection(stream.Context(), stream)
+	return p.handleInboundConnection(stream.Context(), stream, false)
 }
 
 // Connect connects to the given address
@@ -49,7 +60,8 @@ func (p *p2pServer) Connect(address string) (server.Connection, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
 
-	gRPCClientConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	gRPCClientConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock(),
+		grpc.WithContextDialer(p.dialContext))
 	if err != nil {
 		return nil, errors.Wrapf(err, "%s error connecting to %s", p.name, address)
 	}
@@ -65,12 +77,15 @@ func (p *p2pServer) Connect(address string) (server.Connection, error) {
 	if !ok {
 		return nil, errors.Errorf("%s error getting stream peer info from context for %s", p.name, address)
 	}
-	tcpAddress, ok := peerInfo.Addr.(*net.TCPAddr)
-	if !ok {
-		return nil, errors.Errorf("non-tcp addresses are not supported")
+	var connectionAddress net.Addr = peerInfo.Addr
+	if _, ok := peerInfo.Addr.(*net.TCPAddr); !ok {
+		connectionAddress, err = proxiedConnectionAddress(address)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	connection := newConnection(&p.gRPCServer, tcpAddress, stream, gRPCClientConnection)
+	connection := newConnection(&p.gRPCServer, connectionAddress, stream, gRPCClientConnection)
 
 	err = p.onConnectedHandler(connection)
 	if err != nil {
@@ -81,3 +96,37 @@ func (p *p2pServer) Connect(address string) (server.Connection, error) {
 
 	return connection, nil
 }
+
+func (p *p2pServer) dialContext(ctx context.Context, address string) (net.Conn, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil, errors.Errorf("%s dialing to %s without a deadline", p.name, address)
+	}
+	return p.dial("tcp", address, time.Until(deadline))
+}
+
+// proxiedConnectionAddress returns the address of a connection that was made through a proxy,
+// which is the address it was dialed to rather than the address of the proxy
+func proxiedConnectionAddress(address string) (net.Addr, error) {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid port in address %s", address)
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return &net.TCPAddr{IP: ip, Port: port}, nil
+	}
+	if network.IsOnionHost(host) {
+		publicKey, err := network.DecodeOnionHost(host)
+		if err != nil {
+			return nil, err
+		}
+		return &network.OnionAddr{PublicKey: publicKey, Port: port}, nil
+	}
+	return nil, errors.Errorf("connections through a proxy must be made to an IP address or "+
+		"an onion host, but got %s", address)
+}
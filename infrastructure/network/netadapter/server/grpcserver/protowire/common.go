@@ -9,6 +9,7 @@ import (
 
 	"github.com/Kash-Protocol/kashd/app/appmessage"
 	"github.com/Kash-Protocol/kashd/util/mstime"
+	"github.com/Kash-Protocol/kashd/util/network"
 	"github.com/pkg/errors"
 )
 
@@ -103,18 +104,24 @@ func (x *NetAddress) toAppMessage() (*appmessage.NetAddress, error) {
 	if x.Port > math.MaxUint16 {
 		return nil, errors.Errorf("port number is larger than %d", math.MaxUint16)
 	}
+	if len(x.OnionPublicKey) != 0 && len(x.OnionPublicKey) != network.OnionPublicKeyLength {
+		return nil, errors.Errorf("onion public key must be %d bytes long, but got %d",
+			network.OnionPublicKeyLength, len(x.OnionPublicKey))
+	}
 	return &appmessage.NetAddress{
-		Timestamp: mstime.UnixMilliseconds(x.Timestamp),
-		IP:        x.Ip,
-		Port:      uint16(x.Port),
+		Timestamp:      mstime.UnixMilliseconds(x.Timestamp),
+		IP:             x.Ip,
+		OnionPublicKey: x.OnionPublicKey,
+		Port:           uint16(x.Port),
 	}, nil
 }
 
 func appMessageNetAddressToProto(address *appmessage.NetAddress) *NetAddress {
 	return &NetAddress{
-		Timestamp: address.Timestamp.UnixMilliseconds(),
-		Ip:        address.IP,
-		Port:      uint32(address.Port),
+		Timestamp:      address.Timestamp.UnixMilliseconds(),
+		Ip:             address.IP,
+		OnionPublicKey: address.OnionPublicKey,
+		Port:           uint32(address.Port),
 	}
 }
 
@@ -26,7 +26,6 @@ type KashdMessage struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Payload:
-	//
 	//	*KashdMessage_Addresses
 	//	*KashdMessage_Block
 	//	*KashdMessage_Transaction
@@ -157,6 +156,21 @@ type KashdMessage struct {
 	//	*KashdMessage_GetMempoolEntriesByAddressesResponse
 	//	*KashdMessage_GetCoinSupplyRequest
 	//	*KashdMessage_GetCoinSupplyResponse
+	//	*KashdMessage_GetReserveStateRequest
+	//	*KashdMessage_GetReserveStateResponse
+	//	*KashdMessage_GetOraclePriceRequest
+	//	*KashdMessage_GetOraclePriceResponse
+	//	*KashdMessage_NotifyReserveRatioChangedRequest
+	//	*KashdMessage_NotifyReserveRatioChangedResponse
+	//	*KashdMessage_ReserveRatioChangedNotification
+	//	*KashdMessage_GetFeeEstimateRequest
+	//	*KashdMessage_GetFeeEstimateResponse
+	//	*KashdMessage_GetTransactionRequest
+	//	*KashdMessage_GetTransactionResponse
+	//	*KashdMessage_GetTransactionConfirmationsRequest
+	//	*KashdMessage_GetTransactionConfirmationsResponse
+	//	*KashdMessage_GetTransactionsByAddressesRequest
+	//	*KashdMessage_GetTransactionsByAddressesResponse
 	Payload isKashdMessage_Payload `protobuf_oneof:"payload"`
 }
 
@@ -1109,6 +1123,111 @@ func (x *KashdMessage) GetGetCoinSupplyResponse() *GetCoinSupplyResponseMessage
 	return nil
 }
 
+func (x *KashdMessage) GetGetReserveStateRequest() *GetReserveStateRequestMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetReserveStateRequest); ok {
+		return x.GetReserveStateRequest
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetReserveStateResponse() *GetReserveStateResponseMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetReserveStateResponse); ok {
+		return x.GetReserveStateResponse
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetOraclePriceRequest() *GetOraclePriceRequestMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetOraclePriceRequest); ok {
+		return x.GetOraclePriceRequest
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetOraclePriceResponse() *GetOraclePriceResponseMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetOraclePriceResponse); ok {
+		return x.GetOraclePriceResponse
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetNotifyReserveRatioChangedRequest() *NotifyReserveRatioChangedRequestMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_NotifyReserveRatioChangedRequest); ok {
+		return x.NotifyReserveRatioChangedRequest
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetNotifyReserveRatioChangedResponse() *NotifyReserveRatioChangedResponseMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_NotifyReserveRatioChangedResponse); ok {
+		return x.NotifyReserveRatioChangedResponse
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetReserveRatioChangedNotification() *ReserveRatioChangedNotificationMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_ReserveRatioChangedNotification); ok {
+		return x.ReserveRatioChangedNotification
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetFeeEstimateRequest() *GetFeeEstimateRequestMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetFeeEstimateRequest); ok {
+		return x.GetFeeEstimateRequest
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetFeeEstimateResponse() *GetFeeEstimateResponseMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetFeeEstimateResponse); ok {
+		return x.GetFeeEstimateResponse
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetTransactionRequest() *GetTransactionRequestMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetTransactionRequest); ok {
+		return x.GetTransactionRequest
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetTransactionResponse() *GetTransactionResponseMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetTransactionResponse); ok {
+		return x.GetTransactionResponse
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetTransactionConfirmationsRequest() *GetTransactionConfirmationsRequestMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetTransactionConfirmationsRequest); ok {
+		return x.GetTransactionConfirmationsRequest
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetTransactionConfirmationsResponse() *GetTransactionConfirmationsResponseMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetTransactionConfirmationsResponse); ok {
+		return x.GetTransactionConfirmationsResponse
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetTransactionsByAddressesRequest() *GetTransactionsByAddressesRequestMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetTransactionsByAddressesRequest); ok {
+		return x.GetTransactionsByAddressesRequest
+	}
+	return nil
+}
+
+func (x *KashdMessage) GetGetTransactionsByAddressesResponse() *GetTransactionsByAddressesResponseMessage {
+	if x, ok := x.GetPayload().(*KashdMessage_GetTransactionsByAddressesResponse); ok {
+		return x.GetTransactionsByAddressesResponse
+	}
+	return nil
+}
+
 type isKashdMessage_Payload interface {
 	isKashdMessage_Payload()
 }
@@ -1633,6 +1752,66 @@ type KashdMessage_GetCoinSupplyResponse struct {
 	GetCoinSupplyResponse *GetCoinSupplyResponseMessage `protobuf:"bytes,1087,opt,name=getCoinSupplyResponse,proto3,oneof"`
 }
 
+type KashdMessage_GetReserveStateRequest struct {
+	GetReserveStateRequest *GetReserveStateRequestMessage `protobuf:"bytes,1088,opt,name=getReserveStateRequest,proto3,oneof"`
+}
+
+type KashdMessage_GetReserveStateResponse struct {
+	GetReserveStateResponse *GetReserveStateResponseMessage `protobuf:"bytes,1089,opt,name=getReserveStateResponse,proto3,oneof"`
+}
+
+type KashdMessage_GetOraclePriceRequest struct {
+	GetOraclePriceRequest *GetOraclePriceRequestMessage `protobuf:"bytes,1090,opt,name=getOraclePriceRequest,proto3,oneof"`
+}
+
+type KashdMessage_GetOraclePriceResponse struct {
+	GetOraclePriceResponse *GetOraclePriceResponseMessage `protobuf:"bytes,1091,opt,name=getOraclePriceResponse,proto3,oneof"`
+}
+
+type KashdMessage_NotifyReserveRatioChangedRequest struct {
+	NotifyReserveRatioChangedRequest *NotifyReserveRatioChangedRequestMessage `protobuf:"bytes,1092,opt,name=notifyReserveRatioChangedRequest,proto3,oneof"`
+}
+
+type KashdMessage_NotifyReserveRatioChangedResponse struct {
+	NotifyReserveRatioChangedResponse *NotifyReserveRatioChangedResponseMessage `protobuf:"bytes,1093,opt,name=notifyReserveRatioChangedResponse,proto3,oneof"`
+}
+
+type KashdMessage_ReserveRatioChangedNotification struct {
+	ReserveRatioChangedNotification *ReserveRatioChangedNotificationMessage `protobuf:"bytes,1094,opt,name=reserveRatioChangedNotification,proto3,oneof"`
+}
+
+type KashdMessage_GetFeeEstimateRequest struct {
+	GetFeeEstimateRequest *GetFeeEstimateRequestMessage `protobuf:"bytes,1095,opt,name=getFeeEstimateRequest,proto3,oneof"`
+}
+
+type KashdMessage_GetFeeEstimateResponse struct {
+	GetFeeEstimateResponse *GetFeeEstimateResponseMessage `protobuf:"bytes,1096,opt,name=getFeeEstimateResponse,proto3,oneof"`
+}
+
+type KashdMessage_GetTransactionRequest struct {
+	GetTransactionRequest *GetTransactionRequestMessage `protobuf:"bytes,1097,opt,name=getTransactionRequest,proto3,oneof"`
+}
+
+type KashdMessage_GetTransactionResponse struct {
+	GetTransactionResponse *GetTransactionResponseMessage `protobuf:"bytes,1098,opt,name=getTransactionResponse,proto3,oneof"`
+}
+
+type KashdMessage_GetTransactionConfirmationsRequest struct {
+	GetTransactionConfirmationsRequest *GetTransactionConfirmationsRequestMessage `protobuf:"bytes,1099,opt,name=getTransactionConfirmationsRequest,proto3,oneof"`
+}
+
+type KashdMessage_GetTransactionConfirmationsResponse struct {
+	GetTransactionConfirmationsResponse *GetTransactionConfirmationsResponseMessage `protobuf:"bytes,1100,opt,name=getTransactionConfirmationsResponse,proto3,oneof"`
+}
+
+type KashdMessage_GetTransactionsByAddressesRequest struct {
+	GetTransactionsByAddressesRequest *GetTransactionsByAddressesRequestMessage `protobuf:"bytes,1101,opt,name=getTransactionsByAddressesRequest,proto3,oneof"`
+}
+
+type KashdMessage_GetTransactionsByAddressesResponse struct {
+	GetTransactionsByAddressesResponse *GetTransactionsByAddressesResponseMessage `protobuf:"bytes,1102,opt,name=getTransactionsByAddressesResponse,proto3,oneof"`
+}
+
 func (*KashdMessage_Addresses) isKashdMessage_Payload() {}
 
 func (*KashdMessage_Block) isKashdMessage_Payload() {}
@@ -1893,13 +2072,43 @@ func (*KashdMessage_GetCoinSupplyRequest) isKashdMessage_Payload() {}
 
 func (*KashdMessage_GetCoinSupplyResponse) isKashdMessage_Payload() {}
 
+func (*KashdMessage_GetReserveStateRequest) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetReserveStateResponse) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetOraclePriceRequest) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetOraclePriceResponse) isKashdMessage_Payload() {}
+
+func (*KashdMessage_NotifyReserveRatioChangedRequest) isKashdMessage_Payload() {}
+
+func (*KashdMessage_NotifyReserveRatioChangedResponse) isKashdMessage_Payload() {}
+
+func (*KashdMessage_ReserveRatioChangedNotification) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetFeeEstimateRequest) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetFeeEstimateResponse) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetTransactionRequest) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetTransactionResponse) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetTransactionConfirmationsRequest) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetTransactionConfirmationsResponse) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetTransactionsByAddressesRequest) isKashdMessage_Payload() {}
+
+func (*KashdMessage_GetTransactionsByAddressesResponse) isKashdMessage_Payload() {}
+
 var File_messages_proto protoreflect.FileDescriptor
 
 var file_messages_proto_rawDesc = []byte{
 	0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x12, 0x09, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x1a, 0x09, 0x70, 0x32, 0x70,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x09, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xbe, 0x6d, 0x0a, 0x0c, 0x4b, 0x61, 0x73, 0x68, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x6f, 0x22, 0x93, 0x7b, 0x0a, 0x0c, 0x4b, 0x61, 0x73, 0x68, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
 	0x67, 0x65, 0x12, 0x3b, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72,
 	0x65, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61,
@@ -2774,21 +2983,130 @@ var file_messages_proto_rawDesc = []byte{
 	0x65, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x69, 0x6e, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
 	0x52, 0x15, 0x67, 0x65, 0x74, 0x43, 0x6f, 0x69, 0x6e, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
-	0x61, 0x64, 0x32, 0x4e, 0x0a, 0x03, 0x50, 0x32, 0x50, 0x12, 0x47, 0x0a, 0x0d, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4b, 0x61, 0x73, 0x68, 0x64, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e,
-	0x4b, 0x61, 0x73, 0x68, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x28, 0x01,
-	0x30, 0x01, 0x32, 0x4e, 0x0a, 0x03, 0x52, 0x50, 0x43, 0x12, 0x47, 0x0a, 0x0d, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4b, 0x61, 0x73, 0x68, 0x64, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e,
-	0x4b, 0x61, 0x73, 0x68, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x28, 0x01,
-	0x30, 0x01, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x4b, 0x61, 0x73, 0x68, 0x2d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x6b,
-	0x61, 0x73, 0x68, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x16, 0x67, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0xc0, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x77, 0x69, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x16, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x66, 0x0a, 0x17,
+	0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0xc1, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x17, 0x67, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a, 0x15, 0x67, 0x65, 0x74, 0x4f, 0x72, 0x61, 0x63, 0x6c,
+	0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0xc2, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65,
+	0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x15, 0x67, 0x65, 0x74, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x63, 0x0a, 0x16, 0x67, 0x65, 0x74, 0x4f, 0x72, 0x61,
+	0x63, 0x6c, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x18, 0xc3, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x16, 0x67, 0x65, 0x74, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x81, 0x01, 0x0a, 0x20,
+	0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x61, 0x74,
+	0x69, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0xc4, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x20, 0x6e,
+	0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x61, 0x74, 0x69,
+	0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x84, 0x01, 0x0a, 0x21, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0xc5, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x21, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7e, 0x0a, 0x1f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x4e, 0x6f, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0xc6, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x31, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x1f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x61,
+	0x74, 0x69, 0x6f, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x60, 0x0a, 0x15, 0x67, 0x65, 0x74, 0x46, 0x65, 0x65,
+	0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0xc7, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69,
+	0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x65, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x15, 0x67, 0x65, 0x74, 0x46, 0x65, 0x65, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x63, 0x0a, 0x16, 0x67, 0x65, 0x74, 0x46,
+	0x65, 0x65, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x18, 0xc8, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x65, 0x45, 0x73, 0x74, 0x69,
+	0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x16, 0x67, 0x65, 0x74, 0x46, 0x65, 0x65, 0x45, 0x73, 0x74,
+	0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x60, 0x0a,
+	0x15, 0x67, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0xc9, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x15, 0x67, 0x65, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x63, 0x0a, 0x16, 0x67, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0xca, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x16, 0x67, 0x65,
+	0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x87, 0x01, 0x0a, 0x22, 0x67, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0xcb, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x34, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x47,
+	0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x22, 0x67, 0x65, 0x74, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x8a,
+	0x01, 0x0a, 0x23, 0x67, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0xcc, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x23, 0x67, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x84, 0x01, 0x0a, 0x21,
+	0x67, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42,
+	0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0xcd, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x77, 0x69, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x42, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x21, 0x67, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x42, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x87, 0x01, 0x0a, 0x22, 0x67, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0xce, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x34, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x79, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x22, 0x67, 0x65, 0x74, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x79, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x32, 0x4e, 0x0a, 0x03, 0x50, 0x32, 0x50, 0x12, 0x47,
+	0x0a, 0x0d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4b, 0x61, 0x73, 0x68,
+	0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x77, 0x69, 0x72, 0x65, 0x2e, 0x4b, 0x61, 0x73, 0x68, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x32, 0x4e, 0x0a, 0x03, 0x52, 0x50, 0x43, 0x12, 0x47,
+	0x0a, 0x0d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x4b, 0x61, 0x73, 0x68,
+	0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x77, 0x69, 0x72, 0x65, 0x2e, 0x4b, 0x61, 0x73, 0x68, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4b, 0x61, 0x73, 0x68, 0x2d, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x2f, 0x6b, 0x61, 0x73, 0x68, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x77,
+	0x69, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2935,6 +3253,21 @@ var file_messages_proto_goTypes = []interface{}{
 	(*GetMempoolEntriesByAddressesResponseMessage)(nil),                // 127: protowire.GetMempoolEntriesByAddressesResponseMessage
 	(*GetCoinSupplyRequestMessage)(nil),                                // 128: protowire.GetCoinSupplyRequestMessage
 	(*GetCoinSupplyResponseMessage)(nil),                               // 129: protowire.GetCoinSupplyResponseMessage
+	(*GetReserveStateRequestMessage)(nil),                              // 130: protowire.GetReserveStateRequestMessage
+	(*GetReserveStateResponseMessage)(nil),                             // 131: protowire.GetReserveStateResponseMessage
+	(*GetOraclePriceRequestMessage)(nil),                               // 132: protowire.GetOraclePriceRequestMessage
+	(*GetOraclePriceResponseMessage)(nil),                              // 133: protowire.GetOraclePriceResponseMessage
+	(*NotifyReserveRatioChangedRequestMessage)(nil),                    // 134: protowire.NotifyReserveRatioChangedRequestMessage
+	(*NotifyReserveRatioChangedResponseMessage)(nil),                   // 135: protowire.NotifyReserveRatioChangedResponseMessage
+	(*ReserveRatioChangedNotificationMessage)(nil),                     // 136: protowire.ReserveRatioChangedNotificationMessage
+	(*GetFeeEstimateRequestMessage)(nil),                               // 137: protowire.GetFeeEstimateRequestMessage
+	(*GetFeeEstimateResponseMessage)(nil),                              // 138: protowire.GetFeeEstimateResponseMessage
+	(*GetTransactionRequestMessage)(nil),                               // 139: protowire.GetTransactionRequestMessage
+	(*GetTransactionResponseMessage)(nil),                              // 140: protowire.GetTransactionResponseMessage
+	(*GetTransactionConfirmationsRequestMessage)(nil),                  // 141: protowire.GetTransactionConfirmationsRequestMessage
+	(*GetTransactionConfirmationsResponseMessage)(nil),                 // 142: protowire.GetTransactionConfirmationsResponseMessage
+	(*GetTransactionsByAddressesRequestMessage)(nil),                   // 143: protowire.GetTransactionsByAddressesRequestMessage
+	(*GetTransactionsByAddressesResponseMessage)(nil),                  // 144: protowire.GetTransactionsByAddressesResponseMessage
 }
 var file_messages_proto_depIdxs = []int32{
 	1,   // 0: protowire.KashdMessage.addresses:type_name -> protowire.AddressesMessage
@@ -3067,15 +3400,30 @@ var file_messages_proto_depIdxs = []int32{
 	127, // 127: protowire.KashdMessage.getMempoolEntriesByAddressesResponse:type_name -> protowire.GetMempoolEntriesByAddressesResponseMessage
 	128, // 128: protowire.KashdMessage.getCoinSupplyRequest:type_name -> protowire.GetCoinSupplyRequestMessage
 	129, // 129: protowire.KashdMessage.getCoinSupplyResponse:type_name -> protowire.GetCoinSupplyResponseMessage
-	0,   // 130: protowire.P2P.MessageStream:input_type -> protowire.KashdMessage
-	0,   // 131: protowire.RPC.MessageStream:input_type -> protowire.KashdMessage
-	0,   // 132: protowire.P2P.MessageStream:output_type -> protowire.KashdMessage
-	0,   // 133: protowire.RPC.MessageStream:output_type -> protowire.KashdMessage
-	132, // [132:134] is the sub-list for method output_type
-	130, // [130:132] is the sub-list for method input_type
-	130, // [130:130] is the sub-list for extension type_name
-	130, // [130:130] is the sub-list for extension extendee
-	0,   // [0:130] is the sub-list for field type_name
+	130, // 130: protowire.KashdMessage.getReserveStateRequest:type_name -> protowire.GetReserveStateRequestMessage
+	131, // 131: protowire.KashdMessage.getReserveStateResponse:type_name -> protowire.GetReserveStateResponseMessage
+	132, // 132: protowire.KashdMessage.getOraclePriceRequest:type_name -> protowire.GetOraclePriceRequestMessage
+	133, // 133: protowire.KashdMessage.getOraclePriceResponse:type_name -> protowire.GetOraclePriceResponseMessage
+	134, // 134: protowire.KashdMessage.notifyReserveRatioChangedRequest:type_name -> protowire.NotifyReserveRatioChangedRequestMessage
+	135, // 135: protowire.KashdMessage.notifyReserveRatioChangedResponse:type_name -> protowire.NotifyReserveRatioChangedResponseMessage
+	136, // 136: protowire.KashdMessage.reserveRatioChangedNotification:type_name -> protowire.ReserveRatioChangedNotificationMessage
+	137, // 137: protowire.KashdMessage.getFeeEstimateRequest:type_name -> protowire.GetFeeEstimateRequestMessage
+	138, // 138: protowire.KashdMessage.getFeeEstimateResponse:type_name -> protowire.GetFeeEstimateResponseMessage
+	139, // 139: protowire.KashdMessage.getTransactionRequest:type_name -> protowire.GetTransactionRequestMessage
+	140, // 140: protowire.KashdMessage.getTransactionResponse:type_name -> protowire.GetTransactionResponseMessage
+	141, // 141: protowire.KashdMessage.getTransactionConfirmationsRequest:type_name -> protowire.GetTransactionConfirmationsRequestMessage
+	142, // 142: protowire.KashdMessage.getTransactionConfirmationsResponse:type_name -> protowire.GetTransactionConfirmationsResponseMessage
+	143, // 143: protowire.KashdMessage.getTransactionsByAddressesRequest:type_name -> protowire.GetTransactionsByAddressesRequestMessage
+	144, // 144: protowire.KashdMessage.getTransactionsByAddressesResponse:type_name -> protowire.GetTransactionsByAddressesResponseMessage
+	0,   // 145: protowire.P2P.MessageStream:input_type -> protowire.KashdMessage
+	0,   // 146: protowire.RPC.MessageStream:input_type -> protowire.KashdMessage
+	0,   // 147: protowire.P2P.MessageStream:output_type -> protowire.KashdMessage
+	0,   // 148: protowire.RPC.MessageStream:output_type -> protowire.KashdMessage
+	147, // [147:149] is the sub-list for method output_type
+	145, // [145:147] is the sub-list for method input_type
+	145, // [145:145] is the sub-list for extension type_name
+	145, // [145:145] is the sub-list for extension extendee
+	0,   // [0:145] is the sub-list for field type_name
 }
 
 func init() { file_messages_proto_init() }
@@ -3230,6 +3578,21 @@ func file_messages_proto_init() {
 		(*KashdMessage_GetMempoolEntriesByAddressesResponse)(nil),
 		(*KashdMessage_GetCoinSupplyRequest)(nil),
 		(*KashdMessage_GetCoinSupplyResponse)(nil),
+		(*KashdMessage_GetReserveStateRequest)(nil),
+		(*KashdMessage_GetReserveStateResponse)(nil),
+		(*KashdMessage_GetOraclePriceRequest)(nil),
+		(*KashdMessage_GetOraclePriceResponse)(nil),
+		(*KashdMessage_NotifyReserveRatioChangedRequest)(nil),
+		(*KashdMessage_NotifyReserveRatioChangedResponse)(nil),
+		(*KashdMessage_ReserveRatioChangedNotification)(nil),
+		(*KashdMessage_GetFeeEstimateRequest)(nil),
+		(*KashdMessage_GetFeeEstimateResponse)(nil),
+		(*KashdMessage_GetTransactionRequest)(nil),
+		(*KashdMessage_GetTransactionResponse)(nil),
+		(*KashdMessage_GetTransactionConfirmationsRequest)(nil),
+		(*KashdMessage_GetTransactionConfirmationsResponse)(nil),
+		(*KashdMessage_GetTransactionsByAddressesRequest)(nil),
+		(*KashdMessage_GetTransactionsByAddressesResponse)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
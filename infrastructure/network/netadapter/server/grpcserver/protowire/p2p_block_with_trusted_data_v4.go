@@ -2,6 +2,7 @@ package protowire
 
 import (
 	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/pkg/errors"
 )
 
@@ -19,6 +20,8 @@ func (x *KashdMessage_BlockWithTrustedDataV4) toAppMessage() (appmessage.Message
 		Block:               msgBlock,
 		DAAWindowIndices:    x.BlockWithTrustedDataV4.DaaWindowIndices,
 		GHOSTDAGDataIndices: x.BlockWithTrustedDataV4.GhostdagDataIndices,
+		OraclePrices:        x.BlockWithTrustedDataV4.OraclePrices.toDomain(),
+		ReserveState:        x.BlockWithTrustedDataV4.ReserveState.toDomain(),
 	}, nil
 }
 
@@ -27,6 +30,8 @@ func (x *KashdMessage_BlockWithTrustedDataV4) fromAppMessage(msgBlockWithTrusted
 		Block:               &BlockMessage{},
 		DaaWindowIndices:    msgBlockWithTrustedData.DAAWindowIndices,
 		GhostdagDataIndices: msgBlockWithTrustedData.GHOSTDAGDataIndices,
+		OraclePrices:        oraclePricesFromDomain(msgBlockWithTrustedData.OraclePrices),
+		ReserveState:        reserveStateFromDomain(msgBlockWithTrustedData.ReserveState),
 	}
 
 	err := x.BlockWithTrustedDataV4.Block.fromAppMessage(msgBlockWithTrustedData.Block)
@@ -73,3 +78,57 @@ func (x *DaaBlockV4) fromAppMessage(daaBlock *appmessage.TrustedDataDAAHeader) e
 
 	return nil
 }
+
+// toDomain returns nil if x is nil, which means that no price record is in effect
+func (x *OraclePrices) toDomain() *externalapi.OraclePrices {
+	if x == nil {
+		return nil
+	}
+	return &externalapi.OraclePrices{
+		KSH:       x.Ksh,
+		KRV:       x.Krv,
+		KUSD:      x.Kusd,
+		KSHMA:     x.KshMa,
+		KRVMA:     x.KrvMa,
+		KUSDMA:    x.KusdMa,
+		Timestamp: x.Timestamp,
+	}
+}
+
+func oraclePricesFromDomain(prices *externalapi.OraclePrices) *OraclePrices {
+	if prices == nil {
+		return nil
+	}
+	return &OraclePrices{
+		Ksh:       prices.KSH,
+		Krv:       prices.KRV,
+		Kusd:      prices.KUSD,
+		KshMa:     prices.KSHMA,
+		KrvMa:     prices.KRVMA,
+		KusdMa:    prices.KUSDMA,
+		Timestamp: prices.Timestamp,
+	}
+}
+
+// toDomain returns nil if x is nil, which means that the reserve state is not provided
+func (x *ReserveState) toDomain() *externalapi.ReserveState {
+	if x == nil {
+		return nil
+	}
+	return &externalapi.ReserveState{
+		Reserve:    x.Reserve,
+		KUSDSupply: x.KusdSupply,
+		KRVSupply:  x.KrvSupply,
+	}
+}
+
+func reserveStateFromDomain(reserveState *externalapi.ReserveState) *ReserveState {
+	if reserveState == nil {
+		return nil
+	}
+	return &ReserveState{
+		Reserve:    reserveState.Reserve,
+		KusdSupply: reserveState.KUSDSupply,
+		KrvSupply:  reserveState.KRVSupply,
+	}
+}
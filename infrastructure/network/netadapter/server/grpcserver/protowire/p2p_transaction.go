@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
 	"github.com/pkg/errors"
 )
 
@@ -58,6 +59,7 @@ func (x *TransactionMessage) toAppMessage() (appmessage.Message, error) {
 		SubnetworkID: *subnetworkID,
 		Gas:          x.Gas,
 		Payload:      x.Payload,
+		Type:         externalapi.DomainTransactionType(x.Type),
 	}, nil
 }
 
@@ -122,5 +124,6 @@ func (x *TransactionMessage) fromAppMessage(msgTx *appmessage.MsgTx) {
 		SubnetworkId: domainSubnetworkIDToProto(&msgTx.SubnetworkID),
 		Gas:          msgTx.Gas,
 		Payload:      msgTx.Payload,
+		Type:         uint32(msgTx.Type),
 	}
 }
@@ -37,6 +37,7 @@ func (x *KashdMessage_GetConnectedPeerInfoResponse) fromAppMessage(message *appm
 			AdvertisedProtocolVersion: info.AdvertisedProtocolVersion,
 			TimeConnected:             info.TimeConnected,
 			IsIbdPeer:                 info.IsIBDPeer,
+			MisbehaviorScore:          info.MisbehaviorScore,
 		}
 	}
 	x.GetConnectedPeerInfoResponse = &GetConnectedPeerInfoResponseMessage{
@@ -88,5 +89,6 @@ func (x *GetConnectedPeerInfoMessage) toAppMessage() (*appmessage.GetConnectedPe
 		AdvertisedProtocolVersion: x.AdvertisedProtocolVersion,
 		TimeConnected:             x.TimeOffset,
 		IsIBDPeer:                 x.IsIbdPeer,
+		MisbehaviorScore:          x.MisbehaviorScore,
 	}, nil
 }
@@ -0,0 +1,56 @@
+package protowire
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KashdMessage_GetFeeEstimateRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetFeeEstimateRequestMessage{}, nil
+}
+
+func (x *KashdMessage_GetFeeEstimateRequest) fromAppMessage(_ *appmessage.GetFeeEstimateRequestMessage) error {
+	x.GetFeeEstimateRequest = &GetFeeEstimateRequestMessage{}
+	return nil
+}
+
+func (x *KashdMessage_GetFeeEstimateResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_GetFeeEstimateResponse is nil")
+	}
+	return x.GetFeeEstimateResponse.toAppMessage()
+}
+
+func (x *KashdMessage_GetFeeEstimateResponse) fromAppMessage(message *appmessage.GetFeeEstimateResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GetFeeEstimateResponse = &GetFeeEstimateResponseMessage{
+		LowFeeRate:      message.LowFeeRate,
+		NormalFeeRate:   message.NormalFeeRate,
+		PriorityFeeRate: message.PriorityFeeRate,
+
+		Error: err,
+	}
+	return nil
+}
+
+func (x *GetFeeEstimateResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetFeeEstimateResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetFeeEstimateResponseMessage{
+		LowFeeRate:      x.LowFeeRate,
+		NormalFeeRate:   x.NormalFeeRate,
+		PriorityFeeRate: x.PriorityFeeRate,
+
+		Error: rpcErr,
+	}, nil
+}
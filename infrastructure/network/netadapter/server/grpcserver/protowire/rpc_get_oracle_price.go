@@ -0,0 +1,64 @@
+package protowire
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KashdMessage_GetOraclePriceRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetOraclePriceRequestMessage{}, nil
+}
+
+func (x *KashdMessage_GetOraclePriceRequest) fromAppMessage(_ *appmessage.GetOraclePriceRequestMessage) error {
+	x.GetOraclePriceRequest = &GetOraclePriceRequestMessage{}
+	return nil
+}
+
+func (x *KashdMessage_GetOraclePriceResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_GetOraclePriceResponse is nil")
+	}
+	return x.GetOraclePriceResponse.toAppMessage()
+}
+
+func (x *KashdMessage_GetOraclePriceResponse) fromAppMessage(message *appmessage.GetOraclePriceResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GetOraclePriceResponse = &GetOraclePriceResponseMessage{
+		KshPrice:          message.KSHPrice,
+		KrvPrice:          message.KRVPrice,
+		KusdPrice:         message.KUSDPrice,
+		KshMovingAverage:  message.KSHMovingAverage,
+		KrvMovingAverage:  message.KRVMovingAverage,
+		KusdMovingAverage: message.KUSDMovingAverage,
+		Timestamp:         message.Timestamp,
+
+		Error: err,
+	}
+	return nil
+}
+
+func (x *GetOraclePriceResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetOraclePriceResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetOraclePriceResponseMessage{
+		KSHPrice:          x.KshPrice,
+		KRVPrice:          x.KrvPrice,
+		KUSDPrice:         x.KusdPrice,
+		KSHMovingAverage:  x.KshMovingAverage,
+		KRVMovingAverage:  x.KrvMovingAverage,
+		KUSDMovingAverage: x.KusdMovingAverage,
+		Timestamp:         x.Timestamp,
+
+		Error: rpcErr,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package protowire
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KashdMessage_GetReserveStateRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetReserveStateRequestMessage{}, nil
+}
+
+func (x *KashdMessage_GetReserveStateRequest) fromAppMessage(_ *appmessage.GetReserveStateRequestMessage) error {
+	x.GetReserveStateRequest = &GetReserveStateRequestMessage{}
+	return nil
+}
+
+func (x *KashdMessage_GetReserveStateResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_GetReserveStateResponse is nil")
+	}
+	return x.GetReserveStateResponse.toAppMessage()
+}
+
+func (x *KashdMessage_GetReserveStateResponse) fromAppMessage(message *appmessage.GetReserveStateResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	conversionQuotes := make([]*ConversionQuote, len(message.ConversionQuotes))
+	for i, conversionQuote := range message.ConversionQuotes {
+		conversionQuotes[i] = &ConversionQuote{
+			TransactionType: conversionQuote.TransactionType,
+			KshSompi:        conversionQuote.KSHSompi,
+			FeeSompi:        conversionQuote.FeeSompi,
+		}
+	}
+	x.GetReserveStateResponse = &GetReserveStateResponseMessage{
+		ReserveSompi:               message.ReserveSompi,
+		KusdSupplySompi:            message.KUSDSupplySompi,
+		KrvSupplySompi:             message.KRVSupplySompi,
+		KusdLiabilitiesSompi:       message.KUSDLiabilitiesSompi,
+		KrvEquitySompi:             message.KRVEquitySompi,
+		ExchangeRate:               message.ExchangeRate,
+		ReserveRatioBasisPoints:    message.ReserveRatioBasisPoints,
+		MinReserveRatioBasisPoints: message.MinReserveRatioBasisPoints,
+		MaxReserveRatioBasisPoints: message.MaxReserveRatioBasisPoints,
+		ConversionFeeBasisPoints:   message.ConversionFeeBasisPoints,
+		ConversionQuotes:           conversionQuotes,
+
+		Error: err,
+	}
+	return nil
+}
+
+func (x *GetReserveStateResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetReserveStateResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	conversionQuotes := make([]*appmessage.ConversionQuote, len(x.ConversionQuotes))
+	for i, conversionQuote := range x.ConversionQuotes {
+		conversionQuotes[i], err = conversionQuote.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &appmessage.GetReserveStateResponseMessage{
+		ReserveSompi:               x.ReserveSompi,
+		KUSDSupplySompi:            x.KusdSupplySompi,
+		KRVSupplySompi:             x.KrvSupplySompi,
+		KUSDLiabilitiesSompi:       x.KusdLiabilitiesSompi,
+		KRVEquitySompi:             x.KrvEquitySompi,
+		ExchangeRate:               x.ExchangeRate,
+		ReserveRatioBasisPoints:    x.ReserveRatioBasisPoints,
+		MinReserveRatioBasisPoints: x.MinReserveRatioBasisPoints,
+		MaxReserveRatioBasisPoints: x.MaxReserveRatioBasisPoints,
+		ConversionFeeBasisPoints:   x.ConversionFeeBasisPoints,
+		ConversionQuotes:           conversionQuotes,
+
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *ConversionQuote) toAppMessage() (*appmessage.ConversionQuote, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ConversionQuote is nil")
+	}
+	return &appmessage.ConversionQuote{
+		TransactionType: x.TransactionType,
+		KSHSompi:        x.KshSompi,
+		FeeSompi:        x.FeeSompi,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package protowire
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KashdMessage_GetTransactionRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_GetTransactionRequest is nil")
+	}
+	return x.GetTransactionRequest.toAppMessage()
+}
+
+func (x *KashdMessage_GetTransactionRequest) fromAppMessage(message *appmessage.GetTransactionRequestMessage) error {
+	x.GetTransactionRequest = &GetTransactionRequestMessage{
+		TxId: message.TxID,
+	}
+	return nil
+}
+
+func (x *GetTransactionRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionRequestMessage is nil")
+	}
+	return &appmessage.GetTransactionRequestMessage{
+		TxID: x.TxId,
+	}, nil
+}
+
+func (x *KashdMessage_GetTransactionResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_GetTransactionResponse is nil")
+	}
+	return x.GetTransactionResponse.toAppMessage()
+}
+
+func (x *KashdMessage_GetTransactionResponse) fromAppMessage(message *appmessage.GetTransactionResponseMessage) error {
+	var rpcErr *RPCError
+	if message.Error != nil {
+		rpcErr = &RPCError{Message: message.Error.Message}
+	}
+	var transaction *RpcTransaction
+	if message.Transaction != nil {
+		transaction = new(RpcTransaction)
+		transaction.fromAppMessage(message.Transaction)
+	}
+	x.GetTransactionResponse = &GetTransactionResponseMessage{
+		Transaction:           transaction,
+		AcceptingBlockHash:    message.AcceptingBlockHash,
+		ContainingBlockHashes: message.ContainingBlockHashes,
+		TransactionType:       message.TransactionType,
+		InputAssetType:        message.InputAssetType,
+		OutputAssetType:       message.OutputAssetType,
+		Confirmations:         message.Confirmations,
+		Error:                 rpcErr,
+	}
+	return nil
+}
+
+func (x *GetTransactionResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	var transaction *appmessage.RPCTransaction
+	if x.Transaction != nil {
+		transaction, err = x.Transaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rpcErr != nil && transaction != nil {
+		return nil, errors.New("GetTransactionResponseMessage contains both an error and a response")
+	}
+
+	return &appmessage.GetTransactionResponseMessage{
+		Transaction:           transaction,
+		AcceptingBlockHash:    x.AcceptingBlockHash,
+		ContainingBlockHashes: x.ContainingBlockHashes,
+		TransactionType:       x.TransactionType,
+		InputAssetType:        x.InputAssetType,
+		OutputAssetType:       x.OutputAssetType,
+		Confirmations:         x.Confirmations,
+		Error:                 rpcErr,
+	}, nil
+}
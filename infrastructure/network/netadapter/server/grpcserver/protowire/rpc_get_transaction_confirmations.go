@@ -0,0 +1,70 @@
+package protowire
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KashdMessage_GetTransactionConfirmationsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_GetTransactionConfirmationsRequest is nil")
+	}
+	return x.GetTransactionConfirmationsRequest.toAppMessage()
+}
+
+func (x *KashdMessage_GetTransactionConfirmationsRequest) fromAppMessage(
+	message *appmessage.GetTransactionConfirmationsRequestMessage) error {
+
+	x.GetTransactionConfirmationsRequest = &GetTransactionConfirmationsRequestMessage{
+		TxId: message.TxID,
+	}
+	return nil
+}
+
+func (x *GetTransactionConfirmationsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionConfirmationsRequestMessage is nil")
+	}
+	return &appmessage.GetTransactionConfirmationsRequestMessage{
+		TxID: x.TxId,
+	}, nil
+}
+
+func (x *KashdMessage_GetTransactionConfirmationsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_GetTransactionConfirmationsResponse is nil")
+	}
+	return x.GetTransactionConfirmationsResponse.toAppMessage()
+}
+
+func (x *KashdMessage_GetTransactionConfirmationsResponse) fromAppMessage(
+	message *appmessage.GetTransactionConfirmationsResponseMessage) error {
+
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GetTransactionConfirmationsResponse = &GetTransactionConfirmationsResponseMessage{
+		Confirmations: message.Confirmations,
+
+		Error: err,
+	}
+	return nil
+}
+
+func (x *GetTransactionConfirmationsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionConfirmationsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetTransactionConfirmationsResponseMessage{
+		Confirmations: x.Confirmations,
+
+		Error: rpcErr,
+	}, nil
+}
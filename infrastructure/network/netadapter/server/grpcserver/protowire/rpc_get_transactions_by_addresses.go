@@ -0,0 +1,157 @@
+package protowire
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KashdMessage_GetTransactionsByAddressesRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_GetTransactionsByAddressesRequest is nil")
+	}
+	return x.GetTransactionsByAddressesRequest.toAppMessage()
+}
+
+func (x *KashdMessage_GetTransactionsByAddressesRequest) fromAppMessage(
+	message *appmessage.GetTransactionsByAddressesRequestMessage) error {
+
+	var startAfter *TransactionsByAddressesPageKey
+	if message.StartAfter != nil {
+		startAfter = &TransactionsByAddressesPageKey{}
+		startAfter.fromAppMessage(message.StartAfter)
+	}
+	x.GetTransactionsByAddressesRequest = &GetTransactionsByAddressesRequestMessage{
+		Addresses:  message.Addresses,
+		StartAfter: startAfter,
+		Limit:      message.Limit,
+	}
+	return nil
+}
+
+func (x *GetTransactionsByAddressesRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionsByAddressesRequestMessage is nil")
+	}
+	// StartAfter is an optional field
+	startAfter, err := x.StartAfter.toAppMessage()
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.GetTransactionsByAddressesRequestMessage{
+		Addresses:  x.Addresses,
+		StartAfter: startAfter,
+		Limit:      x.Limit,
+	}, nil
+}
+
+func (x *KashdMessage_GetTransactionsByAddressesResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_GetTransactionsByAddressesResponse is nil")
+	}
+	return x.GetTransactionsByAddressesResponse.toAppMessage()
+}
+
+func (x *KashdMessage_GetTransactionsByAddressesResponse) fromAppMessage(
+	message *appmessage.GetTransactionsByAddressesResponseMessage) error {
+
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	entries := make([]*TransactionsByAddressesEntry, len(message.Entries))
+	for i, entry := range message.Entries {
+		entries[i] = &TransactionsByAddressesEntry{}
+		entries[i].fromAppMessage(entry)
+	}
+	var nextPageKey *TransactionsByAddressesPageKey
+	if message.NextPageKey != nil {
+		nextPageKey = &TransactionsByAddressesPageKey{}
+		nextPageKey.fromAppMessage(message.NextPageKey)
+	}
+	x.GetTransactionsByAddressesResponse = &GetTransactionsByAddressesResponseMessage{
+		Entries:     entries,
+		NextPageKey: nextPageKey,
+		Error:       err,
+	}
+	return nil
+}
+
+func (x *GetTransactionsByAddressesResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionsByAddressesResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Entries) != 0 {
+		return nil, errors.New("GetTransactionsByAddressesResponseMessage contains both an error and a response")
+	}
+
+	entries := make([]*appmessage.TransactionsByAddressesEntry, len(x.Entries))
+	for i, entry := range x.Entries {
+		entryAsAppMessage, err := entry.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = entryAsAppMessage
+	}
+
+	// NextPageKey is an optional field
+	nextPageKey, err := x.NextPageKey.toAppMessage()
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	return &appmessage.GetTransactionsByAddressesResponseMessage{
+		Entries:     entries,
+		NextPageKey: nextPageKey,
+		Error:       rpcErr,
+	}, nil
+}
+
+func (x *TransactionsByAddressesEntry) toAppMessage() (*appmessage.TransactionsByAddressesEntry, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "TransactionsByAddressesEntry is nil")
+	}
+	return &appmessage.TransactionsByAddressesEntry{
+		Address:                 x.Address,
+		TransactionID:           x.TransactionId,
+		AcceptingBlockHash:      x.AcceptingBlockHash,
+		AcceptingBlockBlueScore: x.AcceptingBlockBlueScore,
+		AssetType:               x.AssetType,
+		AmountDelta:             x.AmountDelta,
+	}, nil
+}
+
+func (x *TransactionsByAddressesEntry) fromAppMessage(message *appmessage.TransactionsByAddressesEntry) {
+	*x = TransactionsByAddressesEntry{
+		Address:                 message.Address,
+		TransactionId:           message.TransactionID,
+		AcceptingBlockHash:      message.AcceptingBlockHash,
+		AcceptingBlockBlueScore: message.AcceptingBlockBlueScore,
+		AssetType:               message.AssetType,
+		AmountDelta:             message.AmountDelta,
+	}
+}
+
+func (x *TransactionsByAddressesPageKey) toAppMessage() (*appmessage.TransactionsByAddressesPageKey, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "TransactionsByAddressesPageKey is nil")
+	}
+	return &appmessage.TransactionsByAddressesPageKey{
+		AcceptingBlockBlueScore: x.AcceptingBlockBlueScore,
+		TransactionID:           x.TransactionId,
+		AssetType:               x.AssetType,
+	}, nil
+}
+
+func (x *TransactionsByAddressesPageKey) fromAppMessage(message *appmessage.TransactionsByAddressesPageKey) {
+	*x = TransactionsByAddressesPageKey{
+		AcceptingBlockBlueScore: message.AcceptingBlockBlueScore,
+		TransactionId:           message.TransactionID,
+		AssetType:               message.AssetType,
+	}
+}
@@ -0,0 +1,88 @@
+package protowire
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KashdMessage_NotifyReserveRatioChangedRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_NotifyReserveRatioChangedRequest is nil")
+	}
+	return x.NotifyReserveRatioChangedRequest.toAppMessage()
+}
+
+func (x *KashdMessage_NotifyReserveRatioChangedRequest) fromAppMessage(message *appmessage.NotifyReserveRatioChangedRequestMessage) error {
+	x.NotifyReserveRatioChangedRequest = &NotifyReserveRatioChangedRequestMessage{
+		ThresholdsBasisPoints: message.ThresholdsBasisPoints,
+	}
+	return nil
+}
+
+func (x *NotifyReserveRatioChangedRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyReserveRatioChangedRequestMessage is nil")
+	}
+	return &appmessage.NotifyReserveRatioChangedRequestMessage{
+		ThresholdsBasisPoints: x.ThresholdsBasisPoints,
+	}, nil
+}
+
+func (x *KashdMessage_NotifyReserveRatioChangedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_NotifyReserveRatioChangedResponse is nil")
+	}
+	return x.NotifyReserveRatioChangedResponse.toAppMessage()
+}
+
+func (x *KashdMessage_NotifyReserveRatioChangedResponse) fromAppMessage(message *appmessage.NotifyReserveRatioChangedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.NotifyReserveRatioChangedResponse = &NotifyReserveRatioChangedResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyReserveRatioChangedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyReserveRatioChangedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyReserveRatioChangedResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KashdMessage_ReserveRatioChangedNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KashdMessage_ReserveRatioChangedNotification is nil")
+	}
+	return x.ReserveRatioChangedNotification.toAppMessage()
+}
+
+func (x *KashdMessage_ReserveRatioChangedNotification) fromAppMessage(message *appmessage.ReserveRatioChangedNotificationMessage) error {
+	x.ReserveRatioChangedNotification = &ReserveRatioChangedNotificationMessage{
+		ReserveRatioBasisPoints:         message.ReserveRatioBasisPoints,
+		PreviousReserveRatioBasisPoints: message.PreviousReserveRatioBasisPoints,
+		CrossedThresholdsBasisPoints:    message.CrossedThresholdsBasisPoints,
+	}
+	return nil
+}
+
+func (x *ReserveRatioChangedNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ReserveRatioChangedNotificationMessage is nil")
+	}
+	return &appmessage.ReserveRatioChangedNotificationMessage{
+		ReserveRatioBasisPoints:         x.ReserveRatioBasisPoints,
+		PreviousReserveRatioBasisPoints: x.PreviousReserveRatioBasisPoints,
+		CrossedThresholdsBasisPoints:    x.CrossedThresholdsBasisPoints,
+	}, nil
+}
@@ -968,6 +968,111 @@ func toRPCPayload(message appmessage.Message) (isKashdMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *appmessage.GetReserveStateRequestMessage:
+		payload := new(KashdMessage_GetReserveStateRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetReserveStateResponseMessage:
+		payload := new(KashdMessage_GetReserveStateResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetOraclePriceRequestMessage:
+		payload := new(KashdMessage_GetOraclePriceRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetOraclePriceResponseMessage:
+		payload := new(KashdMessage_GetOraclePriceResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyReserveRatioChangedRequestMessage:
+		payload := new(KashdMessage_NotifyReserveRatioChangedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyReserveRatioChangedResponseMessage:
+		payload := new(KashdMessage_NotifyReserveRatioChangedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ReserveRatioChangedNotificationMessage:
+		payload := new(KashdMessage_ReserveRatioChangedNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetFeeEstimateRequestMessage:
+		payload := new(KashdMessage_GetFeeEstimateRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetFeeEstimateResponseMessage:
+		payload := new(KashdMessage_GetFeeEstimateResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionRequestMessage:
+		payload := new(KashdMessage_GetTransactionRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionResponseMessage:
+		payload := new(KashdMessage_GetTransactionResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionConfirmationsRequestMessage:
+		payload := new(KashdMessage_GetTransactionConfirmationsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionConfirmationsResponseMessage:
+		payload := new(KashdMessage_GetTransactionConfirmationsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionsByAddressesRequestMessage:
+		payload := new(KashdMessage_GetTransactionsByAddressesRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionsByAddressesResponseMessage:
+		payload := new(KashdMessage_GetTransactionsByAddressesResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	default:
 		return nil, nil
 	}
@@ -0,0 +1,101 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RPCAuthorizationMetadataKey is the key of the gRPC metadata entry RPC clients authenticate with.
+// Its value is either "Bearer <token>" or "Basic <base64 of user:password>".
+const RPCAuthorizationMetadataKey = "authorization"
+
+// RPCCredentials are the credentials RPC clients authenticate with. Clients that authenticate
+// with the limited credentials are restricted as if the node were running with --saferpc.
+// Empty credentials are not accepted.
+type RPCCredentials struct {
+	User     string
+	Password string
+	Token    string
+
+	LimitedUser     string
+	LimitedPassword string
+	LimitedToken    string
+}
+
+// BasicAuthorization returns the authorization metadata value of the given user and password
+func BasicAuthorization(user, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password))
+}
+
+// BearerAuthorization returns the authorization metadata value of the given token
+func BearerAuthorization(token string) string {
+	return "Bearer " + token
+}
+
+// rpcAuthenticator authenticates RPC streams. It holds the hashes of the accepted authorization
+// values, so that they are compared in constant time.
+type rpcAuthenticator struct {
+	authorizations        [][sha256.Size]byte
+	limitedAuthorizations [][sha256.Size]byte
+}
+
+func newRPCAuthenticator(credentials *RPCCredentials) *rpcAuthenticator {
+	authenticator := &rpcAuthenticator{}
+	if credentials == nil {
+		return authenticator
+	}
+
+	if credentials.User != "" || credentials.Password != "" {
+		authenticator.authorizations = append(authenticator.authorizations,
+			sha256.Sum256([]byte(BasicAuthorization(credentials.User, credentials.Password))))
+	}
+	if credentials.Token != "" {
+		authenticator.authorizations = append(authenticator.authorizations,
+			sha256.Sum256([]byte(BearerAuthorization(credentials.Token))))
+	}
+	if credentials.LimitedUser != "" || credentials.LimitedPassword != "" {
+		authenticator.limitedAuthorizations = append(authenticator.limitedAuthorizations,
+			sha256.Sum256([]byte(BasicAuthorization(credentials.LimitedUser, credentials.LimitedPassword))))
+	}
+	if credentials.LimitedToken != "" {
+		authenticator.limitedAuthorizations = append(authenticator.limitedAuthorizations,
+			sha256.Sum256([]byte(BearerAuthorization(credentials.LimitedToken))))
+	}
+	return authenticator
+}
+
+func (a *rpcAuthenticator) isRequired() bool {
+	return len(a.authorizations) > 0 || len(a.limitedAuthorizations) > 0
+}
+
+// authenticate authenticates the stream of the given context, and returns whether it
+// authenticated with limited credentials
+func (a *rpcAuthenticator) authenticate(ctx context.Context) (isLimited bool, err error) {
+	if !a.isRequired() {
+		return false, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(RPCAuthorizationMetadataKey)) == 0 {
+		return false, status.Error(codes.Unauthenticated, "authentication is required")
+	}
+	authorizationHash := sha256.Sum256([]byte(md.Get(RPCAuthorizationMetadataKey)[0]))
+
+	for _, authorization := range a.authorizations {
+		if subtle.ConstantTimeCompare(authorizationHash[:], authorization[:]) == 1 {
+			return false, nil
+		}
+	}
+	for _, authorization := range a.limitedAuthorizations {
+		if subtle.ConstantTimeCompare(authorizationHash[:], authorization[:]) == 1 {
+			return true, nil
+		}
+	}
+	return false, status.Error(codes.Unauthenticated, "invalid credentials")
+}
@@ -0,0 +1,53 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRPCAuthenticator(t *testing.T) {
+	withAuthorization := func(authorization string) context.Context {
+		return metadata.NewIncomingContext(context.Background(),
+			metadata.Pairs(RPCAuthorizationMetadataKey, authorization))
+	}
+
+	noAuthenticator := newRPCAuthenticator(nil)
+	isLimited, err := noAuthenticator.authenticate(context.Background())
+	if err != nil || isLimited {
+		t.Fatalf("Expected streams to be accepted when no credentials are set, but got %t, %v", isLimited, err)
+	}
+
+	authenticator := newRPCAuthenticator(&RPCCredentials{
+		User:         "user",
+		Password:     "password",
+		LimitedToken: "limited-token",
+	})
+
+	tests := []struct {
+		name              string
+		ctx               context.Context
+		expectedIsLimited bool
+		expectedCode      codes.Code
+	}{
+		{name: "full user", ctx: withAuthorization(BasicAuthorization("user", "password")), expectedCode: codes.OK},
+		{name: "limited token", ctx: withAuthorization(BearerAuthorization("limited-token")),
+			expectedIsLimited: true, expectedCode: codes.OK},
+		{name: "wrong password", ctx: withAuthorization(BasicAuthorization("user", "wrong")),
+			expectedCode: codes.Unauthenticated},
+		{name: "unset token", ctx: withAuthorization(BearerAuthorization("")), expectedCode: codes.Unauthenticated},
+		{name: "no authorization", ctx: context.Background(), expectedCode: codes.Unauthenticated},
+	}
+	for _, test := range tests {
+		isLimited, err := authenticator.authenticate(test.ctx)
+		if status.Code(err) != test.expectedCode {
+			t.Fatalf("%s: expected code %s, but got %v", test.name, test.expectedCode, err)
+		}
+		if isLimited != test.expectedIsLimited {
+			t.Fatalf("%s: expected isLimited to be %t, but got %t", test.name, test.expectedIsLimited, isLimited)
+		}
+	}
+}
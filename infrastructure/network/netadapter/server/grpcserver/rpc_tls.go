@@ -0,0 +1,70 @@
+package grpcserver
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Kash-Protocol/kashd/util"
+	"github.com/pkg/errors"
+)
+
+// rpcCertValidity is how long automatically generated RPC certificates are valid for
+const rpcCertValidity = 10 * 365 * 24 * time.Hour
+
+// RPCTLSConfig returns the TLS configuration of the RPC server, which serves the certificate in certFile
+// with the key in keyFile. If neither of the files exists, a self-signed certificate that is valid for
+// the local addresses and for the hosts of the given listeners is generated into them.
+func RPCTLSConfig(certFile, keyFile string, listeners []string) (*tls.Config, error) {
+	if !fileExists(certFile) && !fileExists(keyFile) {
+		err := generateRPCCertPair(certFile, keyFile, listeners)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keyPair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading the RPC certificate from %s and %s", certFile, keyFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func generateRPCCertPair(certFile, keyFile string, listeners []string) error {
+	log.Infof("Generating a self-signed RPC certificate at %s", certFile)
+
+	cert, key, err := util.NewTLSCertPair("kashd autogenerated cert", time.Now().Add(rpcCertValidity), listeners)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range []string{certFile, keyFile} {
+		err = os.MkdirAll(filepath.Dir(file), 0700)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.WriteFile(certFile, cert, 0666)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(keyFile, key, 0600)
+	if err != nil {
+		os.Remove(certFile)
+		return err
+	}
+
+	log.Infof("Done generating the RPC certificate. Clients verify the server with it through --rpccert")
+	return nil
+}
+
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return !os.IsNotExist(err)
+}
@@ -1,23 +1,39 @@
 package grpcserver
 
 import (
+	"crypto/tls"
+
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/server"
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/server/grpcserver/protowire"
 	"github.com/Kash-Protocol/kashd/util/panics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type rpcServer struct {
 	protowire.UnimplementedRPCServer
 	gRPCServer
+
+	authenticator *rpcAuthenticator
 }
 
 // RPCMaxMessageSize is the max message size for the RPC server to send and receive
 const RPCMaxMessageSize = 1024 * 1024 * 1024 // 1 GB
 
-// NewRPCServer creates a new RPCServer
-func NewRPCServer(listeningAddresses []string, rpcMaxInboundConnections int) (server.Server, error) {
-	gRPCServer := newGRPCServer(listeningAddresses, RPCMaxMessageSize, rpcMaxInboundConnections, "RPC")
-	rpcServer := &rpcServer{gRPCServer: *gRPCServer}
+// NewRPCServer creates a new RPCServer. The server uses TLS if tlsConfig is not nil, and requires
+// every stream to authenticate with one of the given credentials if any are set.
+func NewRPCServer(listeningAddresses []string, rpcMaxInboundConnections int, tlsConfig *tls.Config,
+	rpcCredentials *RPCCredentials) (server.Server, error) {
+
+	var serverOptions []grpc.ServerOption
+	if tlsConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	gRPCServer := newGRPCServer(listeningAddresses, RPCMaxMessageSize, rpcMaxInboundConnections, "RPC", serverOptions...)
+	rpcServer := &rpcServer{
+		gRPCServer:    *gRPCServer,
+		authenticator: newRPCAuthenticator(rpcCredentials),
+	}
 	protowire.RegisterRPCServer(gRPCServer.server, rpcServer)
 	return rpcServer, nil
 }
@@ -25,5 +41,11 @@ func NewRPCServer(listeningAddresses []string, rpcMaxInboundConnections int) (se
 func (r *rpcServer) MessageStream(stream protowire.RPC_MessageStreamServer) error {
 	defer panics.HandlePanic(log, "rpcServer.MessageStream", nil)
 
-	return r.handleInboundConnection(stream.Context(), stream)
+	isLimited, err := r.authenticator.authenticate(stream.Context())
+	if err != nil {
+		log.Warnf("%s Rejected an RPC stream: %s", r.name, err)
+		return err
+	}
+
+	return r.handleInboundConnection(stream.Context(), stream, isLimited)
 }
@@ -40,7 +40,9 @@ type Connection interface {
 	Disconnect()
 	IsConnected() bool
 	IsOutbound() bool
+	// IsLimitedRPCUser returns whether this is an RPC connection that authenticated with limited credentials
+	IsLimitedRPCUser() bool
 	SetOnDisconnectedHandler(onDisconnectedHandler OnDisconnectedHandler)
 	SetOnInvalidMessageHandler(onInvalidMessageHandler OnInvalidMessageHandler)
-	Address() *net.TCPAddr
+	Address() net.Addr
 }
@@ -8,7 +8,9 @@ import (
 	"github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/server/grpcserver/protowire"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
 	"io"
 	"time"
 )
@@ -27,21 +29,70 @@ type GRPCClient struct {
 	onDisconnectedHandler OnDisconnectedHandler
 }
 
+// Credentials holds what the client needs in order to connect to an RPC server
+// that runs with TLS or requires authentication
+type Credentials struct {
+	// TLSCertFile is the certificate of the RPC server. The connection is made
+	// over TLS if it is set
+	TLSCertFile string
+
+	// Token is sent as a bearer token if it is set. Otherwise, User and Password
+	// are sent as basic authentication if they are set
+	Token    string
+	User     string
+	Password string
+}
+
+func (c *Credentials) authorization() string {
+	if c.Token != "" {
+		return grpcserver.BearerAuthorization(c.Token)
+	}
+	if c.User != "" || c.Password != "" {
+		return grpcserver.BasicAuthorization(c.User, c.Password)
+	}
+	return ""
+}
+
 // Connect connects to the RPC server with the given address
 func Connect(address string) (*GRPCClient, error) {
+	return ConnectWithCredentials(address, nil)
+}
+
+// ConnectWithCredentials connects to the RPC server with the given address using the given
+// credentials. A nil credentials connects the same way Connect does
+func ConnectWithCredentials(address string, rpcCredentials *Credentials) (*GRPCClient, error) {
 	const dialTimeout = 5 * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
 
-	gRPCConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	transportOption := grpc.WithInsecure()
+	if rpcCredentials != nil && rpcCredentials.TLSCertFile != "" {
+		transportCredentials, err := credentials.NewClientTLSFromFile(rpcCredentials.TLSCertFile, "")
+		if err != nil {
+			return nil, errors.Wrapf(err, "error loading the RPC certificate %s", rpcCredentials.TLSCertFile)
+		}
+		transportOption = grpc.WithTransportCredentials(transportCredentials)
+	}
+
+	gRPCConnection, err := grpc.DialContext(ctx, address, transportOption, grpc.WithBlock())
 	if err != nil {
 		return nil, errors.Wrapf(err, "error connecting to %s", address)
 	}
 
+	streamContext := context.Background()
+	if rpcCredentials != nil {
+		authorization := rpcCredentials.authorization()
+		if authorization != "" {
+			streamContext = metadata.AppendToOutgoingContext(streamContext,
+				grpcserver.RPCAuthorizationMetadataKey, authorization)
+		}
+	}
+
 	grpcClient := protowire.NewRPCClient(gRPCConnection)
-	stream, err := grpcClient.MessageStream(context.Background(), grpc.UseCompressor(gzip.Name),
+	stream, err := grpcClient.MessageStream(streamContext, grpc.UseCompressor(gzip.Name),
 		grpc.MaxCallRecvMsgSize(grpcserver.RPCMaxMessageSize), grpc.MaxCallSendMsgSize(grpcserver.RPCMaxMessageSize))
 	if err != nil {
+		gRPCConnection.Close()
 		return nil, errors.Wrapf(err, "error getting client stream for %s", address)
 	}
 	return &GRPCClient{stream: stream, connection: gRPCConnection}, nil
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/Kash-Protocol/kashd/app/appmessage"
+
+// GetOraclePrice sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetOraclePrice() (*appmessage.GetOraclePriceResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetOraclePriceRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetOraclePriceResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getOraclePriceResponse := response.(*appmessage.GetOraclePriceResponseMessage)
+	if getOraclePriceResponse.Error != nil {
+		return nil, c.convertRPCError(getOraclePriceResponse.Error)
+	}
+	return getOraclePriceResponse, nil
+}
@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/Kash-Protocol/kashd/app/appmessage"
+
+// GetReserveState sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetReserveState() (*appmessage.GetReserveStateResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetReserveStateRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetReserveStateResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getReserveStateResponse := response.(*appmessage.GetReserveStateResponseMessage)
+	if getReserveStateResponse.Error != nil {
+		return nil, c.convertRPCError(getReserveStateResponse.Error)
+	}
+	return getReserveStateResponse, nil
+}
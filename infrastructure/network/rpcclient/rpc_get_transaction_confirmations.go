@@ -0,0 +1,20 @@
+package rpcclient
+
+import "github.com/Kash-Protocol/kashd/app/appmessage"
+
+// GetTransactionConfirmations sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetTransactionConfirmations(txID string) (*appmessage.GetTransactionConfirmationsResponseMessage, error) {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetTransactionConfirmationsRequestMessage(txID))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetTransactionConfirmationsResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getTransactionConfirmationsResponse := response.(*appmessage.GetTransactionConfirmationsResponseMessage)
+	if getTransactionConfirmationsResponse.Error != nil {
+		return nil, c.convertRPCError(getTransactionConfirmationsResponse.Error)
+	}
+	return getTransactionConfirmationsResponse, nil
+}
@@ -0,0 +1,22 @@
+package rpcclient
+
+import "github.com/Kash-Protocol/kashd/app/appmessage"
+
+// GetTransactionsByAddresses sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetTransactionsByAddresses(addresses []string,
+	startAfter *appmessage.TransactionsByAddressesPageKey, limit uint64) (*appmessage.GetTransactionsByAddressesResponseMessage, error) {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetTransactionsByAddressesRequestMessage(addresses, startAfter, limit))
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.route(appmessage.CmdGetTransactionsByAddressesResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return nil, err
+	}
+	getTransactionsByAddressesResponse := response.(*appmessage.GetTransactionsByAddressesResponseMessage)
+	if getTransactionsByAddressesResponse.Error != nil {
+		return nil, c.convertRPCError(getTransactionsByAddressesResponse.Error)
+	}
+	return getTransactionsByAddressesResponse, nil
+}
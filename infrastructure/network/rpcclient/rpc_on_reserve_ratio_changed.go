@@ -0,0 +1,42 @@
+package rpcclient
+
+import (
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	routerpkg "github.com/Kash-Protocol/kashd/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// RegisterForReserveRatioChangedNotifications sends an RPC request respective to the function's
+// name and returns the RPC server's response. Additionally, it starts listening for the appropriate notification
+// using the given handler function.
+// If thresholdsBasisPoints is empty, the minimal and maximal reserve ratios of the network are used.
+func (c *RPCClient) RegisterForReserveRatioChangedNotifications(thresholdsBasisPoints []uint64,
+	onReserveRatioChanged func(notification *appmessage.ReserveRatioChangedNotificationMessage)) error {
+
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyReserveRatioChangedRequestMessage(thresholdsBasisPoints))
+	if err != nil {
+		return err
+	}
+	response, err := c.route(appmessage.CmdNotifyReserveRatioChangedResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return err
+	}
+	notifyReserveRatioChangedResponse := response.(*appmessage.NotifyReserveRatioChangedResponseMessage)
+	if notifyReserveRatioChangedResponse.Error != nil {
+		return c.convertRPCError(notifyReserveRatioChangedResponse.Error)
+	}
+	spawn("RegisterForReserveRatioChangedNotifications", func() {
+		for {
+			notification, err := c.route(appmessage.CmdReserveRatioChangedNotificationMessage).Dequeue()
+			if err != nil {
+				if errors.Is(err, routerpkg.ErrRouteClosed) {
+					break
+				}
+				panic(err)
+			}
+			reserveRatioChangedNotification := notification.(*appmessage.ReserveRatioChangedNotificationMessage)
+			onReserveRatioChanged(reserveRatioChangedNotification)
+		}
+	})
+	return nil
+}
@@ -12,20 +12,10 @@ import (
 func (c *RPCClient) RegisterForUTXOsChangedNotifications(addresses []string, assetTypes []externalapi.AssetType,
 	onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyUTXOsChangedRequestMessage(
-		addresses, externalapi.ConvertAssetTypeSliceToUint32Slice(assetTypes)))
-
-	if err != nil {
-		return err
-	}
-	response, err := c.route(appmessage.CmdNotifyUTXOsChangedResponseMessage).DequeueWithTimeout(c.timeout)
+	err := c.notifyUTXOsChanged(addresses, assetTypes)
 	if err != nil {
 		return err
 	}
-	notifyUTXOsChangedResponse := response.(*appmessage.NotifyUTXOsChangedResponseMessage)
-	if notifyUTXOsChangedResponse.Error != nil {
-		return c.convertRPCError(notifyUTXOsChangedResponse.Error)
-	}
 	spawn("RegisterForUTXOsChangedNotifications", func() {
 		for {
 			notification, err := c.route(appmessage.CmdUTXOsChangedNotificationMessage).Dequeue()
@@ -41,3 +31,28 @@ func (c *RPCClient) RegisterForUTXOsChangedNotifications(addresses []string, ass
 	})
 	return nil
 }
+
+// AddUTXOsChangedNotificationAddresses sends an RPC request respective to the function's name and returns the RPC server's response.
+// The given addresses are added to the ones notifications are sent for, and are handled by the handler function
+// previously given to RegisterForUTXOsChangedNotifications
+func (c *RPCClient) AddUTXOsChangedNotificationAddresses(addresses []string, assetTypes []externalapi.AssetType) error {
+	return c.notifyUTXOsChanged(addresses, assetTypes)
+}
+
+func (c *RPCClient) notifyUTXOsChanged(addresses []string, assetTypes []externalapi.AssetType) error {
+	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyUTXOsChangedRequestMessage(
+		addresses, externalapi.ConvertAssetTypeSliceToUint32Slice(assetTypes)))
+
+	if err != nil {
+		return err
+	}
+	response, err := c.route(appmessage.CmdNotifyUTXOsChangedResponseMessage).DequeueWithTimeout(c.timeout)
+	if err != nil {
+		return err
+	}
+	notifyUTXOsChangedResponse := response.(*appmessage.NotifyUTXOsChangedResponseMessage)
+	if notifyUTXOsChangedResponse.Error != nil {
+		return c.convertRPCError(notifyUTXOsChangedResponse.Error)
+	}
+	return nil
+}
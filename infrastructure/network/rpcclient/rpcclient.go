@@ -20,20 +20,33 @@ type RPCClient struct {
 	*grpcclient.GRPCClient
 
 	rpcAddress           string
+	rpcCredentials       *grpcclient.Credentials
 	rpcRouter            *rpcRouter
 	isConnected          uint32
 	isClosed             uint32
 	isReconnecting       uint32
 	lastDisconnectedTime time.Time
+	onReconnectedHandler OnReconnectedHandler
 
 	timeout time.Duration
 }
 
+// OnReconnectedHandler defines a handler function for when the client reconnected.
+// Notification registrations do not survive reconnection, so this is where they should be renewed
+type OnReconnectedHandler func()
+
 // NewRPCClient сreates a new RPC client with a default call timeout value
 func NewRPCClient(rpcAddress string) (*RPCClient, error) {
+	return NewRPCClientWithCredentials(rpcAddress, nil)
+}
+
+// NewRPCClientWithCredentials creates a new RPC client with a default call timeout value, which
+// connects to an RPC server that runs with TLS or requires authentication
+func NewRPCClientWithCredentials(rpcAddress string, rpcCredentials *grpcclient.Credentials) (*RPCClient, error) {
 	rpcClient := &RPCClient{
-		rpcAddress: rpcAddress,
-		timeout:    defaultTimeout,
+		rpcAddress:     rpcAddress,
+		rpcCredentials: rpcCredentials,
+		timeout:        defaultTimeout,
 	}
 	err := rpcClient.connect()
 	if err != nil {
@@ -44,7 +57,7 @@ func NewRPCClient(rpcAddress string) (*RPCClient, error) {
 }
 
 func (c *RPCClient) connect() error {
-	rpcClient, err := grpcclient.Connect(c.rpcAddress)
+	rpcClient, err := grpcclient.ConnectWithCredentials(c.rpcAddress, c.rpcCredentials)
 	if err != nil {
 		return errors.Wrapf(err, "error connecting to address %s", c.rpcAddress)
 	}
@@ -118,6 +131,9 @@ func (c *RPCClient) Reconnect() error {
 		if time.Since(c.lastDisconnectedTime) > retryDelay {
 			err := c.connect()
 			if err == nil {
+				if c.onReconnectedHandler != nil {
+					c.onReconnectedHandler()
+				}
 				return nil
 			}
 			log.Warnf("Could not automatically reconnect to %s: %s", c.rpcAddress, err)
@@ -150,6 +166,11 @@ func (c *RPCClient) handleClientError(err error) {
 	c.handleClientDisconnected()
 }
 
+// SetOnReconnectedHandler sets the client's onReconnectedHandler
+func (c *RPCClient) SetOnReconnectedHandler(onReconnectedHandler OnReconnectedHandler) {
+	c.onReconnectedHandler = onReconnectedHandler
+}
+
 // SetTimeout sets the timeout by which to wait for RPC responses
 func (c *RPCClient) SetTimeout(timeout time.Duration) {
 	c.timeout = timeout
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+)
+
+func TestAddressIndex(t *testing.T) {
+	kashd, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		addressIndex:            true,
+	})
+	defer teardown()
+
+	// The coinbase transaction of a block pays the miners of the blocks it merges, and is
+	// accepted by the chain block that merges it, so the last two of these blocks accept
+	// a coinbase transaction that pays to the mining address
+	for i := 0; i < 4; i++ {
+		mineNextBlock(t, kashd)
+	}
+
+	const expectedEntries = 2
+	addresses := []string{miningAddress1}
+	var entries []*appmessage.TransactionsByAddressesEntry
+	waitForIndex(t, "the address index to catch up", func() bool {
+		response, err := kashd.rpcClient.GetTransactionsByAddresses(addresses, nil, 100)
+		if err != nil {
+			t.Fatalf("Error getting transactions by addresses: %s", err)
+		}
+		if response.NextPageKey != nil {
+			t.Fatalf("Expected all the entries to fit in a single page")
+		}
+		entries = response.Entries
+		return len(entries) == expectedEntries
+	})
+
+	for i, entry := range entries {
+		if entry.Address != miningAddress1 {
+			t.Fatalf("Expected entry %d to be of address %s, but got %s", i, miningAddress1, entry.Address)
+		}
+		if entry.AssetType != externalapi.KSH.ToUint32() || entry.AmountDelta <= 0 {
+			t.Fatalf("Expected entry %d to be a KSH payment, but got asset type %d with amount delta %d",
+				i, entry.AssetType, entry.AmountDelta)
+		}
+		if i > 0 && entry.AcceptingBlockBlueScore <= entries[i-1].AcceptingBlockBlueScore {
+			t.Fatalf("Expected the entries to be ordered by accepting block blue score")
+		}
+	}
+
+	// Requesting the same address twice should not duplicate its entries
+	duplicatedAddresses := []string{miningAddress1, miningAddress1}
+	firstPage, err := kashd.rpcClient.GetTransactionsByAddresses(duplicatedAddresses, nil, 1)
+	if err != nil {
+		t.Fatalf("Error getting a page of transactions by addresses: %s", err)
+	}
+	if len(firstPage.Entries) != 1 || firstPage.Entries[0].TransactionID != entries[0].TransactionID {
+		t.Fatalf("Expected the first page to contain transaction %s, but got %+v",
+			entries[0].TransactionID, firstPage.Entries)
+	}
+	if firstPage.NextPageKey == nil || firstPage.NextPageKey.TransactionID != entries[0].TransactionID {
+		t.Fatalf("Expected the next page key to point at transaction %s, but got %+v",
+			entries[0].TransactionID, firstPage.NextPageKey)
+	}
+	secondPage, err := kashd.rpcClient.GetTransactionsByAddresses(duplicatedAddresses, firstPage.NextPageKey, 1)
+	if err != nil {
+		t.Fatalf("Error getting a page of transactions by addresses: %s", err)
+	}
+	if len(secondPage.Entries) != 1 || secondPage.Entries[0].TransactionID != entries[1].TransactionID {
+		t.Fatalf("Expected the second page to contain transaction %s, but got %+v",
+			entries[1].TransactionID, secondPage.Entries)
+	}
+	if secondPage.NextPageKey != nil {
+		t.Fatalf("Expected the second page to be the last, but got next page key %+v", secondPage.NextPageKey)
+	}
+
+	_, err = kashd.rpcClient.GetTransactionsByAddresses(addresses, nil, 0)
+	if err == nil {
+		t.Fatalf("Expected getting transactions by addresses without a limit to fail")
+	}
+}
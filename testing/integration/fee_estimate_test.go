@@ -0,0 +1,31 @@
+package integration
+
+import (
+	"testing"
+)
+
+func TestGetFeeEstimate(t *testing.T) {
+	kashd, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	// Mining builds a block template, which takes no transactions from the empty mempool
+	mineNextBlock(t, kashd)
+
+	feeEstimate, err := kashd.rpcClient.GetFeeEstimate()
+	if err != nil {
+		t.Fatalf("Error getting the fee estimate: %s", err)
+	}
+
+	// With an empty mempool, every priority only needs the minimum relay fee rate
+	minimumRelayFeeRate := float64(kashd.config.MinRelayTxFee) / 1000
+	if feeEstimate.LowFeeRate != minimumRelayFeeRate || feeEstimate.NormalFeeRate != minimumRelayFeeRate ||
+		feeEstimate.PriorityFeeRate != minimumRelayFeeRate {
+		t.Fatalf("Expected all fee rates to be the minimum relay fee rate %f, but got %+v",
+			minimumRelayFeeRate, feeEstimate)
+	}
+}
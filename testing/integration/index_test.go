@@ -0,0 +1,19 @@
+package integration
+
+import (
+	"testing"
+	"time"
+)
+
+// waitForIndex waits until isCaughtUp returns true. Indexes are updated
+// asynchronously, so tests wait for them to catch up with the last block
+// before querying them.
+func waitForIndex(t *testing.T, description string, isCaughtUp func() bool) {
+	deadline := time.Now().Add(defaultTimeout)
+	for !isCaughtUp() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", description)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,53 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/app/appmessage"
+)
+
+func TestReserveStateRPCs(t *testing.T) {
+	kashd, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	err := kashd.rpcClient.RegisterForReserveRatioChangedNotifications(nil,
+		func(notification *appmessage.ReserveRatioChangedNotificationMessage) {
+			t.Errorf("Unexpected reserve ratio changed notification: %+v", notification)
+		})
+	if err != nil {
+		t.Fatalf("Failed to register for reserve ratio changed notifications: %s", err)
+	}
+
+	// No oracle price record is committed while mining without oracle data,
+	// so the reserve stays empty and no conversion can be quoted
+	mineNextBlock(t, kashd)
+
+	reserveState, err := kashd.rpcClient.GetReserveState()
+	if err != nil {
+		t.Fatalf("Error getting the reserve state: %s", err)
+	}
+	if reserveState.ReserveSompi != 0 || reserveState.KUSDSupplySompi != 0 || reserveState.KRVSupplySompi != 0 {
+		t.Fatalf("Expected an empty reserve but got %+v", reserveState)
+	}
+	params := kashd.config.ActiveNetParams
+	if reserveState.MinReserveRatioBasisPoints != params.MinReserveRatio*100 ||
+		reserveState.MaxReserveRatioBasisPoints != params.MaxReserveRatio*100 {
+		t.Fatalf("Unexpected reserve ratio bounds. Want: %d-%d, got: %d-%d",
+			params.MinReserveRatio*100, params.MaxReserveRatio*100,
+			reserveState.MinReserveRatioBasisPoints, reserveState.MaxReserveRatioBasisPoints)
+	}
+	if len(reserveState.ConversionQuotes) != 0 {
+		t.Fatalf("Expected no conversion quotes without an exchange rate but got %d",
+			len(reserveState.ConversionQuotes))
+	}
+
+	_, err = kashd.rpcClient.GetOraclePrice()
+	if err == nil {
+		t.Fatalf("Expected GetOraclePrice to fail without an oracle price record")
+	}
+}
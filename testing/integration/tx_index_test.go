@@ -0,0 +1,72 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/Kash-Protocol/kashd/domain/consensus/model/externalapi"
+	"github.com/Kash-Protocol/kashd/domain/consensus/utils/consensushashing"
+)
+
+func TestTXIndex(t *testing.T) {
+	kashd, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		txIndex:                 true,
+	})
+	defer teardown()
+
+	// The coinbase transaction of a block is accepted by the chain block that merges it
+	containingBlock := mineNextBlock(t, kashd)
+	acceptingBlock := mineNextBlock(t, kashd)
+	mineNextBlock(t, kashd)
+	mineNextBlock(t, kashd)
+
+	coinbaseTransactionID := consensushashing.TransactionID(containingBlock.Transactions[0]).String()
+
+	const expectedConfirmations = 3
+	waitForIndex(t, "the transaction index to catch up", func() bool {
+		// The transaction is not found until the index accepts it
+		confirmationsResponse, err := kashd.rpcClient.GetTransactionConfirmations(coinbaseTransactionID)
+		return err == nil && confirmationsResponse.Confirmations == expectedConfirmations
+	})
+
+	response, err := kashd.rpcClient.GetTransaction(coinbaseTransactionID)
+	if err != nil {
+		t.Fatalf("Error getting transaction %s: %s", coinbaseTransactionID, err)
+	}
+	if response.Transaction.VerboseData.TransactionID != coinbaseTransactionID {
+		t.Fatalf("Expected transaction %s, but got %s",
+			coinbaseTransactionID, response.Transaction.VerboseData.TransactionID)
+	}
+	acceptingBlockHash := consensushashing.BlockHash(acceptingBlock).String()
+	if response.AcceptingBlockHash != acceptingBlockHash {
+		t.Fatalf("Expected the accepting block to be %s, but got %s", acceptingBlockHash, response.AcceptingBlockHash)
+	}
+	containingBlockHash := consensushashing.BlockHash(containingBlock).String()
+	if len(response.ContainingBlockHashes) != 1 || response.ContainingBlockHashes[0] != containingBlockHash {
+		t.Fatalf("Expected the containing blocks to be [%s], but got %s",
+			containingBlockHash, response.ContainingBlockHashes)
+	}
+	if response.TransactionType != externalapi.TransferKSH.String() ||
+		response.OutputAssetType != externalapi.KSH.ToUint32() {
+
+		t.Fatalf("Unexpected transaction type %s with output asset type %d",
+			response.TransactionType, response.OutputAssetType)
+	}
+	if response.Confirmations != expectedConfirmations {
+		t.Fatalf("Expected %d confirmations, but got %d", expectedConfirmations, response.Confirmations)
+	}
+
+	_, err = kashd.rpcClient.GetTransaction(consensushashing.TransactionID(acceptingBlock.Transactions[0]).String())
+	if err != nil {
+		t.Fatalf("Error getting the coinbase transaction of the accepting block: %s", err)
+	}
+
+	unknownTransactionID := externalapi.DomainTransactionID{}
+	_, err = kashd.rpcClient.GetTransaction(unknownTransactionID.String())
+	if err == nil {
+		t.Fatalf("Expected getting an unknown transaction to fail")
+	}
+}
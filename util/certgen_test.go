@@ -0,0 +1,55 @@
+package util_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Kash-Protocol/kashd/util"
+)
+
+func TestNewTLSCertPair(t *testing.T) {
+	validUntil := time.Unix(time.Now().Add(10*365*24*time.Hour).Unix(), 0)
+	extraHosts := []string{"testtlscert.kash", "127.0.0.2:16110", "::2"}
+	cert, key, err := util.NewTLSCertPair("test organization", validUntil, extraHosts)
+	if err != nil {
+		t.Fatalf("NewTLSCertPair: %+v", err)
+	}
+
+	keyPair, err := tls.X509KeyPair(cert, key)
+	if err != nil {
+		t.Fatalf("X509KeyPair: %+v", err)
+	}
+	x509Cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		t.Fatalf("ParseCertificate: %+v", err)
+	}
+
+	if !x509Cert.NotAfter.Equal(validUntil) {
+		t.Fatalf("Expected the certificate to be valid until %s, but it is valid until %s",
+			validUntil, x509Cert.NotAfter)
+	}
+	err = x509Cert.VerifyHostname("testtlscert.kash")
+	if err != nil {
+		t.Fatalf("Expected the certificate to be valid for the extra host name: %s", err)
+	}
+	for _, ip := range []string{"127.0.0.1", "::1", "127.0.0.2", "::2"} {
+		found := false
+		for _, certIP := range x509Cert.IPAddresses {
+			if certIP.Equal(net.ParseIP(ip)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("Expected the certificate to be valid for %s", ip)
+		}
+	}
+
+	_, _, err = util.NewTLSCertPair("test organization", time.Now().Add(-time.Hour), nil)
+	if err == nil {
+		t.Fatalf("Expected an already expired certificate to be rejected")
+	}
+}
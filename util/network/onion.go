@@ -0,0 +1,101 @@
+package network
+
+import (
+	"bytes"
+	"encoding/base32"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	onionSuffix = ".onion"
+
+	// OnionPublicKeyLength is the length of the public key of a Tor v3 onion service
+	OnionPublicKeyLength = 32
+
+	onionChecksumLength = 2
+	onionVersion        = 3
+)
+
+// IsOnionHost returns whether the given host is a Tor onion service
+func IsOnionHost(host string) bool {
+	return strings.HasSuffix(strings.ToLower(host), onionSuffix)
+}
+
+// DecodeOnionHost returns the public key of the given Tor v3 onion service host,
+// such as "<56 base32 characters>.onion"
+func DecodeOnionHost(host string) ([]byte, error) {
+	if !IsOnionHost(host) {
+		return nil, errors.Errorf("%s is not an onion host", host)
+	}
+
+	encoded := strings.ToUpper(strings.TrimSuffix(strings.ToLower(host), onionSuffix))
+	decoded, err := base32.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode onion host %s", host)
+	}
+	if len(decoded) != OnionPublicKeyLength+onionChecksumLength+1 {
+		return nil, errors.Errorf("onion host %s is not a v3 onion service. "+
+			"Older versions are not supported", host)
+	}
+
+	publicKey := decoded[:OnionPublicKeyLength]
+	checksum := decoded[OnionPublicKeyLength : OnionPublicKeyLength+onionChecksumLength]
+	version := decoded[OnionPublicKeyLength+onionChecksumLength]
+	if version != onionVersion {
+		return nil, errors.Errorf("onion host %s has version %d, while only version %d is supported",
+			host, version, onionVersion)
+	}
+	if !bytes.Equal(checksum, onionChecksum(publicKey)) {
+		return nil, errors.Errorf("onion host %s has an invalid checksum", host)
+	}
+
+	return publicKey, nil
+}
+
+// EncodeOnionHost returns the host of the Tor v3 onion service with the given public key
+func EncodeOnionHost(publicKey []byte) string {
+	decoded := make([]byte, 0, OnionPublicKeyLength+onionChecksumLength+1)
+	decoded = append(decoded, publicKey...)
+	decoded = append(decoded, onionChecksum(publicKey)...)
+	decoded = append(decoded, onionVersion)
+
+	return strings.ToLower(base32.StdEncoding.EncodeToString(decoded)) + onionSuffix
+}
+
+// onionChecksum returns the checksum of a v3 onion service host, as defined in
+// section 6 of the Tor rend-spec-v3
+func onionChecksum(publicKey []byte) []byte {
+	hasher := sha3.New256()
+	hasher.Write([]byte(".onion checksum"))
+	hasher.Write(publicKey)
+	hasher.Write([]byte{onionVersion})
+	return hasher.Sum(nil)[:onionChecksumLength]
+}
+
+// OnionAddr is the address of a Tor v3 onion service. It implements the net.Addr interface.
+type OnionAddr struct {
+	PublicKey []byte
+	Port      int
+}
+
+// String returns the onion host and port of the address.
+//
+// This is part of the net.Addr interface.
+func (a *OnionAddr) String() string {
+	return net.JoinHostPort(EncodeOnionHost(a.PublicKey), strconv.Itoa(a.Port))
+}
+
+// Network returns the network of the address.
+//
+// This is part of the net.Addr interface.
+func (a *OnionAddr) Network() string {
+	return "tcp"
+}
+
+// Ensure OnionAddr implements the net.Addr interface.
+var _ net.Addr = (*OnionAddr)(nil)
@@ -0,0 +1,35 @@
+package network
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOnionHost(t *testing.T) {
+	const host = "duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion"
+
+	publicKey, err := DecodeOnionHost(strings.ToUpper(host))
+	if err != nil {
+		t.Fatalf("DecodeOnionHost: %s", err)
+	}
+	if len(publicKey) != OnionPublicKeyLength {
+		t.Fatalf("Expected a public key of %d bytes, but got %d", OnionPublicKeyLength, len(publicKey))
+	}
+	if encoded := EncodeOnionHost(publicKey); encoded != host {
+		t.Fatalf("Expected the public key to be encoded back to %s, but got %s", host, encoded)
+	}
+
+	invalidHosts := []string{
+		"example.com",
+		// A v2 onion host
+		"expyuzz4wqqyqhjn.onion",
+		// A corrupted checksum
+		"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczbd.onion",
+	}
+	for _, invalidHost := range invalidHosts {
+		_, err := DecodeOnionHost(invalidHost)
+		if err == nil {
+			t.Fatalf("Expected DecodeOnionHost to fail for %s", invalidHost)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2013-2016 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package network
+
+import (
+	"io"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// torLookupTimeout bounds the time a DNS resolution through the proxy may take
+const torLookupTimeout = 30 * time.Second
+
+const (
+	socksAuthNone             = 0x00
+	socksAuthUsernamePassword = 0x02
+)
+
+const (
+	torSucceeded         = 0x00
+	torGeneralError      = 0x01
+	torNotAllowed        = 0x02
+	torNetUnreachable    = 0x03
+	torHostUnreachable   = 0x04
+	torConnectionRefused = 0x05
+	torTTLExpired        = 0x06
+	torCmdNotSupported   = 0x07
+	torAddrNotSupported  = 0x08
+)
+
+var (
+	// ErrTorInvalidAddressResponse indicates an invalid address was
+	// returned by the Tor DNS resolver.
+	ErrTorInvalidAddressResponse = errors.New("invalid address response")
+
+	// ErrTorInvalidProxyResponse indicates the Tor proxy returned a
+	// response in an unexpected format.
+	ErrTorInvalidProxyResponse = errors.New("invalid proxy response")
+
+	// ErrTorUnrecognizedAuthMethod indicates the authentication method
+	// provided is not recognized.
+	ErrTorUnrecognizedAuthMethod = errors.New("invalid proxy authentication method")
+
+	// ErrTorAuthFailed indicates the proxy rejected the provided username
+	// and password.
+	ErrTorAuthFailed = errors.New("proxy authentication failed")
+
+	torStatusErrors = map[byte]error{
+		torSucceeded:         errors.New("tor succeeded"),
+		torGeneralError:      errors.New("tor general error"),
+		torNotAllowed:        errors.New("tor not allowed"),
+		torNetUnreachable:    errors.New("tor network is unreachable"),
+		torHostUnreachable:   errors.New("tor host is unreachable"),
+		torConnectionRefused: errors.New("tor connection refused"),
+		torTTLExpired:        errors.New("tor TTL expired"),
+		torCmdNotSupported:   errors.New("tor command not supported"),
+		torAddrNotSupported:  errors.New("tor address type not supported"),
+	}
+)
+
+// TorLookupIP uses Tor to resolve DNS via the SOCKS extension they provide for
+// resolution over the Tor network. Tor itself doesn't support ipv6 so this
+// doesn't either. If username is not empty, it authenticates to the proxy with
+// the given username and password when the proxy requires it.
+func TorLookupIP(host, proxy, username, password string) ([]net.IP, error) {
+	conn, err := net.DialTimeout("tcp", proxy, torLookupTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	err = conn.SetDeadline(time.Now().Add(torLookupTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	buf := []byte{'\x05', '\x01', socksAuthNone}
+	if username != "" {
+		buf = []byte{'\x05', '\x02', socksAuthNone, socksAuthUsernamePassword}
+	}
+	_, err = conn.Write(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	buf = make([]byte, 2)
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		return nil, err
+	}
+	if buf[0] != '\x05' {
+		return nil, ErrTorInvalidProxyResponse
+	}
+	switch {
+	case buf[1] == socksAuthNone:
+	case buf[1] == socksAuthUsernamePassword && username != "":
+		err = torAuthenticate(conn, username, password)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrTorUnrecognizedAuthMethod
+	}
+
+	buf = make([]byte, 7+len(host))
+	buf[0] = 5      // protocol version
+	buf[1] = '\xF0' // Tor Resolve
+	buf[2] = 0      // reserved
+	buf[3] = 3      // Tor Resolve
+	buf[4] = byte(len(host))
+	copy(buf[5:], host)
+	buf[5+len(host)] = 0 // Port 0
+
+	_, err = conn.Write(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	buf = make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		return nil, err
+	}
+	if buf[0] != 5 {
+		return nil, ErrTorInvalidProxyResponse
+	}
+	if buf[1] != 0 {
+		if err, ok := torStatusErrors[buf[1]]; ok {
+			return nil, err
+		}
+		return nil, ErrTorInvalidProxyResponse
+	}
+	if buf[3] != 1 {
+		return nil, torStatusErrors[torGeneralError]
+	}
+
+	buf = make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		return nil, ErrTorInvalidAddressResponse
+	}
+
+	return []net.IP{net.IPv4(buf[0], buf[1], buf[2], buf[3])}, nil
+}
+
+// torAuthenticate authenticates to the proxy with the given username and
+// password, as described in RFC 1929.
+func torAuthenticate(conn net.Conn, username, password string) error {
+	if len(username) > 255 || len(password) > 255 {
+		return errors.New("the proxy username and password must be at most 255 bytes long")
+	}
+
+	buf := make([]byte, 0, 3+len(username)+len(password))
+	buf = append(buf, 1) // subnegotiation version
+	buf = append(buf, byte(len(username)))
+	buf = append(buf, username...)
+	buf = append(buf, byte(len(password)))
+	buf = append(buf, password...)
+	_, err := conn.Write(buf)
+	if err != nil {
+		return err
+	}
+
+	buf = make([]byte, 2)
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		return err
+	}
+	if buf[0] != 1 {
+		return ErrTorInvalidProxyResponse
+	}
+	if buf[1] != 0 {
+		return ErrTorAuthFailed
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package network
+
+import (
+	"io"
+	"net"
+	"testing"
+)
+
+// serveTorResolve serves a single Tor resolve request on listener, requiring
+// the given username and password, and resolving every host to 1.2.3.4.
+func serveTorResolve(t *testing.T, listener net.Listener, username, password string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	header := make([]byte, 2)
+	_, err = io.ReadFull(conn, header)
+	if err != nil {
+		t.Errorf("Failed to read the greeting: %s", err)
+		return
+	}
+	methods := make([]byte, header[1])
+	_, err = io.ReadFull(conn, methods)
+	if err != nil {
+		t.Errorf("Failed to read the authentication methods: %s", err)
+		return
+	}
+	offersUsernamePassword := false
+	for _, method := range methods {
+		if method == socksAuthUsernamePassword {
+			offersUsernamePassword = true
+		}
+	}
+	if !offersUsernamePassword {
+		conn.Write([]byte{5, 0xff})
+		return
+	}
+	conn.Write([]byte{5, socksAuthUsernamePassword})
+
+	buf := make([]byte, 2)
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		t.Errorf("Failed to read the username: %s", err)
+		return
+	}
+	receivedUsername := make([]byte, buf[1])
+	_, err = io.ReadFull(conn, receivedUsername)
+	if err != nil {
+		t.Errorf("Failed to read the username: %s", err)
+		return
+	}
+	buf = make([]byte, 1)
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		t.Errorf("Failed to read the password: %s", err)
+		return
+	}
+	receivedPassword := make([]byte, buf[0])
+	_, err = io.ReadFull(conn, receivedPassword)
+	if err != nil {
+		t.Errorf("Failed to read the password: %s", err)
+		return
+	}
+	if string(receivedUsername) != username || string(receivedPassword) != password {
+		conn.Write([]byte{1, 1})
+		return
+	}
+	conn.Write([]byte{1, 0})
+
+	buf = make([]byte, 5)
+	_, err = io.ReadFull(conn, buf)
+	if err != nil {
+		t.Errorf("Failed to read the resolve request: %s", err)
+		return
+	}
+	_, err = io.ReadFull(conn, make([]byte, int(buf[4])+2))
+	if err != nil {
+		t.Errorf("Failed to read the resolve request: %s", err)
+		return
+	}
+	conn.Write([]byte{5, 0, 0, 1, 1, 2, 3, 4})
+}
+
+func TestTorLookupIPWithAuthentication(t *testing.T) {
+	const username, password = "user", "pass"
+
+	tests := []struct {
+		username    string
+		password    string
+		expectedErr error
+	}{
+		{username: username, password: password},
+		{username: username, password: "wrong", expectedErr: ErrTorAuthFailed},
+		{expectedErr: ErrTorUnrecognizedAuthMethod},
+	}
+	for _, test := range tests {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Listen: %s", err)
+		}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			serveTorResolve(t, listener, username, password)
+		}()
+
+		ips, err := TorLookupIP("example.com", listener.Addr().String(), test.username, test.password)
+		listener.Close()
+		<-done
+
+		if err != test.expectedErr {
+			t.Fatalf("Expected the lookup with username %q and password %q to return %v, but got %v",
+				test.username, test.password, test.expectedErr, err)
+		}
+		if err == nil && (len(ips) != 1 || !ips[0].Equal(net.IPv4(1, 2, 3, 4))) {
+			t.Fatalf("Expected the lookup to resolve to 1.2.3.4, but got %v", ips)
+		}
+	}
+}
@@ -87,6 +87,13 @@ func (f Flag) toC() C.randomx_flags {
 	return (C.randomx_flags)(f)
 }
 
+// GetFlags returns the recommended flags for the current machine. It never includes
+// FlagLargePages, FlagFullMEM or FlagSecure, which have to be added explicitly.
+// The flags only affect performance: hashes are the same regardless of them.
+func GetFlags() Flag {
+	return Flag(C.randomx_get_flags())
+}
+
 // AllocCache allocates and initializes a new RandomX cache with given flags.
 func AllocCache(flags ...Flag) (*C.randomx_cache, error) {
 	var SumFlag = FlagDefault
@@ -141,6 +148,14 @@ func DatasetItemCount() uint32 {
 	return uint32(length)
 }
 
+// CacheSize is the size in bytes of a RandomX cache.
+const CacheSize = 256 << 20
+
+// DatasetSize returns the size in bytes of a RandomX dataset.
+func DatasetSize() uint64 {
+	return uint64(DatasetItemCount()) * C.RANDOMX_DATASET_ITEM_SIZE
+}
+
 // InitDataset initializes the given RandomX dataset with specified parameters.
 func InitDataset(dataset *C.randomx_dataset, cache *C.randomx_cache, startItem uint32, itemCount uint32) {
 	if dataset == nil {
@@ -185,13 +200,14 @@ func ReleaseDataset(dataset *C.randomx_dataset) {
 }
 
 // CreateVM creates a new RandomX VM with the given cache, dataset, and flags.
+// The dataset may be nil unless FlagFullMEM is set.
 func CreateVM(cache *C.randomx_cache, dataset *C.randomx_dataset, flags ...Flag) (*C.randomx_vm, error) {
 	var SumFlag = FlagDefault
 	for _, flag := range flags {
 		SumFlag = SumFlag | flag
 	}
 
-	if dataset == nil {
+	if dataset == nil && SumFlag&FlagFullMEM != 0 {
 		panic("failed creating vm: using empty dataset")
 	}
 
@@ -26,6 +26,21 @@ func NewRxDataset(flags ...Flag) (*RxDataset, error) {
 	}, nil
 }
 
+// NewLightRxDataset creates a new RxDataset with the specified flags that only has a cache.
+// It can only be used by VMs created without FlagFullMEM.
+func NewLightRxDataset(flags ...Flag) (*RxDataset, error) {
+	cache, err := NewRxCache(flags...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RxDataset{
+		rxCache: cache,
+
+		workerNum: 1,
+	}, nil
+}
+
 // Close releases the resources associated with the RxDataset.
 func (ds *RxDataset) Close() {
 	if ds.dataset != nil {
@@ -76,3 +91,17 @@ func (ds *RxDataset) CInit(seed []byte, workerNum uint32) bool {
 
 	return true
 }
+
+// InitCache initializes only the cache of the RxDataset with the given seed, which is all that
+// VMs created without FlagFullMEM use. Returns true if initialization was successful.
+func (ds *RxDataset) InitCache(seed []byte) bool {
+	if ds.rxCache == nil || ds.rxCache.cache == nil {
+		return false
+	}
+
+	if ds.rxCache.Init(seed) == false {
+		fmt.Println("WARN: rxCache has already been initialized by the same seed")
+	}
+
+	return true
+}